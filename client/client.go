@@ -0,0 +1,497 @@
+// Package client is a Go client for the PR reviewer assignment service. It wraps the
+// client generated into client/api with typed errors, retries and default deadlines.
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"avito/client/api"
+)
+
+const (
+	DefaultRetries = 3
+	DefaultBackoff = 100 * time.Millisecond
+	DefaultTimeout = 10 * time.Second
+)
+
+type Client struct {
+	api     api.ClientInterface
+	retries int
+	backoff time.Duration
+	timeout time.Duration
+}
+
+type options struct {
+	doer    api.HttpRequestDoer
+	editors []api.RequestEditorFn
+	retries int
+	backoff time.Duration
+	timeout time.Duration
+}
+
+type Option func(*options)
+
+func WithHTTPClient(doer api.HttpRequestDoer) Option {
+	return func(o *options) {
+		o.doer = doer
+	}
+}
+
+// WithToken sends the token as a Bearer token, the service rate limits per token.
+func WithToken(token string) Option {
+	return WithRequestEditor(func(_ context.Context, req *http.Request) error {
+		req.Header.Set("Authorization", "Bearer "+token)
+
+		return nil
+	})
+}
+
+// WithAdminToken sends the admin token the /admin operations require next to the API key.
+func WithAdminToken(token string) Option {
+	return WithRequestEditor(func(_ context.Context, req *http.Request) error {
+		req.Header.Set("X-Admin-Token", token)
+
+		return nil
+	})
+}
+
+func WithRequestEditor(fn api.RequestEditorFn) Option {
+	return func(o *options) {
+		o.editors = append(o.editors, fn)
+	}
+}
+
+// WithRetries sets how many times a call is repeated and the first delay, which doubles
+// on every attempt. A Retry-After from the service overrides a shorter delay.
+func WithRetries(retries int, backoff time.Duration) Option {
+	return func(o *options) {
+		o.retries = retries
+		o.backoff = backoff
+	}
+}
+
+// WithTimeout sets the deadline of calls whose context has none. Zero disables it.
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.timeout = timeout
+	}
+}
+
+func New(baseURL string, opts ...Option) (*Client, error) {
+	o := options{
+		retries: DefaultRetries,
+		backoff: DefaultBackoff,
+		timeout: DefaultTimeout,
+	}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	apiOpts := []api.ClientOption{}
+
+	if o.doer != nil {
+		apiOpts = append(apiOpts, api.WithHTTPClient(o.doer))
+	}
+
+	for _, fn := range o.editors {
+		apiOpts = append(apiOpts, api.WithRequestEditorFn(fn))
+	}
+
+	apiClient, err := api.NewClient(baseURL, apiOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{
+		api:     apiClient,
+		retries: o.retries,
+		backoff: o.backoff,
+		timeout: o.timeout,
+	}, nil
+}
+
+// call sends the request and parses the response, retrying while it is safe. Rate
+// limited requests never reached a handler, so they are repeated for every call; server
+// and transport errors only for idempotent ones.
+func call[R any](ctx context.Context, c *Client, idempotent bool,
+	send func(ctx context.Context) (*http.Response, error),
+	parse func(rsp *http.Response) (R, error),
+) (R, error) {
+	var zero R
+
+	if _, ok := ctx.Deadline(); !ok && c.timeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
+	for attempt := 0; ; attempt++ {
+		rsp, err := send(ctx)
+
+		var retry bool
+
+		switch {
+		case err != nil:
+			retry = idempotent && ctx.Err() == nil
+		case rsp.StatusCode == http.StatusTooManyRequests:
+			retry = true
+		case rsp.StatusCode == http.StatusBadGateway, rsp.StatusCode == http.StatusServiceUnavailable,
+			rsp.StatusCode == http.StatusGatewayTimeout:
+			retry = idempotent
+		}
+
+		delay := c.backoff << attempt
+		if rsp != nil {
+			delay = max(delay, retryAfter(rsp))
+		}
+
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+			retry = false
+		}
+
+		if !retry || attempt >= c.retries {
+			if err != nil {
+				return zero, err
+			}
+
+			return parse(rsp)
+		}
+
+		if rsp != nil {
+			_, _ = io.Copy(io.Discard, rsp.Body)
+			_ = rsp.Body.Close()
+		}
+
+		timer := time.NewTimer(delay)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			return zero, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func (c *Client) CreateTeam(ctx context.Context, team api.Team) (*api.Team, error) {
+	res, err := call(ctx, c, false, func(ctx context.Context) (*http.Response, error) {
+		return c.api.PostTeamAdd(ctx, team)
+	}, api.ParsePostTeamAddResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.JSON201 == nil || res.JSON201.Team == nil {
+		return nil, responseError(res.HTTPResponse, res.Body)
+	}
+
+	return res.JSON201.Team, nil
+}
+
+func (c *Client) GetTeam(ctx context.Context, teamName string) (*api.Team, error) {
+	res, err := call(ctx, c, true, func(ctx context.Context) (*http.Response, error) {
+		return c.api.GetTeamGet(ctx, &api.GetTeamGetParams{TeamName: teamName})
+	}, api.ParseGetTeamGetResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.JSON200 == nil {
+		return nil, responseError(res.HTTPResponse, res.Body)
+	}
+
+	return res.JSON200, nil
+}
+
+// ApplyTeams brings the teams in line with the full desired set, with dryRun it only
+// returns the plan. Applying the same set twice changes nothing, so it is retried.
+func (c *Client) ApplyTeams(ctx context.Context, teams []api.Team, dryRun bool) (*api.TeamPlan, error) {
+	res, err := call(ctx, c, true, func(ctx context.Context) (*http.Response, error) {
+		return c.api.PostAdminTeamsApply(ctx, &api.PostAdminTeamsApplyParams{DryRun: &dryRun}, api.PostAdminTeamsApplyJSONRequestBody{Teams: teams})
+	}, api.ParsePostAdminTeamsApplyResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.JSON200 == nil {
+		return nil, responseError(res.HTTPResponse, res.Body)
+	}
+
+	return res.JSON200, nil
+}
+
+func (c *Client) SetIsActive(ctx context.Context, userID string, isActive bool) (*api.User, error) {
+	res, err := call(ctx, c, true, func(ctx context.Context) (*http.Response, error) {
+		return c.api.PostUsersSetIsActive(ctx, api.PostUsersSetIsActiveJSONRequestBody{UserId: userID, IsActive: isActive})
+	}, api.ParsePostUsersSetIsActiveResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.JSON200 == nil || res.JSON200.User == nil {
+		return nil, responseError(res.HTTPResponse, res.Body)
+	}
+
+	return res.JSON200.User, nil
+}
+
+func (c *Client) GetReview(ctx context.Context, userID string) ([]api.PullRequestShort, error) {
+	res, err := call(ctx, c, true, func(ctx context.Context) (*http.Response, error) {
+		return c.api.GetUsersGetReview(ctx, &api.GetUsersGetReviewParams{UserId: userID})
+	}, api.ParseGetUsersGetReviewResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.JSON200 == nil {
+		return nil, responseError(res.HTTPResponse, res.Body)
+	}
+
+	return res.JSON200.PullRequests, nil
+}
+
+// EraseUser anonymizes the user and returns the erasure and the open reviews it handed
+// off. It is not retried, a retry after a lost response would find no user left.
+func (c *Client) EraseUser(ctx context.Context, userID string) (*api.UserErasure, []api.Reassignment, error) {
+	res, err := call(ctx, c, false, func(ctx context.Context) (*http.Response, error) {
+		return c.api.PostAdminUsersErase(ctx, api.PostAdminUsersEraseJSONRequestBody{UserId: userID})
+	}, api.ParsePostAdminUsersEraseResponse)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if res.JSON200 == nil {
+		return nil, nil, responseError(res.HTTPResponse, res.Body)
+	}
+
+	return &res.JSON200.Erasure, res.JSON200.Reassignments, nil
+}
+
+// Erasures returns the audit trail of erased users, oldest first.
+func (c *Client) Erasures(ctx context.Context) ([]api.UserErasure, error) {
+	res, err := call(ctx, c, true, func(ctx context.Context) (*http.Response, error) {
+		return c.api.GetAdminUsersErasures(ctx)
+	}, api.ParseGetAdminUsersErasuresResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.JSON200 == nil {
+		return nil, responseError(res.HTTPResponse, res.Body)
+	}
+
+	return res.JSON200.Erasures, nil
+}
+
+func (c *Client) GetNotifications(ctx context.Context, userID string) (*api.NotificationSettings, error) {
+	res, err := call(ctx, c, true, func(ctx context.Context) (*http.Response, error) {
+		return c.api.GetUsersGetNotifications(ctx, &api.GetUsersGetNotificationsParams{UserId: userID})
+	}, api.ParseGetUsersGetNotificationsResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.JSON200 == nil {
+		return nil, responseError(res.HTTPResponse, res.Body)
+	}
+
+	return res.JSON200, nil
+}
+
+// SetNotifications replaces the email settings of the user, a nil email turns all mails off.
+func (c *Client) SetNotifications(ctx context.Context, settings api.NotificationSettings) (*api.NotificationSettings, error) {
+	res, err := call(ctx, c, true, func(ctx context.Context) (*http.Response, error) {
+		return c.api.PostUsersSetNotifications(ctx, api.PostUsersSetNotificationsJSONRequestBody{
+			UserId:           settings.UserId,
+			Email:            settings.Email,
+			EmailAssignments: settings.EmailAssignments,
+			EmailDigest:      settings.EmailDigest,
+		})
+	}, api.ParsePostUsersSetNotificationsResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.JSON200 == nil {
+		return nil, responseError(res.HTTPResponse, res.Body)
+	}
+
+	return res.JSON200, nil
+}
+
+func (c *Client) GetTeamSLA(ctx context.Context, teamName string) (*api.TeamSLA, error) {
+	res, err := call(ctx, c, true, func(ctx context.Context) (*http.Response, error) {
+		return c.api.GetTeamGetSla(ctx, &api.GetTeamGetSlaParams{TeamName: teamName})
+	}, api.ParseGetTeamGetSlaResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.JSON200 == nil {
+		return nil, responseError(res.HTTPResponse, res.Body)
+	}
+
+	return res.JSON200, nil
+}
+
+// SetTeamSLA replaces the review SLA of the team.
+func (c *Client) SetTeamSLA(ctx context.Context, sla api.TeamSLA) (*api.TeamSLA, error) {
+	res, err := call(ctx, c, true, func(ctx context.Context) (*http.Response, error) {
+		return c.api.PostTeamSetSla(ctx, api.PostTeamSetSlaJSONRequestBody{
+			TeamName:           sla.TeamName,
+			RemindAfterHours:   sla.RemindAfterHours,
+			EscalateAfterHours: sla.EscalateAfterHours,
+			LeadId:             sla.LeadId,
+			AutoReassign:       &sla.AutoReassign,
+		})
+	}, api.ParsePostTeamSetSlaResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.JSON200 == nil {
+		return nil, responseError(res.HTTPResponse, res.Body)
+	}
+
+	return res.JSON200, nil
+}
+
+// RemoveTeamSLA is not retried, a retry after a lost response would report ErrNotFound.
+func (c *Client) RemoveTeamSLA(ctx context.Context, teamName string) error {
+	res, err := call(ctx, c, false, func(ctx context.Context) (*http.Response, error) {
+		return c.api.PostTeamRemoveSla(ctx, api.PostTeamRemoveSlaJSONRequestBody{TeamName: teamName})
+	}, api.ParsePostTeamRemoveSlaResponse)
+	if err != nil {
+		return err
+	}
+
+	if res.JSON200 == nil {
+		return responseError(res.HTTPResponse, res.Body)
+	}
+
+	return nil
+}
+
+func (c *Client) CreatePullRequest(ctx context.Context, pullRequestID string, name string, authorID string) (*api.PullRequest, error) {
+	res, err := call(ctx, c, false, func(ctx context.Context) (*http.Response, error) {
+		return c.api.PostPullRequestCreate(ctx, api.PostPullRequestCreateJSONRequestBody{
+			AuthorId:        authorID,
+			PullRequestId:   pullRequestID,
+			PullRequestName: name,
+		})
+	}, api.ParsePostPullRequestCreateResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.JSON201 == nil || res.JSON201.Pr == nil {
+		return nil, responseError(res.HTTPResponse, res.Body)
+	}
+
+	return res.JSON201.Pr, nil
+}
+
+// MergePullRequest is idempotent, merging a merged pull request returns it unchanged.
+func (c *Client) MergePullRequest(ctx context.Context, pullRequestID string) (*api.PullRequest, error) {
+	res, err := call(ctx, c, true, func(ctx context.Context) (*http.Response, error) {
+		return c.api.PostPullRequestMerge(ctx, api.PostPullRequestMergeJSONRequestBody{PullRequestId: pullRequestID})
+	}, api.ParsePostPullRequestMergeResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.JSON200 == nil || res.JSON200.Pr == nil {
+		return nil, responseError(res.HTTPResponse, res.Body)
+	}
+
+	return res.JSON200.Pr, nil
+}
+
+// RestorePullRequest moves an archived pull request back, it comes back merged with its
+// reviewers.
+func (c *Client) RestorePullRequest(ctx context.Context, pullRequestID string) (*api.PullRequest, error) {
+	res, err := call(ctx, c, false, func(ctx context.Context) (*http.Response, error) {
+		return c.api.PostAdminPullRequestsRestore(ctx, api.PostAdminPullRequestsRestoreJSONRequestBody{PullRequestId: pullRequestID})
+	}, api.ParsePostAdminPullRequestsRestoreResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.JSON200 == nil || res.JSON200.Pr == nil {
+		return nil, responseError(res.HTTPResponse, res.Body)
+	}
+
+	return res.JSON200.Pr, nil
+}
+
+// Reassign returns the updated pull request and the id of the new reviewer.
+func (c *Client) Reassign(ctx context.Context, pullRequestID string, oldUserID string) (*api.PullRequest, string, error) {
+	res, err := call(ctx, c, false, func(ctx context.Context) (*http.Response, error) {
+		return c.api.PostPullRequestReassign(ctx, api.PostPullRequestReassignJSONRequestBody{
+			OldUserId:     oldUserID,
+			PullRequestId: pullRequestID,
+		})
+	}, api.ParsePostPullRequestReassignResponse)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if res.JSON200 == nil {
+		return nil, "", responseError(res.HTTPResponse, res.Body)
+	}
+
+	return &res.JSON200.Pr, res.JSON200.ReplacedBy, nil
+}
+
+func (c *Client) UserStat(ctx context.Context, userID string) (*api.UserStat, error) {
+	res, err := call(ctx, c, true, func(ctx context.Context) (*http.Response, error) {
+		return c.api.GetStatisticsUser(ctx, &api.GetStatisticsUserParams{UserId: userID})
+	}, api.ParseGetStatisticsUserResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.JSON200 == nil {
+		return nil, responseError(res.HTTPResponse, res.Body)
+	}
+
+	return res.JSON200, nil
+}
+
+type TeamStat struct {
+	TeamName  string         `json:"team_name"`
+	UsersStat []api.UserStat `json:"users_stat"`
+	// AvgDuration is -1 when the team has no merged reviews.
+	AvgDuration float64 `json:"avg_duration"`
+}
+
+func (c *Client) TeamStat(ctx context.Context, teamName string) (*TeamStat, error) {
+	res, err := call(ctx, c, true, func(ctx context.Context) (*http.Response, error) {
+		return c.api.GetStatisticsTeam(ctx, &api.GetStatisticsTeamParams{TeamName: teamName})
+	}, api.ParseGetStatisticsTeamResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.JSON200 == nil {
+		return nil, responseError(res.HTTPResponse, res.Body)
+	}
+
+	return &TeamStat{
+		TeamName:    res.JSON200.TeamName,
+		UsersStat:   res.JSON200.UsersStat,
+		AvgDuration: res.JSON200.AvgDuration,
+	}, nil
+}
+
+// IsRetryable reports whether the call may succeed if repeated later.
+func IsRetryable(err error) bool {
+	return errors.Is(err, ErrRateLimited) || errors.Is(err, ErrUnavailable)
+}
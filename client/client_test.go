@@ -0,0 +1,337 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"avito/client"
+	"avito/client/api"
+	delivery "avito/internal/delivery/http"
+	"avito/internal/delivery/http/middleware"
+	"avito/internal/gen"
+	"avito/internal/repo"
+	"avito/internal/repo/memory"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const adminToken = "admin-secret"
+
+func newServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	gin.SetMode(gin.TestMode)
+
+	store := memory.InitStore()
+
+	swagger, err := gen.GetSwagger()
+	require.NoError(t, err)
+
+	validation, err := middleware.Validation(swagger)
+	require.NoError(t, err)
+
+	g := gin.New()
+	g.Use(validation)
+	delivery.RegisterHandlers(g, delivery.InitServer(repo.Repos{
+		Team:         memory.InitTeamRepo(store),
+		User:         memory.InitUserRepo(store),
+		PullRequest:  memory.InitPullRequestRepo(store),
+		Stat:         memory.InitStatRepo(store),
+		History:      memory.InitHistoryRepo(store),
+		Notification: memory.InitNotificationRepo(store),
+		SLA:          memory.InitSLARepo(store),
+		Archive:      memory.InitArchiveRepo(store),
+		Erasure:      memory.InitErasureRepo(store),
+	}), middleware.Admin(adminToken))
+
+	srv := httptest.NewServer(g)
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func member(id string) api.TeamMember {
+	return api.TeamMember{UserId: id, Username: "name-" + id, IsActive: true}
+}
+
+func TestClient(t *testing.T) {
+	ctx := context.Background()
+
+	url := newServer(t).URL
+
+	c, err := client.New(url, client.WithAdminToken(adminToken))
+	require.NoError(t, err)
+
+	backend := api.Team{TeamName: "backend", Members: []api.TeamMember{member("u1"), member("u2")}}
+
+	team, err := c.CreateTeam(ctx, backend)
+	require.NoError(t, err)
+	assert.Equal(t, backend, *team)
+
+	_, err = c.CreateTeam(ctx, backend)
+	assert.ErrorIs(t, err, client.ErrTeamExists)
+
+	var apiErr *client.Error
+
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+
+	_, err = c.CreateTeam(ctx, api.Team{TeamName: "dup", Members: []api.TeamMember{member("d1"), member("d1")}})
+	assert.ErrorIs(t, err, client.ErrValidation)
+	require.ErrorAs(t, err, &apiErr)
+	require.Len(t, apiErr.Details, 1)
+	assert.Equal(t, "members[1].user_id", apiErr.Details[0].Field)
+
+	team, err = c.GetTeam(ctx, "backend")
+	require.NoError(t, err)
+	assert.Equal(t, backend, *team)
+
+	_, err = c.GetTeam(ctx, "unknown")
+	assert.ErrorIs(t, err, client.ErrNotFound)
+
+	pr, err := c.CreatePullRequest(ctx, "pr-1", "Add search", "u1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u2"}, pr.AssignedReviewers)
+	assert.Equal(t, api.PullRequestStatusOPEN, pr.Status)
+
+	_, err = c.CreatePullRequest(ctx, "pr-1", "Add search", "u1")
+	assert.ErrorIs(t, err, client.ErrPRExists)
+
+	_, _, err = c.Reassign(ctx, "pr-1", "u2")
+	assert.ErrorIs(t, err, client.ErrNoCandidate)
+
+	reviews, err := c.GetReview(ctx, "u2")
+	require.NoError(t, err)
+	assert.Equal(t, []api.PullRequestShort{{
+		AuthorId:        "u1",
+		PullRequestId:   "pr-1",
+		PullRequestName: "Add search",
+		Status:          api.PullRequestShortStatusOPEN,
+	}}, reviews)
+
+	pr, err = c.MergePullRequest(ctx, "pr-1")
+	require.NoError(t, err)
+	assert.Equal(t, api.PullRequestStatusMERGED, pr.Status)
+
+	_, _, err = c.Reassign(ctx, "pr-1", "u2")
+	assert.ErrorIs(t, err, client.ErrPRMerged)
+
+	user, err := c.SetIsActive(ctx, "u2", false)
+	require.NoError(t, err)
+	assert.Equal(t, api.User{UserId: "u2", Username: "name-u2", TeamName: "backend", IsActive: false}, *user)
+
+	stat, err := c.UserStat(ctx, "u2")
+	require.NoError(t, err)
+	assert.Equal(t, 1, stat.CountPr)
+
+	teamStat, err := c.TeamStat(ctx, "backend")
+	require.NoError(t, err)
+	assert.Len(t, teamStat.UsersStat, 2)
+
+	settings, err := c.GetNotifications(ctx, "u2")
+	require.NoError(t, err)
+	assert.Equal(t, api.NotificationSettings{UserId: "u2", EmailAssignments: true, EmailDigest: true}, *settings)
+
+	email := "u2@example.com"
+	want := api.NotificationSettings{UserId: "u2", Email: &email, EmailAssignments: true, EmailDigest: false}
+
+	settings, err = c.SetNotifications(ctx, want)
+	require.NoError(t, err)
+	assert.Equal(t, want, *settings)
+
+	email = "not an email"
+
+	_, err = c.SetNotifications(ctx, want)
+	assert.ErrorIs(t, err, client.ErrValidation)
+
+	_, err = c.GetNotifications(ctx, "unknown")
+	assert.ErrorIs(t, err, client.ErrNotFound)
+
+	_, err = c.GetTeamSLA(ctx, "backend")
+	assert.ErrorIs(t, err, client.ErrNotFound)
+
+	lead := "u1"
+	wantSLA := api.TeamSLA{TeamName: "backend", RemindAfterHours: 4, EscalateAfterHours: 24.5, LeadId: &lead, AutoReassign: true}
+
+	sla, err := c.SetTeamSLA(ctx, wantSLA)
+	require.NoError(t, err)
+	assert.Equal(t, wantSLA, *sla)
+
+	sla, err = c.GetTeamSLA(ctx, "backend")
+	require.NoError(t, err)
+	assert.Equal(t, wantSLA, *sla)
+
+	wantSLA.EscalateAfterHours = 2
+
+	_, err = c.SetTeamSLA(ctx, wantSLA)
+	assert.ErrorIs(t, err, client.ErrValidation)
+
+	require.NoError(t, c.RemoveTeamSLA(ctx, "backend"))
+	assert.ErrorIs(t, c.RemoveTeamSLA(ctx, "backend"), client.ErrNotFound)
+
+	noAdmin, err := client.New(url)
+	require.NoError(t, err)
+
+	_, _, err = noAdmin.EraseUser(ctx, "u2")
+	assert.ErrorIs(t, err, client.ErrUnauthorized)
+
+	_, err = c.RestorePullRequest(ctx, "pr-1")
+	assert.ErrorIs(t, err, client.ErrNotFound, "not archived")
+
+	erasure, _, err := c.EraseUser(ctx, "u2")
+	require.NoError(t, err)
+	assert.Equal(t, "backend", erasure.TeamName)
+
+	_, _, err = c.EraseUser(ctx, "u2")
+	assert.ErrorIs(t, err, client.ErrNotFound)
+
+	erasures, err := c.Erasures(ctx)
+	require.NoError(t, err)
+	require.Len(t, erasures, 1)
+	assert.Equal(t, erasure.Pseudonym, erasures[0].Pseudonym)
+}
+
+// flaky answers every request with status until it was called fail times.
+func flaky(t *testing.T, fail int, status int, calls *atomic.Int32) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if int(calls.Add(1)) <= fail {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte(`{"error":{"code":"SERVICE_UNAVAILABLE","message":"service temporarily unavailable"}}`))
+
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"team_name":"backend","members":[]}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func TestRetries(t *testing.T) {
+	tests := []struct {
+		description string
+		status      int
+		fail        int
+		call        func(c *client.Client) error
+		wantCalls   int32
+		wantErr     error
+	}{
+		{
+			description: "idempotent call is retried on 503",
+			status:      http.StatusServiceUnavailable,
+			fail:        2,
+			call: func(c *client.Client) error {
+				_, err := c.GetTeam(context.Background(), "backend")
+
+				return err
+			},
+			wantCalls: 3,
+		},
+		{
+			description: "retries run out",
+			status:      http.StatusServiceUnavailable,
+			fail:        10,
+			call: func(c *client.Client) error {
+				_, err := c.GetTeam(context.Background(), "backend")
+
+				return err
+			},
+			wantCalls: 4,
+			wantErr:   client.ErrUnavailable,
+		},
+		{
+			description: "create is not retried on 503",
+			status:      http.StatusServiceUnavailable,
+			fail:        1,
+			call: func(c *client.Client) error {
+				_, err := c.CreatePullRequest(context.Background(), "pr-1", "name", "u1")
+
+				return err
+			},
+			wantCalls: 1,
+			wantErr:   client.ErrUnavailable,
+		},
+		{
+			description: "create is retried on 429",
+			status:      http.StatusTooManyRequests,
+			fail:        1,
+			call: func(c *client.Client) error {
+				_, err := c.CreateTeam(context.Background(), api.Team{TeamName: "backend"})
+
+				return err
+			},
+			wantCalls: 2,
+			// The stub answers 200 where the API answers 201.
+			wantErr: &client.Error{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			var calls atomic.Int32
+
+			c, err := client.New(flaky(t, test.fail, test.status, &calls).URL, client.WithRetries(3, time.Millisecond))
+			require.NoError(t, err)
+
+			err = test.call(c)
+
+			switch want := test.wantErr.(type) {
+			case nil:
+				assert.NoError(t, err)
+			case *client.Error:
+				if want.Code == "" {
+					var apiErr *client.Error
+
+					assert.True(t, errors.As(err, &apiErr), err)
+				} else {
+					assert.ErrorIs(t, err, want)
+				}
+			}
+
+			assert.Equal(t, test.wantCalls, calls.Load())
+		})
+	}
+}
+
+func TestTimeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer srv.Close()
+
+	c, err := client.New(srv.URL, client.WithTimeout(50*time.Millisecond), client.WithRetries(0, 0))
+	require.NoError(t, err)
+
+	_, err = c.GetTeam(context.Background(), "backend")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestToken(t *testing.T) {
+	var auth atomic.Value
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth.Store(r.Header.Get("Authorization"))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"team_name":"backend","members":[]}`))
+	}))
+	defer srv.Close()
+
+	c, err := client.New(srv.URL, client.WithToken("secret"))
+	require.NoError(t, err)
+
+	_, err = c.GetTeam(context.Background(), "backend")
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer secret", auth.Load())
+}
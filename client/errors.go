@@ -0,0 +1,103 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"avito/client/api"
+)
+
+// Error is a failed call. Code is empty when the response did not carry an ErrorResponse,
+// for example a 502 from a proxy.
+type Error struct {
+	StatusCode    int
+	Code          api.ErrorResponseErrorCode
+	Message       string
+	CorrelationID string
+	Details       []api.FieldError
+	// RetryAfter is set from the Retry-After header of 429 and 503 responses.
+	RetryAfter time.Duration
+}
+
+// Sentinels for errors.Is, they match any Error with the same code.
+var (
+	ErrTeamExists   = &Error{Code: api.TEAMEXISTS}
+	ErrUserExists   = &Error{Code: api.USEREXISTS}
+	ErrPRExists     = &Error{Code: api.PREXISTS}
+	ErrPRMerged     = &Error{Code: api.PRMERGED}
+	ErrNotAssigned  = &Error{Code: api.NOTASSIGNED}
+	ErrNoCandidate  = &Error{Code: api.NOCANDIDATE}
+	ErrNotFound     = &Error{Code: api.NOTFOUND}
+	ErrValidation   = &Error{Code: api.VALIDATIONERROR}
+	ErrInternal     = &Error{Code: api.INTERNALERROR}
+	ErrUnavailable  = &Error{Code: api.SERVICEUNAVAILABLE}
+	ErrRateLimited  = &Error{Code: api.RATELIMITED}
+	ErrUnauthorized = &Error{Code: api.UNAUTHORIZED}
+)
+
+func (e *Error) Error() string {
+	code := string(e.Code)
+	if code == "" {
+		code = http.StatusText(e.StatusCode)
+	}
+
+	msg := fmt.Sprintf("avito: %d %s", e.StatusCode, code)
+
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+
+	if e.CorrelationID != "" {
+		msg += " (correlation_id " + e.CorrelationID + ")"
+	}
+
+	return msg
+}
+
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+
+	return ok && t.Code != "" && t.Code == e.Code
+}
+
+func responseError(rsp *http.Response, body []byte) error {
+	apiErr := &Error{
+		StatusCode: rsp.StatusCode,
+		RetryAfter: retryAfter(rsp),
+	}
+
+	var errResp api.ErrorResponse
+
+	if err := json.Unmarshal(body, &errResp); err != nil || errResp.Error.Code == "" {
+		if rsp.StatusCode < http.StatusBadRequest {
+			apiErr.Message = "unexpected response body"
+		}
+
+		return apiErr
+	}
+
+	apiErr.Code = errResp.Error.Code
+	apiErr.Message = errResp.Error.Message
+
+	if errResp.Error.CorrelationId != nil {
+		apiErr.CorrelationID = *errResp.Error.CorrelationId
+	}
+
+	if errResp.Error.Details != nil {
+		apiErr.Details = *errResp.Error.Details
+	}
+
+	return apiErr
+}
+
+func retryAfter(rsp *http.Response) time.Duration {
+	seconds, err := strconv.Atoi(rsp.Header.Get("Retry-After"))
+	if err != nil || seconds < 0 {
+		return 0
+	}
+
+	return time.Duration(seconds) * time.Second
+}
@@ -1,7 +1,42 @@
 package main
 
-import "avito/internal/app"
+import (
+	"fmt"
+	"os"
+
+	"avito/internal/app"
+)
+
+const usage = `usage: main <command> [arguments]
+
+commands:
+  serve                              run the HTTP server (default)
+  migrate up|down|status|redo|reset  manage the database schema
+  seed [-f seed.json]                create teams from a JSON file
+  simulate [-f history.json]         compare reviewer selection strategies on PR history
+  loadgen [-url http://host:port]    benchmark a running instance`
 
 func main() {
-	app.Start()
+	command := "serve"
+	args := os.Args[1:]
+
+	if len(args) > 0 {
+		command, args = args[0], args[1:]
+	}
+
+	switch command {
+	case "serve":
+		app.Start()
+	case "migrate":
+		app.Migrate(args)
+	case "seed":
+		app.Seed(args)
+	case "simulate":
+		app.Simulate(args)
+	case "loadgen":
+		app.Loadgen(args)
+	default:
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
 }
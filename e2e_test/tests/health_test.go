@@ -0,0 +1,49 @@
+//go:build e2e
+
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"avito/internal/health"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestHealth test /healthz and /readyz
+func TestHealth(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), RequestTimeout)
+	defer cancel()
+
+	tests := []TestData{
+		{
+			path:         BasePath + "/healthz",
+			description:  "Liveness",
+			expectedCode: http.StatusOK,
+		}, {
+			path:         BasePath + "/readyz",
+			description:  "Readiness",
+			expectedCode: http.StatusOK,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			resp, err := DoWebRequest(ctx, http.MethodGet, test.path, nil)
+			require.NoError(t, err)
+
+			defer resp.Body.Close()
+
+			var report health.Report
+
+			err = json.NewDecoder(resp.Body).Decode(&report)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expectedCode, resp.StatusCode)
+			assert.Equal(t, health.StatusOK, report.Status, report.Checks)
+		})
+	}
+}
@@ -1,40 +1,44 @@
 package tests
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"testing"
 	"time"
 
+	"avito/client"
+	"avito/client/api"
 	"avito/internal/cerr"
 	"avito/internal/config"
 	"avito/internal/gen"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 var (
 	Host           string
 	RequestTimeout = 10 * time.Second
 	BasePath       string
-)
-
-var (
-	basePathPR    string
-	basePathUsers string
-	basePathTeam  string
+	Client         *client.Client
 )
 
 func init() {
 	cfg := config.InitConfig()
 	Host = fmt.Sprintf("%v:%v", cfg.ServiceHost, cfg.ServicePort)
 	BasePath = "http://" + Host
-	basePathPR = BasePath + "/pullRequest"
-	basePathUsers = BasePath + "/users"
-	basePathTeam = BasePath + "/team"
+
+	var err error
+
+	// Failures are part of the expectations, a retried call would hide them.
+	Client, err = client.New(BasePath, client.WithTimeout(RequestTimeout), client.WithRetries(0, 0))
+	if err != nil {
+		panic(fmt.Sprintf("error init client: %v", err.Error()))
+	}
 }
 
+// DoWebRequest is left for the health probes, they are not part of the API spec.
 func DoWebRequest(ctx context.Context, method, url string, body io.Reader) (*http.Response, error) {
 	req, err := http.NewRequestWithContext(ctx, method, url, body)
 	if err != nil {
@@ -51,112 +55,88 @@ func DoWebRequest(ctx context.Context, method, url string, body io.Reader) (*htt
 	return resp, nil
 }
 
-func GetError(err cerr.ErrorType) gen.ErrorResponse {
-	_, genErr := cerr.HandleErrs(cerr.CustomError{ErrType: err})
-
-	return genErr
-}
-
-type TestData struct {
-	teamForTest      *gen.Team                          //nolint:unused
-	prForTest        *gen.PostPullRequestCreateJSONBody //nolint:unused
-	mergeForTest     *gen.PostPullRequestMergeJSONBody  //nolint:unused
-	setActiveForTest *gen.PostUsersSetIsActiveJSONBody  //nolint:unused
-	path             string                             //nolint:unused
-	description      string                             //nolint:unused
-	body             any                                //nolint:unused
-	expectedCode     int                                //nolint:unused
-	expectedBody     any                                //nolint:unused
+func GetError(err cerr.ErrorType) *client.Error {
+	return toClientError(cerr.HandleErrs(cerr.CustomError{ErrType: err}))
 }
 
-func CreateTeamForTest(team *gen.Team) error {
-	ctx, cancel := context.WithTimeout(context.Background(), RequestTimeout)
-	defer cancel()
-
-	jsonData, err := json.Marshal(team)
-	if err != nil {
-		return err
-	}
-
-	resp, err := DoWebRequest(ctx, http.MethodPost, basePathTeam+"/add", bytes.NewBuffer(jsonData))
-	if err != nil {
-		return err
+func GetValidationError(fields ...api.FieldError) *client.Error {
+	genFields := make([]gen.FieldError, 0, len(fields))
+	for _, field := range fields {
+		genFields = append(genFields, gen.FieldError{Field: field.Field, Reason: field.Reason})
 	}
 
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusCreated {
-		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
-	}
-
-	return nil
+	return toClientError(cerr.HandleErrs(cerr.CustomError{
+		Err:     cerr.FieldsError{Fields: genFields},
+		ErrType: cerr.VALIDATION,
+	}))
 }
 
-func CreatePRForTest(pr *gen.PostPullRequestCreateJSONBody) error {
-	ctx, cancel := context.WithTimeout(context.Background(), RequestTimeout)
-	defer cancel()
-
-	jsonData, err := json.Marshal(pr)
-	if err != nil {
-		return err
+func toClientError(code int, genErr gen.ErrorResponse) *client.Error {
+	apiErr := &client.Error{
+		StatusCode: code,
+		Code:       api.ErrorResponseErrorCode(genErr.Error.Code),
+		Message:    genErr.Error.Message,
 	}
 
-	resp, err := DoWebRequest(ctx, http.MethodPost, basePathPR+"/create", bytes.NewBuffer(jsonData))
-	if err != nil {
-		return err
+	if genErr.Error.Details != nil {
+		for _, field := range *genErr.Error.Details {
+			apiErr.Details = append(apiErr.Details, api.FieldError{Field: field.Field, Reason: field.Reason})
+		}
 	}
 
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusCreated {
-		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
-	}
-
-	return nil
+	return apiErr
 }
 
-func MergePRForTest(pr *gen.PostPullRequestMergeJSONBody) error {
-	ctx, cancel := context.WithTimeout(context.Background(), RequestTimeout)
-	defer cancel()
-
-	jsonData, err := json.Marshal(pr)
-	if err != nil {
-		return err
-	}
-
-	resp, err := DoWebRequest(ctx, http.MethodPost, basePathPR+"/merge", bytes.NewBuffer(jsonData))
-	if err != nil {
-		return err
-	}
+// AssertError checks that err is the API error the service answers with for want.
+func AssertError(t *testing.T, want *client.Error, err error) {
+	t.Helper()
 
-	defer resp.Body.Close()
+	var got *client.Error
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
-	}
+	require.ErrorAs(t, err, &got)
+	assert.Equal(t, want.StatusCode, got.StatusCode)
+	assert.Equal(t, want.Code, got.Code)
+	assert.Equal(t, want.Message, got.Message)
+	assert.Equal(t, want.Details, got.Details)
+}
 
-	return nil
+type TestData struct {
+	teamForTest      *api.Team                          //nolint:unused
+	prForTest        *api.PostPullRequestCreateJSONBody //nolint:unused
+	mergeForTest     *api.PostPullRequestMergeJSONBody  //nolint:unused
+	setActiveForTest *api.PostUsersSetIsActiveJSONBody  //nolint:unused
+	path             string                             //nolint:unused
+	description      string                             //nolint:unused
+	body             any                                //nolint:unused
+	expectedCode     int                                //nolint:unused
+	expectedBody     any                                //nolint:unused
+	expectedErr      *client.Error                      //nolint:unused
 }
 
-func SetIsActiveForTest(user *gen.PostUsersSetIsActiveJSONBody) error {
+// SetUp creates what the test case needs before the call under test.
+func SetUp(t *testing.T, test TestData) {
+	t.Helper()
+
 	ctx, cancel := context.WithTimeout(context.Background(), RequestTimeout)
 	defer cancel()
 
-	jsonData, err := json.Marshal(user)
-	if err != nil {
-		return err
+	if test.teamForTest != nil {
+		_, err := Client.CreateTeam(ctx, *test.teamForTest)
+		require.NoError(t, err)
 	}
 
-	resp, err := DoWebRequest(ctx, http.MethodPost, basePathUsers+"/setIsActive", bytes.NewBuffer(jsonData))
-	if err != nil {
-		return err
+	if test.prForTest != nil {
+		_, err := Client.CreatePullRequest(ctx, test.prForTest.PullRequestId, test.prForTest.PullRequestName, test.prForTest.AuthorId)
+		require.NoError(t, err)
 	}
 
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	if test.setActiveForTest != nil {
+		_, err := Client.SetIsActive(ctx, test.setActiveForTest.UserId, test.setActiveForTest.IsActive)
+		require.NoError(t, err)
 	}
 
-	return nil
+	if test.mergeForTest != nil {
+		_, err := Client.MergePullRequest(ctx, test.mergeForTest.PullRequestId)
+		require.NoError(t, err)
+	}
 }
@@ -3,18 +3,30 @@
 package tests
 
 import (
-	"avito/internal/cerr"
-	"avito/internal/gen"
-	"bytes"
 	"context"
-	"encoding/json"
+	"testing"
+
+	"avito/client/api"
+	"avito/internal/cerr"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
-	"io"
-	"net/http"
-	"testing"
 )
 
+type reassignResult struct {
+	pr         api.PullRequest
+	replacedBy string
+}
+
+func assertPR(t *testing.T, expected api.PullRequest, actual api.PullRequest) {
+	t.Helper()
+
+	assert.Equal(t, expected.PullRequestId, actual.PullRequestId)
+	assert.Equal(t, expected.PullRequestName, actual.PullRequestName)
+	assert.Equal(t, expected.AuthorId, actual.AuthorId)
+	assert.Equal(t, expected.Status, actual.Status)
+	assert.ElementsMatch(t, expected.AssignedReviewers, actual.AssignedReviewers)
+}
+
 // TestCreate test/pullRequest/create
 func TestCreate(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), RequestTimeout)
@@ -22,9 +34,9 @@ func TestCreate(t *testing.T) {
 
 	tests := []TestData{
 		{
-			teamForTest: &gen.Team{
+			teamForTest: &api.Team{
 				TeamName: "TestCreatePRSuccess_2assign",
-				Members: []gen.TeamMember{
+				Members: []api.TeamMember{
 					{
 						IsActive: true,
 						UserId:   "TestCreatePRSuccess_2assign_1",
@@ -42,30 +54,26 @@ func TestCreate(t *testing.T) {
 					},
 				},
 			},
-			path:        basePathPR + "/create",
 			description: "Create success 2 assign",
-			body: gen.PostPullRequestCreateJSONBody{
+			body: api.PostPullRequestCreateJSONBody{
 				AuthorId:        "TestCreatePRSuccess_2assign_1",
 				PullRequestId:   "TestCreatePRSuccess_2assign",
 				PullRequestName: "TestCreatePRSuccess_2assign",
 			},
-			expectedCode: http.StatusCreated,
-			expectedBody: gen.PostPullRequestCreate201JSONResponse{
-				Pr: &gen.PullRequest{
-					PullRequestId:   "TestCreatePRSuccess_2assign",
-					PullRequestName: "TestCreatePRSuccess_2assign",
-					AuthorId:        "TestCreatePRSuccess_2assign_1",
-					Status:          gen.PullRequestStatusOPEN,
-					AssignedReviewers: []string{
-						"TestCreatePRSuccess_2assign_2",
-						"TestCreatePRSuccess_2assign_3",
-					},
+			expectedBody: &api.PullRequest{
+				PullRequestId:   "TestCreatePRSuccess_2assign",
+				PullRequestName: "TestCreatePRSuccess_2assign",
+				AuthorId:        "TestCreatePRSuccess_2assign_1",
+				Status:          api.PullRequestStatusOPEN,
+				AssignedReviewers: []string{
+					"TestCreatePRSuccess_2assign_2",
+					"TestCreatePRSuccess_2assign_3",
 				},
 			},
 		}, {
-			teamForTest: &gen.Team{
+			teamForTest: &api.Team{
 				TeamName: "TestCreatePRSuccess_1assign_2users",
-				Members: []gen.TeamMember{
+				Members: []api.TeamMember{
 					{
 						IsActive: true,
 						UserId:   "TestCreatePRSuccess_1assign_2users_1",
@@ -78,29 +86,25 @@ func TestCreate(t *testing.T) {
 					},
 				},
 			},
-			path:        basePathPR + "/create",
 			description: "Create success 1 assign when 2 users in team",
-			body: gen.PostPullRequestCreateJSONBody{
+			body: api.PostPullRequestCreateJSONBody{
 				AuthorId:        "TestCreatePRSuccess_1assign_2users_1",
 				PullRequestId:   "TestCreatePRSuccess_1assign_2users",
 				PullRequestName: "TestCreatePRSuccess_1assign_2users",
 			},
-			expectedCode: http.StatusCreated,
-			expectedBody: gen.PostPullRequestCreate201JSONResponse{
-				Pr: &gen.PullRequest{
-					PullRequestId:   "TestCreatePRSuccess_1assign_2users",
-					PullRequestName: "TestCreatePRSuccess_1assign_2users",
-					AuthorId:        "TestCreatePRSuccess_1assign_2users_1",
-					Status:          gen.PullRequestStatusOPEN,
-					AssignedReviewers: []string{
-						"TestCreatePRSuccess_1assign_2users_2",
-					},
+			expectedBody: &api.PullRequest{
+				PullRequestId:   "TestCreatePRSuccess_1assign_2users",
+				PullRequestName: "TestCreatePRSuccess_1assign_2users",
+				AuthorId:        "TestCreatePRSuccess_1assign_2users_1",
+				Status:          api.PullRequestStatusOPEN,
+				AssignedReviewers: []string{
+					"TestCreatePRSuccess_1assign_2users_2",
 				},
 			},
 		}, {
-			teamForTest: &gen.Team{
+			teamForTest: &api.Team{
 				TeamName: "TestCreatePRSuccess_1assign_3users",
-				Members: []gen.TeamMember{
+				Members: []api.TeamMember{
 					{
 						IsActive: true,
 						UserId:   "TestCreatePRSuccess_1assign_3users_1",
@@ -118,29 +122,25 @@ func TestCreate(t *testing.T) {
 					},
 				},
 			},
-			path:        basePathPR + "/create",
 			description: "Create success 1 assign when 3 users in team one is not active",
-			body: gen.PostPullRequestCreateJSONBody{
+			body: api.PostPullRequestCreateJSONBody{
 				AuthorId:        "TestCreatePRSuccess_1assign_3users_1",
 				PullRequestId:   "TestCreatePRSuccess_1assign_3users",
 				PullRequestName: "TestCreatePRSuccess_1assign_3users",
 			},
-			expectedCode: http.StatusCreated,
-			expectedBody: gen.PostPullRequestCreate201JSONResponse{
-				Pr: &gen.PullRequest{
-					PullRequestId:   "TestCreatePRSuccess_1assign_3users",
-					PullRequestName: "TestCreatePRSuccess_1assign_3users",
-					AuthorId:        "TestCreatePRSuccess_1assign_3users_1",
-					Status:          gen.PullRequestStatusOPEN,
-					AssignedReviewers: []string{
-						"TestCreatePRSuccess_1assign_3users_2",
-					},
+			expectedBody: &api.PullRequest{
+				PullRequestId:   "TestCreatePRSuccess_1assign_3users",
+				PullRequestName: "TestCreatePRSuccess_1assign_3users",
+				AuthorId:        "TestCreatePRSuccess_1assign_3users_1",
+				Status:          api.PullRequestStatusOPEN,
+				AssignedReviewers: []string{
+					"TestCreatePRSuccess_1assign_3users_2",
 				},
 			},
 		}, {
-			teamForTest: &gen.Team{
+			teamForTest: &api.Team{
 				TeamName: "TestCreatePRSuccess_0assign",
-				Members: []gen.TeamMember{
+				Members: []api.TeamMember{
 					{
 						IsActive: true,
 						UserId:   "TestCreatePRSuccess_0assign_1",
@@ -148,39 +148,31 @@ func TestCreate(t *testing.T) {
 					},
 				},
 			},
-			path:        basePathPR + "/create",
 			description: "Create success 0 assign ",
-			body: gen.PostPullRequestCreateJSONBody{
+			body: api.PostPullRequestCreateJSONBody{
 				AuthorId:        "TestCreatePRSuccess_0assign_1",
 				PullRequestId:   "TestCreatePRSuccess_0assign",
 				PullRequestName: "TestCreatePRSuccess_0assign",
 			},
-			expectedCode: http.StatusCreated,
-			expectedBody: gen.PostPullRequestCreate201JSONResponse{
-				Pr: &gen.PullRequest{
-					PullRequestId:     "TestCreatePRSuccess_0assign",
-					PullRequestName:   "TestCreatePRSuccess_0assign",
-					AuthorId:          "TestCreatePRSuccess_0assign_1",
-					Status:            gen.PullRequestStatusOPEN,
-					AssignedReviewers: []string{},
-				},
+			expectedBody: &api.PullRequest{
+				PullRequestId:     "TestCreatePRSuccess_0assign",
+				PullRequestName:   "TestCreatePRSuccess_0assign",
+				AuthorId:          "TestCreatePRSuccess_0assign_1",
+				Status:            api.PullRequestStatusOPEN,
+				AssignedReviewers: []string{},
 			},
 		}, {
-			path:        basePathPR + "/create",
 			description: "Create NotFound data",
-			body: gen.PostPullRequestCreateJSONBody{
+			body: api.PostPullRequestCreateJSONBody{
 				AuthorId:        "TestCreatePRNotFound",
 				PullRequestId:   "TestCreatePRNotFound",
 				PullRequestName: "TestCreatePRNotFound",
 			},
-			expectedCode: http.StatusNotFound,
-			expectedBody: gen.PostPullRequestCreate404JSONResponse{
-				Error: GetError(cerr.NOT_FOUND).Error,
-			},
+			expectedErr: GetError(cerr.NOT_FOUND),
 		}, {
-			teamForTest: &gen.Team{
+			teamForTest: &api.Team{
 				TeamName: "TestCreatePRExist",
-				Members: []gen.TeamMember{
+				Members: []api.TeamMember{
 					{
 						IsActive: false,
 						UserId:   "TestCreatePRExist",
@@ -188,83 +180,37 @@ func TestCreate(t *testing.T) {
 					},
 				},
 			},
-			prForTest: &gen.PostPullRequestCreateJSONBody{
+			prForTest: &api.PostPullRequestCreateJSONBody{
 				AuthorId:        "TestCreatePRExist",
 				PullRequestId:   "TestCreatePRExist",
 				PullRequestName: "TestCreatePRExist",
 			},
-			path:        basePathPR + "/create",
 			description: "Create when PR exist",
-			body: gen.PostPullRequestCreateJSONBody{
+			body: api.PostPullRequestCreateJSONBody{
 				AuthorId:        "TestCreatePRExist",
 				PullRequestId:   "TestCreatePRExist",
 				PullRequestName: "TestCreatePRExist",
 			},
-			expectedCode: http.StatusConflict,
-			expectedBody: gen.PostPullRequestCreate409JSONResponse{
-				Error: GetError(cerr.PR_EXISTS).Error,
-			},
+			expectedErr: GetError(cerr.PR_EXISTS),
 		},
 	}
-	var errorData gen.ErrorResponse
 
 	for _, test := range tests {
 		t.Run(test.description, func(t *testing.T) {
-			if test.teamForTest != nil {
-				err := CreateTeamForTest(test.teamForTest)
-				if err != nil {
-					t.Fatalf("error: %s", err)
-				}
-			}
-			if test.prForTest != nil {
-				err := CreatePRForTest(test.prForTest)
-				if err != nil {
-					t.Fatalf("error: %s", err)
-				}
-			}
-			jsonData, err := json.Marshal(test.body)
-			if err != nil {
-				t.Fatalf("error: %s", err)
-			}
+			SetUp(t, test)
 
-			resp, err := DoWebRequest(ctx, http.MethodPost, test.path, bytes.NewBuffer(jsonData))
-			if err != nil {
-				t.Fatalf("error: %s", err)
-			}
-			defer resp.Body.Close()
+			body := test.body.(api.PostPullRequestCreateJSONBody)
 
-			if resp.StatusCode != test.expectedCode {
-				err = json.NewDecoder(resp.Body).Decode(&errorData)
-				if err != nil {
-					t.Fatalf("Unexpected error: %s", err)
-				}
+			pr, err := Client.CreatePullRequest(ctx, body.PullRequestId, body.PullRequestName, body.AuthorId)
+			if test.expectedErr != nil {
+				AssertError(t, test.expectedErr, err)
 
-				t.Errorf("got %d : %v", resp.StatusCode, errorData)
-			} else if resp.StatusCode == http.StatusCreated {
-				var respBody gen.PostPullRequestCreate201JSONResponse
-				expBody := test.expectedBody.(gen.PostPullRequestCreate201JSONResponse)
-				if err = json.NewDecoder(resp.Body).Decode(&respBody); err != nil {
-					t.Fatalf("Failed to decode response body: %v", err)
-				} else {
-					if respBody.Pr.AuthorId != expBody.Pr.AuthorId &&
-						respBody.Pr.PullRequestId != expBody.Pr.PullRequestId &&
-						respBody.Pr.PullRequestName != expBody.Pr.PullRequestName &&
-						respBody.Pr.Status != expBody.Pr.Status &&
-						respBody.Pr.CreatedAt == nil {
-						t.Fatalf("want %v got %v", expBody, respBody)
-					}
-					assert.ElementsMatch(t, respBody.Pr.AssignedReviewers, expBody.Pr.AssignedReviewers)
-				}
-			} else {
-				bodyBytes, err := io.ReadAll(resp.Body)
-				require.NoError(t, err)
-
-				expectedBytes, err := json.Marshal(test.expectedBody)
-				require.NoError(t, err)
-
-				assert.JSONEq(t, string(expectedBytes), string(bodyBytes))
+				return
 			}
 
+			require.NoError(t, err)
+			assertPR(t, *test.expectedBody.(*api.PullRequest), *pr)
+			assert.NotNil(t, pr.CreatedAt)
 		})
 	}
 }
@@ -276,9 +222,9 @@ func TestMerge(t *testing.T) {
 
 	tests := []TestData{
 		{
-			teamForTest: &gen.Team{
+			teamForTest: &api.Team{
 				TeamName: "TestMergePRSuccess",
-				Members: []gen.TeamMember{
+				Members: []api.TeamMember{
 					{
 						IsActive: true,
 						UserId:   "TestMergePRSuccess",
@@ -286,97 +232,48 @@ func TestMerge(t *testing.T) {
 					},
 				},
 			},
-			prForTest: &gen.PostPullRequestCreateJSONBody{
+			prForTest: &api.PostPullRequestCreateJSONBody{
 				AuthorId:        "TestMergePRSuccess",
 				PullRequestId:   "TestMergePRSuccess",
 				PullRequestName: "TestMergePRSuccess",
 			},
-			path:        basePathPR + "/merge",
 			description: "Merge PR Success",
-			body: gen.PostPullRequestMergeJSONRequestBody{
+			body: api.PostPullRequestMergeJSONRequestBody{
 				PullRequestId: "TestMergePRSuccess",
 			},
-			expectedCode: http.StatusOK,
-			expectedBody: gen.PostPullRequestMerge200JSONResponse{
-				Pr: &gen.PullRequest{
-					PullRequestId:     "TestMergePRSuccess",
-					PullRequestName:   "TestMergePRSuccess",
-					AuthorId:          "TestMergePRSuccess",
-					Status:            gen.PullRequestStatusOPEN,
-					AssignedReviewers: []string{},
-				},
+			expectedBody: &api.PullRequest{
+				PullRequestId:     "TestMergePRSuccess",
+				PullRequestName:   "TestMergePRSuccess",
+				AuthorId:          "TestMergePRSuccess",
+				Status:            api.PullRequestStatusMERGED,
+				AssignedReviewers: []string{},
 			},
 		}, {
-			path:        basePathPR + "/merge",
 			description: "Merge PR NotFound",
-			body: gen.PostPullRequestMergeJSONRequestBody{
+			body: api.PostPullRequestMergeJSONRequestBody{
 				PullRequestId: "TestMergePRNotFound",
 			},
-			expectedCode: http.StatusNotFound,
-			expectedBody: gen.PostPullRequestMerge404JSONResponse{
-				Error: GetError(cerr.NOT_FOUND).Error,
-			},
+			expectedErr: GetError(cerr.NOT_FOUND),
 		},
 	}
-	var errorData gen.ErrorResponse
 
 	for _, test := range tests {
 		t.Run(test.description, func(t *testing.T) {
-			if test.teamForTest != nil {
-				err := CreateTeamForTest(test.teamForTest)
-				if err != nil {
-					t.Fatalf("error: %s", err)
-				}
-			}
-			if test.prForTest != nil {
-				err := CreatePRForTest(test.prForTest)
-				if err != nil {
-					t.Fatalf("error: %s", err)
-				}
-			}
-			jsonData, err := json.Marshal(test.body)
-			if err != nil {
-				t.Fatalf("error: %s", err)
-			}
-
-			resp, err := DoWebRequest(ctx, http.MethodPost, test.path, bytes.NewBuffer(jsonData))
-			if err != nil {
-				t.Fatalf("error: %s", err)
-			}
-			defer resp.Body.Close()
-
-			if resp.StatusCode != test.expectedCode {
-				err = json.NewDecoder(resp.Body).Decode(&errorData)
-				if err != nil {
-					t.Fatalf("Unexpected error: %s", err)
-				}
+			SetUp(t, test)
 
-				t.Errorf("got %d : %v", resp.StatusCode, errorData)
-			} else if resp.StatusCode == http.StatusOK {
-				var respBody gen.PostPullRequestMerge200JSONResponse
-				expBody := test.expectedBody.(gen.PostPullRequestMerge200JSONResponse)
-				if err = json.NewDecoder(resp.Body).Decode(&respBody); err != nil {
-					t.Fatalf("Failed to decode response body: %v", err)
-				} else {
-					if respBody.Pr.AuthorId != expBody.Pr.AuthorId &&
-						respBody.Pr.PullRequestId != expBody.Pr.PullRequestId &&
-						respBody.Pr.PullRequestName != expBody.Pr.PullRequestName &&
-						respBody.Pr.Status != expBody.Pr.Status &&
-						respBody.Pr.CreatedAt == nil && respBody.Pr.MergedAt == nil {
-						t.Fatalf("want %v got %v", expBody, respBody)
-					}
-					assert.ElementsMatch(t, respBody.Pr.AssignedReviewers, expBody.Pr.AssignedReviewers)
-				}
-			} else {
-				bodyBytes, err := io.ReadAll(resp.Body)
-				require.NoError(t, err)
+			body := test.body.(api.PostPullRequestMergeJSONRequestBody)
 
-				expectedBytes, err := json.Marshal(test.expectedBody)
-				require.NoError(t, err)
+			pr, err := Client.MergePullRequest(ctx, body.PullRequestId)
+			if test.expectedErr != nil {
+				AssertError(t, test.expectedErr, err)
 
-				assert.JSONEq(t, string(expectedBytes), string(bodyBytes))
+				return
 			}
 
+			require.NoError(t, err)
+			assertPR(t, *test.expectedBody.(*api.PullRequest), *pr)
+			assert.NotNil(t, pr.CreatedAt)
+			assert.NotNil(t, pr.MergedAt)
 		})
 	}
 }
@@ -388,9 +285,9 @@ func TestReassign(t *testing.T) {
 
 	tests := []TestData{
 		{
-			teamForTest: &gen.Team{
+			teamForTest: &api.Team{
 				TeamName: "TestReassignPRSuccess",
-				Members: []gen.TeamMember{
+				Members: []api.TeamMember{
 					{
 						IsActive: true,
 						UserId:   "TestReassignPRSuccess_1",
@@ -413,39 +310,37 @@ func TestReassign(t *testing.T) {
 					},
 				},
 			},
-			prForTest: &gen.PostPullRequestCreateJSONBody{
+			prForTest: &api.PostPullRequestCreateJSONBody{
 				AuthorId:        "TestReassignPRSuccess_1",
 				PullRequestId:   "TestReassignPRSuccess",
 				PullRequestName: "TestReassignPRSuccess",
 			},
-			setActiveForTest: &gen.PostUsersSetIsActiveJSONBody{
+			setActiveForTest: &api.PostUsersSetIsActiveJSONBody{
 				UserId:   "TestReassignPRSuccess_4",
 				IsActive: true,
 			},
-			path:        basePathPR + "/reassign",
 			description: "Reassign PR Success",
-			body: gen.PostPullRequestReassignJSONBody{
+			body: api.PostPullRequestReassignJSONBody{
 				PullRequestId: "TestReassignPRSuccess",
 				OldUserId:     "TestReassignPRSuccess_2",
 			},
-			expectedCode: http.StatusOK,
-			expectedBody: gen.PostPullRequestReassign200JSONResponse{
-				Pr: gen.PullRequest{
+			expectedBody: reassignResult{
+				pr: api.PullRequest{
 					PullRequestId:   "TestReassignPRSuccess",
 					PullRequestName: "TestReassignPRSuccess",
 					AuthorId:        "TestReassignPRSuccess_1",
-					Status:          gen.PullRequestStatusMERGED,
+					Status:          api.PullRequestStatusOPEN,
 					AssignedReviewers: []string{
 						"TestReassignPRSuccess_3",
 						"TestReassignPRSuccess_4",
 					},
 				},
-				ReplacedBy: "TestReassignPRSuccess_4",
+				replacedBy: "TestReassignPRSuccess_4",
 			},
 		}, {
-			teamForTest: &gen.Team{
+			teamForTest: &api.Team{
 				TeamName: " TestReassignPRNotFoundUser",
-				Members: []gen.TeamMember{
+				Members: []api.TeamMember{
 					{
 						IsActive: true,
 						UserId:   "TestReassignPRNotFoundUser_1",
@@ -468,29 +363,25 @@ func TestReassign(t *testing.T) {
 					},
 				},
 			},
-			prForTest: &gen.PostPullRequestCreateJSONBody{
+			prForTest: &api.PostPullRequestCreateJSONBody{
 				AuthorId:        "TestReassignPRNotFoundUser_1",
 				PullRequestId:   "TestReassignPRNotFoundUser",
 				PullRequestName: "TestReassignPRNotFoundUser",
 			},
-			setActiveForTest: &gen.PostUsersSetIsActiveJSONBody{
+			setActiveForTest: &api.PostUsersSetIsActiveJSONBody{
 				UserId:   "TestReassignPRNotFoundUser_4",
 				IsActive: true,
 			},
-			path:        basePathPR + "/reassign",
 			description: "Reassign PR Not Found User",
-			body: gen.PostPullRequestReassignJSONBody{
+			body: api.PostPullRequestReassignJSONBody{
 				PullRequestId: "TestReassignPRNotFoundUser",
 				OldUserId:     "TestReassignPRNotFoundUser_5",
 			},
-			expectedCode: http.StatusNotFound,
-			expectedBody: gen.PostPullRequestReassign404JSONResponse{
-				Error: GetError(cerr.NOT_FOUND).Error,
-			},
+			expectedErr: GetError(cerr.NOT_FOUND),
 		}, {
-			teamForTest: &gen.Team{
+			teamForTest: &api.Team{
 				TeamName: "TestReassignPRNotFoundPR",
-				Members: []gen.TeamMember{
+				Members: []api.TeamMember{
 					{
 						IsActive: true,
 						UserId:   "TestReassignPRNotFoundPR_1",
@@ -513,29 +404,25 @@ func TestReassign(t *testing.T) {
 					},
 				},
 			},
-			prForTest: &gen.PostPullRequestCreateJSONBody{
+			prForTest: &api.PostPullRequestCreateJSONBody{
 				AuthorId:        "TestReassignPRNotFoundPR_1",
 				PullRequestId:   "TestReassignPRNotFoundPR",
 				PullRequestName: "TestReassignPRNotFoundPR",
 			},
-			setActiveForTest: &gen.PostUsersSetIsActiveJSONBody{
+			setActiveForTest: &api.PostUsersSetIsActiveJSONBody{
 				UserId:   "TestReassignPRNotFoundPR_4",
 				IsActive: true,
 			},
-			path:        basePathPR + "/reassign",
 			description: "Reassign PR Not Found PR",
-			body: gen.PostPullRequestReassignJSONBody{
+			body: api.PostPullRequestReassignJSONBody{
 				PullRequestId: "TestReassignPRNotFoundPR_2",
 				OldUserId:     "TestReassignPRNotFoundPR_2",
 			},
-			expectedCode: http.StatusNotFound,
-			expectedBody: gen.PostPullRequestReassign404JSONResponse{
-				Error: GetError(cerr.NOT_FOUND).Error,
-			},
+			expectedErr: GetError(cerr.NOT_FOUND),
 		}, {
-			teamForTest: &gen.Team{
+			teamForTest: &api.Team{
 				TeamName: "TestReassignPRMergedPR",
-				Members: []gen.TeamMember{
+				Members: []api.TeamMember{
 					{
 						IsActive: true,
 						UserId:   "TestReassignPRMergedPR_1",
@@ -558,28 +445,24 @@ func TestReassign(t *testing.T) {
 					},
 				},
 			},
-			prForTest: &gen.PostPullRequestCreateJSONBody{
+			prForTest: &api.PostPullRequestCreateJSONBody{
 				AuthorId:        "TestReassignPRMergedPR_1",
 				PullRequestId:   "TestReassignPRMergedPR",
 				PullRequestName: "TestReassignPRMergedPR",
 			},
-			mergeForTest: &gen.PostPullRequestMergeJSONBody{
+			mergeForTest: &api.PostPullRequestMergeJSONBody{
 				PullRequestId: "TestReassignPRMergedPR",
 			},
-			path:        basePathPR + "/reassign",
 			description: "Reassign PR Merged PR",
-			body: gen.PostPullRequestReassignJSONBody{
+			body: api.PostPullRequestReassignJSONBody{
 				PullRequestId: "TestReassignPRMergedPR",
 				OldUserId:     "TestReassignPRMergedPR_2",
 			},
-			expectedCode: http.StatusConflict,
-			expectedBody: gen.PostPullRequestReassign409JSONResponse{
-				Error: GetError(cerr.PR_MERGED).Error,
-			},
+			expectedErr: GetError(cerr.PR_MERGED),
 		}, {
-			teamForTest: &gen.Team{
+			teamForTest: &api.Team{
 				TeamName: "TestReassignPRNotAssigned",
-				Members: []gen.TeamMember{
+				Members: []api.TeamMember{
 					{
 						IsActive: true,
 						UserId:   "TestReassignPRNotAssigned_1",
@@ -602,29 +485,25 @@ func TestReassign(t *testing.T) {
 					},
 				},
 			},
-			prForTest: &gen.PostPullRequestCreateJSONBody{
+			prForTest: &api.PostPullRequestCreateJSONBody{
 				AuthorId:        "TestReassignPRNotAssigned_1",
 				PullRequestId:   "TestReassignPRNotAssigned",
 				PullRequestName: "TestReassignPRNotAssigned",
 			},
-			setActiveForTest: &gen.PostUsersSetIsActiveJSONBody{
+			setActiveForTest: &api.PostUsersSetIsActiveJSONBody{
 				UserId:   "TestReassignPRNotAssigned_4",
 				IsActive: true,
 			},
-			path:        basePathPR + "/reassign",
 			description: "Reassign PR NotAssigned user",
-			body: gen.PostPullRequestReassignJSONBody{
+			body: api.PostPullRequestReassignJSONBody{
 				PullRequestId: "TestReassignPRNotAssigned",
 				OldUserId:     "TestReassignPRNotAssigned_4",
 			},
-			expectedCode: http.StatusConflict,
-			expectedBody: gen.PostPullRequestReassign409JSONResponse{
-				Error: GetError(cerr.NOT_ASSIGNED).Error,
-			},
+			expectedErr: GetError(cerr.NOT_ASSIGNED),
 		}, {
-			teamForTest: &gen.Team{
+			teamForTest: &api.Team{
 				TeamName: "TestReassignPRNoCandidate",
-				Members: []gen.TeamMember{
+				Members: []api.TeamMember{
 					{
 						IsActive: true,
 						UserId:   "TestReassignPRNoCandidate_1",
@@ -647,96 +526,38 @@ func TestReassign(t *testing.T) {
 					},
 				},
 			},
-			prForTest: &gen.PostPullRequestCreateJSONBody{
+			prForTest: &api.PostPullRequestCreateJSONBody{
 				AuthorId:        "TestReassignPRNoCandidate_1",
 				PullRequestId:   "TestReassignPRNoCandidate",
 				PullRequestName: "TestReassignPRNoCandidate",
 			},
-			path:        basePathPR + "/reassign",
 			description: "Reassign PR NoCandidate user",
-			body: gen.PostPullRequestReassignJSONBody{
+			body: api.PostPullRequestReassignJSONBody{
 				PullRequestId: "TestReassignPRNoCandidate",
 				OldUserId:     "TestReassignPRNoCandidate_2",
 			},
-			expectedCode: http.StatusConflict,
-			expectedBody: gen.PostPullRequestReassign409JSONResponse{
-				Error: GetError(cerr.NO_CANDIDATE).Error,
-			},
+			expectedErr: GetError(cerr.NO_CANDIDATE),
 		},
 	}
-	var errorData gen.ErrorResponse
 
 	for _, test := range tests {
 		t.Run(test.description, func(t *testing.T) {
-			if test.teamForTest != nil {
-				err := CreateTeamForTest(test.teamForTest)
-				if err != nil {
-					t.Fatalf("error: %s", err)
-				}
-			}
-			if test.prForTest != nil {
-				err := CreatePRForTest(test.prForTest)
-				if err != nil {
-					t.Fatalf("error: %s", err)
-				}
-			}
+			SetUp(t, test)
 
-			if test.setActiveForTest != nil {
-				err := SetIsActiveForTest(test.setActiveForTest)
-				if err != nil {
-					t.Fatalf("error: %s", err)
-				}
-			}
+			body := test.body.(api.PostPullRequestReassignJSONBody)
 
-			if test.mergeForTest != nil {
-				err := MergePRForTest(test.mergeForTest)
-				if err != nil {
-					t.Fatalf("error: %s", err)
-				}
-			}
-			jsonData, err := json.Marshal(test.body)
-			if err != nil {
-				t.Fatalf("error: %s", err)
-			}
+			pr, replacedBy, err := Client.Reassign(ctx, body.PullRequestId, body.OldUserId)
+			if test.expectedErr != nil {
+				AssertError(t, test.expectedErr, err)
 
-			resp, err := DoWebRequest(ctx, http.MethodPost, test.path, bytes.NewBuffer(jsonData))
-			if err != nil {
-				t.Fatalf("error: %s", err)
+				return
 			}
-			defer resp.Body.Close()
-
-			if resp.StatusCode != test.expectedCode {
-				err = json.NewDecoder(resp.Body).Decode(&errorData)
-				if err != nil {
-					t.Fatalf("Unexpected error: %s", err)
-				}
 
-				t.Errorf("got %d : %v", resp.StatusCode, errorData)
-			} else if resp.StatusCode == http.StatusOK {
-				var respBody gen.PostPullRequestReassign200JSONResponse
-				expBody := test.expectedBody.(gen.PostPullRequestReassign200JSONResponse)
-				if err = json.NewDecoder(resp.Body).Decode(&respBody); err != nil {
-					t.Fatalf("Failed to decode response body: %v", err)
-				} else {
-					if respBody.Pr.AuthorId != expBody.Pr.AuthorId &&
-						respBody.Pr.PullRequestId != expBody.Pr.PullRequestId &&
-						respBody.Pr.PullRequestName != expBody.Pr.PullRequestName &&
-						respBody.Pr.Status != expBody.Pr.Status &&
-						respBody.Pr.CreatedAt == nil && respBody.ReplacedBy != expBody.ReplacedBy {
-						t.Fatalf("want %v got %v", expBody, respBody)
-					}
-					assert.ElementsMatch(t, respBody.Pr.AssignedReviewers, expBody.Pr.AssignedReviewers)
-				}
-			} else {
-				bodyBytes, err := io.ReadAll(resp.Body)
-				require.NoError(t, err)
-
-				expectedBytes, err := json.Marshal(test.expectedBody)
-				require.NoError(t, err)
-
-				assert.JSONEq(t, string(expectedBytes), string(bodyBytes))
-			}
+			require.NoError(t, err)
 
+			expected := test.expectedBody.(reassignResult)
+			assertPR(t, expected.pr, *pr)
+			assert.Equal(t, expected.replacedBy, replacedBy)
 		})
 	}
 }
@@ -3,16 +3,13 @@
 package tests
 
 import (
-	"avito/internal/cerr"
-	"avito/internal/gen"
-	"bytes"
 	"context"
-	"encoding/json"
+	"testing"
+
+	"avito/client/api"
+	"avito/internal/cerr"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
-	"io"
-	"net/http"
-	"testing"
 )
 
 // TestAdd test /team/add
@@ -22,11 +19,10 @@ func TestAdd(t *testing.T) {
 
 	tests := []TestData{
 		{
-			path:        basePathTeam + "/add",
 			description: "Add success",
-			body: gen.Team{
+			body: api.Team{
 				TeamName: "testAddSuccess",
-				Members: []gen.TeamMember{
+				Members: []api.TeamMember{
 					{
 						IsActive: true,
 						Username: "testAddSuccess",
@@ -34,25 +30,21 @@ func TestAdd(t *testing.T) {
 					},
 				},
 			},
-			expectedCode: http.StatusCreated,
-			expectedBody: gen.PostTeamAdd201JSONResponse{
-				Team: &gen.Team{
-					TeamName: "testAddSuccess",
-					Members: []gen.TeamMember{
-						{
-							IsActive: true,
-							Username: "testAddSuccess",
-							UserId:   "testAddSuccess_1",
-						},
+			expectedBody: &api.Team{
+				TeamName: "testAddSuccess",
+				Members: []api.TeamMember{
+					{
+						IsActive: true,
+						Username: "testAddSuccess",
+						UserId:   "testAddSuccess_1",
 					},
 				},
 			},
 		}, {
-			path:        basePathTeam + "/add",
 			description: "Add team_name already exists",
-			teamForTest: &gen.Team{
+			teamForTest: &api.Team{
 				TeamName: "testAddTeamNameExist",
-				Members: []gen.TeamMember{
+				Members: []api.TeamMember{
 					{
 						IsActive: true,
 						Username: "testAddTeamNameExist",
@@ -60,9 +52,9 @@ func TestAdd(t *testing.T) {
 					},
 				},
 			},
-			body: gen.Team{
+			body: api.Team{
 				TeamName: "testAddTeamNameExist",
-				Members: []gen.TeamMember{
+				Members: []api.TeamMember{
 					{
 						IsActive: true,
 						Username: "testAddTeamNameExist",
@@ -70,50 +62,44 @@ func TestAdd(t *testing.T) {
 					},
 				},
 			},
-			expectedCode: http.StatusBadRequest,
-			expectedBody: gen.PostTeamAdd400JSONResponse{
-				Error: GetError(cerr.TEAM_EXISTS).Error,
+			expectedErr: GetError(cerr.TEAM_EXISTS),
+		}, {
+			description: "Add duplicate members",
+			body: api.Team{
+				TeamName: "testAddDuplicateMembers",
+				Members: []api.TeamMember{
+					{
+						IsActive: true,
+						Username: "testAddDuplicateMembers",
+						UserId:   "testAddDuplicateMembers_1",
+					},
+					{
+						IsActive: true,
+						Username: "testAddDuplicateMembers",
+						UserId:   "testAddDuplicateMembers_1",
+					},
+				},
 			},
+			expectedErr: GetValidationError(api.FieldError{
+				Field:  "members[1].user_id",
+				Reason: `duplicates members[0].user_id "testAddDuplicateMembers_1"`,
+			}),
 		},
 	}
-	var errorData gen.ErrorResponse
 
 	for _, test := range tests {
 		t.Run(test.description, func(t *testing.T) {
-			if test.teamForTest != nil {
-				err := CreateTeamForTest(test.teamForTest)
-				if err != nil {
-					t.Fatalf("error: %s", err)
-				}
-			}
-			jsonData, err := json.Marshal(test.body)
-			if err != nil {
-				t.Fatalf("error: %s", err)
-			}
-
-			resp, err := DoWebRequest(ctx, http.MethodPost, test.path, bytes.NewBuffer(jsonData))
-			if err != nil {
-				t.Fatalf("error: %s", err)
-			}
-			defer resp.Body.Close()
-
-			if resp.StatusCode != test.expectedCode {
-				err = json.NewDecoder(resp.Body).Decode(&errorData)
-				if err != nil {
-					t.Fatalf("Unexpected error: %s", err)
-				}
-
-				t.Errorf("got %d : %v", resp.StatusCode, errorData)
-			} else {
-				bodyBytes, err := io.ReadAll(resp.Body)
-				require.NoError(t, err)
+			SetUp(t, test)
 
-				expectedBytes, err := json.Marshal(test.expectedBody)
-				require.NoError(t, err)
+			team, err := Client.CreateTeam(ctx, test.body.(api.Team))
+			if test.expectedErr != nil {
+				AssertError(t, test.expectedErr, err)
 
-				assert.JSONEq(t, string(expectedBytes), string(bodyBytes))
+				return
 			}
 
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedBody, team)
 		})
 	}
 }
@@ -125,11 +111,10 @@ func TestGet(t *testing.T) {
 
 	tests := []TestData{
 		{
-			path:        basePathTeam + "/get?team_name=",
 			description: "Get team_name Success",
-			teamForTest: &gen.Team{
+			teamForTest: &api.Team{
 				TeamName: "testGetTeamNameSuccess",
-				Members: []gen.TeamMember{
+				Members: []api.TeamMember{
 					{
 						IsActive: true,
 						Username: "testGetTeamNameSuccess",
@@ -137,10 +122,9 @@ func TestGet(t *testing.T) {
 					},
 				},
 			},
-			body:         "testGetTeamNameSuccess",
-			expectedCode: http.StatusOK,
-			expectedBody: gen.GetTeamGet200JSONResponse{
-				Members: []gen.TeamMember{
+			body: "testGetTeamNameSuccess",
+			expectedBody: &api.Team{
+				Members: []api.TeamMember{
 					{
 						IsActive: true,
 						Username: "testGetTeamNameSuccess",
@@ -151,48 +135,25 @@ func TestGet(t *testing.T) {
 			},
 		},
 		{
-			path:         basePathTeam + "/get?team_name=",
-			description:  "Get team_name NotFound",
-			body:         "testGetTeamNameNotFound",
-			expectedCode: http.StatusNotFound,
-			expectedBody: gen.GetTeamGet404JSONResponse{
-				Error: GetError(cerr.NOT_FOUND).Error,
-			},
+			description: "Get team_name NotFound",
+			body:        "testGetTeamNameNotFound",
+			expectedErr: GetError(cerr.NOT_FOUND),
 		},
 	}
-	var errorData gen.ErrorResponse
 
 	for _, test := range tests {
 		t.Run(test.description, func(t *testing.T) {
-			if test.teamForTest != nil {
-				err := CreateTeamForTest(test.teamForTest)
-				if err != nil {
-					t.Fatalf("error: %s", err)
-				}
-			}
-
-			resp, err := DoWebRequest(ctx, http.MethodGet, test.path+test.body.(string), nil)
-			if err != nil {
-				t.Fatalf("error: %s", err)
-			}
-			defer resp.Body.Close()
+			SetUp(t, test)
 
-			if resp.StatusCode != test.expectedCode {
-				err = json.NewDecoder(resp.Body).Decode(&errorData)
-				if err != nil {
-					t.Fatalf("Unexpected error: %s", err)
-				}
+			team, err := Client.GetTeam(ctx, test.body.(string))
+			if test.expectedErr != nil {
+				AssertError(t, test.expectedErr, err)
 
-				t.Errorf("got %d : %v", resp.StatusCode, errorData)
-			} else {
-				bodyBytes, err := io.ReadAll(resp.Body)
-				require.NoError(t, err)
-
-				expectedBytes, err := json.Marshal(test.expectedBody)
-				require.NoError(t, err)
-
-				assert.JSONEq(t, string(expectedBytes), string(bodyBytes))
+				return
 			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedBody, team)
 		})
 	}
 }
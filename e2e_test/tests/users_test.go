@@ -3,16 +3,13 @@
 package tests
 
 import (
-	"avito/internal/cerr"
-	"avito/internal/gen"
-	"bytes"
 	"context"
-	"encoding/json"
+	"testing"
+
+	"avito/client/api"
+	"avito/internal/cerr"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
-	"io"
-	"net/http"
-	"testing"
 )
 
 // TestSetIsActive test /user/setIsActive
@@ -22,9 +19,9 @@ func TestSetIsActive(t *testing.T) {
 
 	tests := []TestData{
 		{
-			teamForTest: &gen.Team{
+			teamForTest: &api.Team{
 				TeamName: "testSetIsActiveSuccess",
-				Members: []gen.TeamMember{
+				Members: []api.TeamMember{
 					{
 						IsActive: true,
 						Username: "testSetIsActiveSuccess",
@@ -32,75 +29,45 @@ func TestSetIsActive(t *testing.T) {
 					},
 				},
 			},
-			path:        basePathUsers + "/setIsActive",
 			description: "Set Is Active Success",
 
-			body: gen.PostUsersSetIsActiveJSONBody{
+			body: api.PostUsersSetIsActiveJSONBody{
 				IsActive: false,
 				UserId:   "testSetIsActiveSuccess",
 			},
-			expectedCode: http.StatusOK,
-			expectedBody: gen.PostUsersSetIsActive200JSONResponse{
-				User: &gen.User{
-					UserId:   "testSetIsActiveSuccess",
-					Username: "testSetIsActiveSuccess",
-					TeamName: "testSetIsActiveSuccess",
-					IsActive: false,
-				},
+			expectedBody: &api.User{
+				UserId:   "testSetIsActiveSuccess",
+				Username: "testSetIsActiveSuccess",
+				TeamName: "testSetIsActiveSuccess",
+				IsActive: false,
 			},
 		},
 		{
-			path:        basePathUsers + "/setIsActive",
 			description: "Set Is Active NotFound",
 
-			body: gen.PostUsersSetIsActiveJSONBody{
+			body: api.PostUsersSetIsActiveJSONBody{
 				IsActive: false,
 				UserId:   "testSetIsActiveNotFound",
 			},
-			expectedCode: http.StatusNotFound,
-			expectedBody: gen.PostUsersSetIsActive404JSONResponse{
-				Error: GetError(cerr.NOT_FOUND).Error,
-			},
+			expectedErr: GetError(cerr.NOT_FOUND),
 		},
 	}
-	var errorData gen.ErrorResponse
 
 	for _, test := range tests {
 		t.Run(test.description, func(t *testing.T) {
-			if test.teamForTest != nil {
-				err := CreateTeamForTest(test.teamForTest)
-				if err != nil {
-					t.Fatalf("error: %s", err)
-				}
-			}
-
-			jsonData, err := json.Marshal(test.body)
-			if err != nil {
-				t.Fatalf("error: %s", err)
-			}
-
-			resp, err := DoWebRequest(ctx, http.MethodPost, test.path, bytes.NewBuffer(jsonData))
-			if err != nil {
-				t.Fatalf("error: %s", err)
-			}
-			defer resp.Body.Close()
+			SetUp(t, test)
 
-			if resp.StatusCode != test.expectedCode {
-				err = json.NewDecoder(resp.Body).Decode(&errorData)
-				if err != nil {
-					t.Fatalf("Unexpected error: %s", err)
-				}
+			body := test.body.(api.PostUsersSetIsActiveJSONBody)
 
-				t.Errorf("got %d : %v", resp.StatusCode, errorData)
-			} else {
-				bodyBytes, err := io.ReadAll(resp.Body)
-				require.NoError(t, err)
+			user, err := Client.SetIsActive(ctx, body.UserId, body.IsActive)
+			if test.expectedErr != nil {
+				AssertError(t, test.expectedErr, err)
 
-				expectedBytes, err := json.Marshal(test.expectedBody)
-				require.NoError(t, err)
-
-				assert.JSONEq(t, string(expectedBytes), string(bodyBytes))
+				return
 			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedBody, user)
 		})
 	}
 }
@@ -112,9 +79,9 @@ func TestGetReview(t *testing.T) {
 
 	tests := []TestData{
 		{
-			teamForTest: &gen.Team{
+			teamForTest: &api.Team{
 				TeamName: "testGetReviewSuccessNil",
-				Members: []gen.TeamMember{
+				Members: []api.TeamMember{
 					{
 						IsActive: true,
 						Username: "testGetReviewSuccessNil",
@@ -122,19 +89,14 @@ func TestGetReview(t *testing.T) {
 					},
 				},
 			},
-			path:         basePathUsers + "/getReview?user_id=",
 			description:  "Get Review Success 0 PR",
 			body:         "testGetReviewSuccessNil",
-			expectedCode: http.StatusOK,
-			expectedBody: gen.GetUsersGetReview200JSONResponse{
-				UserId:       "testGetReviewSuccessNil",
-				PullRequests: []gen.PullRequestShort{},
-			},
+			expectedBody: []api.PullRequestShort{},
 		},
 		{
-			teamForTest: &gen.Team{
+			teamForTest: &api.Team{
 				TeamName: "testGetReviewSuccess",
-				Members: []gen.TeamMember{
+				Members: []api.TeamMember{
 					{
 						IsActive: true,
 						Username: "testGetReviewSuccess",
@@ -147,83 +109,44 @@ func TestGetReview(t *testing.T) {
 					},
 				},
 			},
-			prForTest: &gen.PostPullRequestCreateJSONBody{
+			prForTest: &api.PostPullRequestCreateJSONBody{
 				AuthorId:        "testGetReviewSuccess",
 				PullRequestName: "testGetReviewSuccess",
 				PullRequestId:   "testGetReviewSuccess",
 			},
-			path:        basePathUsers + "/getReview?user_id=",
 			description: "Get Review Success 1 PR",
 
-			body:         "testGetReviewSuccess_1",
-			expectedCode: http.StatusOK,
-			expectedBody: gen.GetUsersGetReview200JSONResponse{
-				UserId: "testGetReviewSuccess_1",
-				PullRequests: []gen.PullRequestShort{
-					{
-						AuthorId:        "testGetReviewSuccess",
-						PullRequestName: "testGetReviewSuccess",
-						PullRequestId:   "testGetReviewSuccess",
-						Status:          gen.PullRequestShortStatusOPEN,
-					},
+			body: "testGetReviewSuccess_1",
+			expectedBody: []api.PullRequestShort{
+				{
+					AuthorId:        "testGetReviewSuccess",
+					PullRequestName: "testGetReviewSuccess",
+					PullRequestId:   "testGetReviewSuccess",
+					Status:          api.PullRequestShortStatusOPEN,
 				},
 			},
 		},
 		{
-			path:        basePathUsers + "/getReview?user_id=",
 			description: "Get Review Success NotFound",
 
-			body:         "testGetReviewNotFound",
-			expectedCode: http.StatusNotFound,
-			expectedBody: gen.GetTeamGet404JSONResponse{
-				Error: GetError(cerr.NOT_FOUND).Error,
-			},
+			body:        "testGetReviewNotFound",
+			expectedErr: GetError(cerr.NOT_FOUND),
 		},
 	}
-	var errorData gen.ErrorResponse
 
 	for _, test := range tests {
 		t.Run(test.description, func(t *testing.T) {
-			if test.teamForTest != nil {
-				err := CreateTeamForTest(test.teamForTest)
-				if err != nil {
-					t.Fatalf("error: %s", err)
-				}
-			}
-			if test.prForTest != nil {
-				err := CreatePRForTest(test.prForTest)
-				if err != nil {
-					t.Fatalf("error: %s", err)
-				}
-			}
-			jsonData, err := json.Marshal(test.body)
-			if err != nil {
-				t.Fatalf("error: %s", err)
-			}
-
-			resp, err := DoWebRequest(ctx, http.MethodGet, test.path+test.body.(string), bytes.NewBuffer(jsonData))
-			if err != nil {
-				t.Fatalf("error: %s", err)
-			}
-			defer resp.Body.Close()
+			SetUp(t, test)
 
-			if resp.StatusCode != test.expectedCode {
-				t.Log(resp.StatusCode)
-				err = json.NewDecoder(resp.Body).Decode(&errorData)
-				if err != nil {
-					t.Fatalf("Unexpected error: %s", err)
-				}
+			reviews, err := Client.GetReview(ctx, test.body.(string))
+			if test.expectedErr != nil {
+				AssertError(t, test.expectedErr, err)
 
-				t.Errorf("got %d : %v", resp.StatusCode, errorData)
-			} else {
-				bodyBytes, err := io.ReadAll(resp.Body)
-				require.NoError(t, err)
-
-				expectedBytes, err := json.Marshal(test.expectedBody)
-				require.NoError(t, err)
-
-				assert.JSONEq(t, string(expectedBytes), string(bodyBytes))
+				return
 			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedBody, reviews)
 		})
 	}
 }
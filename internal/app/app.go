@@ -1,47 +1,141 @@
 package app
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"net"
 	"net/http"
+	"os/signal"
+	"strings"
+	"syscall"
 	"time"
 
 	"avito/internal/config"
+	chatopsDelivery "avito/internal/delivery/chatops"
+	graphqlDelivery "avito/internal/delivery/graphql"
+	grpcDelivery "avito/internal/delivery/grpc"
+	grpcMiddleware "avito/internal/delivery/grpc/middleware"
 	delivery "avito/internal/delivery/http"
+	"avito/internal/delivery/http/middleware"
+	scimDelivery "avito/internal/delivery/scim"
 	"avito/internal/gen"
+	"avito/internal/health"
 	"avito/internal/log"
+	"avito/internal/notify"
+	"avito/internal/org"
 	"avito/internal/postgres"
+	"avito/internal/ratelimit"
+	"avito/internal/retention"
+	directoryServ "avito/internal/service/directory"
+	pullRequestServ "avito/internal/service/pullRequest"
+	statServ "avito/internal/service/stat"
+	userServ "avito/internal/service/user"
+	"avito/internal/sla"
+	"avito/internal/tracing"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 )
 
+// workerTimeout is how long a background worker may go without a beat before readiness
+// fails. The workers beat while they wait and between organizations, so it only has to
+// cover one organization of the longest run.
+const workerTimeout = 5 * time.Minute
+
 func Start() {
 	cfg := config.InitConfig()
 
 	log.Log.Info("Config Initialized")
 
-	db := postgres.MustInitPg(cfg)
-	defer db.Close()
+	tracer := tracing.MustInitTracer(cfg)
+
+	log.Log.Info("Tracer Initialized")
+
+	store := mustInitStorage(cfg)
+
+	log.Log.Info(fmt.Sprintf("Storage %v Initialized", cfg.Storage))
+
+	expectedVersion, err := checkSchema(store)
+	if err != nil {
+		panic(fmt.Sprintf("error checking schema: %v", err.Error()))
+	}
+
+	log.Log.Info(fmt.Sprintf("Schema is at version %d", expectedVersion))
+
+	checker := health.InitChecker(store.db, expectedVersion)
+
+	keys, err := org.ParseKeys(cfg.OrgAPIKeys)
+	if err != nil {
+		panic(fmt.Sprintf("error parsing api keys: %v", err.Error()))
+	}
 
-	log.Log.Info("PG Initialized")
+	err = store.repos.Organization.Ensure(context.Background(), append(keys.Orgs(), cfg.SCIMOrg, cfg.ChatOpsOrg))
+	if err != nil {
+		panic(fmt.Sprintf("error creating organizations: %v", err.Error()))
+	}
 
 	g := gin.New()
+	g.ContextWithFallback = true
+
+	// The rate limiter keys anonymous callers by IP, so X-Forwarded-For is only believed
+	// from the proxies in front of the service.
+	if err = g.SetTrustedProxies(trustedProxies(cfg.TrustedProxies)); err != nil {
+		panic(fmt.Sprintf("error setting trusted proxies: %v", err.Error()))
+	}
+
+	// Mails are queued by the repo, so every API that assigns reviewers sends them.
+	var notifier *notify.Notifier
+	if cfg.SMTPAddr != "" {
+		notifier = notify.InitNotifier(store.repos.Notification, store.repos.User,
+			notify.InitSMTP(cfg.SMTPAddr, cfg.SMTPFrom, cfg.SMTPUsername, cfg.SMTPPassword))
+		store.repos.PullRequest = notifier.PullRequestRepo(store.repos.PullRequest)
+	}
 
-	handlers := delivery.InitServer(db)
+	handlers := delivery.InitServer(store.repos)
 
-	g.Use(gin.Logger(), gin.Recovery())
+	g.Use(middleware.RequestID(), middleware.Logger("/healthz", "/readyz"), middleware.Recovery())
+
+	delivery.InitHealth(g, checker)
+
+	g.Use(otelgin.Middleware(cfg.OtelService), middleware.Timeout(cfg.RequestTimeout))
 
 	g.Use(cors.New(cors.Config{
-		AllowOrigins:     []string{"*"},
-		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization"},
-		ExposeHeaders:    []string{"Content-Length"},
+		AllowOrigins: []string{"*"},
+		AllowMethods: []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
+		AllowHeaders: []string{
+			"Origin",
+			"Content-Type",
+			"Authorization",
+			"traceparent",
+			"tracestate",
+			middleware.HeaderRequestID,
+			middleware.HeaderAdminToken,
+		},
+		ExposeHeaders: []string{
+			"Content-Length",
+			middleware.HeaderRetryAfter,
+			middleware.HeaderRequestID,
+			middleware.HeaderRateLimitLimit,
+			middleware.HeaderRateLimitRemaining,
+			middleware.HeaderRateLimitReset,
+		},
 		AllowCredentials: true,
 		MaxAge:           time.Hour,
 	}))
 
+	limiter, pgLimiter := initLimiter(cfg, store.pg)
+
+	g.Use(middleware.RateLimit(limiter,
+		ratelimit.Limit{Rate: cfg.RateLimitReadRate, Burst: cfg.RateLimitReadBurst},
+		ratelimit.Limit{Rate: cfg.RateLimitWriteRate, Burst: cfg.RateLimitWriteBurst},
+		keys,
+	))
+
 	g.GET("/openapi.json", func(c *gin.Context) {
 		swagger, _ := gen.GetSwagger()
 
@@ -62,12 +156,198 @@ func Start() {
 		ginSwagger.URL("/openapi.json"),
 	))
 
-	gen.RegisterHandlers(g, handlers)
+	swagger, err := gen.GetSwagger()
+	if err != nil {
+		panic(fmt.Sprintf("error loading OpenAPI spec: %v", err.Error()))
+	}
+
+	// SCIM lets anyone holding the token deactivate users, so it is off without one.
+	if cfg.SCIMToken != "" {
+		directory := directoryServ.InitDirectoryServ(store.repos.Team, userServ.InitUserServ(store.repos.User), cfg.SCIMDefaultTeam)
+
+		if err = scimDelivery.InitHandler(g.Group("/scim/v2", middleware.InOrganization(cfg.SCIMOrg)), directory, swagger, cfg.SCIMToken); err != nil {
+			panic(fmt.Sprintf("error init scim: %v", err.Error()))
+		}
+	}
+
+	// Slash commands act as whoever the chat says typed them, so they are off unless the
+	// chat server is verified.
+	if cfg.ChatOpsSigningSecret != "" || cfg.ChatOpsToken != "" {
+		users, err := chatopsDelivery.ParseUsers(cfg.ChatOpsUsers)
+		if err != nil {
+			panic(fmt.Sprintf("error init chatops: %v", err.Error()))
+		}
+
+		chatopsHandler, err := chatopsDelivery.InitHandler(chatopsDelivery.Services{
+			User:        userServ.InitUserServ(store.repos.User),
+			PullRequest: pullRequestServ.InitPullRequestServ(store.repos.PullRequest),
+			Stat:        statServ.InitStatServ(store.repos.Stat),
+		}, chatopsDelivery.Config{
+			SigningSecret: cfg.ChatOpsSigningSecret,
+			Token:         cfg.ChatOpsToken,
+			Users:         users,
+		})
+		if err != nil {
+			panic(fmt.Sprintf("error init chatops: %v", err.Error()))
+		}
+
+		g.POST("/chatops/command", middleware.InOrganization(cfg.ChatOpsOrg), chatopsHandler)
+	}
+
+	// Only the routes added from here on take API keys. SCIM and the slash commands have
+	// credentials of their own and act in the organization they are configured for.
+	g.Use(middleware.Organization(keys))
+
+	graphqlHandler, err := graphqlDelivery.InitHandler(store.repos.Lookup)
+	if err != nil {
+		panic(fmt.Sprintf("error init graphql: %v", err.Error()))
+	}
+
+	g.GET("/graphql", graphqlHandler)
+	g.POST("/graphql", graphqlHandler)
+
+	validation, err := middleware.Validation(swagger)
+	if err != nil {
+		panic(fmt.Sprintf("error init validation: %v", err.Error()))
+	}
+
+	g.Use(validation)
+
+	var admin gin.HandlerFunc
+	if cfg.AdminToken != "" {
+		admin = middleware.Admin(cfg.AdminToken)
+	}
+
+	delivery.RegisterHandlers(g, handlers, admin)
+
+	srv := InitServer(cfg, g, checker)
+
+	if pgLimiter != nil {
+		srv.Go("rate-limit-prune", workerTimeout, pruneRateLimits(pgLimiter))
+	}
+
+	if notifier != nil {
+		srv.Go("mail", workerTimeout, notifier.Run)
+
+		if cfg.NotifyDigestAt != "" {
+			at, err := notify.ParseTimeOfDay(cfg.NotifyDigestAt)
+			if err != nil {
+				panic(fmt.Sprintf("error init digest: %v", err.Error()))
+			}
+
+			srv.Go("digest", workerTimeout, notifier.Digests(store.repos.Organization, at))
+		}
+	}
+
+	// The reassignments of the scheduler go through the wrapped repo and are mailed too.
+	if cfg.SLACheckInterval > 0 {
+		events := []sla.Events{sla.LogEvents{}}
+		if notifier != nil {
+			events = append(events, notifier)
+		}
+
+		scheduler := sla.InitScheduler(store.repos.SLA, pullRequestServ.InitPullRequestServ(store.repos.PullRequest), events...)
+		srv.Go("sla", workerTimeout, scheduler.Run(store.repos.Organization, cfg.SLACheckInterval))
+	}
+
+	if cfg.ArchiveAfter > 0 {
+		archiver := retention.InitArchiver(store.repos.Archive, cfg.ArchiveAfter)
+		srv.Go("archive", workerTimeout, archiver.Run(store.repos.Organization, cfg.ArchiveInterval))
+	}
+
+	if cfg.GRPCEnabled {
+		grpcServer := grpcDelivery.InitServer(store.repos, swagger, grpc.ChainUnaryInterceptor(
+			grpcMiddleware.RequestID(),
+			grpcMiddleware.Logger(healthpb.Health_ServiceDesc.ServiceName),
+			grpcMiddleware.Recovery(),
+			grpcMiddleware.Timeout(cfg.RequestTimeout),
+			grpcMiddleware.Organization(keys, healthpb.Health_ServiceDesc.ServiceName),
+		))
+
+		srv.Go("grpc-health", workerTimeout, grpcDelivery.InitHealth(grpcServer, checker))
+
+		grpcLn, err := net.Listen("tcp", fmt.Sprintf("%v:%v", cfg.ServiceHost, cfg.GRPCPort))
+		if err != nil {
+			panic(fmt.Sprintf("error listening for gRPC: %v", err.Error()))
+		}
+
+		srv.ServeGRPC(grpcServer, grpcLn)
+
+		log.Log.Info(fmt.Sprintf("gRPC server listening on %v", grpcLn.Addr()))
+	}
+
+	srv.OnClose(func() {
+		if err := tracer.Shutdown(context.Background()); err != nil {
+			log.Log.Error(err)
+		}
+	})
+	srv.OnClose(store.close)
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
 
 	log.Log.Info("Start Server")
 
-	err := g.Run(fmt.Sprintf("%v:%v", cfg.ServiceHost, cfg.ServicePort))
+	err = srv.Run(ctx)
 	if err != nil {
 		panic(fmt.Sprintf("error running client: %v", err.Error()))
 	}
+
+	log.Log.Info("Server Stopped")
+}
+
+func initLimiter(cfg *config.Config, db *postgres.Pg) (ratelimit.Limiter, *ratelimit.Pg) {
+	switch cfg.RateLimitBackend {
+	case ratelimit.BackendMemory, "":
+		return ratelimit.InitMemoryLimiter(), nil
+	case ratelimit.BackendPostgres:
+		if db == nil {
+			panic("error init rate limiter: postgres backend requires postgres storage")
+		}
+
+		limiter := ratelimit.InitPgLimiter(db)
+
+		return limiter, limiter
+	default:
+		panic(fmt.Sprintf("error init rate limiter: unknown backend %q", cfg.RateLimitBackend))
+	}
+}
+
+// trustedProxies splits the comma-separated addresses and CIDRs, none are trusted when
+// it is empty.
+func trustedProxies(s string) []string {
+	var proxies []string
+
+	for _, proxy := range strings.Split(s, ",") {
+		if proxy = strings.TrimSpace(proxy); proxy != "" {
+			proxies = append(proxies, proxy)
+		}
+	}
+
+	return proxies
+}
+
+func pruneRateLimits(limiter *ratelimit.Pg) func(ctx context.Context) {
+	return func(ctx context.Context) {
+		ticker := time.NewTicker(time.Minute)
+		defer ticker.Stop()
+
+		beat := time.NewTicker(health.BeatInterval)
+		defer beat.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-beat.C:
+				health.Beat(ctx)
+			case <-ticker.C:
+				health.Beat(ctx)
+
+				if _, err := limiter.Prune(ctx, 10*time.Minute); err != nil {
+					log.Log.Error(fmt.Errorf("pruning rate limits: %w", err))
+				}
+			}
+		}
+	}
 }
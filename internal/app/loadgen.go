@@ -0,0 +1,63 @@
+package app
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	"avito/internal/config"
+	"avito/internal/loadgen"
+)
+
+func Loadgen(args []string) {
+	cfg := config.InitConfig()
+
+	flags := flag.NewFlagSet("loadgen", flag.ExitOnError)
+	baseURL := flags.String("url", fmt.Sprintf("http://%v:%v", cfg.ServiceHost, cfg.ServicePort), "base URL of a running instance")
+	token := flags.String("token", "", "Bearer token to send, the rate limiter keys clients by it")
+	teams := flags.Int("teams", 10, "teams to seed")
+	users := flags.Int("users", 10, "users per seeded team")
+	concurrency := flags.Int("c", 16, "concurrent workers")
+	duration := flags.Duration("d", 30*time.Second, "how long to run")
+	requests := flags.Int("n", 0, "stop after this many calls, 0 for no limit")
+	mix := flags.String("mix", loadgen.DefaultMix, "weighted operations: "+fmt.Sprint(loadgen.Ops))
+	runID := flags.String("run", "lg"+strconv.FormatInt(time.Now().Unix(), 36), "prefix of seeded ids")
+	format := flags.String("format", loadgen.FormatText, "report format, text or json")
+
+	if err := flags.Parse(args); err != nil {
+		panic(fmt.Sprintf("error parsing flags: %v", err.Error()))
+	}
+
+	parsedMix, err := loadgen.ParseMix(*mix)
+	if err != nil {
+		panic(fmt.Sprintf("error parsing mix: %v", err.Error()))
+	}
+
+	// Ctrl+C ends the run early but still prints the report.
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	report, err := loadgen.Run(ctx, loadgen.Config{
+		BaseURL:      *baseURL,
+		Token:        *token,
+		Teams:        *teams,
+		UsersPerTeam: *users,
+		Concurrency:  *concurrency,
+		Duration:     *duration,
+		Requests:     *requests,
+		Mix:          parsedMix,
+		RunID:        *runID,
+	})
+	if err != nil {
+		panic(fmt.Sprintf("error running load: %v", err.Error()))
+	}
+
+	if err = report.Write(os.Stdout, *format); err != nil {
+		panic(fmt.Sprintf("error writing report: %v", err.Error()))
+	}
+}
@@ -0,0 +1,98 @@
+package app
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"avito/internal/config"
+	"avito/internal/log"
+	"avito/internal/migrator"
+	"github.com/pressly/goose/v3"
+)
+
+const migrateUsage = `usage: main migrate <up|down|status|redo|reset> [-confirm=<database name>]`
+
+var migrateCommands = map[string]bool{
+	"up":     true,
+	"down":   true,
+	"status": true,
+	"redo":   true,
+	"reset":  true,
+}
+
+func Migrate(args []string) {
+	flags := flag.NewFlagSet("migrate", flag.ExitOnError)
+	confirm := flags.String("confirm", "", "database name, required by reset")
+
+	if len(args) == 0 || !migrateCommands[args[0]] {
+		fmt.Fprintln(os.Stderr, migrateUsage)
+		os.Exit(2)
+	}
+
+	command := args[0]
+
+	if err := flags.Parse(args[1:]); err != nil {
+		panic(fmt.Sprintf("error parsing flags: %v", err.Error()))
+	}
+
+	cfg := config.InitConfig()
+
+	store := mustInitStorage(cfg)
+	defer store.close()
+
+	migrator, err := store.migrator()
+	if err != nil {
+		panic(fmt.Sprintf("error init migrations: %v", err.Error()))
+	}
+	defer migrator.Close()
+
+	ctx := context.Background()
+
+	var results []*goose.MigrationResult
+
+	switch command {
+	case "up":
+		results, err = migrator.Up(ctx)
+	case "down":
+		var result *goose.MigrationResult
+
+		result, err = migrator.Down(ctx)
+		if result != nil {
+			results = append(results, result)
+		}
+	case "redo":
+		results, err = migrator.Redo(ctx)
+	case "reset":
+		results, err = migrator.Reset(ctx, *confirm)
+	case "status":
+		err = printStatus(ctx, migrator)
+	}
+
+	for _, result := range results {
+		log.Log.Info(result.String())
+	}
+
+	if err != nil {
+		panic(fmt.Sprintf("error running migrate %v: %v", command, err.Error()))
+	}
+}
+
+func printStatus(ctx context.Context, migrator *migrator.Migrator) error {
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, status := range statuses {
+		appliedAt := "pending"
+		if status.State == goose.StateApplied {
+			appliedAt = status.AppliedAt.Format("2006-01-02 15:04:05")
+		}
+
+		fmt.Printf("%-24v %v\n", appliedAt, status.Source.Path)
+	}
+
+	return nil
+}
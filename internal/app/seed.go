@@ -0,0 +1,70 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"avito/internal/cerr"
+	"avito/internal/config"
+	"avito/internal/entity"
+	"avito/internal/log"
+	"avito/internal/org"
+	teamServ "avito/internal/service/team"
+)
+
+func Seed(args []string) {
+	flags := flag.NewFlagSet("seed", flag.ExitOnError)
+	file := flags.String("f", "./seed.json", "JSON file with a list of teams")
+	orgID := flags.String("org", org.Default, "organization to seed")
+
+	if err := flags.Parse(args); err != nil {
+		panic(fmt.Sprintf("error parsing flags: %v", err.Error()))
+	}
+
+	data, err := os.ReadFile(*file)
+	if err != nil {
+		panic(fmt.Sprintf("error reading seed file: %v", err.Error()))
+	}
+
+	var teams []entity.Team
+
+	if err = json.Unmarshal(data, &teams); err != nil {
+		panic(fmt.Sprintf("error parsing seed file: %v", err.Error()))
+	}
+
+	cfg := config.InitConfig()
+
+	store := mustInitStorage(cfg)
+	defer store.close()
+
+	if _, err = checkSchema(store); err != nil {
+		panic(fmt.Sprintf("error checking schema: %v", err.Error()))
+	}
+
+	ctx := org.With(context.Background(), *orgID)
+
+	if err = store.repos.Organization.Ensure(ctx, []string{*orgID}); err != nil {
+		panic(fmt.Sprintf("error creating organization: %v", err.Error()))
+	}
+
+	servTeam := teamServ.InitTeamServ(store.repos.Team)
+
+	for _, team := range teams {
+		err = servTeam.Create(ctx, &team)
+
+		var customErr cerr.CustomError
+
+		switch {
+		case err == nil:
+			log.Log.Info(fmt.Sprintf("Team %v seeded with %d members", team.TeamName, len(team.Members)))
+		case errors.As(err, &customErr) && customErr.ErrType == cerr.TEAM_EXISTS:
+			log.Log.Info(fmt.Sprintf("Team %v already exists, skipped", team.TeamName))
+		default:
+			panic(fmt.Sprintf("error seeding team %v: %v", team.TeamName, err.Error()))
+		}
+	}
+}
@@ -0,0 +1,225 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"avito/internal/config"
+	"avito/internal/health"
+	"avito/internal/log"
+	"google.golang.org/grpc"
+)
+
+type Server struct {
+	http            *http.Server
+	checker         *health.Checker
+	shutdownDelay   time.Duration
+	shutdownTimeout time.Duration
+
+	grpc   *grpc.Server
+	grpcLn net.Listener
+
+	workersCtx    context.Context
+	workersCancel context.CancelFunc
+	workers       sync.WaitGroup
+
+	closers []func()
+}
+
+func InitServer(cfg *config.Config, handler http.Handler, checker *health.Checker) *Server {
+	workersCtx, workersCancel := context.WithCancel(context.Background())
+
+	return &Server{
+		http: &http.Server{
+			Addr:              fmt.Sprintf("%v:%v", cfg.ServiceHost, cfg.ServicePort),
+			Handler:           handler,
+			ReadHeaderTimeout: cfg.HTTPReadTimeout,
+			ReadTimeout:       cfg.HTTPReadTimeout,
+			WriteTimeout:      cfg.HTTPWriteTimeout,
+			IdleTimeout:       cfg.HTTPIdleTimeout,
+		},
+		checker:         checker,
+		shutdownDelay:   cfg.ShutdownDelay,
+		shutdownTimeout: cfg.ShutdownTimeout,
+		workersCtx:      workersCtx,
+		workersCancel:   workersCancel,
+	}
+}
+
+// ServeGRPC serves gs on ln next to the HTTP server. It is drained after the HTTP
+// server and before workers are stopped.
+func (s *Server) ServeGRPC(gs *grpc.Server, ln net.Listener) {
+	s.grpc = gs
+	s.grpcLn = ln
+}
+
+// Go starts a background worker that is stopped after the HTTP server has drained. The
+// worker beats through health.Beat, readiness fails once it is silent for timeout.
+func (s *Server) Go(name string, timeout time.Duration, worker func(ctx context.Context)) {
+	ctx := s.workersCtx
+
+	var beats *health.Worker
+	if s.checker != nil {
+		beats = s.checker.RegisterWorker(name, timeout)
+		ctx = health.WithWorker(ctx, beats)
+	}
+
+	s.workers.Add(1)
+
+	go func() {
+		defer s.workers.Done()
+
+		worker(ctx)
+
+		if beats != nil {
+			beats.Stop()
+		}
+
+		log.Log.Info(fmt.Sprintf("Worker %v stopped", name))
+	}()
+}
+
+// OnClose registers a resource to release once requests and workers are finished.
+// Closers run in reverse registration order.
+func (s *Server) OnClose(closer func()) {
+	s.closers = append(s.closers, closer)
+}
+
+func (s *Server) Run(ctx context.Context) error {
+	ln, err := net.Listen("tcp", s.http.Addr)
+	if err != nil {
+		return err
+	}
+
+	return s.Serve(ctx, ln)
+}
+
+func (s *Server) Serve(ctx context.Context, ln net.Listener) error {
+	serveErr := make(chan error, 1)
+	grpcErr := make(chan error, 1)
+
+	go func() {
+		serveErr <- s.http.Serve(ln)
+	}()
+
+	if s.grpc != nil {
+		go func() {
+			grpcErr <- s.grpc.Serve(s.grpcLn)
+		}()
+	}
+
+	select {
+	case err := <-serveErr:
+		s.stopGRPC()
+		s.stop()
+
+		return err
+	case err := <-grpcErr:
+		_ = s.http.Close()
+		s.stop()
+
+		return fmt.Errorf("grpc: %w", err)
+	case <-ctx.Done():
+	}
+
+	return s.shutdown(serveErr)
+}
+
+func (s *Server) shutdown(serveErr <-chan error) error {
+	if s.checker != nil {
+		s.checker.SetShuttingDown()
+	}
+
+	log.Log.Info(fmt.Sprintf("Shutdown requested, readiness failing for %v", s.shutdownDelay))
+
+	time.Sleep(s.shutdownDelay)
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.shutdownTimeout)
+	defer cancel()
+
+	log.Log.Info("Draining in-flight requests")
+
+	err := s.http.Shutdown(ctx)
+	if err != nil {
+		log.Log.Error(fmt.Errorf("draining requests: %w", err))
+	}
+
+	if srvErr := <-serveErr; srvErr != nil && !errors.Is(srvErr, http.ErrServerClosed) {
+		err = errors.Join(err, srvErr)
+	}
+
+	s.drainGRPC(ctx)
+	s.stopWorkers(ctx)
+	s.close()
+
+	return err
+}
+
+func (s *Server) stop() {
+	ctx, cancel := context.WithTimeout(context.Background(), s.shutdownTimeout)
+	defer cancel()
+
+	s.stopWorkers(ctx)
+	s.close()
+}
+
+// drainGRPC waits for in-flight calls like http.Server.Shutdown and cuts them off when
+// ctx expires.
+func (s *Server) drainGRPC(ctx context.Context) {
+	if s.grpc == nil {
+		return
+	}
+
+	log.Log.Info("Draining in-flight gRPC calls")
+
+	done := make(chan struct{})
+
+	go func() {
+		s.grpc.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		log.Log.Error(fmt.Errorf("draining gRPC calls: %w", ctx.Err()))
+		s.grpc.Stop()
+	}
+}
+
+func (s *Server) stopGRPC() {
+	if s.grpc != nil {
+		s.grpc.Stop()
+	}
+}
+
+func (s *Server) stopWorkers(ctx context.Context) {
+	s.workersCancel()
+
+	done := make(chan struct{})
+
+	go func() {
+		s.workers.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		log.Log.Info("Workers stopped")
+	case <-ctx.Done():
+		log.Log.Error(fmt.Errorf("stopping workers: %w", ctx.Err()))
+	}
+}
+
+func (s *Server) close() {
+	for i := len(s.closers) - 1; i >= 0; i-- {
+		s.closers[i]()
+	}
+
+	s.closers = nil
+}
@@ -0,0 +1,257 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"avito/internal/config"
+	delivery "avito/internal/delivery/http"
+	"avito/internal/health"
+	"avito/internal/sqlite"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type database struct{}
+
+func (database) Ping(context.Context) error { return nil }
+
+func (database) MigrationVersion(context.Context) (int64, error) { return 1, nil }
+
+type events struct {
+	mu   sync.Mutex
+	list []string
+}
+
+func (e *events) add(event string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.list = append(e.list, event)
+}
+
+func (e *events) get() []string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return append([]string(nil), e.list...)
+}
+
+// TestServerDrainsInFlightTransaction checks that shutdown waits for a request in the
+// middle of its transaction, and only then stops workers and closes the pool.
+func TestServerDrainsInFlightTransaction(t *testing.T) {
+	var trail events
+
+	path := filepath.Join(t.TempDir(), "avito_test.db")
+
+	db := sqlite.MustInitSqlite(&config.Config{SqlitePath: path})
+
+	migrator, err := db.Migrator()
+	require.NoError(t, err)
+
+	_, err = migrator.Up(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, migrator.Close())
+
+	started := make(chan struct{})
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tx, err := db.DB.BeginTx(r.Context(), nil)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+		defer func() { _ = tx.Rollback() }()
+
+		if _, err = tx.ExecContext(r.Context(), `INSERT INTO organizations (id) VALUES ('acme')`); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		trail.add("tx begin")
+		close(started)
+
+		// Shutdown starts while the transaction is open.
+		time.Sleep(300 * time.Millisecond)
+
+		if err = tx.Commit(); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		trail.add("tx commit")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("committed"))
+	})
+
+	checker := health.InitChecker(db, 0)
+
+	srv := InitServer(&config.Config{
+		HTTPReadTimeout:  time.Second,
+		HTTPWriteTimeout: time.Second,
+		HTTPIdleTimeout:  time.Second,
+		ShutdownDelay:    10 * time.Millisecond,
+		ShutdownTimeout:  5 * time.Second,
+	}, handler, checker)
+
+	srv.Go("test", time.Minute, func(ctx context.Context) {
+		<-ctx.Done()
+		trail.add("worker stopped")
+	})
+	srv.OnClose(func() {
+		trail.add("pool closed")
+		db.Close()
+	})
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	served := make(chan error, 1)
+
+	go func() {
+		served <- srv.Serve(ctx, ln)
+	}()
+
+	type result struct {
+		code int
+		body string
+		err  error
+	}
+
+	response := make(chan result, 1)
+
+	go func() {
+		resp, err := http.Get("http://" + ln.Addr().String())
+		if err != nil {
+			response <- result{err: err}
+
+			return
+		}
+		defer resp.Body.Close()
+
+		body, err := io.ReadAll(resp.Body)
+		response <- result{code: resp.StatusCode, body: string(body), err: err}
+	}()
+
+	<-started
+	cancel()
+
+	res := <-response
+	require.NoError(t, res.err)
+	assert.Equal(t, http.StatusOK, res.code)
+	assert.Equal(t, "committed", res.body)
+
+	require.NoError(t, <-served)
+	assert.True(t, checker.IsShuttingDown())
+	assert.Equal(t, []string{"tx begin", "tx commit", "worker stopped", "pool closed"}, trail.get())
+	assert.Error(t, db.Ping(context.Background()), "the pool is closed")
+
+	// The row outlives the server, the transaction was not cut off.
+	reopened := sqlite.MustInitSqlite(&config.Config{SqlitePath: path})
+	defer reopened.Close()
+
+	var orgs int
+
+	require.NoError(t, reopened.DB.QueryRow(`SELECT count(*) FROM organizations WHERE id = 'acme'`).Scan(&orgs))
+	assert.Equal(t, 1, orgs)
+}
+
+// TestStalledWorkerFailsReadiness checks that /readyz fails once a worker stops beating
+// and that a worker that has returned is reported as stopped.
+func TestStalledWorkerFailsReadiness(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	checker := health.InitChecker(database{}, 1)
+
+	g := gin.New()
+	delivery.InitHealth(g, checker)
+
+	srv := InitServer(&config.Config{
+		HTTPReadTimeout:  time.Second,
+		HTTPWriteTimeout: time.Second,
+		HTTPIdleTimeout:  time.Second,
+		ShutdownTimeout:  5 * time.Second,
+	}, g, checker)
+
+	stall := make(chan struct{})
+
+	srv.Go("alive", 200*time.Millisecond, func(ctx context.Context) {
+		ticker := time.NewTicker(10 * time.Millisecond)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				health.Beat(ctx)
+			}
+		}
+	})
+	srv.Go("stalled", 200*time.Millisecond, func(ctx context.Context) {
+		health.Beat(ctx)
+
+		select {
+		case <-stall:
+		case <-ctx.Done():
+		}
+	})
+	srv.Go("done", 200*time.Millisecond, func(context.Context) {})
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	served := make(chan error, 1)
+
+	go func() {
+		served <- srv.Serve(ctx, ln)
+	}()
+
+	readyz := func() (int, health.Report) {
+		resp, err := http.Get("http://" + ln.Addr().String() + "/readyz")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		var report health.Report
+
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&report))
+
+		return resp.StatusCode, report
+	}
+
+	code, report := readyz()
+	assert.Equal(t, http.StatusOK, code, report)
+
+	require.Eventually(t, func() bool {
+		code, report = readyz()
+
+		return code == http.StatusServiceUnavailable
+	}, 2*time.Second, 20*time.Millisecond)
+
+	workers := report.Checks["workers"]
+	assert.Equal(t, health.StatusFail, workers.Status)
+	assert.Contains(t, workers.Error, "stalled")
+	assert.NotContains(t, workers.Error, "alive")
+	assert.Equal(t, "stopped", workers.Details["done"].(map[string]any)["status"])
+
+	close(stall)
+	cancel()
+	require.NoError(t, <-served)
+}
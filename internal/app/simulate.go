@@ -0,0 +1,82 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"avito/internal/config"
+	"avito/internal/entity"
+	"avito/internal/org"
+	"avito/internal/simulate"
+)
+
+func Simulate(args []string) {
+	flags := flag.NewFlagSet("simulate", flag.ExitOnError)
+	file := flags.String("f", "", "JSON history export to replay, the database is read when empty")
+	export := flags.String("export", "", "write the loaded history to this file")
+	strategies := flags.String("strategies", strings.Join(simulate.Strategies, ","), "comma separated strategies to compare")
+	seed := flags.Uint64("seed", 1, "seed for the random strategy")
+	format := flags.String("format", simulate.FormatText, "report format, text or json")
+	orgID := flags.String("org", org.Default, "organization whose history is read from the database")
+
+	if err := flags.Parse(args); err != nil {
+		panic(fmt.Sprintf("error parsing flags: %v", err.Error()))
+	}
+
+	var history *entity.History
+
+	if *file != "" {
+		data, err := os.ReadFile(*file)
+		if err != nil {
+			panic(fmt.Sprintf("error reading history file: %v", err.Error()))
+		}
+
+		if err = json.Unmarshal(data, &history); err != nil {
+			panic(fmt.Sprintf("error parsing history file: %v", err.Error()))
+		}
+	} else {
+		history = loadHistory(*orgID)
+	}
+
+	if *export != "" {
+		data, err := json.MarshalIndent(history, "", "  ")
+		if err != nil {
+			panic(fmt.Sprintf("error encoding history: %v", err.Error()))
+		}
+
+		if err = os.WriteFile(*export, data, 0o644); err != nil {
+			panic(fmt.Sprintf("error writing history file: %v", err.Error()))
+		}
+	}
+
+	results, err := simulate.Compare(history, strings.Split(*strategies, ","), *seed)
+	if err != nil {
+		panic(fmt.Sprintf("error running simulation: %v", err.Error()))
+	}
+
+	if err = simulate.WriteReport(os.Stdout, results, *format); err != nil {
+		panic(fmt.Sprintf("error writing report: %v", err.Error()))
+	}
+}
+
+func loadHistory(orgID string) *entity.History {
+	cfg := config.InitConfig()
+
+	store := mustInitStorage(cfg)
+	defer store.close()
+
+	if _, err := checkSchema(store); err != nil {
+		panic(fmt.Sprintf("error checking schema: %v", err.Error()))
+	}
+
+	history, err := store.repos.History.Load(org.With(context.Background(), orgID))
+	if err != nil {
+		panic(fmt.Sprintf("error loading history: %v", err.Error()))
+	}
+
+	return history
+}
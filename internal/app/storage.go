@@ -0,0 +1,117 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"avito/internal/config"
+	"avito/internal/health"
+	"avito/internal/migrator"
+	"avito/internal/postgres"
+	"avito/internal/repo"
+	archiveRepo "avito/internal/repo/archive"
+	historyRepo "avito/internal/repo/history"
+	lookupRepo "avito/internal/repo/lookup"
+	notificationRepo "avito/internal/repo/notification"
+	organizationRepo "avito/internal/repo/organization"
+	PRRepo "avito/internal/repo/pullRequest"
+	slaRepo "avito/internal/repo/sla"
+	sqliteRepo "avito/internal/repo/sqlite"
+	statRepo "avito/internal/repo/stat"
+	teamRepo "avito/internal/repo/team"
+	userRepo "avito/internal/repo/user"
+	"avito/internal/sqlite"
+)
+
+const (
+	StoragePostgres = "postgres"
+	StorageSqlite   = "sqlite"
+)
+
+type storage struct {
+	repos repo.Repos
+	db    health.Database
+	// pg is set only for the Postgres storage, features that need it check for nil.
+	pg          *postgres.Pg
+	migrator    func() (*migrator.Migrator, error)
+	autoMigrate bool
+	close       func()
+}
+
+func mustInitStorage(cfg *config.Config) *storage {
+	switch cfg.Storage {
+	case StoragePostgres, "":
+		db := postgres.MustInitPg(cfg)
+
+		return &storage{
+			repos: repo.Repos{
+				Team:         teamRepo.InitTeamRepo(db),
+				User:         userRepo.InitUserRepo(db),
+				PullRequest:  PRRepo.InitPullRequestRepo(db),
+				Stat:         statRepo.InitStatRepo(db),
+				History:      historyRepo.InitHistoryRepo(db),
+				Lookup:       lookupRepo.InitLookupRepo(db),
+				Notification: notificationRepo.InitNotificationRepo(db),
+				SLA:          slaRepo.InitSLARepo(db),
+				Organization: organizationRepo.InitOrganizationRepo(db),
+				Archive:      archiveRepo.InitArchiveRepo(db),
+				Erasure:      teamRepo.InitErasureRepo(db),
+			},
+			db:       db,
+			pg:       db,
+			migrator: db.Migrator,
+			close:    db.Close,
+		}
+	case StorageSqlite:
+		db := sqlite.MustInitSqlite(cfg)
+
+		return &storage{
+			repos: repo.Repos{
+				Team:         sqliteRepo.InitTeamRepo(db),
+				User:         sqliteRepo.InitUserRepo(db),
+				PullRequest:  sqliteRepo.InitPullRequestRepo(db),
+				Stat:         sqliteRepo.InitStatRepo(db),
+				History:      sqliteRepo.InitHistoryRepo(db),
+				Lookup:       sqliteRepo.InitLookupRepo(db),
+				Notification: sqliteRepo.InitNotificationRepo(db),
+				SLA:          sqliteRepo.InitSLARepo(db),
+				Organization: sqliteRepo.InitOrganizationRepo(db),
+				Archive:      sqliteRepo.InitArchiveRepo(db),
+				Erasure:      sqliteRepo.InitErasureRepo(db),
+			},
+			db:       db,
+			migrator: db.Migrator,
+			// A single-file database has nobody else to run migrations for it.
+			autoMigrate: true,
+			close:       db.Close,
+		}
+	default:
+		panic(fmt.Sprintf("error init storage: unknown storage %q", cfg.Storage))
+	}
+}
+
+// checkSchema returns the schema version the binary expects, failing if the database
+// is behind it. SQLite storage is migrated up first.
+func checkSchema(s *storage) (int64, error) {
+	migrator, err := s.migrator()
+	if err != nil {
+		return 0, err
+	}
+	defer migrator.Close()
+
+	ctx := context.Background()
+
+	if s.autoMigrate {
+		if _, err = migrator.Up(ctx); err != nil {
+			return 0, err
+		}
+	}
+
+	if err = migrator.CheckSchema(ctx); err != nil {
+		return 0, err
+	}
+
+	_, expected, err := migrator.Versions(ctx)
+
+	return expected, err
+}
@@ -1,13 +1,19 @@
 package cerr
 
 import (
+	"context"
+	"database/sql"
 	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 
 	"avito/internal/gen"
+	"avito/internal/log"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
 )
 
 type ErrorType struct {
@@ -24,29 +30,59 @@ func (c CustomError) Error() string {
 	return fmt.Sprintf("Type: %v, Error: %v", c.ErrType.message, c.Err)
 }
 
+func (c CustomError) Unwrap() error {
+	return c.Err
+}
+
 const (
 	M_TEAM_EXISTS  string = "team_name already exists"
+	M_USER_EXISTS  string = "user_id already exists"
 	M_PR_EXISTS    string = "PR id already exists"
 	M_PR_MERGED    string = "cannot reassign on merged PR"
 	M_NOT_ASSIGNED string = "reviewer is not assigned to this PR"
 	M_NO_CANDIDATE string = "no active replacement candidate in team"
 	M_NOT_FOUND    string = "data not found"
 	M_SERVER       string = "error in service work"
+	M_VALIDATION   string = "request validation failed"
+	M_UNAVAILABLE  string = "service temporarily unavailable"
+	M_RATE_LIMITED string = "rate limit exceeded"
+	M_UNAUTHORIZED string = "missing or unknown api key"
 )
 
 var (
 	TEAM_EXISTS  = ErrorType{"TEAM_EXIST", M_TEAM_EXISTS}
+	USER_EXISTS  = ErrorType{"USER_EXISTS", M_USER_EXISTS}
 	PR_EXISTS    = ErrorType{"PR_EXISTS", M_PR_EXISTS}
 	PR_MERGED    = ErrorType{"PR_MERGED", M_PR_MERGED}
 	NOT_ASSIGNED = ErrorType{"NOT_ASSIGNED", M_NOT_ASSIGNED}
 	NO_CANDIDATE = ErrorType{"NO_CANDIDATE", M_NO_CANDIDATE}
 	NOT_FOUND    = ErrorType{"NOT_FOUND", M_NOT_FOUND}
 	SERVER       = ErrorType{"SERVER", M_SERVER}
+	VALIDATION   = ErrorType{"VALIDATION_ERROR", M_VALIDATION}
+	UNAVAILABLE  = ErrorType{"SERVICE_UNAVAILABLE", M_UNAVAILABLE}
+	RATE_LIMITED = ErrorType{"RATE_LIMITED", M_RATE_LIMITED}
+	UNAUTHORIZED = ErrorType{"UNAUTHORIZED", M_UNAUTHORIZED}
 )
 
-var ErrServerTime = errors.New(M_SERVER)
+// RetryAfter is the number of seconds clients are asked to wait after a 503.
+const RetryAfter = 5
+
+type FieldsError struct {
+	Fields []gen.FieldError
+}
+
+func (f FieldsError) Error() string {
+	return fmt.Sprintf("invalid fields: %v", f.Fields)
+}
 
 func HandlePgErr(err error) error {
+	if isUnavailable(err) {
+		return CustomError{
+			Err:     err,
+			ErrType: UNAVAILABLE,
+		}
+	}
+
 	if errors.Is(err, pgx.ErrNoRows) {
 		return CustomError{
 			Err:     err,
@@ -68,6 +104,11 @@ func HandlePgErr(err error) error {
 				Err:     err,
 				ErrType: PR_EXISTS,
 			}
+		case "teams_pkey":
+			return CustomError{
+				Err:     err,
+				ErrType: TEAM_EXISTS,
+			}
 		default:
 			err = CustomError{
 				Err:     err,
@@ -75,7 +116,8 @@ func HandlePgErr(err error) error {
 			}
 		}
 	case "23503":
-		if pgErr.ConstraintName == "pull_requests_author_id_fkey" {
+		switch pgErr.ConstraintName {
+		case "pull_requests_author_id_fkey", "team_slas_team_name_fkey", "team_slas_lead_id_fkey":
 			return CustomError{
 				Err:     err,
 				ErrType: NOT_FOUND,
@@ -96,66 +138,132 @@ func HandlePgErr(err error) error {
 	return err
 }
 
+// HandleSqliteErr is HandlePgErr for the SQLite backend. SQLite reports the violated
+// columns instead of constraint names, so unique violations are matched on those.
+func HandleSqliteErr(err error) error {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return CustomError{Err: err, ErrType: UNAVAILABLE}
+	}
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return CustomError{Err: err, ErrType: NOT_FOUND}
+	}
+
+	var liteErr *sqlite.Error
+	if !errors.As(err, &liteErr) {
+		return err
+	}
+
+	switch liteErr.Code() {
+	case sqlite3.SQLITE_BUSY, sqlite3.SQLITE_LOCKED:
+		return CustomError{Err: err, ErrType: UNAVAILABLE}
+	case sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY, sqlite3.SQLITE_CONSTRAINT_UNIQUE:
+		switch {
+		case strings.Contains(liteErr.Error(), "pull_requests.id"):
+			return CustomError{Err: err, ErrType: PR_EXISTS}
+		case strings.Contains(liteErr.Error(), "teams.name"):
+			return CustomError{Err: err, ErrType: TEAM_EXISTS}
+		}
+	}
+
+	return CustomError{Err: err, ErrType: SERVER}
+}
+
+func isUnavailable(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) || pgconn.Timeout(err) {
+		return true
+	}
+
+	var connectErr *pgconn.ConnectError
+	if errors.As(err, &connectErr) {
+		return true
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch {
+		case strings.HasPrefix(pgErr.Code, "08"), // connection_exception
+			pgErr.Code == "53300", // too_many_connections
+			pgErr.Code == "57P01", // admin_shutdown
+			pgErr.Code == "57P03": // cannot_connect_now
+			return true
+		}
+	}
+
+	return false
+}
+
+// HandleErrsCtx is HandleErrs for request handlers: unexpected errors get the request
+// correlation ID and are logged with their whole wrapped chain.
+func HandleErrsCtx(ctx context.Context, err error) (int, gen.ErrorResponse) {
+	code, response := HandleErrs(err)
+
+	if code >= http.StatusInternalServerError {
+		correlationID := log.RequestID(ctx)
+		if correlationID == "" {
+			correlationID = log.NewRequestID()
+			ctx = log.WithRequestID(ctx, correlationID)
+		}
+
+		response.Error.CorrelationId = &correlationID
+
+		log.Ctx(ctx).Error(err)
+	}
+
+	return code, response
+}
+
 func HandleErrs(err error) (int, gen.ErrorResponse) {
 	var Cerr CustomError
 	if errors.As(err, &Cerr) {
 		switch {
 		case Cerr.ErrType == TEAM_EXISTS:
-			return http.StatusBadRequest, gen.ErrorResponse{
-				Error: struct {
-					Code    gen.ErrorResponseErrorCode `json:"code"`
-					Message string                     `json:"message"`
-				}{Code: gen.TEAMEXISTS, Message: M_TEAM_EXISTS},
-			}
+			return http.StatusBadRequest, newErrorResponse(gen.TEAMEXISTS, M_TEAM_EXISTS)
+		case Cerr.ErrType == USER_EXISTS:
+			return http.StatusConflict, newErrorResponse(gen.USEREXISTS, M_USER_EXISTS)
 		case Cerr.ErrType == NOT_FOUND:
-			return http.StatusNotFound, gen.ErrorResponse{
-				Error: struct {
-					Code    gen.ErrorResponseErrorCode `json:"code"`
-					Message string                     `json:"message"`
-				}{Code: gen.NOTFOUND, Message: M_NOT_FOUND},
-			}
+			return http.StatusNotFound, newErrorResponse(gen.NOTFOUND, M_NOT_FOUND)
 		case Cerr.ErrType == PR_EXISTS:
-			return http.StatusConflict, gen.ErrorResponse{
-				Error: struct {
-					Code    gen.ErrorResponseErrorCode `json:"code"`
-					Message string                     `json:"message"`
-				}{Code: gen.PREXISTS, Message: M_PR_EXISTS},
-			}
+			return http.StatusConflict, newErrorResponse(gen.PREXISTS, M_PR_EXISTS)
 		case Cerr.ErrType == PR_MERGED:
-			return http.StatusConflict, gen.ErrorResponse{
-				Error: struct {
-					Code    gen.ErrorResponseErrorCode `json:"code"`
-					Message string                     `json:"message"`
-				}{Code: gen.PRMERGED, Message: M_PR_MERGED},
-			}
+			return http.StatusConflict, newErrorResponse(gen.PRMERGED, M_PR_MERGED)
 		case Cerr.ErrType == NOT_ASSIGNED:
-			return http.StatusConflict, gen.ErrorResponse{
-				Error: struct {
-					Code    gen.ErrorResponseErrorCode `json:"code"`
-					Message string                     `json:"message"`
-				}{Code: gen.NOTASSIGNED, Message: M_NOT_ASSIGNED},
-			}
+			return http.StatusConflict, newErrorResponse(gen.NOTASSIGNED, M_NOT_ASSIGNED)
 		case Cerr.ErrType == NO_CANDIDATE:
-			return http.StatusConflict, gen.ErrorResponse{
-				Error: struct {
-					Code    gen.ErrorResponseErrorCode `json:"code"`
-					Message string                     `json:"message"`
-				}{Code: gen.NOCANDIDATE, Message: M_NO_CANDIDATE},
+			return http.StatusConflict, newErrorResponse(gen.NOCANDIDATE, M_NO_CANDIDATE)
+		case Cerr.ErrType == VALIDATION:
+			response := newErrorResponse(gen.VALIDATIONERROR, M_VALIDATION)
+
+			var fieldsErr FieldsError
+			if errors.As(Cerr.Err, &fieldsErr) {
+				details := fieldsErr.Fields
+				response.Error.Details = &details
 			}
+
+			return http.StatusBadRequest, response
+		case Cerr.ErrType == UNAVAILABLE:
+			return http.StatusServiceUnavailable, newErrorResponse(gen.SERVICEUNAVAILABLE, M_UNAVAILABLE)
+		case Cerr.ErrType == RATE_LIMITED:
+			return http.StatusTooManyRequests, newErrorResponse(gen.RATELIMITED, M_RATE_LIMITED)
+		case Cerr.ErrType == UNAUTHORIZED:
+			return http.StatusUnauthorized, newErrorResponse(gen.UNAUTHORIZED, M_UNAUTHORIZED)
 		default:
-			return http.StatusTeapot, gen.ErrorResponse{
-				Error: struct {
-					Code    gen.ErrorResponseErrorCode `json:"code"`
-					Message string                     `json:"message"`
-				}{Code: "SERVER", Message: M_SERVER},
-			}
+			return http.StatusInternalServerError, newErrorResponse(gen.INTERNALERROR, M_SERVER)
 		}
 	}
 
-	return http.StatusTeapot, gen.ErrorResponse{
-		Error: struct {
-			Code    gen.ErrorResponseErrorCode `json:"code"`
-			Message string                     `json:"message"`
-		}{Code: "SERVER", Message: M_SERVER},
+	if isUnavailable(err) {
+		return http.StatusServiceUnavailable, newErrorResponse(gen.SERVICEUNAVAILABLE, M_UNAVAILABLE)
 	}
+
+	return http.StatusInternalServerError, newErrorResponse(gen.INTERNALERROR, M_SERVER)
+}
+
+func newErrorResponse(code gen.ErrorResponseErrorCode, message string) gen.ErrorResponse {
+	var response gen.ErrorResponse
+
+	response.Error.Code = code
+	response.Error.Message = message
+
+	return response
 }
@@ -0,0 +1,75 @@
+package cerr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"avito/internal/gen"
+	"avito/internal/log"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleErrsInternal(t *testing.T) {
+	tests := []struct {
+		description  string
+		err          error
+		expectedCode int
+		expectedType gen.ErrorResponseErrorCode
+	}{
+		{
+			description:  "unknown error",
+			err:          errors.New("boom"),
+			expectedCode: http.StatusInternalServerError,
+			expectedType: gen.INTERNALERROR,
+		},
+		{
+			description:  "unknown pg error",
+			err:          HandlePgErr(&pgconn.PgError{Code: "42P01"}),
+			expectedCode: http.StatusInternalServerError,
+			expectedType: gen.INTERNALERROR,
+		},
+		{
+			description:  "pool exhausted until deadline",
+			err:          HandlePgErr(fmt.Errorf("acquire: %w", context.DeadlineExceeded)),
+			expectedCode: http.StatusServiceUnavailable,
+			expectedType: gen.SERVICEUNAVAILABLE,
+		},
+		{
+			description:  "database shutting down",
+			err:          HandlePgErr(&pgconn.PgError{Code: "57P01"}),
+			expectedCode: http.StatusServiceUnavailable,
+			expectedType: gen.SERVICEUNAVAILABLE,
+		},
+		{
+			description:  "connection lost",
+			err:          HandlePgErr(&pgconn.PgError{Code: "08006"}),
+			expectedCode: http.StatusServiceUnavailable,
+			expectedType: gen.SERVICEUNAVAILABLE,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			ctx := log.WithRequestID(context.Background(), "req-1")
+
+			code, response := HandleErrsCtx(ctx, test.err)
+
+			assert.Equal(t, test.expectedCode, code)
+			assert.Equal(t, test.expectedType, response.Error.Code)
+			require.NotNil(t, response.Error.CorrelationId)
+			assert.Equal(t, "req-1", *response.Error.CorrelationId)
+		})
+	}
+}
+
+func TestHandleErrsBusinessHasNoCorrelation(t *testing.T) {
+	code, response := HandleErrsCtx(context.Background(), CustomError{ErrType: NOT_FOUND})
+
+	assert.Equal(t, http.StatusNotFound, code)
+	assert.Nil(t, response.Error.CorrelationId)
+}
@@ -7,10 +7,13 @@ import (
 	"time"
 
 	"avito/internal/log"
+	"avito/internal/org"
 	"github.com/spf13/viper"
 )
 
 type Config struct {
+	Storage      string
+	SqlitePath   string
 	PGName       string
 	PGUser       string
 	PGPassword   string
@@ -21,10 +24,57 @@ type Config struct {
 	ConnAttempts int
 	ServiceHost  string
 	ServicePort  string
-	IsTest       bool
+	GRPCEnabled  bool
+	GRPCPort     string
+	OtelExporter string
+	OtelEndpoint string
+	OtelService  string
+	OtelRatio    float64
+	LogLevel     string
+	LogFormat    string
+
+	OrgAPIKeys string
+	AdminToken string
+
+	SCIMToken       string
+	SCIMDefaultTeam string
+	SCIMOrg         string
+
+	ChatOpsSigningSecret string
+	ChatOpsToken         string
+	ChatOpsUsers         string
+	ChatOpsOrg           string
+
+	SMTPAddr       string
+	SMTPFrom       string
+	SMTPUsername   string
+	SMTPPassword   string
+	NotifyDigestAt string
+
+	SLACheckInterval time.Duration
+
+	ArchiveAfter    time.Duration
+	ArchiveInterval time.Duration
+
+	RateLimitBackend    string
+	RateLimitReadRate   float64
+	RateLimitReadBurst  int
+	RateLimitWriteRate  float64
+	RateLimitWriteBurst int
+
+	TrustedProxies string
+
+	HTTPReadTimeout  time.Duration
+	HTTPWriteTimeout time.Duration
+	HTTPIdleTimeout  time.Duration
+	RequestTimeout   time.Duration
+	ShutdownDelay    time.Duration
+	ShutdownTimeout  time.Duration
 }
 
 const (
+	Storage      = "STORAGE"
+	SqlitePath   = "SQLITE_PATH"
 	PGName       = "PG_NAME"
 	PGUser       = "PG_USER"
 	PGPassword   = "PG_PASSWORD"
@@ -35,12 +85,91 @@ const (
 	ConnAttempts = "CONN_ATTEMPTS"
 	ServiceHost  = "SERVICE_HOST"
 	ServicePort  = "SERVICE_PORT"
-	IsTest       = "IS_TEST"
+	GRPCEnabled  = "GRPC_ENABLED"
+	GRPCPort     = "GRPC_PORT"
+	OtelExporter = "OTEL_EXPORTER"
+	OtelEndpoint = "OTEL_ENDPOINT"
+	OtelService  = "OTEL_SERVICE_NAME"
+	OtelRatio    = "OTEL_SAMPLE_RATIO"
+	LogLevel     = "LOG_LEVEL"
+	LogFormat    = "LOG_FORMAT"
+
+	OrgAPIKeys = "ORG_API_KEYS"
+	AdminToken = "ADMIN_TOKEN"
+
+	SCIMToken       = "SCIM_TOKEN"
+	SCIMDefaultTeam = "SCIM_DEFAULT_TEAM"
+	SCIMOrg         = "SCIM_ORG"
+
+	ChatOpsSigningSecret = "CHATOPS_SIGNING_SECRET"
+	ChatOpsToken         = "CHATOPS_TOKEN"
+	ChatOpsUsers         = "CHATOPS_USERS"
+	ChatOpsOrg           = "CHATOPS_ORG"
+
+	SMTPAddr       = "SMTP_ADDR"
+	SMTPFrom       = "SMTP_FROM"
+	SMTPUsername   = "SMTP_USERNAME"
+	SMTPPassword   = "SMTP_PASSWORD"
+	NotifyDigestAt = "NOTIFY_DIGEST_AT"
+
+	SLACheckInterval = "SLA_CHECK_INTERVAL"
+
+	ArchiveAfter    = "ARCHIVE_AFTER"
+	ArchiveInterval = "ARCHIVE_INTERVAL"
+
+	RateLimitBackend    = "RATE_LIMIT_BACKEND"
+	RateLimitReadRate   = "RATE_LIMIT_READ_RPS"
+	RateLimitReadBurst  = "RATE_LIMIT_READ_BURST"
+	RateLimitWriteRate  = "RATE_LIMIT_WRITE_RPS"
+	RateLimitWriteBurst = "RATE_LIMIT_WRITE_BURST"
+
+	TrustedProxies = "TRUSTED_PROXIES"
+
+	HTTPReadTimeout  = "HTTP_READ_TIMEOUT"
+	HTTPWriteTimeout = "HTTP_WRITE_TIMEOUT"
+	HTTPIdleTimeout  = "HTTP_IDLE_TIMEOUT"
+	RequestTimeout   = "REQUEST_TIMEOUT"
+	ShutdownDelay    = "SHUTDOWN_DELAY"
+	ShutdownTimeout  = "SHUTDOWN_TIMEOUT"
 )
 
 const (
-	_defaultServiceHost = "localhost"
-	_defaultServicePort = "8080"
+	_defaultStorage      = "postgres"
+	_defaultSqlitePath   = "./avito.db"
+	_defaultServiceHost  = "localhost"
+	_defaultServicePort  = "8080"
+	_defaultGRPCEnabled  = true
+	_defaultGRPCPort     = "9090"
+	_defaultOtelExporter = "none"
+	_defaultOtelService  = "avito"
+	_defaultOtelRatio    = 1.0
+	_defaultLogLevel     = "info"
+	_defaultLogFormat    = log.FormatJSON
+
+	_defaultSCIMDefaultTeam = "unassigned"
+	_defaultSCIMOrg         = org.Default
+
+	_defaultChatOpsOrg = org.Default
+
+	_defaultSMTPFrom       = "reviewers@localhost"
+	_defaultNotifyDigestAt = "09:00"
+
+	_defaultSLACheckInterval = 5 * time.Minute
+
+	_defaultArchiveInterval = time.Hour
+
+	_defaultRateLimitBackend    = "memory"
+	_defaultRateLimitReadRate   = 50.0
+	_defaultRateLimitReadBurst  = 100
+	_defaultRateLimitWriteRate  = 10.0
+	_defaultRateLimitWriteBurst = 20
+
+	_defaultHTTPReadTimeout  = 10 * time.Second
+	_defaultHTTPWriteTimeout = 15 * time.Second
+	_defaultHTTPIdleTimeout  = time.Minute
+	_defaultRequestTimeout   = 10 * time.Second
+	_defaultShutdownDelay    = 5 * time.Second
+	_defaultShutdownTimeout  = 30 * time.Second
 )
 
 func InitConfig() *Config {
@@ -55,8 +184,35 @@ func InitConfig() *Config {
 
 	viper.AutomaticEnv()
 
+	viper.SetDefault(Storage, _defaultStorage)
+	viper.SetDefault(SqlitePath, _defaultSqlitePath)
 	viper.SetDefault(ServiceHost, _defaultServiceHost)
 	viper.SetDefault(ServicePort, _defaultServicePort)
+	viper.SetDefault(GRPCEnabled, _defaultGRPCEnabled)
+	viper.SetDefault(GRPCPort, _defaultGRPCPort)
+	viper.SetDefault(OtelExporter, _defaultOtelExporter)
+	viper.SetDefault(OtelService, _defaultOtelService)
+	viper.SetDefault(OtelRatio, _defaultOtelRatio)
+	viper.SetDefault(LogLevel, _defaultLogLevel)
+	viper.SetDefault(LogFormat, _defaultLogFormat)
+	viper.SetDefault(SCIMDefaultTeam, _defaultSCIMDefaultTeam)
+	viper.SetDefault(SCIMOrg, _defaultSCIMOrg)
+	viper.SetDefault(ChatOpsOrg, _defaultChatOpsOrg)
+	viper.SetDefault(SMTPFrom, _defaultSMTPFrom)
+	viper.SetDefault(NotifyDigestAt, _defaultNotifyDigestAt)
+	viper.SetDefault(SLACheckInterval, _defaultSLACheckInterval)
+	viper.SetDefault(ArchiveInterval, _defaultArchiveInterval)
+	viper.SetDefault(RateLimitBackend, _defaultRateLimitBackend)
+	viper.SetDefault(RateLimitReadRate, _defaultRateLimitReadRate)
+	viper.SetDefault(RateLimitReadBurst, _defaultRateLimitReadBurst)
+	viper.SetDefault(RateLimitWriteRate, _defaultRateLimitWriteRate)
+	viper.SetDefault(RateLimitWriteBurst, _defaultRateLimitWriteBurst)
+	viper.SetDefault(HTTPReadTimeout, _defaultHTTPReadTimeout)
+	viper.SetDefault(HTTPWriteTimeout, _defaultHTTPWriteTimeout)
+	viper.SetDefault(HTTPIdleTimeout, _defaultHTTPIdleTimeout)
+	viper.SetDefault(RequestTimeout, _defaultRequestTimeout)
+	viper.SetDefault(ShutdownDelay, _defaultShutdownDelay)
+	viper.SetDefault(ShutdownTimeout, _defaultShutdownTimeout)
 
 	err = viper.ReadInConfig()
 	if err != nil {
@@ -67,7 +223,14 @@ func InitConfig() *Config {
 		}
 	}
 
+	err = log.Configure(viper.GetString(LogLevel), viper.GetString(LogFormat))
+	if err != nil {
+		panic(fmt.Sprintf("err configuring logger: %v", err.Error()))
+	}
+
 	return &Config{
+		Storage:      viper.GetString(Storage),
+		SqlitePath:   viper.GetString(SqlitePath),
 		PGName:       viper.GetString(PGName),
 		PGUser:       viper.GetString(PGUser),
 		PGPassword:   viper.GetString(PGPassword),
@@ -78,6 +241,51 @@ func InitConfig() *Config {
 		ConnAttempts: viper.GetInt(ConnAttempts),
 		ServiceHost:  viper.GetString(ServiceHost),
 		ServicePort:  viper.GetString(ServicePort),
-		IsTest:       viper.GetBool(IsTest),
+		GRPCEnabled:  viper.GetBool(GRPCEnabled),
+		GRPCPort:     viper.GetString(GRPCPort),
+		OtelExporter: viper.GetString(OtelExporter),
+		OtelEndpoint: viper.GetString(OtelEndpoint),
+		OtelService:  viper.GetString(OtelService),
+		OtelRatio:    viper.GetFloat64(OtelRatio),
+		LogLevel:     viper.GetString(LogLevel),
+		LogFormat:    viper.GetString(LogFormat),
+
+		OrgAPIKeys: viper.GetString(OrgAPIKeys),
+		AdminToken: viper.GetString(AdminToken),
+
+		SCIMToken:       viper.GetString(SCIMToken),
+		SCIMDefaultTeam: viper.GetString(SCIMDefaultTeam),
+		SCIMOrg:         viper.GetString(SCIMOrg),
+
+		ChatOpsSigningSecret: viper.GetString(ChatOpsSigningSecret),
+		ChatOpsToken:         viper.GetString(ChatOpsToken),
+		ChatOpsUsers:         viper.GetString(ChatOpsUsers),
+		ChatOpsOrg:           viper.GetString(ChatOpsOrg),
+
+		SMTPAddr:       viper.GetString(SMTPAddr),
+		SMTPFrom:       viper.GetString(SMTPFrom),
+		SMTPUsername:   viper.GetString(SMTPUsername),
+		SMTPPassword:   viper.GetString(SMTPPassword),
+		NotifyDigestAt: viper.GetString(NotifyDigestAt),
+
+		SLACheckInterval: viper.GetDuration(SLACheckInterval),
+
+		ArchiveAfter:    viper.GetDuration(ArchiveAfter),
+		ArchiveInterval: viper.GetDuration(ArchiveInterval),
+
+		RateLimitBackend:    viper.GetString(RateLimitBackend),
+		RateLimitReadRate:   viper.GetFloat64(RateLimitReadRate),
+		RateLimitReadBurst:  viper.GetInt(RateLimitReadBurst),
+		RateLimitWriteRate:  viper.GetFloat64(RateLimitWriteRate),
+		RateLimitWriteBurst: viper.GetInt(RateLimitWriteBurst),
+
+		TrustedProxies: viper.GetString(TrustedProxies),
+
+		HTTPReadTimeout:  viper.GetDuration(HTTPReadTimeout),
+		HTTPWriteTimeout: viper.GetDuration(HTTPWriteTimeout),
+		HTTPIdleTimeout:  viper.GetDuration(HTTPIdleTimeout),
+		RequestTimeout:   viper.GetDuration(RequestTimeout),
+		ShutdownDelay:    viper.GetDuration(ShutdownDelay),
+		ShutdownTimeout:  viper.GetDuration(ShutdownTimeout),
 	}
 }
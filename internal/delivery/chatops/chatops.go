@@ -0,0 +1,175 @@
+// Package chatops serves the slash command reviewers type in Slack or Mattermost, such as
+// /review mine or /review reassign PR-123. The command runs through the same services as
+// the HTTP API and the reply is shown only to the user who typed it.
+package chatops
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"avito/internal/log"
+	"avito/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	HeaderSlackSignature = "X-Slack-Signature"
+	HeaderSlackTimestamp = "X-Slack-Request-Timestamp"
+)
+
+// maxSkew is how old a signed request may be, Slack recommends five minutes against replays.
+const maxSkew = 5 * time.Minute
+
+// maxBody caps the form, slash command posts are a few hundred bytes.
+const maxBody = 64 << 10
+
+type Services struct {
+	User        service.User
+	PullRequest service.PullRequest
+	Stat        service.Stat
+}
+
+type Config struct {
+	// SigningSecret verifies the X-Slack-Signature of Slack requests.
+	SigningSecret string
+	// Token is compared with the token field Mattermost sends with the command.
+	Token string
+	// Users maps chat user ids to users.id.
+	Users map[string]string
+}
+
+// response is the reply both Slack and Mattermost accept for a slash command.
+type response struct {
+	ResponseType string `json:"response_type"`
+	Text         string `json:"text"`
+}
+
+type handler struct {
+	services Services
+	cfg      Config
+}
+
+// InitHandler returns the handler for slash command posts. At least one of the signing
+// secret and the token must be set, requests that match neither are rejected.
+func InitHandler(services Services, cfg Config) (gin.HandlerFunc, error) {
+	if cfg.SigningSecret == "" && cfg.Token == "" {
+		return nil, errors.New("chatops needs a signing secret or a token")
+	}
+
+	h := &handler{services: services, cfg: cfg}
+
+	return h.handle, nil
+}
+
+func (h *handler) handle(c *gin.Context) {
+	body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxBody))
+	if err != nil {
+		c.String(http.StatusBadRequest, "cannot read body: %v", err)
+
+		return
+	}
+
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		c.String(http.StatusBadRequest, "body is not a form: %v", err)
+
+		return
+	}
+
+	if err = h.verify(c.Request.Header, body, form); err != nil {
+		c.String(http.StatusUnauthorized, err.Error())
+
+		return
+	}
+
+	userID, ok := h.cfg.Users[form.Get("user_id")]
+	if !ok {
+		c.JSON(http.StatusOK, reply(fmt.Sprintf("Your chat user `%s` is not mapped to a reviewer. "+
+			"Ask an admin to add `%s=<your user_id>` to CHATOPS_USERS.", form.Get("user_id"), form.Get("user_id"))))
+
+		return
+	}
+
+	ctx := c.Request.Context()
+	log.AddField(ctx, "chat_user_id", form.Get("user_id"))
+	log.AddField(ctx, "user_id", userID)
+
+	c.JSON(http.StatusOK, reply(h.run(ctx, userID, form.Get("command"), form.Get("text"))))
+}
+
+// verify accepts a request signed with the Slack signing secret or carrying the
+// Mattermost token. A Slack signature is checked whenever the header is there.
+func (h *handler) verify(header http.Header, body []byte, form url.Values) error {
+	if signature := header.Get(HeaderSlackSignature); signature != "" && h.cfg.SigningSecret != "" {
+		return h.verifySignature(signature, header.Get(HeaderSlackTimestamp), body)
+	}
+
+	if h.cfg.Token != "" && subtle.ConstantTimeCompare([]byte(form.Get("token")), []byte(h.cfg.Token)) == 1 {
+		return nil
+	}
+
+	return errors.New("request is neither signed nor carries a valid token")
+}
+
+func (h *handler) verifySignature(signature string, timestamp string, body []byte) error {
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("%s %q is not a unix time", HeaderSlackTimestamp, timestamp)
+	}
+
+	if skew := time.Since(time.Unix(ts, 0)); skew > maxSkew || skew < -maxSkew {
+		return fmt.Errorf("%s is %v off", HeaderSlackTimestamp, skew.Round(time.Second))
+	}
+
+	if !hmac.Equal([]byte(signature), []byte(Sign(h.cfg.SigningSecret, timestamp, body))) {
+		return errors.New("signature does not match")
+	}
+
+	return nil
+}
+
+// Sign computes the v0 signature Slack sends in X-Slack-Signature.
+func Sign(secret string, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("v0:" + timestamp + ":"))
+	mac.Write(body)
+
+	return "v0=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// ParseUsers reads the chat user mapping in the form "U024BE7LH=alice,U0G9QF9C6=bob".
+func ParseUsers(s string) (map[string]string, error) {
+	users := map[string]string{}
+
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		chatID, userID, ok := strings.Cut(pair, "=")
+
+		chatID, userID = strings.TrimSpace(chatID), strings.TrimSpace(userID)
+		if !ok || chatID == "" || userID == "" {
+			return nil, fmt.Errorf("chat user %q is not chat_id=user_id", pair)
+		}
+
+		users[chatID] = userID
+	}
+
+	return users, nil
+}
+
+func reply(text string) response {
+	return response{ResponseType: "ephemeral", Text: text}
+}
@@ -0,0 +1,183 @@
+package chatops_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"avito/internal/delivery/chatops"
+	"avito/internal/entity"
+	"avito/internal/repo"
+	"avito/internal/repo/memory"
+	"avito/internal/service/pullRequest"
+	"avito/internal/service/stat"
+	"avito/internal/service/user"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	secret = "signing-secret"
+	token  = "mattermost-token"
+)
+
+type server struct {
+	g     *gin.Engine
+	repos repo.Repos
+}
+
+func newServer(t *testing.T) *server {
+	t.Helper()
+
+	gin.SetMode(gin.TestMode)
+
+	store := memory.InitStore()
+	repos := repo.Repos{
+		Team:        memory.InitTeamRepo(store),
+		User:        memory.InitUserRepo(store),
+		PullRequest: memory.InitPullRequestRepo(store),
+		Stat:        memory.InitStatRepo(store),
+	}
+
+	ctx := context.Background()
+	require.NoError(t, repos.Team.Create(ctx, &entity.Team{TeamName: "backend", Members: []entity.TeamMember{
+		{UserId: "author", Username: "Author", IsActive: true},
+		{UserId: "alice", Username: "Alice", IsActive: true},
+		{UserId: "bob", Username: "Bob", IsActive: true},
+		{UserId: "carol", Username: "Carol", IsActive: false},
+	}}))
+
+	handler, err := chatops.InitHandler(chatops.Services{
+		User:        user.InitUserServ(repos.User),
+		PullRequest: pullRequest.InitPullRequestServ(repos.PullRequest),
+		Stat:        stat.InitStatServ(repos.Stat),
+	}, chatops.Config{SigningSecret: secret, Token: token, Users: map[string]string{"U024BE7LH": "alice", "bob-id": "bob"}})
+	require.NoError(t, err)
+
+	g := gin.New()
+	g.POST("/chatops/command", handler)
+
+	return &server{g: g, repos: repos}
+}
+
+func (s *server) post(body string, header http.Header) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/chatops/command", strings.NewReader(body))
+	req.Header = header
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	rec := httptest.NewRecorder()
+	s.g.ServeHTTP(rec, req)
+
+	return rec
+}
+
+// slack sends the command the way Slack does, signed and with the chat user id.
+func (s *server) slack(t *testing.T, text string) string {
+	t.Helper()
+
+	body := url.Values{"command": {"/review"}, "user_id": {"U024BE7LH"}, "user_name": {"alice.smith"}, "text": {text}}.Encode()
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	rec := s.post(body, http.Header{
+		chatops.HeaderSlackTimestamp: {timestamp},
+		chatops.HeaderSlackSignature: {chatops.Sign(secret, timestamp, []byte(body))},
+	})
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var res map[string]string
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	assert.Equal(t, "ephemeral", res["response_type"])
+
+	return res["text"]
+}
+
+func TestVerify(t *testing.T) {
+	s := newServer(t)
+
+	body := url.Values{"command": {"/review"}, "user_name": {"bob"}, "text": {"mine"}, "token": {"wrong"}}.Encode()
+	now := strconv.FormatInt(time.Now().Unix(), 10)
+	stale := strconv.FormatInt(time.Now().Add(-10*time.Minute).Unix(), 10)
+
+	for name, header := range map[string]http.Header{
+		"no signature":    {},
+		"wrong signature": {chatops.HeaderSlackTimestamp: {now}, chatops.HeaderSlackSignature: {chatops.Sign("other", now, []byte(body))}},
+		"stale":           {chatops.HeaderSlackTimestamp: {stale}, chatops.HeaderSlackSignature: {chatops.Sign(secret, stale, []byte(body))}},
+	} {
+		assert.Equal(t, http.StatusUnauthorized, s.post(body, header).Code, name)
+	}
+
+	// Mattermost sends the token with the form instead of signing it.
+	body = url.Values{"command": {"/review"}, "user_id": {"bob-id"}, "user_name": {"bob"}, "text": {"mine"}, "token": {token}}.Encode()
+
+	rec := s.post(body, http.Header{})
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "no open reviews")
+}
+
+func TestUnmappedUser(t *testing.T) {
+	s := newServer(t)
+
+	// The chat username is not trusted as a user id, anyone can pick "alice" in the chat.
+	body := url.Values{"command": {"/review"}, "user_id": {"U0G9QF9C6"}, "user_name": {"alice"}, "text": {"away"}, "token": {token}}.Encode()
+
+	rec := s.post(body, http.Header{})
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "not mapped")
+	assert.Contains(t, rec.Body.String(), "`U0G9QF9C6=")
+
+	team, err := s.repos.Team.Get(context.Background(), "backend")
+	require.NoError(t, err)
+	assert.Contains(t, team.Members, entity.TeamMember{UserId: "alice", Username: "Alice", IsActive: true})
+}
+
+func TestCommands(t *testing.T) {
+	s := newServer(t)
+	ctx := context.Background()
+
+	assert.Contains(t, s.slack(t, ""), "/review reassign <pr> [reviewer]")
+	assert.Contains(t, s.slack(t, "deploy"), "Unknown command `deploy`")
+
+	pr, err := s.repos.PullRequest.Create(ctx, &entity.PullRequestCreate{PullRequestId: "PR-123", PullRequestName: "Add search", AuthorId: "author"})
+	require.NoError(t, err)
+
+	require.ElementsMatch(t, []string{"alice", "bob"}, pr.AssignedReviewers)
+
+	_, err = s.repos.User.SetIsActive(ctx, "carol", true)
+	require.NoError(t, err)
+
+	assert.Contains(t, s.slack(t, "mine"), "`PR-123` Add search by `author`")
+
+	// The chat user id maps to alice, so she hands her own review off.
+	assert.Contains(t, s.slack(t, "reassign PR-123"), "`alice` replaced by `carol`")
+
+	assert.Equal(t, "You have no open reviews.", s.slack(t, "mine"))
+
+	assert.Contains(t, s.slack(t, "reassign PR-404"), "NOT_FOUND")
+	assert.Contains(t, s.slack(t, "reassign PR-123 alice"), "NOT_ASSIGNED")
+
+	s.slack(t, "away")
+	assert.Contains(t, s.slack(t, "stats"), "`alice`: 0 reviews, average time n/a, away")
+
+	s.slack(t, "back")
+	assert.NotContains(t, s.slack(t, "stats alice"), "away")
+
+	text := s.slack(t, "stats team backend")
+	assert.Contains(t, text, "Team `backend`, average review time n/a:")
+	assert.Contains(t, text, "`carol`: 1 reviews")
+}
+
+func TestParseUsers(t *testing.T) {
+	users, err := chatops.ParseUsers(" U024BE7LH=alice, U0G9QF9C6 = bob ,")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"U024BE7LH": "alice", "U0G9QF9C6": "bob"}, users)
+
+	_, err = chatops.ParseUsers("U024BE7LH")
+	assert.Error(t, err)
+}
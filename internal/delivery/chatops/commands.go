@@ -0,0 +1,204 @@
+package chatops
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"avito/internal/cerr"
+	"avito/internal/entity"
+	"avito/internal/gen"
+)
+
+type command struct {
+	usage string
+	help  string
+	run   func(h *handler, ctx context.Context, userID string, args []string) (string, error)
+}
+
+var commands = map[string]command{
+	"mine":     {"mine", "your open reviews", (*handler).mine},
+	"reassign": {"reassign <pr> [reviewer]", "hand your review, or the reviewer's, to a teammate", (*handler).reassign},
+	"away":     {"away", "stop getting new reviews", (*handler).away},
+	"back":     {"back", "get new reviews again", (*handler).back},
+	"stats":    {"stats [user | team <name>]", "review stats, yours by default", (*handler).stats},
+}
+
+// order is the order of the commands in the help.
+var order = []string{"mine", "reassign", "away", "back", "stats"}
+
+// run executes the text after the slash command and returns the reply. Errors are
+// replies too, the chat shows nothing for a failed request.
+func (h *handler) run(ctx context.Context, userID string, slash string, text string) string {
+	fields := strings.Fields(text)
+	if len(fields) == 0 || fields[0] == "help" {
+		return usage(slash)
+	}
+
+	cmd, ok := commands[strings.ToLower(fields[0])]
+	if !ok {
+		return fmt.Sprintf("Unknown command `%s`.\n%s", fields[0], usage(slash))
+	}
+
+	out, err := cmd.run(h, ctx, userID, fields[1:])
+	if err != nil {
+		return failure(ctx, userID, err)
+	}
+
+	return out
+}
+
+func (h *handler) mine(ctx context.Context, userID string, args []string) (string, error) {
+	if len(args) != 0 {
+		return "Usage: `mine`", nil
+	}
+
+	reviews, err := h.services.User.GetReview(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+
+	for _, pr := range reviews {
+		if pr.Status == entity.PRStatusOPEN {
+			fmt.Fprintf(&b, "\n• `%s` %s by `%s`", pr.PullRequestId, pr.PullRequestName, pr.AuthorId)
+		}
+	}
+
+	if b.Len() == 0 {
+		return "You have no open reviews.", nil
+	}
+
+	return "Your open reviews:" + b.String(), nil
+}
+
+func (h *handler) reassign(ctx context.Context, userID string, args []string) (string, error) {
+	if len(args) != 1 && len(args) != 2 {
+		return "Usage: `reassign <pr> [reviewer]`", nil
+	}
+
+	oldUserID := userID
+	if len(args) == 2 {
+		oldUserID = args[1]
+	}
+
+	pr, replacedBy, err := h.services.PullRequest.Reassign(ctx, args[0], oldUserID)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("`%s` %s: `%s` replaced by `%s`. Reviewers: %s.",
+		pr.PullRequestId, pr.PullRequestName, oldUserID, replacedBy, codes(pr.AssignedReviewers)), nil
+}
+
+func (h *handler) away(ctx context.Context, userID string, _ []string) (string, error) {
+	if _, err := h.services.User.SetIsActive(ctx, userID, false); err != nil {
+		return "", err
+	}
+
+	return "You will not get new reviews. Open ones stay yours, hand them off with `reassign`.", nil
+}
+
+func (h *handler) back(ctx context.Context, userID string, _ []string) (string, error) {
+	if _, err := h.services.User.SetIsActive(ctx, userID, true); err != nil {
+		return "", err
+	}
+
+	return "Welcome back, you will get new reviews again.", nil
+}
+
+func (h *handler) stats(ctx context.Context, userID string, args []string) (string, error) {
+	switch {
+	case len(args) == 0:
+		return h.userStats(ctx, userID)
+	case len(args) == 1:
+		return h.userStats(ctx, args[0])
+	case len(args) == 2 && strings.EqualFold(args[0], "team"):
+		stat, err := h.services.Stat.Team(ctx, args[1])
+		if err != nil {
+			return "", err
+		}
+
+		var b strings.Builder
+
+		fmt.Fprintf(&b, "Team `%s`, average review time %s:", stat.TeamName, hours(&stat.AvgDuration))
+
+		for _, u := range stat.UsersStat {
+			fmt.Fprintf(&b, "\n• %s", userStat(u))
+		}
+
+		return b.String(), nil
+	default:
+		return "Usage: `stats [user | team <name>]`", nil
+	}
+}
+
+func (h *handler) userStats(ctx context.Context, userID string) (string, error) {
+	stat, err := h.services.Stat.User(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+
+	return userStat(*stat), nil
+}
+
+func userStat(u entity.UserStat) string {
+	active := ""
+	if !u.IsActive {
+		active = ", away"
+	}
+
+	return fmt.Sprintf("`%s`: %d reviews, average time %s%s", u.UserId, u.CountPr, hours(u.AvgDuration), active)
+}
+
+// hours formats an average duration, the stats have none, or -1 for teams, until a PR is merged.
+func hours(h *float64) string {
+	if h == nil || *h < 0 {
+		return "n/a"
+	}
+
+	return fmt.Sprintf("%.1fh", *h)
+}
+
+func codes(ids []string) string {
+	if len(ids) == 0 {
+		return "none"
+	}
+
+	return "`" + strings.Join(ids, "`, `") + "`"
+}
+
+func usage(slash string) string {
+	if slash == "" {
+		slash = "/review"
+	}
+
+	var b strings.Builder
+
+	b.WriteString("Commands:")
+
+	for _, name := range order {
+		fmt.Fprintf(&b, "\n• `%s %s` — %s", slash, commands[name].usage, commands[name].help)
+	}
+
+	return b.String()
+}
+
+// failure goes through cerr.HandleErrsCtx like the other APIs, so server errors are logged
+// and carry the correlation ID the user can report.
+func failure(ctx context.Context, userID string, err error) string {
+	_, response := cerr.HandleErrsCtx(ctx, err)
+
+	text := fmt.Sprintf("Failed: %s (%s).", response.Error.Message, response.Error.Code)
+
+	if response.Error.Code == gen.NOTFOUND {
+		text += fmt.Sprintf(" Your chat account maps to the user `%s`, check the PR and user ids.", userID)
+	}
+
+	if response.Error.CorrelationId != nil {
+		text += fmt.Sprintf(" Correlation ID `%s`.", *response.Error.CorrelationId)
+	}
+
+	return text
+}
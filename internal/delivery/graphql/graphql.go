@@ -0,0 +1,108 @@
+// Package graphql serves a read-only GraphQL API for dashboards. The objects are read
+// through per-request dataloaders over repo.Lookup, so a query costs one repo call per
+// type and level instead of one per object.
+package graphql
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"avito/internal/cerr"
+	"avito/internal/repo"
+	"github.com/gin-gonic/gin"
+	"github.com/graph-gophers/graphql-go"
+	gqlerrors "github.com/graph-gophers/graphql-go/errors"
+)
+
+//go:embed schema.graphql
+var schemaSDL string
+
+// maxDepth bounds the nesting of a query, user.team.members.reviews... can go on forever.
+const maxDepth = 10
+
+// maxParallelism bounds the resolvers running at once in a request. The loaders only
+// batch the resolvers that are running, so it is well above the width of a dashboard.
+const maxParallelism = 200
+
+type request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// InitHandler returns the handler for GET and POST requests as described by the GraphQL
+// over HTTP spec. GET takes query, operationName and variables as query parameters.
+func InitHandler(lookup repo.Lookup) (gin.HandlerFunc, error) {
+	schema, err := graphql.ParseSchema(schemaSDL, &resolver{}, graphql.MaxDepth(maxDepth), graphql.MaxParallelism(maxParallelism))
+	if err != nil {
+		return nil, fmt.Errorf("parse graphql schema: %w", err)
+	}
+
+	return func(c *gin.Context) {
+		req, err := parseRequest(c)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, &graphql.Response{Errors: []*gqlerrors.QueryError{gqlerrors.Errorf("%v", err)}})
+
+			return
+		}
+
+		ctx := withLoaders(c.Request.Context(), newLoaders(lookup))
+
+		c.JSON(http.StatusOK, schema.Exec(ctx, req.Query, req.OperationName, req.Variables))
+	}, nil
+}
+
+func parseRequest(c *gin.Context) (request, error) {
+	var req request
+
+	if c.Request.Method == http.MethodGet {
+		req.Query = c.Query("query")
+		req.OperationName = c.Query("operationName")
+
+		if variables := c.Query("variables"); variables != "" {
+			if err := json.Unmarshal([]byte(variables), &req.Variables); err != nil {
+				return req, fmt.Errorf("variables are not a JSON object: %w", err)
+			}
+		}
+	} else if err := json.NewDecoder(c.Request.Body).Decode(&req); err != nil {
+		return req, fmt.Errorf("body is not a GraphQL request: %w", err)
+	}
+
+	if req.Query == "" {
+		return req, errors.New("query is required")
+	}
+
+	return req, nil
+}
+
+// resolverError carries the error code of the HTTP API in the GraphQL error extensions.
+type resolverError struct {
+	message    string
+	extensions map[string]interface{}
+}
+
+func (e resolverError) Error() string {
+	return e.message
+}
+
+func (e resolverError) Extensions() map[string]interface{} {
+	return e.extensions
+}
+
+// toError goes through cerr.HandleErrsCtx, so server errors are logged and hidden behind
+// a correlation ID like on the other APIs.
+func toError(ctx context.Context, err error) error {
+	_, response := cerr.HandleErrsCtx(ctx, err)
+
+	extensions := map[string]interface{}{"code": response.Error.Code}
+
+	if response.Error.CorrelationId != nil {
+		extensions["correlation_id"] = *response.Error.CorrelationId
+	}
+
+	return resolverError{message: response.Error.Message, extensions: extensions}
+}
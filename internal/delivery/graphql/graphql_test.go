@@ -0,0 +1,327 @@
+package graphql_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+
+	"avito/internal/cerr"
+	"avito/internal/delivery/graphql"
+	"avito/internal/entity"
+	"avito/internal/repo"
+	"avito/internal/repo/memory"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingLookup counts the calls of every lookup method.
+type countingLookup struct {
+	repo.Lookup
+
+	mu    sync.Mutex
+	calls map[string]int
+	fail  error
+}
+
+func (l *countingLookup) count(method string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.calls[method]++
+
+	return l.fail
+}
+
+func (l *countingLookup) Users(ctx context.Context, userIDs []string) ([]entity.User, error) {
+	if err := l.count("Users"); err != nil {
+		return nil, err
+	}
+
+	return l.Lookup.Users(ctx, userIDs)
+}
+
+func (l *countingLookup) Teams(ctx context.Context, teamNames []string) ([]entity.Team, error) {
+	if err := l.count("Teams"); err != nil {
+		return nil, err
+	}
+
+	return l.Lookup.Teams(ctx, teamNames)
+}
+
+func (l *countingLookup) PullRequests(ctx context.Context, pullRequestIDs []string) ([]entity.PullRequest, error) {
+	if err := l.count("PullRequests"); err != nil {
+		return nil, err
+	}
+
+	return l.Lookup.PullRequests(ctx, pullRequestIDs)
+}
+
+func (l *countingLookup) Reviews(ctx context.Context, userIDs []string) ([]entity.PullRequest, error) {
+	if err := l.count("Reviews"); err != nil {
+		return nil, err
+	}
+
+	return l.Lookup.Reviews(ctx, userIDs)
+}
+
+func (l *countingLookup) UserStats(ctx context.Context, userIDs []string) ([]entity.UserStat, error) {
+	if err := l.count("UserStats"); err != nil {
+		return nil, err
+	}
+
+	return l.Lookup.UserStats(ctx, userIDs)
+}
+
+func newRouter(t *testing.T) (*gin.Engine, *countingLookup) {
+	t.Helper()
+
+	gin.SetMode(gin.TestMode)
+
+	store := memory.InitStore()
+	ctx := context.Background()
+
+	teams := memory.InitTeamRepo(store)
+	prs := memory.InitPullRequestRepo(store)
+
+	for _, team := range []entity.Team{
+		{TeamName: "backend", Members: []entity.TeamMember{
+			{UserId: "u1", Username: "Alice", IsActive: true},
+			{UserId: "u2", Username: "Bob", IsActive: true},
+			{UserId: "u3", Username: "Carol", IsActive: true},
+		}},
+		{TeamName: "frontend", Members: []entity.TeamMember{
+			{UserId: "f1", Username: "Dave", IsActive: true},
+			{UserId: "f2", Username: "Eve", IsActive: true},
+		}},
+	} {
+		require.NoError(t, teams.Create(ctx, &team))
+	}
+
+	for _, pr := range []entity.PullRequestCreate{
+		{PullRequestId: "pr-1", PullRequestName: "Add search", AuthorId: "u1"},
+		{PullRequestId: "pr-2", PullRequestName: "Fix login", AuthorId: "u2"},
+		{PullRequestId: "pr-3", PullRequestName: "New theme", AuthorId: "f1"},
+	} {
+		_, err := prs.Create(ctx, &pr)
+		require.NoError(t, err)
+	}
+
+	_, err := prs.Merge(ctx, "pr-1")
+	require.NoError(t, err)
+
+	lookup := &countingLookup{Lookup: memory.InitLookupRepo(store), calls: make(map[string]int)}
+
+	handler, err := graphql.InitHandler(lookup)
+	require.NoError(t, err)
+
+	g := gin.New()
+	g.GET("/graphql", handler)
+	g.POST("/graphql", handler)
+
+	return g, lookup
+}
+
+type response struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []struct {
+		Message    string         `json:"message"`
+		Extensions map[string]any `json:"extensions"`
+	} `json:"errors"`
+}
+
+func post(t *testing.T, g *gin.Engine, query string, variables map[string]any) (int, response) {
+	t.Helper()
+
+	body, err := json.Marshal(map[string]any{"query": query, "variables": variables})
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	g.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/graphql", bytes.NewReader(body)))
+
+	var res response
+
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res), w.Body.String())
+
+	return w.Code, res
+}
+
+const dashboardQuery = `query($names: [String!]!) {
+  teams(names: $names) {
+    name
+    members {
+      id
+      username
+      team { name }
+      reviews {
+        id
+        status
+        author { username }
+        reviewers { id }
+      }
+      stats { reviewCount avgDuration }
+    }
+    stats { avgDuration users { user { id } reviewCount } }
+  }
+}`
+
+func TestBatching(t *testing.T) {
+	g, lookup := newRouter(t)
+
+	code, res := post(t, g, dashboardQuery, map[string]any{"names": []string{"backend", "frontend", "missing"}})
+	require.Equal(t, http.StatusOK, code)
+	require.Empty(t, res.Errors)
+
+	var data struct {
+		Teams []struct {
+			Name    string
+			Members []struct {
+				ID      string
+				Team    struct{ Name string }
+				Reviews []struct {
+					ID        string
+					Status    string
+					Author    struct{ Username string }
+					Reviewers []struct{ ID string }
+				}
+				Stats struct {
+					ReviewCount int
+					AvgDuration *float64
+				}
+			}
+			Stats struct {
+				AvgDuration *float64
+				Users       []struct{ ReviewCount int }
+			}
+		}
+	}
+
+	require.NoError(t, json.Unmarshal(res.Data, &data))
+	require.Len(t, data.Teams, 2)
+
+	backend := data.Teams[0]
+	assert.Equal(t, "backend", backend.Name)
+	require.Len(t, backend.Members, 3)
+	assert.Equal(t, "backend", backend.Members[0].Team.Name)
+	assert.Len(t, backend.Stats.Users, 3)
+	assert.NotNil(t, backend.Stats.AvgDuration)
+
+	frontend := data.Teams[1]
+	assert.Nil(t, frontend.Stats.AvgDuration)
+
+	for _, member := range frontend.Members {
+		if member.ID == "f2" {
+			require.Len(t, member.Reviews, 1)
+			assert.Equal(t, "pr-3", member.Reviews[0].ID)
+			assert.Equal(t, "OPEN", member.Reviews[0].Status)
+			assert.Equal(t, "Dave", member.Reviews[0].Author.Username)
+			assert.Equal(t, 1, member.Stats.ReviewCount)
+		}
+	}
+
+	// Five members with their reviews, authors and reviewers still cost one call per
+	// type: the teams of the members come from the loader cache, authors and reviewers
+	// sit on the same level.
+	assert.Equal(t, map[string]int{
+		"Teams":     1,
+		"Reviews":   1,
+		"Users":     1,
+		"UserStats": 1,
+	}, lookup.calls)
+}
+
+func TestQueries(t *testing.T) {
+	g, _ := newRouter(t)
+
+	_, res := post(t, g, `{
+  user(id: "u2") { username isActive reviews(status: MERGED) { id mergedAt } }
+  missing: user(id: "nobody") { id }
+  pullRequest(id: "pr-1") { name status createdAt mergedAt author { id } reviewers { id } }
+  pullRequests(ids: ["pr-3", "pr-404", "pr-2"]) { id }
+  users(ids: ["f1", "u1"]) { id }
+  team(name: "missing") { name }
+}`, nil)
+	require.Empty(t, res.Errors)
+
+	var data struct {
+		User struct {
+			Username string
+			IsActive bool
+			Reviews  []struct {
+				ID       string
+				MergedAt *string
+			}
+		}
+		Missing     *struct{}
+		PullRequest struct {
+			Name      string
+			Status    string
+			CreatedAt string
+			MergedAt  *string
+			Author    struct{ ID string }
+			Reviewers []struct{ ID string }
+		}
+		PullRequests []struct{ ID string }
+		Users        []struct{ ID string }
+		Team         *struct{}
+	}
+
+	require.NoError(t, json.Unmarshal(res.Data, &data))
+
+	assert.Equal(t, "Bob", data.User.Username)
+	require.Len(t, data.User.Reviews, 1)
+	assert.Equal(t, "pr-1", data.User.Reviews[0].ID)
+	assert.NotNil(t, data.User.Reviews[0].MergedAt)
+	assert.Nil(t, data.Missing)
+
+	assert.Equal(t, "MERGED", data.PullRequest.Status)
+	assert.NotEmpty(t, data.PullRequest.CreatedAt)
+	assert.NotNil(t, data.PullRequest.MergedAt)
+	assert.Equal(t, "u1", data.PullRequest.Author.ID)
+	assert.Len(t, data.PullRequest.Reviewers, 2)
+
+	assert.Equal(t, []struct{ ID string }{{"pr-3"}, {"pr-2"}}, data.PullRequests)
+	assert.Equal(t, []struct{ ID string }{{"f1"}, {"u1"}}, data.Users)
+	assert.Nil(t, data.Team)
+}
+
+func TestGet(t *testing.T) {
+	g, _ := newRouter(t)
+
+	query := url.Values{
+		"query":     {`query($id: ID!) { user(id: $id) { username } }`},
+		"variables": {`{"id": "f2"}`},
+	}
+
+	w := httptest.NewRecorder()
+	g.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/graphql?"+query.Encode(), nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"data": {"user": {"username": "Eve"}}}`, w.Body.String())
+}
+
+func TestErrors(t *testing.T) {
+	g, lookup := newRouter(t)
+
+	w := httptest.NewRecorder()
+	g.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/graphql", bytes.NewReader([]byte("not json"))))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	_, res := post(t, g, `{ user(id: "u1") { unknownField } }`, nil)
+	require.Len(t, res.Errors, 1)
+	assert.Contains(t, res.Errors[0].Message, "unknownField")
+
+	lookup.fail = cerr.CustomError{Err: errors.New("connection refused"), ErrType: cerr.SERVER}
+
+	_, res = post(t, g, `{ users(ids: ["u1", "u2"]) { id } }`, nil)
+	require.Len(t, res.Errors, 1)
+	assert.NotContains(t, res.Errors[0].Message, "connection refused")
+	assert.Equal(t, "INTERNAL_ERROR", res.Errors[0].Extensions["code"])
+	assert.NotEmpty(t, res.Errors[0].Extensions["correlation_id"])
+}
@@ -0,0 +1,146 @@
+package graphql
+
+import (
+	"context"
+	"time"
+
+	"avito/internal/entity"
+	"avito/internal/repo"
+	"github.com/graph-gophers/dataloader"
+)
+
+// loaderWait is how long a loader collects keys before it queries the repo. The
+// resolvers of one list run concurrently, so a few milliseconds gather the whole level.
+const loaderWait = 5 * time.Millisecond
+
+type loadersKey struct{}
+
+// loaders batch the repo calls of one request, every loader queries the repo once per
+// level of the query instead of once per object. They cache for the request only, so
+// they are built anew for every request.
+type loaders struct {
+	users        *dataloader.Loader
+	teams        *dataloader.Loader
+	pullRequests *dataloader.Loader
+	reviews      *dataloader.Loader
+	userStats    *dataloader.Loader
+}
+
+func newLoaders(lookup repo.Lookup) *loaders {
+	return &loaders{
+		users: newLoader(lookup.Users, func(user entity.User) string {
+			return user.UserId
+		}),
+		teams: newLoader(lookup.Teams, func(team entity.Team) string {
+			return team.TeamName
+		}),
+		pullRequests: newLoader(lookup.PullRequests, func(pr entity.PullRequest) string {
+			return pr.PullRequestId
+		}),
+		reviews: newReviewsLoader(lookup),
+		userStats: newLoader(lookup.UserStats, func(stat entity.UserStat) string {
+			return stat.UserId
+		}),
+	}
+}
+
+func withLoaders(ctx context.Context, l *loaders) context.Context {
+	return context.WithValue(ctx, loadersKey{}, l)
+}
+
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
+
+// newLoader wraps a lookup that returns the rows found for the keys. Keys without a row
+// resolve to nil.
+func newLoader[T any](lookup func(context.Context, []string) ([]T, error), key func(T) string) *dataloader.Loader {
+	return dataloader.NewBatchedLoader(func(ctx context.Context, keys dataloader.Keys) []*dataloader.Result {
+		rows, err := lookup(ctx, keys.Keys())
+		if err != nil {
+			return failed(ctx, len(keys), err)
+		}
+
+		byKey := make(map[string]*T, len(rows))
+		for i := range rows {
+			byKey[key(rows[i])] = &rows[i]
+		}
+
+		results := make([]*dataloader.Result, len(keys))
+		for i, k := range keys {
+			if row, ok := byKey[k.String()]; ok {
+				results[i] = &dataloader.Result{Data: row}
+			} else {
+				results[i] = &dataloader.Result{}
+			}
+		}
+
+		return results
+	}, dataloader.WithWait(loaderWait))
+}
+
+// newReviewsLoader resolves a user id to the pull requests the user reviews.
+func newReviewsLoader(lookup repo.Lookup) *dataloader.Loader {
+	return dataloader.NewBatchedLoader(func(ctx context.Context, keys dataloader.Keys) []*dataloader.Result {
+		prs, err := lookup.Reviews(ctx, keys.Keys())
+		if err != nil {
+			return failed(ctx, len(keys), err)
+		}
+
+		byReviewer := make(map[string][]entity.PullRequest)
+
+		for _, pr := range prs {
+			for _, reviewer := range pr.AssignedReviewers {
+				byReviewer[reviewer] = append(byReviewer[reviewer], pr)
+			}
+		}
+
+		results := make([]*dataloader.Result, len(keys))
+		for i, k := range keys {
+			results[i] = &dataloader.Result{Data: byReviewer[k.String()]}
+		}
+
+		return results
+	}, dataloader.WithWait(loaderWait))
+}
+
+// failed reports a repo error to every key of the batch. The error is converted once
+// here, so a failed batch is logged once rather than by every resolver that waited on it.
+func failed(ctx context.Context, n int, err error) []*dataloader.Result {
+	err = toError(ctx, err)
+
+	results := make([]*dataloader.Result, n)
+	for i := range results {
+		results[i] = &dataloader.Result{Error: err}
+	}
+
+	return results
+}
+
+func load[T any](ctx context.Context, loader *dataloader.Loader, key string) (*T, error) {
+	data, err := loader.Load(ctx, dataloader.StringKey(key))()
+	if err != nil || data == nil {
+		return nil, err
+	}
+
+	return data.(*T), nil
+}
+
+// loadMany loads the keys in one batch and leaves out the ones without a row.
+func loadMany[T any](ctx context.Context, loader *dataloader.Loader, keys []string) ([]*T, error) {
+	data, errs := loader.LoadMany(ctx, dataloader.NewKeysFromStrings(keys))()
+
+	rows := make([]*T, 0, len(data))
+
+	for i, d := range data {
+		if len(errs) > i && errs[i] != nil {
+			return nil, errs[i]
+		}
+
+		if d != nil {
+			rows = append(rows, d.(*T))
+		}
+	}
+
+	return rows, nil
+}
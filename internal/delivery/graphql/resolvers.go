@@ -0,0 +1,304 @@
+package graphql
+
+import (
+	"context"
+
+	"avito/internal/entity"
+	"github.com/graph-gophers/dataloader"
+	"github.com/graph-gophers/graphql-go"
+)
+
+// resolver is the Query type. Every object is read through the request loaders.
+type resolver struct{}
+
+func (*resolver) Team(ctx context.Context, args struct{ Name string }) (*teamResolver, error) {
+	team, err := load[entity.Team](ctx, loadersFrom(ctx).teams, args.Name)
+	if err != nil || team == nil {
+		return nil, err
+	}
+
+	return &teamResolver{team: *team}, nil
+}
+
+func (*resolver) Teams(ctx context.Context, args struct{ Names []string }) ([]*teamResolver, error) {
+	teams, err := loadMany[entity.Team](ctx, loadersFrom(ctx).teams, args.Names)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*teamResolver, 0, len(teams))
+	for _, team := range teams {
+		result = append(result, &teamResolver{team: *team})
+	}
+
+	return result, nil
+}
+
+func (*resolver) User(ctx context.Context, args struct{ ID graphql.ID }) (*userResolver, error) {
+	return loadUser(ctx, string(args.ID))
+}
+
+func (*resolver) Users(ctx context.Context, args struct{ IDs []graphql.ID }) ([]*userResolver, error) {
+	return loadUsers(ctx, ids(args.IDs))
+}
+
+func (*resolver) PullRequest(ctx context.Context, args struct{ ID graphql.ID }) (*pullRequestResolver, error) {
+	pr, err := load[entity.PullRequest](ctx, loadersFrom(ctx).pullRequests, string(args.ID))
+	if err != nil || pr == nil {
+		return nil, err
+	}
+
+	return &pullRequestResolver{pr: *pr}, nil
+}
+
+func (*resolver) PullRequests(ctx context.Context, args struct{ IDs []graphql.ID }) ([]*pullRequestResolver, error) {
+	prs, err := loadMany[entity.PullRequest](ctx, loadersFrom(ctx).pullRequests, ids(args.IDs))
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*pullRequestResolver, 0, len(prs))
+	for _, pr := range prs {
+		result = append(result, &pullRequestResolver{pr: *pr})
+	}
+
+	return result, nil
+}
+
+type teamResolver struct {
+	team entity.Team
+}
+
+func (r *teamResolver) Name() string {
+	return r.team.TeamName
+}
+
+// Members are built from the team row, which already holds everything a user has.
+func (r *teamResolver) Members() []*userResolver {
+	members := make([]*userResolver, 0, len(r.team.Members))
+
+	for _, member := range r.team.Members {
+		members = append(members, &userResolver{user: entity.User{
+			IsActive: member.IsActive,
+			TeamName: r.team.TeamName,
+			UserId:   member.UserId,
+			Username: member.Username,
+		}})
+	}
+
+	return members
+}
+
+// Stats averages the member averages like the HTTP team statistics do.
+func (r *teamResolver) Stats(ctx context.Context) (*teamStatResolver, error) {
+	userIDs := make([]string, 0, len(r.team.Members))
+	for _, member := range r.team.Members {
+		userIDs = append(userIDs, member.UserId)
+	}
+
+	stats, err := loadMany[entity.UserStat](ctx, loadersFrom(ctx).userStats, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &teamStatResolver{users: make([]*userStatResolver, 0, len(stats))}
+
+	var cntMerged, duration float64
+
+	for _, stat := range stats {
+		if stat.AvgDuration != nil {
+			duration += *stat.AvgDuration
+			cntMerged++
+		}
+
+		result.users = append(result.users, &userStatResolver{stat: *stat})
+	}
+
+	if cntMerged != 0 {
+		avg := duration / cntMerged
+		result.avgDuration = &avg
+	}
+
+	return result, nil
+}
+
+type userResolver struct {
+	user entity.User
+}
+
+func (r *userResolver) ID() graphql.ID {
+	return graphql.ID(r.user.UserId)
+}
+
+func (r *userResolver) Username() string {
+	return r.user.Username
+}
+
+func (r *userResolver) IsActive() bool {
+	return r.user.IsActive
+}
+
+func (r *userResolver) Team(ctx context.Context) (*teamResolver, error) {
+	team, err := load[entity.Team](ctx, loadersFrom(ctx).teams, r.user.TeamName)
+	if err != nil {
+		return nil, err
+	}
+
+	// A user always has a team, the row is only missing if the user was just removed.
+	if team == nil {
+		return &teamResolver{team: entity.Team{TeamName: r.user.TeamName}}, nil
+	}
+
+	return &teamResolver{team: *team}, nil
+}
+
+func (r *userResolver) Reviews(ctx context.Context, args struct{ Status *string }) ([]*pullRequestResolver, error) {
+	data, err := loadersFrom(ctx).reviews.Load(ctx, dataloader.StringKey(r.user.UserId))()
+	if err != nil {
+		return nil, err
+	}
+
+	prs, _ := data.([]entity.PullRequest)
+
+	result := make([]*pullRequestResolver, 0, len(prs))
+
+	for _, pr := range prs {
+		if args.Status == nil || string(pr.Status) == *args.Status {
+			result = append(result, &pullRequestResolver{pr: pr})
+		}
+	}
+
+	return result, nil
+}
+
+func (r *userResolver) Stats(ctx context.Context) (*userStatResolver, error) {
+	stat, err := load[entity.UserStat](ctx, loadersFrom(ctx).userStats, r.user.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	if stat == nil {
+		return &userStatResolver{stat: entity.UserStat{UserId: r.user.UserId, IsActive: r.user.IsActive}}, nil
+	}
+
+	return &userStatResolver{stat: *stat}, nil
+}
+
+type pullRequestResolver struct {
+	pr entity.PullRequest
+}
+
+func (r *pullRequestResolver) ID() graphql.ID {
+	return graphql.ID(r.pr.PullRequestId)
+}
+
+func (r *pullRequestResolver) Name() string {
+	return r.pr.PullRequestName
+}
+
+func (r *pullRequestResolver) Status() string {
+	return string(r.pr.Status)
+}
+
+func (r *pullRequestResolver) Author(ctx context.Context) (*userResolver, error) {
+	user, err := loadUser(ctx, r.pr.AuthorId)
+	if err != nil {
+		return nil, err
+	}
+
+	if user == nil {
+		return &userResolver{user: entity.User{UserId: r.pr.AuthorId}}, nil
+	}
+
+	return user, nil
+}
+
+func (r *pullRequestResolver) Reviewers(ctx context.Context) ([]*userResolver, error) {
+	return loadUsers(ctx, r.pr.AssignedReviewers)
+}
+
+func (r *pullRequestResolver) CreatedAt() graphql.Time {
+	if r.pr.CreatedAt == nil {
+		return graphql.Time{}
+	}
+
+	return graphql.Time{Time: *r.pr.CreatedAt}
+}
+
+func (r *pullRequestResolver) MergedAt() *graphql.Time {
+	if r.pr.MergedAt == nil {
+		return nil
+	}
+
+	return &graphql.Time{Time: *r.pr.MergedAt}
+}
+
+type userStatResolver struct {
+	stat entity.UserStat
+}
+
+func (r *userStatResolver) User(ctx context.Context) (*userResolver, error) {
+	user, err := loadUser(ctx, r.stat.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	if user == nil {
+		return &userResolver{user: entity.User{UserId: r.stat.UserId, IsActive: r.stat.IsActive}}, nil
+	}
+
+	return user, nil
+}
+
+func (r *userStatResolver) ReviewCount() int32 {
+	return int32(r.stat.CountPr)
+}
+
+func (r *userStatResolver) AvgDuration() *float64 {
+	return r.stat.AvgDuration
+}
+
+type teamStatResolver struct {
+	avgDuration *float64
+	users       []*userStatResolver
+}
+
+func (r *teamStatResolver) AvgDuration() *float64 {
+	return r.avgDuration
+}
+
+func (r *teamStatResolver) Users() []*userStatResolver {
+	return r.users
+}
+
+func loadUser(ctx context.Context, userID string) (*userResolver, error) {
+	user, err := load[entity.User](ctx, loadersFrom(ctx).users, userID)
+	if err != nil || user == nil {
+		return nil, err
+	}
+
+	return &userResolver{user: *user}, nil
+}
+
+func loadUsers(ctx context.Context, userIDs []string) ([]*userResolver, error) {
+	users, err := loadMany[entity.User](ctx, loadersFrom(ctx).users, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*userResolver, 0, len(users))
+	for _, user := range users {
+		result = append(result, &userResolver{user: *user})
+	}
+
+	return result, nil
+}
+
+func ids(values []graphql.ID) []string {
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		result = append(result, string(value))
+	}
+
+	return result
+}
@@ -0,0 +1,280 @@
+package grpc_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	grpcDelivery "avito/internal/delivery/grpc"
+	"avito/internal/delivery/grpc/middleware"
+	"avito/internal/gen"
+	"avito/internal/health"
+	"avito/internal/org"
+	"avito/internal/repo"
+	"avito/internal/repo/memory"
+	reviewerv1 "avito/proto/reviewer/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type database struct{}
+
+func (database) Ping(context.Context) error { return nil }
+
+func (database) MigrationVersion(context.Context) (int64, error) { return 1, nil }
+
+func newConn(t *testing.T, checker *health.Checker, interceptors ...grpc.UnaryServerInterceptor) *grpc.ClientConn {
+	t.Helper()
+
+	store := memory.InitStore()
+
+	swagger, err := gen.GetSwagger()
+	require.NoError(t, err)
+
+	server := grpcDelivery.InitServer(repo.Repos{
+		Team:        memory.InitTeamRepo(store),
+		User:        memory.InitUserRepo(store),
+		PullRequest: memory.InitPullRequestRepo(store),
+		Stat:        memory.InitStatRepo(store),
+		History:     memory.InitHistoryRepo(store),
+	}, swagger, grpc.ChainUnaryInterceptor(append([]grpc.UnaryServerInterceptor{
+		middleware.RequestID(), middleware.Logger(), middleware.Recovery(),
+	}, interceptors...)...))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	worker := grpcDelivery.InitHealth(server, checker)
+
+	done := make(chan struct{})
+
+	go func() {
+		worker(ctx)
+		close(done)
+	}()
+
+	ln := bufconn.Listen(1 << 20)
+
+	go func() {
+		_ = server.Serve(ln)
+	}()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return ln.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = conn.Close()
+		cancel()
+		<-done
+		server.Stop()
+	})
+
+	return conn
+}
+
+// errorInfo returns the gRPC code and the HTTP error code carried in ErrorInfo.
+func errorInfo(t *testing.T, err error) (codes.Code, string) {
+	t.Helper()
+
+	st, ok := status.FromError(err)
+	require.True(t, ok, err)
+
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			return st.Code(), info.GetReason()
+		}
+	}
+
+	return st.Code(), ""
+}
+
+func member(id string) *reviewerv1.TeamMember {
+	return &reviewerv1.TeamMember{UserId: id, Username: "name-" + id, IsActive: true}
+}
+
+func TestReviewerService(t *testing.T) {
+	ctx := context.Background()
+	c := reviewerv1.NewReviewerServiceClient(newConn(t, health.InitChecker(database{}, 1)))
+
+	backend := &reviewerv1.Team{TeamName: "backend", Members: []*reviewerv1.TeamMember{member("u1"), member("u2")}}
+
+	created, err := c.CreateTeam(ctx, &reviewerv1.CreateTeamRequest{Team: backend})
+	require.NoError(t, err)
+	assert.Equal(t, "backend", created.GetTeam().GetTeamName())
+	assert.Len(t, created.GetTeam().GetMembers(), 2)
+
+	_, err = c.CreateTeam(ctx, &reviewerv1.CreateTeamRequest{Team: backend})
+	code, reason := errorInfo(t, err)
+	assert.Equal(t, codes.AlreadyExists, code)
+	assert.Equal(t, string(gen.TEAMEXISTS), reason)
+
+	_, err = c.CreateTeam(ctx, &reviewerv1.CreateTeamRequest{Team: &reviewerv1.Team{
+		TeamName: "dup",
+		Members:  []*reviewerv1.TeamMember{member("d1"), member("d1")},
+	}})
+	code, reason = errorInfo(t, err)
+	assert.Equal(t, codes.InvalidArgument, code)
+	assert.Equal(t, string(gen.VALIDATIONERROR), reason)
+
+	var badRequest *errdetails.BadRequest
+
+	for _, detail := range status.Convert(err).Details() {
+		if d, ok := detail.(*errdetails.BadRequest); ok {
+			badRequest = d
+		}
+	}
+
+	require.NotNil(t, badRequest)
+	require.Len(t, badRequest.GetFieldViolations(), 1)
+	assert.Equal(t, "members[1].user_id", badRequest.GetFieldViolations()[0].GetField())
+
+	team, err := c.GetTeam(ctx, &reviewerv1.GetTeamRequest{TeamName: "backend"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u1", "u2"}, []string{team.GetTeam().GetMembers()[0].GetUserId(), team.GetTeam().GetMembers()[1].GetUserId()})
+
+	_, err = c.GetTeam(ctx, &reviewerv1.GetTeamRequest{TeamName: "unknown"})
+	code, _ = errorInfo(t, err)
+	assert.Equal(t, codes.NotFound, code)
+
+	pr, err := c.CreatePullRequest(ctx, &reviewerv1.CreatePullRequestRequest{
+		PullRequestId:   "pr-1",
+		PullRequestName: "Add search",
+		AuthorId:        "u1",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u2"}, pr.GetPr().GetAssignedReviewers())
+	assert.Equal(t, reviewerv1.PullRequestStatus_PULL_REQUEST_STATUS_OPEN, pr.GetPr().GetStatus())
+	assert.NotNil(t, pr.GetPr().GetCreatedAt())
+	assert.Nil(t, pr.GetPr().GetMergedAt())
+
+	_, err = c.CreatePullRequest(ctx, &reviewerv1.CreatePullRequestRequest{PullRequestId: "bad id", PullRequestName: "x", AuthorId: "u1"})
+	code, _ = errorInfo(t, err)
+	assert.Equal(t, codes.InvalidArgument, code)
+
+	_, err = c.ReassignReviewer(ctx, &reviewerv1.ReassignReviewerRequest{PullRequestId: "pr-1", OldUserId: "u2"})
+	code, reason = errorInfo(t, err)
+	assert.Equal(t, codes.FailedPrecondition, code)
+	assert.Equal(t, string(gen.NOCANDIDATE), reason)
+
+	reviews, err := c.GetReview(ctx, &reviewerv1.GetReviewRequest{UserId: "u2"})
+	require.NoError(t, err)
+	require.Len(t, reviews.GetPullRequests(), 1)
+	assert.Equal(t, "pr-1", reviews.GetPullRequests()[0].GetPullRequestId())
+
+	merged, err := c.MergePullRequest(ctx, &reviewerv1.MergePullRequestRequest{PullRequestId: "pr-1"})
+	require.NoError(t, err)
+	assert.Equal(t, reviewerv1.PullRequestStatus_PULL_REQUEST_STATUS_MERGED, merged.GetPr().GetStatus())
+	assert.NotNil(t, merged.GetPr().GetMergedAt())
+
+	user, err := c.SetIsActive(ctx, &reviewerv1.SetIsActiveRequest{UserId: "u2", IsActive: false})
+	require.NoError(t, err)
+	assert.Equal(t, "backend", user.GetUser().GetTeamName())
+	assert.False(t, user.GetUser().GetIsActive())
+
+	stat, err := c.GetUserStat(ctx, &reviewerv1.GetUserStatRequest{UserId: "u2"})
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), stat.GetStat().GetCountPr())
+	assert.NotNil(t, stat.GetStat().AvgDuration)
+
+	teamStat, err := c.GetTeamStat(ctx, &reviewerv1.GetTeamStatRequest{TeamName: "backend"})
+	require.NoError(t, err)
+	assert.Len(t, teamStat.GetUsersStat(), 2)
+	assert.NotNil(t, teamStat.AvgDuration)
+
+	_, err = c.GetUserStat(ctx, &reviewerv1.GetUserStatRequest{UserId: ""})
+	code, _ = errorInfo(t, err)
+	assert.Equal(t, codes.InvalidArgument, code)
+}
+
+func TestRequestID(t *testing.T) {
+	c := reviewerv1.NewReviewerServiceClient(newConn(t, health.InitChecker(database{}, 1)))
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), middleware.MetadataRequestID, "req-1")
+
+	var header metadata.MD
+
+	_, err := c.GetTeam(ctx, &reviewerv1.GetTeamRequest{TeamName: "unknown"}, grpc.Header(&header))
+	require.Error(t, err)
+	assert.Equal(t, []string{"req-1"}, header.Get(middleware.MetadataRequestID))
+}
+
+func TestOrganization(t *testing.T) {
+	conn := newConn(t, health.InitChecker(database{}, 1),
+		middleware.Organization(org.Keys{"key-a": "acme", "key-g": "globex"}, healthpb.Health_ServiceDesc.ServiceName))
+	c := reviewerv1.NewReviewerServiceClient(conn)
+
+	acme := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer key-a")
+	globex := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer key-g")
+
+	_, err := c.CreateTeam(acme, &reviewerv1.CreateTeamRequest{Team: &reviewerv1.Team{
+		TeamName: "backend",
+		Members:  []*reviewerv1.TeamMember{member("u1")},
+	}})
+	require.NoError(t, err)
+
+	_, err = c.GetTeam(acme, &reviewerv1.GetTeamRequest{TeamName: "backend"})
+	require.NoError(t, err)
+
+	_, err = c.GetTeam(globex, &reviewerv1.GetTeamRequest{TeamName: "backend"})
+	code, _ := errorInfo(t, err)
+	assert.Equal(t, codes.NotFound, code)
+
+	_, err = c.GetTeam(context.Background(), &reviewerv1.GetTeamRequest{TeamName: "backend"})
+	code, reason := errorInfo(t, err)
+	assert.Equal(t, codes.Unauthenticated, code)
+	assert.Equal(t, string(gen.UNAUTHORIZED), reason)
+
+	_, err = healthpb.NewHealthClient(conn).Check(context.Background(), &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+}
+
+func TestHealthAndReflection(t *testing.T) {
+	ctx := context.Background()
+	checker := health.InitChecker(database{}, 1)
+	conn := newConn(t, checker)
+
+	client := healthpb.NewHealthClient(conn)
+
+	res, err := client.Check(ctx, &healthpb.HealthCheckRequest{Service: reviewerv1.ReviewerService_ServiceDesc.ServiceName})
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, res.GetStatus())
+
+	checker.SetShuttingDown()
+
+	assert.Eventually(t, func() bool {
+		res, err := client.Check(ctx, &healthpb.HealthCheckRequest{})
+
+		return err == nil && res.GetStatus() == healthpb.HealthCheckResponse_NOT_SERVING
+	}, 5*time.Second, 50*time.Millisecond)
+
+	stream, err := reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+	require.NoError(t, err)
+
+	require.NoError(t, stream.Send(&reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{},
+	}))
+
+	reply, err := stream.Recv()
+	require.NoError(t, err)
+
+	var services []string
+	for _, service := range reply.GetListServicesResponse().GetService() {
+		services = append(services, service.GetName())
+	}
+
+	assert.Contains(t, services, reviewerv1.ReviewerService_ServiceDesc.ServiceName)
+	assert.Contains(t, services, healthpb.Health_ServiceDesc.ServiceName)
+}
@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"context"
+	"time"
+
+	"avito/internal/cerr"
+	"avito/internal/gen"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// ErrorDomain is the ErrorInfo domain of errors returned by the service.
+const ErrorDomain = "avito"
+
+var codesByError = map[gen.ErrorResponseErrorCode]codes.Code{
+	gen.TEAMEXISTS:         codes.AlreadyExists,
+	gen.USEREXISTS:         codes.AlreadyExists,
+	gen.PREXISTS:           codes.AlreadyExists,
+	gen.PRMERGED:           codes.FailedPrecondition,
+	gen.NOTASSIGNED:        codes.FailedPrecondition,
+	gen.NOCANDIDATE:        codes.FailedPrecondition,
+	gen.NOTFOUND:           codes.NotFound,
+	gen.VALIDATIONERROR:    codes.InvalidArgument,
+	gen.SERVICEUNAVAILABLE: codes.Unavailable,
+	gen.RATELIMITED:        codes.ResourceExhausted,
+	gen.UNAUTHORIZED:       codes.Unauthenticated,
+	gen.INTERNALERROR:      codes.Internal,
+}
+
+// ToStatus turns a service error into a gRPC status. It goes through cerr.HandleErrsCtx
+// so both APIs agree on the error code and message and log server errors the same way.
+// The HTTP error code travels as the ErrorInfo reason.
+func ToStatus(ctx context.Context, err error) error {
+	_, response := cerr.HandleErrsCtx(ctx, err)
+
+	code, ok := codesByError[response.Error.Code]
+	if !ok {
+		code = codes.Internal
+	}
+
+	info := &errdetails.ErrorInfo{
+		Reason: string(response.Error.Code),
+		Domain: ErrorDomain,
+	}
+
+	if response.Error.CorrelationId != nil {
+		info.Metadata = map[string]string{"correlation_id": *response.Error.CorrelationId}
+	}
+
+	details := []protoadapt.MessageV1{info}
+
+	if response.Error.Details != nil {
+		badRequest := &errdetails.BadRequest{}
+
+		for _, field := range *response.Error.Details {
+			badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       field.Field,
+				Description: field.Reason,
+			})
+		}
+
+		details = append(details, badRequest)
+	}
+
+	if code == codes.Unavailable {
+		details = append(details, &errdetails.RetryInfo{RetryDelay: durationpb.New(cerr.RetryAfter * time.Second)})
+	}
+
+	st, detailsErr := status.New(code, response.Error.Message).WithDetails(details...)
+	if detailsErr != nil {
+		return status.Error(code, response.Error.Message)
+	}
+
+	return st.Err()
+}
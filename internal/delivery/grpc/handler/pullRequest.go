@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"context"
+
+	"avito/internal/entity"
+	"avito/internal/gen"
+	"avito/internal/service"
+	reviewerv1 "avito/proto/reviewer/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type PullRequest struct {
+	service  service.PullRequest
+	validate Validator
+}
+
+func InitPullRequestHandler(service service.PullRequest, validate Validator) *PullRequest {
+	return &PullRequest{
+		service:  service,
+		validate: validate,
+	}
+}
+
+func (r *PullRequest) CreatePullRequest(ctx context.Context, request *reviewerv1.CreatePullRequestRequest) (*reviewerv1.CreatePullRequestResponse, error) {
+	body := gen.PostPullRequestCreateJSONBody{
+		AuthorId:        request.GetAuthorId(),
+		PullRequestId:   request.GetPullRequestId(),
+		PullRequestName: request.GetPullRequestName(),
+	}
+
+	if err := r.validate("PostPullRequestCreate", nil, body); err != nil {
+		return nil, ToStatus(ctx, err)
+	}
+
+	pullRequest, err := r.service.Create(ctx, &entity.PullRequestCreate{
+		AuthorId:        body.AuthorId,
+		PullRequestId:   body.PullRequestId,
+		PullRequestName: body.PullRequestName,
+	})
+	if err != nil {
+		return nil, ToStatus(ctx, err)
+	}
+
+	return &reviewerv1.CreatePullRequestResponse{Pr: toPullRequest(pullRequest)}, nil
+}
+
+func (r *PullRequest) MergePullRequest(ctx context.Context, request *reviewerv1.MergePullRequestRequest) (*reviewerv1.MergePullRequestResponse, error) {
+	body := gen.PostPullRequestMergeJSONBody{PullRequestId: request.GetPullRequestId()}
+
+	if err := r.validate("PostPullRequestMerge", nil, body); err != nil {
+		return nil, ToStatus(ctx, err)
+	}
+
+	pullRequest, err := r.service.Merge(ctx, body.PullRequestId)
+	if err != nil {
+		return nil, ToStatus(ctx, err)
+	}
+
+	return &reviewerv1.MergePullRequestResponse{Pr: toPullRequest(pullRequest)}, nil
+}
+
+func (r *PullRequest) ReassignReviewer(ctx context.Context, request *reviewerv1.ReassignReviewerRequest) (*reviewerv1.ReassignReviewerResponse, error) {
+	body := gen.PostPullRequestReassignJSONBody{
+		OldUserId:     request.GetOldUserId(),
+		PullRequestId: request.GetPullRequestId(),
+	}
+
+	if err := r.validate("PostPullRequestReassign", nil, body); err != nil {
+		return nil, ToStatus(ctx, err)
+	}
+
+	pullRequest, newReviewer, err := r.service.Reassign(ctx, body.PullRequestId, body.OldUserId)
+	if err != nil {
+		return nil, ToStatus(ctx, err)
+	}
+
+	return &reviewerv1.ReassignReviewerResponse{
+		Pr:         toPullRequest(pullRequest),
+		ReplacedBy: newReviewer,
+	}, nil
+}
+
+func toPullRequest(pullRequest *entity.PullRequest) *reviewerv1.PullRequest {
+	res := &reviewerv1.PullRequest{
+		PullRequestId:     pullRequest.PullRequestId,
+		PullRequestName:   pullRequest.PullRequestName,
+		AuthorId:          pullRequest.AuthorId,
+		Status:            toStatusEnum(pullRequest.Status),
+		AssignedReviewers: pullRequest.AssignedReviewers,
+	}
+
+	if pullRequest.CreatedAt != nil {
+		res.CreatedAt = timestamppb.New(*pullRequest.CreatedAt)
+	}
+
+	if pullRequest.MergedAt != nil {
+		res.MergedAt = timestamppb.New(*pullRequest.MergedAt)
+	}
+
+	return res
+}
+
+func toStatusEnum(status entity.PullRequestStatus) reviewerv1.PullRequestStatus {
+	switch status {
+	case entity.PRStatusOPEN:
+		return reviewerv1.PullRequestStatus_PULL_REQUEST_STATUS_OPEN
+	case entity.PRStatusMERGED:
+		return reviewerv1.PullRequestStatus_PULL_REQUEST_STATUS_MERGED
+	default:
+		return reviewerv1.PullRequestStatus_PULL_REQUEST_STATUS_UNSPECIFIED
+	}
+}
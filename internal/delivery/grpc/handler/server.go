@@ -0,0 +1,39 @@
+package handler
+
+import (
+	reviewerv1 "avito/proto/reviewer/v1"
+)
+
+var _ reviewerv1.ReviewerServiceServer = (*Server)(nil)
+
+// Validator checks a request against the OpenAPI operation it mirrors and returns a
+// VALIDATION error listing the invalid fields.
+type Validator func(operationID string, params map[string]string, body any) error
+
+// unimplemented sits one level deeper than the handlers so their methods win, and RPCs
+// without a handler still answer Unimplemented.
+type unimplemented struct {
+	reviewerv1.UnimplementedReviewerServiceServer
+}
+
+type Server struct {
+	unimplemented
+	*Team
+	*PullRequest
+	*User
+	*Stat
+}
+
+func NewServer(
+	userHandler *User,
+	prHandler *PullRequest,
+	teamHandler *Team,
+	statHandler *Stat,
+) *Server {
+	return &Server{
+		User:        userHandler,
+		PullRequest: prHandler,
+		Team:        teamHandler,
+		Stat:        statHandler,
+	}
+}
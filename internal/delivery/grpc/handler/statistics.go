@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"context"
+
+	"avito/internal/entity"
+	"avito/internal/service"
+	reviewerv1 "avito/proto/reviewer/v1"
+)
+
+type Stat struct {
+	service  service.Stat
+	validate Validator
+}
+
+func InitStatHandler(service service.Stat, validate Validator) *Stat {
+	return &Stat{
+		service:  service,
+		validate: validate,
+	}
+}
+
+func (r *Stat) GetUserStat(ctx context.Context, request *reviewerv1.GetUserStatRequest) (*reviewerv1.GetUserStatResponse, error) {
+	if err := r.validate("GetStatisticsUser", map[string]string{"user_id": request.GetUserId()}, nil); err != nil {
+		return nil, ToStatus(ctx, err)
+	}
+
+	stat, err := r.service.User(ctx, request.GetUserId())
+	if err != nil {
+		return nil, ToStatus(ctx, err)
+	}
+
+	return &reviewerv1.GetUserStatResponse{Stat: toUserStat(stat)}, nil
+}
+
+func (r *Stat) GetTeamStat(ctx context.Context, request *reviewerv1.GetTeamStatRequest) (*reviewerv1.GetTeamStatResponse, error) {
+	if err := r.validate("GetStatisticsTeam", map[string]string{"team_name": request.GetTeamName()}, nil); err != nil {
+		return nil, ToStatus(ctx, err)
+	}
+
+	stat, err := r.service.Team(ctx, request.GetTeamName())
+	if err != nil {
+		return nil, ToStatus(ctx, err)
+	}
+
+	res := &reviewerv1.GetTeamStatResponse{
+		TeamName:  stat.TeamName,
+		UsersStat: make([]*reviewerv1.UserStat, len(stat.UsersStat)),
+	}
+	for i := range stat.UsersStat {
+		res.UsersStat[i] = toUserStat(&stat.UsersStat[i])
+	}
+
+	// The HTTP API reports a team without merged reviews as -1.
+	if stat.AvgDuration >= 0 {
+		res.AvgDuration = &stat.AvgDuration
+	}
+
+	return res, nil
+}
+
+func toUserStat(stat *entity.UserStat) *reviewerv1.UserStat {
+	return &reviewerv1.UserStat{
+		UserId:      stat.UserId,
+		IsActive:    stat.IsActive,
+		CountPr:     int32(stat.CountPr),
+		AvgDuration: stat.AvgDuration,
+	}
+}
@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"context"
+
+	"avito/internal/entity"
+	"avito/internal/gen"
+	"avito/internal/service"
+	reviewerv1 "avito/proto/reviewer/v1"
+)
+
+type Team struct {
+	service  service.Team
+	validate Validator
+}
+
+func InitTeamHandler(service service.Team, validate Validator) *Team {
+	return &Team{
+		service:  service,
+		validate: validate,
+	}
+}
+
+func (r *Team) CreateTeam(ctx context.Context, request *reviewerv1.CreateTeamRequest) (*reviewerv1.CreateTeamResponse, error) {
+	body := gen.Team{
+		TeamName: request.GetTeam().GetTeamName(),
+		Members:  make([]gen.TeamMember, len(request.GetTeam().GetMembers())),
+	}
+	for i, member := range request.GetTeam().GetMembers() {
+		body.Members[i] = gen.TeamMember{
+			IsActive: member.GetIsActive(),
+			UserId:   member.GetUserId(),
+			Username: member.GetUsername(),
+		}
+	}
+
+	if err := r.validate("PostTeamAdd", nil, body); err != nil {
+		return nil, ToStatus(ctx, err)
+	}
+
+	createTeam := entity.Team{
+		TeamName: body.TeamName,
+		Members:  make([]entity.TeamMember, len(body.Members)),
+	}
+	for i, member := range body.Members {
+		createTeam.Members[i] = entity.TeamMember{
+			IsActive: member.IsActive,
+			UserId:   member.UserId,
+			Username: member.Username,
+		}
+	}
+
+	if err := r.service.Create(ctx, &createTeam); err != nil {
+		return nil, ToStatus(ctx, err)
+	}
+
+	return &reviewerv1.CreateTeamResponse{Team: toTeam(&createTeam)}, nil
+}
+
+func (r *Team) GetTeam(ctx context.Context, request *reviewerv1.GetTeamRequest) (*reviewerv1.GetTeamResponse, error) {
+	if err := r.validate("GetTeamGet", map[string]string{"team_name": request.GetTeamName()}, nil); err != nil {
+		return nil, ToStatus(ctx, err)
+	}
+
+	team, err := r.service.Get(ctx, request.GetTeamName())
+	if err != nil {
+		return nil, ToStatus(ctx, err)
+	}
+
+	return &reviewerv1.GetTeamResponse{Team: toTeam(team)}, nil
+}
+
+func toTeam(team *entity.Team) *reviewerv1.Team {
+	res := &reviewerv1.Team{
+		TeamName: team.TeamName,
+		Members:  make([]*reviewerv1.TeamMember, len(team.Members)),
+	}
+	for i, member := range team.Members {
+		res.Members[i] = &reviewerv1.TeamMember{
+			UserId:   member.UserId,
+			Username: member.Username,
+			IsActive: member.IsActive,
+		}
+	}
+
+	return res
+}
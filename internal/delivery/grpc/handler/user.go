@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"context"
+
+	"avito/internal/gen"
+	"avito/internal/service"
+	reviewerv1 "avito/proto/reviewer/v1"
+)
+
+type User struct {
+	service  service.User
+	validate Validator
+}
+
+func InitUserHandler(service service.User, validate Validator) *User {
+	return &User{
+		service:  service,
+		validate: validate,
+	}
+}
+
+func (r *User) SetIsActive(ctx context.Context, request *reviewerv1.SetIsActiveRequest) (*reviewerv1.SetIsActiveResponse, error) {
+	body := gen.PostUsersSetIsActiveJSONBody{
+		IsActive: request.GetIsActive(),
+		UserId:   request.GetUserId(),
+	}
+
+	if err := r.validate("PostUsersSetIsActive", nil, body); err != nil {
+		return nil, ToStatus(ctx, err)
+	}
+
+	user, err := r.service.SetIsActive(ctx, body.UserId, body.IsActive)
+	if err != nil {
+		return nil, ToStatus(ctx, err)
+	}
+
+	return &reviewerv1.SetIsActiveResponse{
+		User: &reviewerv1.User{
+			UserId:   user.UserId,
+			Username: user.Username,
+			TeamName: user.TeamName,
+			IsActive: user.IsActive,
+		},
+	}, nil
+}
+
+func (r *User) GetReview(ctx context.Context, request *reviewerv1.GetReviewRequest) (*reviewerv1.GetReviewResponse, error) {
+	if err := r.validate("GetUsersGetReview", map[string]string{"user_id": request.GetUserId()}, nil); err != nil {
+		return nil, ToStatus(ctx, err)
+	}
+
+	pullRequests, err := r.service.GetReview(ctx, request.GetUserId())
+	if err != nil {
+		return nil, ToStatus(ctx, err)
+	}
+
+	res := &reviewerv1.GetReviewResponse{
+		UserId:       request.GetUserId(),
+		PullRequests: make([]*reviewerv1.PullRequestShort, len(pullRequests)),
+	}
+	for i, pr := range pullRequests {
+		res.PullRequests[i] = &reviewerv1.PullRequestShort{
+			PullRequestId:   pr.PullRequestId,
+			PullRequestName: pr.PullRequestName,
+			AuthorId:        pr.AuthorId,
+			Status:          toStatusEnum(pr.Status),
+		}
+	}
+
+	return res, nil
+}
@@ -0,0 +1,140 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"avito/internal/cerr"
+	"avito/internal/delivery/grpc/handler"
+	"avito/internal/log"
+	"avito/internal/org"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// MetadataRequestID is the X-Request-ID header of the HTTP API as gRPC metadata.
+const MetadataRequestID = "x-request-id"
+
+// RequestID takes the request ID from the incoming metadata or makes one, and sends it
+// back in the response header.
+func RequestID() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, next grpc.UnaryHandler) (any, error) {
+		var requestID string
+
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if values := md.Get(MetadataRequestID); len(values) > 0 {
+				requestID = values[0]
+			}
+		}
+
+		if requestID == "" || len(requestID) > 128 {
+			requestID = log.NewRequestID()
+		}
+
+		_ = grpc.SetHeader(ctx, metadata.Pairs(MetadataRequestID, requestID))
+
+		return next(log.WithRequestID(ctx, requestID), req)
+	}
+}
+
+// Logger puts a request-scoped logger into the context and writes one access log entry
+// per call. Calls of the quiet services, like health checks, are logged at debug level.
+func Logger(quiet ...string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, next grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+
+		ctx = log.WithLogger(ctx, log.Log.With("request_id", log.RequestID(ctx)))
+
+		res, err := next(ctx, req)
+
+		code := status.Code(err)
+
+		entry := log.Ctx(ctx).
+			With("method", info.FullMethod).
+			With("code", code.String()).
+			With("latency_ms", float64(time.Since(start).Microseconds())/1000)
+
+		switch {
+		case isQuiet(info.FullMethod, quiet):
+			entry.Debug("call")
+		case code == codes.Internal || code == codes.Unavailable || code == codes.Unknown:
+			entry.Warn("call")
+		default:
+			entry.Info("call")
+		}
+
+		return res, err
+	}
+}
+
+func isQuiet(method string, quiet []string) bool {
+	for _, service := range quiet {
+		if strings.HasPrefix(method, "/"+service+"/") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Organization puts the organization of the API key sent as "authorization: Bearer <key>"
+// metadata into the context, like the HTTP middleware. Without keys every call acts in
+// the default organization. Calls of the exempt services, like health checks, need no key.
+func Organization(keys org.Keys, exempt ...string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, next grpc.UnaryHandler) (any, error) {
+		if len(keys) == 0 || isQuiet(info.FullMethod, exempt) {
+			return next(ctx, req)
+		}
+
+		var token string
+
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if values := md.Get("authorization"); len(values) > 0 {
+				token, _ = strings.CutPrefix(values[0], "Bearer ")
+			}
+		}
+
+		orgID, ok := keys[token]
+		if !ok {
+			return nil, handler.ToStatus(ctx, cerr.CustomError{
+				Err:     fmt.Errorf("missing or unknown api key"),
+				ErrType: cerr.UNAUTHORIZED,
+			})
+		}
+
+		ctx = org.With(ctx, orgID)
+
+		log.AddField(ctx, "org_id", orgID)
+
+		return next(ctx, req)
+	}
+}
+
+func Timeout(timeout time.Duration) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, next grpc.UnaryHandler) (any, error) {
+		if timeout <= 0 {
+			return next(ctx, req)
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+
+		return next(ctx, req)
+	}
+}
+
+func Recovery() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, next grpc.UnaryHandler) (res any, err error) {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				err = handler.ToStatus(ctx, fmt.Errorf("panic: %v", recovered))
+			}
+		}()
+
+		return next(ctx, req)
+	}
+}
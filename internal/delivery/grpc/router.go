@@ -0,0 +1,103 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	"avito/internal/cerr"
+	"avito/internal/delivery/grpc/handler"
+	"avito/internal/delivery/http/middleware"
+	"avito/internal/health"
+	"avito/internal/repo"
+	PRServ "avito/internal/service/pullRequest"
+	statServ "avito/internal/service/stat"
+	teamServ "avito/internal/service/team"
+	userServ "avito/internal/service/user"
+	reviewerv1 "avito/proto/reviewer/v1"
+	"github.com/getkin/kin-openapi/openapi3"
+	"google.golang.org/grpc"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+)
+
+const healthInterval = 2 * time.Second
+
+// InitServer registers the reviewer service on a new gRPC server. Requests are checked
+// against the OpenAPI schema of the HTTP operation they mirror, so both APIs accept the
+// same input.
+func InitServer(repos repo.Repos, swagger *openapi3.T, opts ...grpc.ServerOption) *grpc.Server {
+	validate := func(operationID string, params map[string]string, body any) error {
+		fields := middleware.ValidateOperation(swagger, operationID, params, body)
+		if len(fields) == 0 {
+			return nil
+		}
+
+		return cerr.CustomError{
+			Err:     cerr.FieldsError{Fields: fields},
+			ErrType: cerr.VALIDATION,
+		}
+	}
+
+	servUser := userServ.InitUserServ(repos.User)
+	handlerUser := handler.InitUserHandler(servUser, validate)
+
+	servTeam := teamServ.InitTeamServ(repos.Team)
+	handlerTeam := handler.InitTeamHandler(servTeam, validate)
+
+	servPR := PRServ.InitPullRequestServ(repos.PullRequest)
+	handlerPR := handler.InitPullRequestHandler(servPR, validate)
+
+	servStat := statServ.InitStatServ(repos.Stat)
+	handlerStat := handler.InitStatHandler(servStat, validate)
+
+	server := grpc.NewServer(opts...)
+
+	reviewerv1.RegisterReviewerServiceServer(server, handler.NewServer(handlerUser, handlerPR, handlerTeam, handlerStat))
+	reflection.Register(server)
+
+	return server
+}
+
+// InitHealth registers the standard health service and returns a worker that keeps it
+// in sync with the readiness checks, so it turns NOT_SERVING during shutdown together
+// with /readyz.
+func InitHealth(server *grpc.Server, checker *health.Checker) func(ctx context.Context) {
+	healthServer := grpchealth.NewServer()
+	healthpb.RegisterHealthServer(server, healthServer)
+
+	services := []string{"", reviewerv1.ReviewerService_ServiceDesc.ServiceName}
+
+	update := func(ctx context.Context) {
+		ctx, cancel := context.WithTimeout(ctx, healthInterval)
+		defer cancel()
+
+		status := healthpb.HealthCheckResponse_SERVING
+		if checker.Readiness(ctx).Status != health.StatusOK {
+			status = healthpb.HealthCheckResponse_NOT_SERVING
+		}
+
+		for _, service := range services {
+			healthServer.SetServingStatus(service, status)
+		}
+	}
+
+	update(context.Background())
+
+	return func(ctx context.Context) {
+		ticker := time.NewTicker(healthInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				healthServer.Shutdown()
+
+				return
+			case <-ticker.C:
+				health.Beat(ctx)
+				update(ctx)
+			}
+		}
+	}
+}
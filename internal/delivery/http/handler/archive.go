@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"avito/internal/cerr"
+	"avito/internal/gen"
+	"avito/internal/service"
+)
+
+type Archive struct {
+	service service.Archive
+}
+
+func InitArchiveHandler(service service.Archive) *Archive {
+	return &Archive{
+		service: service,
+	}
+}
+
+func (r *Archive) PostAdminPullRequestsRestore(ctx context.Context, request gen.PostAdminPullRequestsRestoreRequestObject) (gen.PostAdminPullRequestsRestoreResponseObject, error) {
+	pullRequest, err := r.service.Restore(ctx, request.Body.PullRequestId)
+	if err != nil {
+		code, message := cerr.HandleErrsCtx(ctx, err)
+		if code == http.StatusNotFound {
+			return gen.PostAdminPullRequestsRestore404JSONResponse(message), nil
+		}
+
+		if code == http.StatusConflict {
+			return gen.PostAdminPullRequestsRestore409JSONResponse(message), nil
+		}
+
+		if code == http.StatusServiceUnavailable {
+			return gen.PostAdminPullRequestsRestore503JSONResponse{
+				Body:    message,
+				Headers: gen.PostAdminPullRequestsRestore503ResponseHeaders{RetryAfter: cerr.RetryAfter},
+			}, nil
+		}
+
+		return gen.PostAdminPullRequestsRestore500JSONResponse(message), nil
+	}
+
+	return gen.PostAdminPullRequestsRestore200JSONResponse{
+		Pr: &gen.PullRequest{
+			AssignedReviewers: pullRequest.AssignedReviewers,
+			AuthorId:          pullRequest.AuthorId,
+			CreatedAt:         pullRequest.CreatedAt,
+			MergedAt:          pullRequest.MergedAt,
+			PullRequestId:     pullRequest.PullRequestId,
+			PullRequestName:   pullRequest.PullRequestName,
+			Status:            gen.PullRequestStatus(pullRequest.Status),
+		},
+	}, nil
+}
@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"avito/internal/cerr"
+	"avito/internal/entity"
+	"avito/internal/gen"
+	"avito/internal/service"
+)
+
+type Erasure struct {
+	service service.Erasure
+}
+
+func InitErasureHandler(service service.Erasure) *Erasure {
+	return &Erasure{
+		service: service,
+	}
+}
+
+func (r *Erasure) PostAdminUsersErase(ctx context.Context, request gen.PostAdminUsersEraseRequestObject) (gen.PostAdminUsersEraseResponseObject, error) {
+	erasure, reassignments, err := r.service.Erase(ctx, request.Body.UserId)
+	if err != nil {
+		code, message := cerr.HandleErrsCtx(ctx, err)
+		if code == http.StatusNotFound {
+			return gen.PostAdminUsersErase404JSONResponse(message), nil
+		}
+
+		if code == http.StatusServiceUnavailable {
+			return gen.PostAdminUsersErase503JSONResponse{
+				Body:    message,
+				Headers: gen.PostAdminUsersErase503ResponseHeaders{RetryAfter: cerr.RetryAfter},
+			}, nil
+		}
+
+		return gen.PostAdminUsersErase500JSONResponse(message), nil
+	}
+
+	response := gen.PostAdminUsersErase200JSONResponse{
+		Erasure:       toGenUserErasure(erasure),
+		Reassignments: make([]gen.Reassignment, len(reassignments)),
+	}
+
+	for i, reassignment := range reassignments {
+		response.Reassignments[i] = gen.Reassignment{
+			PullRequestId: reassignment.PullRequestId,
+			OldReviewerId: reassignment.OldReviewerId,
+			NewReviewerId: reassignment.NewReviewerId,
+		}
+	}
+
+	return response, nil
+}
+
+func (r *Erasure) GetAdminUsersErasures(ctx context.Context, _ gen.GetAdminUsersErasuresRequestObject) (gen.GetAdminUsersErasuresResponseObject, error) {
+	erasures, err := r.service.List(ctx)
+	if err != nil {
+		code, message := cerr.HandleErrsCtx(ctx, err)
+		if code == http.StatusServiceUnavailable {
+			return gen.GetAdminUsersErasures503JSONResponse{
+				Body:    message,
+				Headers: gen.GetAdminUsersErasures503ResponseHeaders{RetryAfter: cerr.RetryAfter},
+			}, nil
+		}
+
+		return gen.GetAdminUsersErasures500JSONResponse(message), nil
+	}
+
+	response := gen.GetAdminUsersErasures200JSONResponse{Erasures: make([]gen.UserErasure, len(erasures))}
+
+	for i, erasure := range erasures {
+		response.Erasures[i] = toGenUserErasure(&erasure)
+	}
+
+	return response, nil
+}
+
+func toGenUserErasure(erasure *entity.UserErasure) gen.UserErasure {
+	return gen.UserErasure{
+		Pseudonym:         erasure.Pseudonym,
+		TeamName:          erasure.TeamName,
+		ErasedAt:          erasure.ErasedAt,
+		ReassignedReviews: erasure.ReassignedReviews,
+	}
+}
@@ -0,0 +1,40 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"avito/internal/health"
+	"github.com/gin-gonic/gin"
+)
+
+const readinessTimeout = 2 * time.Second
+
+type Health struct {
+	checker *health.Checker
+}
+
+func InitHealthHandler(checker *health.Checker) *Health {
+	return &Health{
+		checker: checker,
+	}
+}
+
+func (h *Health) GetHealthz(c *gin.Context) {
+	c.JSON(http.StatusOK, h.checker.Liveness())
+}
+
+func (h *Health) GetReadyz(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), readinessTimeout)
+	defer cancel()
+
+	report := h.checker.Readiness(ctx)
+	if report.Status != health.StatusOK {
+		c.JSON(http.StatusServiceUnavailable, report)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"avito/internal/cerr"
+	"avito/internal/entity"
+	"avito/internal/gen"
+	"avito/internal/service"
+)
+
+type Notification struct {
+	service service.Notification
+}
+
+func InitNotificationHandler(service service.Notification) *Notification {
+	return &Notification{
+		service: service,
+	}
+}
+
+func (r *Notification) GetUsersGetNotifications(ctx context.Context, request gen.GetUsersGetNotificationsRequestObject) (gen.GetUsersGetNotificationsResponseObject, error) {
+	settings, err := r.service.Get(ctx, request.Params.UserId)
+	if err != nil {
+		code, message := cerr.HandleErrsCtx(ctx, err)
+		if code == http.StatusNotFound {
+			return gen.GetUsersGetNotifications404JSONResponse(message), nil
+		}
+
+		if code == http.StatusServiceUnavailable {
+			return gen.GetUsersGetNotifications503JSONResponse{
+				Body:    message,
+				Headers: gen.GetUsersGetNotifications503ResponseHeaders{RetryAfter: cerr.RetryAfter},
+			}, nil
+		}
+
+		return gen.GetUsersGetNotifications500JSONResponse(message), nil
+	}
+
+	return gen.GetUsersGetNotifications200JSONResponse(toGenNotificationSettings(settings)), nil
+}
+
+func (r *Notification) PostUsersSetNotifications(ctx context.Context, request gen.PostUsersSetNotificationsRequestObject) (gen.PostUsersSetNotificationsResponseObject, error) {
+	settings, err := r.service.Set(ctx, &entity.NotificationSettings{
+		UserId:           request.Body.UserId,
+		Email:            request.Body.Email,
+		EmailAssignments: request.Body.EmailAssignments,
+		EmailDigest:      request.Body.EmailDigest,
+	})
+	if err != nil {
+		code, message := cerr.HandleErrsCtx(ctx, err)
+		if code == http.StatusNotFound {
+			return gen.PostUsersSetNotifications404JSONResponse(message), nil
+		}
+
+		if code == http.StatusServiceUnavailable {
+			return gen.PostUsersSetNotifications503JSONResponse{
+				Body:    message,
+				Headers: gen.PostUsersSetNotifications503ResponseHeaders{RetryAfter: cerr.RetryAfter},
+			}, nil
+		}
+
+		return gen.PostUsersSetNotifications500JSONResponse(message), nil
+	}
+
+	return gen.PostUsersSetNotifications200JSONResponse(toGenNotificationSettings(settings)), nil
+}
+
+func toGenNotificationSettings(settings *entity.NotificationSettings) gen.NotificationSettings {
+	return gen.NotificationSettings{
+		UserId:           settings.UserId,
+		Email:            settings.Email,
+		EmailAssignments: settings.EmailAssignments,
+		EmailDigest:      settings.EmailDigest,
+	}
+}
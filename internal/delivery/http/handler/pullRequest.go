@@ -29,7 +29,7 @@ func (r *PullRequest) PostPullRequestCreate(ctx context.Context, request gen.Pos
 
 	pullRequest, err := r.service.Create(ctx, &PullRequestCreate)
 	if err != nil {
-		code, message := cerr.HandleErrs(err)
+		code, message := cerr.HandleErrsCtx(ctx, err)
 
 		if code == http.StatusConflict {
 			return gen.PostPullRequestCreate409JSONResponse(message), nil
@@ -39,7 +39,14 @@ func (r *PullRequest) PostPullRequestCreate(ctx context.Context, request gen.Pos
 			return gen.PostPullRequestCreate404JSONResponse(message), nil
 		}
 
-		return nil, cerr.ErrServerTime
+		if code == http.StatusServiceUnavailable {
+			return gen.PostPullRequestCreate503JSONResponse{
+				Body:    message,
+				Headers: gen.PostPullRequestCreate503ResponseHeaders{RetryAfter: cerr.RetryAfter},
+			}, nil
+		}
+
+		return gen.PostPullRequestCreate500JSONResponse(message), nil
 	}
 
 	return gen.PostPullRequestCreate201JSONResponse{
@@ -58,12 +65,19 @@ func (r *PullRequest) PostPullRequestCreate(ctx context.Context, request gen.Pos
 func (r *PullRequest) PostPullRequestMerge(ctx context.Context, request gen.PostPullRequestMergeRequestObject) (gen.PostPullRequestMergeResponseObject, error) {
 	pullRequest, err := r.service.Merge(ctx, request.Body.PullRequestId)
 	if err != nil {
-		code, message := cerr.HandleErrs(err)
+		code, message := cerr.HandleErrsCtx(ctx, err)
 		if code == http.StatusNotFound {
 			return gen.PostPullRequestMerge404JSONResponse(message), nil
 		}
 
-		return nil, cerr.ErrServerTime
+		if code == http.StatusServiceUnavailable {
+			return gen.PostPullRequestMerge503JSONResponse{
+				Body:    message,
+				Headers: gen.PostPullRequestMerge503ResponseHeaders{RetryAfter: cerr.RetryAfter},
+			}, nil
+		}
+
+		return gen.PostPullRequestMerge500JSONResponse(message), nil
 	}
 
 	return gen.PostPullRequestMerge200JSONResponse{
@@ -82,7 +96,7 @@ func (r *PullRequest) PostPullRequestMerge(ctx context.Context, request gen.Post
 func (r *PullRequest) PostPullRequestReassign(ctx context.Context, request gen.PostPullRequestReassignRequestObject) (gen.PostPullRequestReassignResponseObject, error) {
 	pullRequest, newReviewer, err := r.service.Reassign(ctx, request.Body.PullRequestId, request.Body.OldUserId)
 	if err != nil {
-		code, message := cerr.HandleErrs(err)
+		code, message := cerr.HandleErrsCtx(ctx, err)
 		if code == http.StatusNotFound {
 			return gen.PostPullRequestReassign404JSONResponse(message), nil
 		}
@@ -91,7 +105,14 @@ func (r *PullRequest) PostPullRequestReassign(ctx context.Context, request gen.P
 			return gen.PostPullRequestReassign409JSONResponse(message), nil
 		}
 
-		return nil, cerr.ErrServerTime
+		if code == http.StatusServiceUnavailable {
+			return gen.PostPullRequestReassign503JSONResponse{
+				Body:    message,
+				Headers: gen.PostPullRequestReassign503ResponseHeaders{RetryAfter: cerr.RetryAfter},
+			}, nil
+		}
+
+		return gen.PostPullRequestReassign500JSONResponse(message), nil
 	}
 
 	return gen.PostPullRequestReassign200JSONResponse{
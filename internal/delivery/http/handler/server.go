@@ -11,6 +11,10 @@ type Server struct {
 	*PullRequest
 	*User
 	*Stat
+	*Notification
+	*SLA
+	*Archive
+	*Erasure
 }
 
 func NewServer(
@@ -18,11 +22,19 @@ func NewServer(
 	prHandler *PullRequest,
 	teamHandler *Team,
 	statHandler *Stat,
+	notificationHandler *Notification,
+	slaHandler *SLA,
+	archiveHandler *Archive,
+	erasureHandler *Erasure,
 ) *Server {
 	return &Server{
-		User:        userHandler,
-		PullRequest: prHandler,
-		Team:        teamHandler,
-		Stat:        statHandler,
+		User:         userHandler,
+		PullRequest:  prHandler,
+		Team:         teamHandler,
+		Stat:         statHandler,
+		Notification: notificationHandler,
+		SLA:          slaHandler,
+		Archive:      archiveHandler,
+		Erasure:      erasureHandler,
 	}
 }
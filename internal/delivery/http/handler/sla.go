@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"avito/internal/cerr"
+	"avito/internal/entity"
+	"avito/internal/gen"
+	"avito/internal/service"
+)
+
+type SLA struct {
+	service service.SLA
+}
+
+func InitSLAHandler(service service.SLA) *SLA {
+	return &SLA{
+		service: service,
+	}
+}
+
+func (r *SLA) GetTeamGetSla(ctx context.Context, request gen.GetTeamGetSlaRequestObject) (gen.GetTeamGetSlaResponseObject, error) {
+	sla, err := r.service.Get(ctx, request.Params.TeamName)
+	if err != nil {
+		code, message := cerr.HandleErrsCtx(ctx, err)
+		if code == http.StatusNotFound {
+			return gen.GetTeamGetSla404JSONResponse(message), nil
+		}
+
+		if code == http.StatusServiceUnavailable {
+			return gen.GetTeamGetSla503JSONResponse{
+				Body:    message,
+				Headers: gen.GetTeamGetSla503ResponseHeaders{RetryAfter: cerr.RetryAfter},
+			}, nil
+		}
+
+		return gen.GetTeamGetSla500JSONResponse(message), nil
+	}
+
+	return gen.GetTeamGetSla200JSONResponse(toGenTeamSLA(sla)), nil
+}
+
+func (r *SLA) PostTeamSetSla(ctx context.Context, request gen.PostTeamSetSlaRequestObject) (gen.PostTeamSetSlaResponseObject, error) {
+	sla := entity.TeamSLA{
+		TeamName:      request.Body.TeamName,
+		RemindAfter:   fromHours(request.Body.RemindAfterHours),
+		EscalateAfter: fromHours(request.Body.EscalateAfterHours),
+		LeadId:        request.Body.LeadId,
+	}
+
+	if request.Body.AutoReassign != nil {
+		sla.AutoReassign = *request.Body.AutoReassign
+	}
+
+	result, err := r.service.Set(ctx, &sla)
+	if err != nil {
+		code, message := cerr.HandleErrsCtx(ctx, err)
+		if code == http.StatusBadRequest {
+			return gen.PostTeamSetSla400JSONResponse(message), nil
+		}
+
+		if code == http.StatusNotFound {
+			return gen.PostTeamSetSla404JSONResponse(message), nil
+		}
+
+		if code == http.StatusServiceUnavailable {
+			return gen.PostTeamSetSla503JSONResponse{
+				Body:    message,
+				Headers: gen.PostTeamSetSla503ResponseHeaders{RetryAfter: cerr.RetryAfter},
+			}, nil
+		}
+
+		return gen.PostTeamSetSla500JSONResponse(message), nil
+	}
+
+	return gen.PostTeamSetSla200JSONResponse(toGenTeamSLA(result)), nil
+}
+
+func (r *SLA) PostTeamRemoveSla(ctx context.Context, request gen.PostTeamRemoveSlaRequestObject) (gen.PostTeamRemoveSlaResponseObject, error) {
+	err := r.service.Delete(ctx, request.Body.TeamName)
+	if err != nil {
+		code, message := cerr.HandleErrsCtx(ctx, err)
+		if code == http.StatusNotFound {
+			return gen.PostTeamRemoveSla404JSONResponse(message), nil
+		}
+
+		if code == http.StatusServiceUnavailable {
+			return gen.PostTeamRemoveSla503JSONResponse{
+				Body:    message,
+				Headers: gen.PostTeamRemoveSla503ResponseHeaders{RetryAfter: cerr.RetryAfter},
+			}, nil
+		}
+
+		return gen.PostTeamRemoveSla500JSONResponse(message), nil
+	}
+
+	return gen.PostTeamRemoveSla200JSONResponse{TeamName: request.Body.TeamName}, nil
+}
+
+func toGenTeamSLA(sla *entity.TeamSLA) gen.TeamSLA {
+	return gen.TeamSLA{
+		TeamName:           sla.TeamName,
+		RemindAfterHours:   float32(sla.RemindAfter.Hours()),
+		EscalateAfterHours: float32(sla.EscalateAfter.Hours()),
+		LeadId:             sla.LeadId,
+		AutoReassign:       sla.AutoReassign,
+	}
+}
+
+// fromHours reads the hours of the API to the seconds the SLA is kept in.
+func fromHours(hours float32) time.Duration {
+	return time.Duration(float64(hours) * float64(time.Hour)).Round(time.Second)
+}
@@ -22,13 +22,20 @@ func InitStatHandler(service service.Stat) *Stat {
 func (s Stat) GetStatisticsTeam(ctx context.Context, request gen.GetStatisticsTeamRequestObject) (gen.GetStatisticsTeamResponseObject, error) {
 	team, err := s.service.Team(ctx, request.Params.TeamName)
 	if err != nil {
-		code, message := cerr.HandleErrs(err)
+		code, message := cerr.HandleErrsCtx(ctx, err)
 
 		if code == http.StatusNotFound {
 			return gen.GetStatisticsTeam404JSONResponse(message), nil
 		}
 
-		return nil, cerr.ErrServerTime
+		if code == http.StatusServiceUnavailable {
+			return gen.GetStatisticsTeam503JSONResponse{
+				Body:    message,
+				Headers: gen.GetStatisticsTeam503ResponseHeaders{RetryAfter: cerr.RetryAfter},
+			}, nil
+		}
+
+		return gen.GetStatisticsTeam500JSONResponse(message), nil
 	}
 
 	var genUsers []gen.UserStat
@@ -51,12 +58,19 @@ func (s Stat) GetStatisticsTeam(ctx context.Context, request gen.GetStatisticsTe
 func (s Stat) GetStatisticsUser(ctx context.Context, request gen.GetStatisticsUserRequestObject) (gen.GetStatisticsUserResponseObject, error) {
 	user, err := s.service.User(ctx, request.Params.UserId)
 	if err != nil {
-		code, message := cerr.HandleErrs(err)
+		code, message := cerr.HandleErrsCtx(ctx, err)
 		if code == http.StatusNotFound {
 			return gen.GetStatisticsUser404JSONResponse(message), nil
 		}
 
-		return nil, cerr.ErrServerTime
+		if code == http.StatusServiceUnavailable {
+			return gen.GetStatisticsUser503JSONResponse{
+				Body:    message,
+				Headers: gen.GetStatisticsUser503ResponseHeaders{RetryAfter: cerr.RetryAfter},
+			}, nil
+		}
+
+		return gen.GetStatisticsUser500JSONResponse(message), nil
 	}
 
 	return gen.GetStatisticsUser200JSONResponse{
@@ -35,12 +35,19 @@ func (r *Team) PostTeamAdd(ctx context.Context, request gen.PostTeamAddRequestOb
 
 	err := r.service.Create(ctx, &createTeam)
 	if err != nil {
-		code, message := cerr.HandleErrs(err)
+		code, message := cerr.HandleErrsCtx(ctx, err)
 		if code == http.StatusBadRequest {
 			return gen.PostTeamAdd400JSONResponse(message), nil
 		}
 
-		return nil, cerr.ErrServerTime
+		if code == http.StatusServiceUnavailable {
+			return gen.PostTeamAdd503JSONResponse{
+				Body:    message,
+				Headers: gen.PostTeamAdd503ResponseHeaders{RetryAfter: cerr.RetryAfter},
+			}, nil
+		}
+
+		return gen.PostTeamAdd500JSONResponse(message), nil
 	}
 
 	return gen.PostTeamAdd201JSONResponse{Team: request.Body}, nil
@@ -49,13 +56,20 @@ func (r *Team) PostTeamAdd(ctx context.Context, request gen.PostTeamAddRequestOb
 func (r *Team) GetTeamGet(ctx context.Context, request gen.GetTeamGetRequestObject) (gen.GetTeamGetResponseObject, error) {
 	team, err := r.service.Get(ctx, request.Params.TeamName)
 	if err != nil {
-		code, message := cerr.HandleErrs(err)
+		code, message := cerr.HandleErrsCtx(ctx, err)
 
 		if code == http.StatusNotFound {
 			return gen.GetTeamGet404JSONResponse(message), nil
 		}
 
-		return nil, cerr.ErrServerTime
+		if code == http.StatusServiceUnavailable {
+			return gen.GetTeamGet503JSONResponse{
+				Body:    message,
+				Headers: gen.GetTeamGet503ResponseHeaders{RetryAfter: cerr.RetryAfter},
+			}, nil
+		}
+
+		return gen.GetTeamGet500JSONResponse(message), nil
 	}
 
 	genTeam := gen.Team{
@@ -72,3 +86,75 @@ func (r *Team) GetTeamGet(ctx context.Context, request gen.GetTeamGetRequestObje
 
 	return gen.GetTeamGet200JSONResponse(genTeam), nil
 }
+
+func (r *Team) PostAdminTeamsApply(ctx context.Context, request gen.PostAdminTeamsApplyRequestObject) (gen.PostAdminTeamsApplyResponseObject, error) {
+	teams := make([]entity.Team, len(request.Body.Teams))
+	for i, team := range request.Body.Teams {
+		teams[i] = entity.Team{
+			TeamName: team.TeamName,
+			Members:  make([]entity.TeamMember, len(team.Members)),
+		}
+
+		for j, member := range team.Members {
+			teams[i].Members[j] = entity.TeamMember{
+				IsActive: member.IsActive,
+				UserId:   member.UserId,
+				Username: member.Username,
+			}
+		}
+	}
+
+	dryRun := request.Params.DryRun != nil && *request.Params.DryRun
+
+	plan, err := r.service.Apply(ctx, teams, dryRun)
+	if err != nil {
+		code, message := cerr.HandleErrsCtx(ctx, err)
+		if code == http.StatusBadRequest {
+			return gen.PostAdminTeamsApply400JSONResponse(message), nil
+		}
+
+		if code == http.StatusServiceUnavailable {
+			return gen.PostAdminTeamsApply503JSONResponse{
+				Body:    message,
+				Headers: gen.PostAdminTeamsApply503ResponseHeaders{RetryAfter: cerr.RetryAfter},
+			}, nil
+		}
+
+		return gen.PostAdminTeamsApply500JSONResponse(message), nil
+	}
+
+	genPlan := gen.TeamPlan{
+		DryRun:        plan.DryRun,
+		Changes:       make([]gen.TeamChange, len(plan.Changes)),
+		Reassignments: make([]gen.Reassignment, len(plan.Reassignments)),
+	}
+	for i, change := range plan.Changes {
+		genPlan.Changes[i] = gen.TeamChange{
+			Action:       gen.TeamChangeAction(change.Action),
+			TeamName:     change.TeamName,
+			UserId:       optional(change.UserId),
+			Username:     optional(change.Username),
+			FromTeam:     optional(change.FromTeam),
+			FromUsername: optional(change.FromUsername),
+		}
+	}
+
+	for i, reassignment := range plan.Reassignments {
+		genPlan.Reassignments[i] = gen.Reassignment{
+			PullRequestId: reassignment.PullRequestId,
+			OldReviewerId: reassignment.OldReviewerId,
+			NewReviewerId: reassignment.NewReviewerId,
+		}
+	}
+
+	return gen.PostAdminTeamsApply200JSONResponse(genPlan), nil
+}
+
+// optional leaves empty strings out of the response.
+func optional(value string) *string {
+	if value == "" {
+		return nil
+	}
+
+	return &value
+}
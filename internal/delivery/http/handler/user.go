@@ -22,12 +22,19 @@ func InitUserHandler(service service.User) *User {
 func (r *User) GetUsersGetReview(ctx context.Context, request gen.GetUsersGetReviewRequestObject) (gen.GetUsersGetReviewResponseObject, error) {
 	PullRequests, err := r.service.GetReview(ctx, request.Params.UserId)
 	if err != nil {
-		code, message := cerr.HandleErrs(err)
+		code, message := cerr.HandleErrsCtx(ctx, err)
 		if code == http.StatusNotFound {
 			return gen.GetUsersGetReview404JSONResponse(message), nil
 		}
 
-		return nil, cerr.ErrServerTime
+		if code == http.StatusServiceUnavailable {
+			return gen.GetUsersGetReview503JSONResponse{
+				Body:    message,
+				Headers: gen.GetUsersGetReview503ResponseHeaders{RetryAfter: cerr.RetryAfter},
+			}, nil
+		}
+
+		return gen.GetUsersGetReview500JSONResponse(message), nil
 	}
 
 	genPullRequests := make([]gen.PullRequestShort, len(PullRequests))
@@ -50,12 +57,19 @@ func (r *User) GetUsersGetReview(ctx context.Context, request gen.GetUsersGetRev
 func (r *User) PostUsersSetIsActive(ctx context.Context, request gen.PostUsersSetIsActiveRequestObject) (gen.PostUsersSetIsActiveResponseObject, error) {
 	user, err := r.service.SetIsActive(ctx, request.Body.UserId, request.Body.IsActive)
 	if err != nil {
-		code, message := cerr.HandleErrs(err)
+		code, message := cerr.HandleErrsCtx(ctx, err)
 		if code == http.StatusNotFound {
 			return gen.PostUsersSetIsActive404JSONResponse(message), nil
 		}
 
-		return nil, cerr.ErrServerTime
+		if code == http.StatusServiceUnavailable {
+			return gen.PostUsersSetIsActive503JSONResponse{
+				Body:    message,
+				Headers: gen.PostUsersSetIsActive503ResponseHeaders{RetryAfter: cerr.RetryAfter},
+			}, nil
+		}
+
+		return gen.PostUsersSetIsActive500JSONResponse(message), nil
 	}
 
 	return gen.PostUsersSetIsActive200JSONResponse{
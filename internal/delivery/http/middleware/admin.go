@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"errors"
+
+	"avito/internal/cerr"
+	"github.com/gin-gonic/gin"
+)
+
+const HeaderAdminToken = "X-Admin-Token"
+
+var errAdminToken = errors.New("missing or wrong admin token")
+
+// Admin guards the operations an organization key is not enough for, like the team sync
+// and the user erasure. The organization still comes from the API key, the admin token
+// goes in a header of its own and is compared in constant time.
+func Admin(token string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if subtle.ConstantTimeCompare([]byte(c.GetHeader(HeaderAdminToken)), []byte(token)) != 1 {
+			code, response := cerr.HandleErrsCtx(c.Request.Context(), cerr.CustomError{
+				Err:     errAdminToken,
+				ErrType: cerr.UNAUTHORIZED,
+			})
+
+			c.AbortWithStatusJSON(code, response)
+
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdmin(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	g := gin.New()
+	g.POST("/admin/teams/apply", Admin("secret"), func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	for token, code := range map[string]int{
+		"":        http.StatusUnauthorized,
+		"secre":   http.StatusUnauthorized,
+		"secret2": http.StatusUnauthorized,
+		"secret":  http.StatusOK,
+	} {
+		req := httptest.NewRequest(http.MethodPost, "/admin/teams/apply", nil)
+		if token != "" {
+			req.Header.Set(HeaderAdminToken, token)
+		}
+
+		rec := httptest.NewRecorder()
+		g.ServeHTTP(rec, req)
+
+		assert.Equal(t, code, rec.Code, token)
+	}
+}
@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"net/http"
+	"time"
+
+	"avito/internal/log"
+	"github.com/gin-gonic/gin"
+)
+
+// Logger puts a request-scoped logger into the context and writes one access log
+// entry per request. Probe endpoints are logged at debug level only.
+func Logger(quiet ...string) gin.HandlerFunc {
+	skip := make(map[string]struct{}, len(quiet))
+	for _, path := range quiet {
+		skip[path] = struct{}{}
+	}
+
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		ctx := c.Request.Context()
+		logger := log.Log.With("request_id", log.RequestID(ctx))
+
+		c.Request = c.Request.WithContext(log.WithLogger(ctx, logger))
+
+		c.Next()
+
+		entry := log.Ctx(c.Request.Context()).
+			With("method", c.Request.Method).
+			With("route", c.FullPath()).
+			With("path", c.Request.URL.Path).
+			With("status", c.Writer.Status()).
+			With("size", c.Writer.Size()).
+			With("client_ip", c.ClientIP()).
+			With("latency_ms", float64(time.Since(start).Microseconds())/1000)
+
+		_, isQuiet := skip[c.Request.URL.Path]
+
+		switch {
+		case isQuiet:
+			entry.Debug("request")
+		case c.Writer.Status() >= http.StatusInternalServerError:
+			entry.Warn("request")
+		default:
+			entry.Info("request")
+		}
+	}
+}
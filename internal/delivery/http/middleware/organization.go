@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"errors"
+	"strings"
+
+	"avito/internal/cerr"
+	"avito/internal/log"
+	"avito/internal/org"
+	"github.com/gin-gonic/gin"
+)
+
+var errUnknownKey = errors.New("missing or unknown api key")
+
+// Organization puts the organization of the caller's API key into the request context.
+// Without keys the deployment has a single tenant and every request acts in the default
+// organization.
+func Organization(keys org.Keys) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if len(keys) == 0 {
+			c.Next()
+
+			return
+		}
+
+		token, _ := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+
+		orgID, ok := keys[token]
+		if !ok {
+			code, response := cerr.HandleErrsCtx(c.Request.Context(), cerr.CustomError{
+				Err:     errUnknownKey,
+				ErrType: cerr.UNAUTHORIZED,
+			})
+
+			c.AbortWithStatusJSON(code, response)
+
+			return
+		}
+
+		InOrganization(orgID)(c)
+	}
+}
+
+// InOrganization pins the routes to one organization. It is used by the integrations
+// that authenticate with their own tokens, like SCIM and chat commands.
+func InOrganization(orgID string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := org.With(c.Request.Context(), orgID)
+
+		log.AddField(ctx, "org_id", orgID)
+
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+	}
+}
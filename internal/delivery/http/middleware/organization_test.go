@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"avito/internal/gen"
+	"avito/internal/org"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrganization(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	newRouter := func(keys org.Keys) *gin.Engine {
+		g := gin.New()
+		g.POST("/scim/v2/Users", InOrganization("acme"), func(c *gin.Context) {
+			c.String(http.StatusOK, org.FromContext(c.Request.Context()))
+		})
+		g.Use(Organization(keys))
+		g.GET("/team/get", func(c *gin.Context) {
+			c.String(http.StatusOK, org.FromContext(c.Request.Context()))
+		})
+
+		return g
+	}
+
+	do := func(g *gin.Engine, method string, path string, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+
+		rec := httptest.NewRecorder()
+		g.ServeHTTP(rec, req)
+
+		return rec
+	}
+
+	// Without keys everything is in the default organization and tokens are ignored.
+	g := newRouter(nil)
+
+	rec := do(g, http.MethodGet, "/team/get", "anything")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, org.Default, rec.Body.String())
+
+	g = newRouter(org.Keys{"key-a": "acme", "key-g": "globex"})
+
+	rec = do(g, http.MethodGet, "/team/get", "key-g")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "globex", rec.Body.String())
+
+	for _, token := range []string{"", "key-x"} {
+		rec = do(g, http.MethodGet, "/team/get", token)
+		require.Equal(t, http.StatusUnauthorized, rec.Code, token)
+
+		var response gen.ErrorResponse
+
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+		assert.Equal(t, gen.UNAUTHORIZED, response.Error.Code)
+	}
+
+	// Routes registered before the middleware keep their own organization.
+	rec = do(g, http.MethodPost, "/scim/v2/Users", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "acme", rec.Body.String())
+}
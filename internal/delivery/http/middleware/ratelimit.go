@@ -0,0 +1,87 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"avito/internal/cerr"
+	"avito/internal/log"
+	"avito/internal/org"
+	"avito/internal/ratelimit"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	HeaderRateLimitLimit     = "RateLimit-Limit"
+	HeaderRateLimitRemaining = "RateLimit-Remaining"
+	HeaderRateLimitReset     = "RateLimit-Reset"
+	HeaderRetryAfter         = "Retry-After"
+)
+
+// RateLimit charges one token per request from the client's read or write bucket.
+// GraphQL has no mutations, so its POST requests are reads too.
+// If the limiter itself fails the request is let through.
+func RateLimit(limiter ratelimit.Limiter, read ratelimit.Limit, write ratelimit.Limit, keys org.Keys) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		limit, class := write, "write"
+		if isRead(c.Request.Method) || c.FullPath() == "/graphql" {
+			limit, class = read, "read"
+		}
+
+		if !limit.Enabled() {
+			c.Next()
+
+			return
+		}
+
+		ctx := c.Request.Context()
+
+		res, err := limiter.Allow(ctx, fmt.Sprintf("%v:%v", clientKey(c, keys), class), limit)
+		if err != nil {
+			log.Ctx(ctx).Error(fmt.Errorf("rate limit: %w", err))
+			c.Next()
+
+			return
+		}
+
+		c.Header(HeaderRateLimitLimit, strconv.Itoa(res.Limit))
+		c.Header(HeaderRateLimitRemaining, strconv.Itoa(res.Remaining))
+		c.Header(HeaderRateLimitReset, strconv.Itoa(int(res.Reset.Seconds())))
+
+		if !res.Allowed {
+			code, response := cerr.HandleErrsCtx(ctx, cerr.CustomError{
+				Err:     fmt.Errorf("%v limit of %d exceeded", class, res.Limit),
+				ErrType: cerr.RATE_LIMITED,
+			})
+
+			c.Header(HeaderRetryAfter, strconv.Itoa(int(res.RetryAfter.Seconds())))
+			c.AbortWithStatusJSON(code, response)
+
+			return
+		}
+
+		c.Next()
+	}
+}
+
+func isRead(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
+
+// clientKey identifies the caller by its API key, or by IP for any other token. The
+// limiter runs before the keys are checked, so a made-up token must not get a bucket of
+// its own. Tokens are hashed so they never end up in the shared rate_limits table.
+func clientKey(c *gin.Context, keys org.Keys) string {
+	token, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+	if _, known := keys[token]; ok && known {
+		sum := sha256.Sum256([]byte(token))
+
+		return "token:" + hex.EncodeToString(sum[:16])
+	}
+
+	return "ip:" + c.ClientIP()
+}
@@ -0,0 +1,97 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"avito/internal/gen"
+	"avito/internal/org"
+	"avito/internal/ratelimit"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	g := gin.New()
+	g.Use(RateLimit(ratelimit.InitMemoryLimiter(),
+		ratelimit.Limit{Rate: 1, Burst: 3},
+		ratelimit.Limit{Rate: 0.5, Burst: 1},
+		org.Keys{"ci": "acme", "ops": "acme"},
+	))
+	g.GET("/team/get", func(c *gin.Context) { c.Status(http.StatusOK) })
+	g.POST("/pullRequest/create", func(c *gin.Context) { c.Status(http.StatusCreated) })
+	g.POST("/graphql", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	do := func(method string, path string, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+
+		rec := httptest.NewRecorder()
+		g.ServeHTTP(rec, req)
+
+		return rec
+	}
+
+	rec := do(http.MethodPost, "/pullRequest/create", "ci")
+	require.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, "1", rec.Header().Get(HeaderRateLimitLimit))
+	assert.Equal(t, "0", rec.Header().Get(HeaderRateLimitRemaining))
+	assert.Equal(t, "2", rec.Header().Get(HeaderRateLimitReset))
+
+	rec = do(http.MethodPost, "/pullRequest/create", "ci")
+	require.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "2", rec.Header().Get(HeaderRetryAfter))
+
+	var response gen.ErrorResponse
+
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, gen.RATELIMITED, response.Error.Code)
+
+	// Reads have their own bucket, and other clients are not affected.
+	rec = do(http.MethodGet, "/team/get", "ci")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "2", rec.Header().Get(HeaderRateLimitRemaining))
+
+	rec = do(http.MethodPost, "/graphql", "ci")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "1", rec.Header().Get(HeaderRateLimitRemaining))
+
+	rec = do(http.MethodPost, "/pullRequest/create", "ops")
+	require.Equal(t, http.StatusCreated, rec.Code)
+
+	rec = do(http.MethodPost, "/pullRequest/create", "")
+	require.Equal(t, http.StatusCreated, rec.Code)
+
+	// Unknown tokens share the bucket of the address, a new one does not reset it.
+	rec = do(http.MethodPost, "/pullRequest/create", "made-up-1")
+	require.Equal(t, http.StatusTooManyRequests, rec.Code)
+
+	rec = do(http.MethodPost, "/pullRequest/create", "made-up-2")
+	require.Equal(t, http.StatusTooManyRequests, rec.Code)
+}
+
+func TestClientKeyIgnoresForwardedFor(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	g := gin.New()
+	require.NoError(t, g.SetTrustedProxies(nil))
+
+	var key string
+
+	g.GET("/team/get", func(c *gin.Context) { key = clientKey(c, nil) })
+
+	req := httptest.NewRequest(http.MethodGet, "/team/get", nil)
+	req.RemoteAddr = "192.0.2.10:4321"
+	req.Header.Set("X-Forwarded-For", "198.51.100.7")
+
+	g.ServeHTTP(httptest.NewRecorder(), req)
+
+	assert.Equal(t, "ip:192.0.2.10", key)
+}
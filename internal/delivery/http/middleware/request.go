@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"avito/internal/cerr"
+	"avito/internal/log"
+	"github.com/gin-gonic/gin"
+)
+
+const HeaderRequestID = "X-Request-ID"
+
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(HeaderRequestID)
+		if requestID == "" || len(requestID) > 128 {
+			requestID = log.NewRequestID()
+		}
+
+		c.Header(HeaderRequestID, requestID)
+		c.Request = c.Request.WithContext(log.WithRequestID(c.Request.Context(), requestID))
+
+		c.Next()
+	}
+}
+
+func Timeout(timeout time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if timeout <= 0 {
+			c.Next()
+
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+		defer cancel()
+
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+	}
+}
+
+func Recovery() gin.HandlerFunc {
+	return gin.CustomRecovery(func(c *gin.Context, recovered any) {
+		code, response := cerr.HandleErrsCtx(c.Request.Context(), fmt.Errorf("panic: %v", recovered))
+
+		c.AbortWithStatusJSON(code, response)
+	})
+}
@@ -0,0 +1,304 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"avito/internal/cerr"
+	"avito/internal/gen"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers/legacy"
+	"github.com/gin-gonic/gin"
+)
+
+// Rule checks a decoded request body against a service rule the OpenAPI schema can not express.
+type Rule func(body []byte) []gen.FieldError
+
+var rules = map[string]Rule{
+	"PostTeamAdd":         uniqueMembers,
+	"PostAdminTeamsApply": uniqueTeams,
+}
+
+func Validation(swagger *openapi3.T) (gin.HandlerFunc, error) {
+	swagger.Servers = nil
+
+	router, err := legacy.NewRouter(swagger)
+	if err != nil {
+		return nil, fmt.Errorf("init validation router: %w", err)
+	}
+
+	// API keys are checked by the Organization middleware, which runs first.
+	options := &openapi3filter.Options{MultiError: true, AuthenticationFunc: openapi3filter.NoopAuthenticationFunc}
+
+	return func(c *gin.Context) {
+		route, pathParams, err := router.FindRoute(c.Request)
+		if err != nil {
+			c.Next()
+
+			return
+		}
+
+		var fields []gen.FieldError
+
+		err = openapi3filter.ValidateRequest(c.Request.Context(), &openapi3filter.RequestValidationInput{
+			Request:    c.Request,
+			PathParams: pathParams,
+			Route:      route,
+			Options:    options,
+		})
+		if err != nil {
+			fields = collectFields(err, "", fields)
+		}
+
+		// The rules run on bodies that failed the schema too, so every offending field is
+		// reported at once.
+		if rule, ok := rules[route.Operation.OperationID]; ok {
+			body, err := readBody(c)
+			if err == nil {
+				fields = append(fields, rule(body)...)
+			}
+		}
+
+		if len(fields) != 0 {
+			sort.SliceStable(fields, func(i, j int) bool {
+				return fields[i].Field < fields[j].Field
+			})
+
+			code, response := cerr.HandleErrs(cerr.CustomError{
+				Err:     cerr.FieldsError{Fields: fields},
+				ErrType: cerr.VALIDATION,
+			})
+
+			c.AbortWithStatusJSON(code, response)
+
+			return
+		}
+
+		c.Next()
+	}, nil
+}
+
+// ValidateOperation applies the checks of Validation to a request that did not come over
+// HTTP, params are the query parameters and body is marshaled to JSON first. The gRPC API
+// uses it so both APIs accept the same input.
+func ValidateOperation(swagger *openapi3.T, operationID string, params map[string]string, body any) []gen.FieldError {
+	var operation *openapi3.Operation
+
+	for _, item := range swagger.Paths.Map() {
+		for _, op := range item.Operations() {
+			if op.OperationID == operationID {
+				operation = op
+			}
+		}
+	}
+
+	if operation == nil {
+		return nil
+	}
+
+	var fields []gen.FieldError
+
+	for _, ref := range operation.Parameters {
+		param := ref.Value
+
+		value, ok := params[param.Name]
+		if !ok || param.Schema == nil {
+			continue
+		}
+
+		if err := param.Schema.Value.VisitJSON(value, openapi3.MultiErrors()); err != nil {
+			fields = collectFields(err, param.Name, fields)
+		}
+	}
+
+	var raw []byte
+
+	if body != nil && operation.RequestBody != nil {
+		var err error
+
+		raw, err = json.Marshal(body)
+		if err != nil {
+			return append(fields, gen.FieldError{Field: "body", Reason: err.Error()})
+		}
+
+		var decoded any
+		if err = json.Unmarshal(raw, &decoded); err != nil {
+			return append(fields, gen.FieldError{Field: "body", Reason: err.Error()})
+		}
+
+		if media := operation.RequestBody.Value.Content.Get("application/json"); media != nil && media.Schema != nil {
+			if err = media.Schema.Value.VisitJSON(decoded, openapi3.MultiErrors()); err != nil {
+				fields = collectFields(err, "", fields)
+			}
+		}
+	}
+
+	if rule, ok := rules[operationID]; ok && raw != nil {
+		fields = append(fields, rule(raw)...)
+	}
+
+	sort.SliceStable(fields, func(i, j int) bool {
+		return fields[i].Field < fields[j].Field
+	})
+
+	return fields
+}
+
+func readBody(c *gin.Context) ([]byte, error) {
+	if c.Request.Body == nil {
+		return nil, nil
+	}
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+	return body, nil
+}
+
+func collectFields(err error, field string, fields []gen.FieldError) []gen.FieldError {
+	var multiErr openapi3.MultiError
+	if errors.As(err, &multiErr) {
+		for _, e := range multiErr {
+			fields = collectFields(e, field, fields)
+		}
+
+		return fields
+	}
+
+	var requestErr *openapi3filter.RequestError
+	if errors.As(err, &requestErr) {
+		if requestErr.Parameter != nil {
+			field = requestErr.Parameter.Name
+		}
+
+		if requestErr.Err == nil {
+			return append(fields, gen.FieldError{Field: fieldOrBody(field), Reason: requestErr.Reason})
+		}
+
+		return collectFields(requestErr.Err, field, fields)
+	}
+
+	var schemaErr *openapi3.SchemaError
+	if errors.As(err, &schemaErr) {
+		return append(fields, gen.FieldError{
+			Field:  joinPath(field, schemaErr.JSONPointer()),
+			Reason: schemaErr.Reason,
+		})
+	}
+
+	var parseErr *openapi3filter.ParseError
+	if errors.As(err, &parseErr) {
+		return append(fields, gen.FieldError{Field: fieldOrBody(field), Reason: parseErr.Reason})
+	}
+
+	return append(fields, gen.FieldError{Field: fieldOrBody(field), Reason: err.Error()})
+}
+
+func joinPath(field string, pointer []string) string {
+	var path strings.Builder
+
+	path.WriteString(field)
+
+	for _, part := range pointer {
+		if part != "" && strings.Trim(part, "0123456789") == "" {
+			path.WriteString("[" + part + "]")
+
+			continue
+		}
+
+		if path.Len() != 0 {
+			path.WriteString(".")
+		}
+
+		path.WriteString(part)
+	}
+
+	return fieldOrBody(path.String())
+}
+
+func fieldOrBody(field string) string {
+	if field == "" {
+		return "body"
+	}
+
+	return field
+}
+
+func uniqueMembers(body []byte) []gen.FieldError {
+	var team gen.Team
+	if err := json.Unmarshal(body, &team); err != nil {
+		return nil
+	}
+
+	var fields []gen.FieldError
+
+	seen := make(map[string]int, len(team.Members))
+
+	for i, member := range team.Members {
+		if first, ok := seen[member.UserId]; ok {
+			fields = append(fields, gen.FieldError{
+				Field:  fmt.Sprintf("members[%d].user_id", i),
+				Reason: fmt.Sprintf("duplicates members[%d].user_id %q", first, member.UserId),
+			})
+
+			continue
+		}
+
+		seen[member.UserId] = i
+	}
+
+	return fields
+}
+
+// uniqueTeams checks that a team is listed once and a user belongs to one team only.
+func uniqueTeams(body []byte) []gen.FieldError {
+	var req gen.PostAdminTeamsApplyJSONRequestBody
+	if err := json.Unmarshal(body, &req); err != nil {
+		return nil
+	}
+
+	var fields []gen.FieldError
+
+	teams := make(map[string]int, len(req.Teams))
+	users := make(map[string]string)
+
+	for i, team := range req.Teams {
+		if first, ok := teams[team.TeamName]; ok {
+			fields = append(fields, gen.FieldError{
+				Field:  fmt.Sprintf("teams[%d].team_name", i),
+				Reason: fmt.Sprintf("duplicates teams[%d].team_name %q", first, team.TeamName),
+			})
+
+			continue
+		}
+
+		teams[team.TeamName] = i
+
+		for j, member := range team.Members {
+			field := fmt.Sprintf("teams[%d].members[%d].user_id", i, j)
+
+			if first, ok := users[member.UserId]; ok {
+				fields = append(fields, gen.FieldError{
+					Field:  field,
+					Reason: fmt.Sprintf("duplicates %v %q", first, member.UserId),
+				})
+
+				continue
+			}
+
+			users[member.UserId] = field
+		}
+	}
+
+	return fields
+}
@@ -0,0 +1,137 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"avito/internal/gen"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	swagger, err := gen.GetSwagger()
+	require.NoError(t, err)
+
+	validation, err := Validation(swagger)
+	require.NoError(t, err)
+
+	g := gin.New()
+	g.Use(validation)
+	g.POST("/team/add", func(c *gin.Context) { c.Status(http.StatusCreated) })
+	g.POST("/admin/teams/apply", func(c *gin.Context) { c.Status(http.StatusOK) })
+	g.POST("/pullRequest/create", func(c *gin.Context) { c.Status(http.StatusCreated) })
+	g.GET("/users/getReview", func(c *gin.Context) { c.Status(http.StatusOK) })
+	g.GET("/healthz", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	tests := []struct {
+		description    string
+		method         string
+		path           string
+		body           string
+		expectedCode   int
+		expectedFields []gen.FieldError
+	}{
+		{
+			description:  "valid team",
+			method:       http.MethodPost,
+			path:         "/team/add",
+			body:         `{"team_name":"backend","members":[{"user_id":"u1","username":"Alice","is_active":true}]}`,
+			expectedCode: http.StatusCreated,
+		},
+		{
+			description:  "duplicate members",
+			method:       http.MethodPost,
+			path:         "/team/add",
+			body:         `{"team_name":"backend","members":[{"user_id":"u1","username":"Alice","is_active":true},{"user_id":"u1","username":"Bob","is_active":true}]}`,
+			expectedCode: http.StatusBadRequest,
+			expectedFields: []gen.FieldError{
+				{Field: "members[1].user_id", Reason: `duplicates members[0].user_id "u1"`},
+			},
+		},
+		{
+			description:  "user in two teams",
+			method:       http.MethodPost,
+			path:         "/admin/teams/apply?dry_run=true",
+			body:         `{"teams":[{"team_name":"backend","members":[{"user_id":"u1","username":"Alice","is_active":true}]},{"team_name":"frontend","members":[{"user_id":"u1","username":"Alice","is_active":true}]},{"team_name":"backend","members":[{"user_id":"u2","username":"Bob","is_active":true}]}]}`,
+			expectedCode: http.StatusBadRequest,
+			expectedFields: []gen.FieldError{
+				{Field: "teams[1].members[0].user_id", Reason: `duplicates teams[0].members[0].user_id "u1"`},
+				{Field: "teams[2].team_name", Reason: `duplicates teams[0].team_name "backend"`},
+			},
+		},
+		{
+			description:  "nested fields",
+			method:       http.MethodPost,
+			path:         "/team/add",
+			body:         `{"team_name":"","members":[{"username":"Alice","is_active":true}]}`,
+			expectedCode: http.StatusBadRequest,
+			expectedFields: []gen.FieldError{
+				{Field: "members[0].user_id", Reason: `property "user_id" is missing`},
+				{Field: "team_name", Reason: "minimum string length is 1"},
+			},
+		},
+		{
+			description:  "schema errors and duplicates together",
+			method:       http.MethodPost,
+			path:         "/team/add",
+			body:         `{"team_name":"","members":[{"user_id":"u1","username":"Alice","is_active":true},{"user_id":"u1","username":"Bob","is_active":true}]}`,
+			expectedCode: http.StatusBadRequest,
+			expectedFields: []gen.FieldError{
+				{Field: "members[1].user_id", Reason: `duplicates members[0].user_id "u1"`},
+				{Field: "team_name", Reason: "minimum string length is 1"},
+			},
+		},
+		{
+			description:  "every empty field is reported",
+			method:       http.MethodPost,
+			path:         "/pullRequest/create",
+			body:         `{"pull_request_id":"","pull_request_name":"","author_id":"u 1"}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			description:  "empty query parameter",
+			method:       http.MethodGet,
+			path:         "/users/getReview?user_id=",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			description:  "route outside the spec",
+			method:       http.MethodGet,
+			path:         "/healthz",
+			expectedCode: http.StatusOK,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			req := httptest.NewRequest(test.method, test.path, bytes.NewBufferString(test.body))
+			req.Header.Set("Content-Type", "application/json")
+
+			rec := httptest.NewRecorder()
+			g.ServeHTTP(rec, req)
+
+			require.Equal(t, test.expectedCode, rec.Code, rec.Body.String())
+
+			if test.expectedCode != http.StatusBadRequest {
+				return
+			}
+
+			var response gen.ErrorResponse
+
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			assert.Equal(t, gen.VALIDATIONERROR, response.Error.Code)
+			require.NotNil(t, response.Error.Details)
+
+			if test.expectedFields != nil {
+				assert.Equal(t, test.expectedFields, *response.Error.Details)
+			}
+		})
+	}
+}
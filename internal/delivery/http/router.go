@@ -1,39 +1,133 @@
 package http
 
 import (
+	"net/http"
+	"strings"
+
+	"avito/internal/cerr"
 	"avito/internal/delivery/http/handler"
 	"avito/internal/gen"
-	"avito/internal/postgres"
-	PRRepo "avito/internal/repo/pullRequest"
-	statRepo "avito/internal/repo/stat"
-	teamRepo "avito/internal/repo/team"
-	userRepo "avito/internal/repo/user"
+	"avito/internal/health"
+	"avito/internal/repo"
+	archiveServ "avito/internal/service/archive"
+	erasureServ "avito/internal/service/erasure"
+	notificationServ "avito/internal/service/notification"
 	PRServ "avito/internal/service/pullRequest"
+	slaServ "avito/internal/service/sla"
 	statServ "avito/internal/service/stat"
 	teamServ "avito/internal/service/team"
 	userServ "avito/internal/service/user"
+	"github.com/gin-gonic/gin"
 )
 
-func InitServer(db *postgres.Pg) gen.ServerInterface {
-	repoUser := userRepo.InitUserRepo(db)
-	servUser := userServ.InitUserServ(repoUser)
+func InitServer(repos repo.Repos) gen.ServerInterface {
+	servUser := userServ.InitUserServ(repos.User)
 	handlerUser := handler.InitUserHandler(servUser)
 
-	repoTeam := teamRepo.InitTeamRepo(db)
-	servTeam := teamServ.InitTeamServ(repoTeam)
+	servTeam := teamServ.InitTeamServ(repos.Team)
 	handlerTeam := handler.InitTeamHandler(servTeam)
 
-	repoPR := PRRepo.InitPullRequestRepo(db)
-	servPR := PRServ.InitPullRequestServ(repoPR)
+	servPR := PRServ.InitPullRequestServ(repos.PullRequest)
 	handlerPR := handler.InitPullRequestHandler(servPR)
 
-	repoStat := statRepo.InitStatRepo(db)
-	servStat := statServ.InitStatServ(repoStat)
+	servStat := statServ.InitStatServ(repos.Stat)
 	handlerStat := handler.InitStatHandler(servStat)
 
-	server := handler.NewServer(handlerUser, handlerPR, handlerTeam, handlerStat)
+	servNotification := notificationServ.InitNotificationServ(repos.Notification)
+	handlerNotification := handler.InitNotificationHandler(servNotification)
+
+	servSLA := slaServ.InitSLAServ(repos.SLA)
+	handlerSLA := handler.InitSLAHandler(servSLA)
+
+	servArchive := archiveServ.InitArchiveServ(repos.Archive)
+	handlerArchive := handler.InitArchiveHandler(servArchive)
+
+	servErasure := erasureServ.InitErasureServ(repos.Erasure)
+	handlerErasure := handler.InitErasureHandler(servErasure)
+
+	server := handler.NewServer(handlerUser, handlerPR, handlerTeam, handlerStat, handlerNotification, handlerSLA, handlerArchive,
+		handlerErasure)
 
 	strictHandler := gen.NewStrictHandler(server, nil)
 
 	return strictHandler
 }
+
+const adminPrefix = "/admin/"
+
+// RegisterHandlers registers the generated routes and puts the /admin ones behind admin.
+// Without admin there are no /admin routes at all, an organization key is not enough to
+// erase users or rewrite teams.
+func RegisterHandlers(g gin.IRouter, handlers gen.ServerInterface, admin gin.HandlerFunc) {
+	gen.RegisterHandlersWithOptions(adminRouter{IRouter: g, admin: admin}, handlers, gen.GinServerOptions{
+		ErrorHandler: paramsError,
+	})
+}
+
+// paramsError answers parameters the generated wrapper cannot bind like any other
+// validation error.
+func paramsError(c *gin.Context, err error, _ int) {
+	code, response := cerr.HandleErrsCtx(c.Request.Context(), cerr.CustomError{
+		Err:     cerr.FieldsError{Fields: []gen.FieldError{{Field: "query", Reason: err.Error()}}},
+		ErrType: cerr.VALIDATION,
+	})
+
+	c.JSON(code, response)
+}
+
+// strictErrors writes the ErrorResponse for the errors the strict handlers only record
+// in the context: a body that does not decode is a validation error, anything else is
+// an internal one. Responses that were already written are left alone.
+func strictErrors(c *gin.Context) {
+	c.Next()
+
+	if len(c.Errors) == 0 || c.Writer.Written() {
+		return
+	}
+
+	err := c.Errors.Last().Err
+	if c.Writer.Status() == http.StatusBadRequest {
+		err = cerr.CustomError{
+			Err:     cerr.FieldsError{Fields: []gen.FieldError{{Field: "body", Reason: err.Error()}}},
+			ErrType: cerr.VALIDATION,
+		}
+	}
+
+	code, response := cerr.HandleErrsCtx(c.Request.Context(), err)
+
+	c.JSON(code, response)
+}
+
+type adminRouter struct {
+	gin.IRouter
+	admin gin.HandlerFunc
+}
+
+func (r adminRouter) GET(path string, handlers ...gin.HandlerFunc) gin.IRoutes {
+	return r.Handle(http.MethodGet, path, handlers...)
+}
+
+func (r adminRouter) POST(path string, handlers ...gin.HandlerFunc) gin.IRoutes {
+	return r.Handle(http.MethodPost, path, handlers...)
+}
+
+func (r adminRouter) Handle(method string, path string, handlers ...gin.HandlerFunc) gin.IRoutes {
+	handlers = append([]gin.HandlerFunc{strictErrors}, handlers...)
+
+	if !strings.HasPrefix(path, adminPrefix) {
+		return r.IRouter.Handle(method, path, handlers...)
+	}
+
+	if r.admin == nil {
+		return r.IRouter
+	}
+
+	return r.IRouter.Handle(method, path, append([]gin.HandlerFunc{r.admin}, handlers...)...)
+}
+
+func InitHealth(g gin.IRouter, checker *health.Checker) {
+	handlerHealth := handler.InitHealthHandler(checker)
+
+	g.GET("/healthz", handlerHealth.GetHealthz)
+	g.GET("/readyz", handlerHealth.GetReadyz)
+}
@@ -0,0 +1,112 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"avito/internal/delivery/http/middleware"
+	"avito/internal/gen"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type noServer struct {
+	gen.ServerInterface
+}
+
+func routes(g *gin.Engine) map[string]bool {
+	paths := map[string]bool{}
+	for _, route := range g.Routes() {
+		paths[route.Method+" "+route.Path] = true
+	}
+
+	return paths
+}
+
+func TestRegisterHandlersWithoutAdmin(t *testing.T) {
+	g := gin.New()
+	RegisterHandlers(g, noServer{}, nil)
+
+	paths := routes(g)
+
+	assert.True(t, paths["GET /team/get"])
+	assert.True(t, paths["POST /pullRequest/create"])
+	assert.False(t, paths["POST /admin/teams/apply"])
+	assert.False(t, paths["POST /admin/users/erase"])
+	assert.False(t, paths["GET /admin/users/erasures"])
+	assert.False(t, paths["POST /admin/pullRequests/restore"])
+}
+
+func TestRegisterHandlersWithAdmin(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	g := gin.New()
+	RegisterHandlers(g, noServer{}, middleware.Admin("secret"))
+
+	assert.True(t, routes(g)["POST /admin/users/erase"])
+
+	for _, path := range []string{"/admin/teams/apply", "/admin/users/erase", "/admin/pullRequests/restore"} {
+		rec := httptest.NewRecorder()
+		g.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, path, nil))
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code, path)
+	}
+}
+
+type failingServer struct {
+	gen.StrictServerInterface
+}
+
+func (failingServer) GetTeamGet(context.Context, gen.GetTeamGetRequestObject) (gen.GetTeamGetResponseObject, error) {
+	return nil, errors.New("boom")
+}
+
+func (failingServer) PostTeamAdd(context.Context, gen.PostTeamAddRequestObject) (gen.PostTeamAddResponseObject, error) {
+	return nil, errors.New("unreachable")
+}
+
+// TestStrictErrors checks that the errors the generated code does not answer itself come
+// back in the ErrorResponse envelope.
+func TestStrictErrors(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	g := gin.New()
+	RegisterHandlers(g, gen.NewStrictHandler(failingServer{}, nil), nil)
+
+	for name, tc := range map[string]struct {
+		method string
+		target string
+		body   string
+		code   int
+		want   gen.ErrorResponseErrorCode
+		field  string
+	}{
+		"body":     {http.MethodPost, "/team/add", `{"team_name": 1}`, http.StatusBadRequest, gen.VALIDATIONERROR, "body"},
+		"params":   {http.MethodGet, "/team/get", "", http.StatusBadRequest, gen.VALIDATIONERROR, "query"},
+		"response": {http.MethodGet, "/team/get?team_name=backend", "", http.StatusInternalServerError, gen.INTERNALERROR, ""},
+	} {
+		rec := httptest.NewRecorder()
+		g.ServeHTTP(rec, httptest.NewRequest(tc.method, tc.target, strings.NewReader(tc.body)))
+
+		require.Equal(t, tc.code, rec.Code, name)
+		assert.Equal(t, "application/json; charset=utf-8", rec.Header().Get("Content-Type"), name)
+
+		var response gen.ErrorResponse
+
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response), name)
+		assert.Equal(t, tc.want, response.Error.Code, name)
+
+		if tc.field != "" {
+			require.NotNil(t, response.Error.Details, name)
+			assert.Equal(t, tc.field, (*response.Error.Details)[0].Field, name)
+		} else {
+			assert.NotNil(t, response.Error.CorrelationId, name)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package scim
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"avito/internal/entity"
+)
+
+// filter is the one form of RFC 7644 filters identity providers send to find a resource
+// before creating it: attribute eq value.
+type filter struct {
+	attr  string
+	value any
+}
+
+// attributes maps the lower-cased filterable attribute names to their values.
+type attributes[T any] map[string]func(T) any
+
+var userAttributes = attributes[entity.User]{
+	"id":          func(u entity.User) any { return u.UserId },
+	"username":    func(u entity.User) any { return u.UserId },
+	"displayname": func(u entity.User) any { return u.Username },
+	"active":      func(u entity.User) any { return u.IsActive },
+}
+
+var groupAttributes = attributes[entity.Team]{
+	"id":          func(t entity.Team) any { return t.TeamName },
+	"displayname": func(t entity.Team) any { return t.TeamName },
+}
+
+var memberAttributes = attributes[member]{
+	"value": func(m member) any { return m.Value },
+}
+
+// parseFilter returns nil for an empty filter, which matches everything.
+func parseFilter[T any](s string, attrs attributes[T]) (*filter, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, nil
+	}
+
+	attr, rest, _ := strings.Cut(s, " ")
+	op, value, _ := strings.Cut(strings.TrimSpace(rest), " ")
+
+	attr = attributeName(attr)
+	if _, ok := attrs[attr]; !ok {
+		return nil, fmt.Errorf("filter %q: unsupported attribute %q", s, attr)
+	}
+
+	if !strings.EqualFold(op, "eq") {
+		return nil, fmt.Errorf("filter %q: only eq is supported", s)
+	}
+
+	f := &filter{attr: attr}
+
+	if err := json.Unmarshal([]byte(strings.TrimSpace(value)), &f.value); err != nil {
+		return nil, fmt.Errorf("filter %q: value is not a JSON string or boolean", s)
+	}
+
+	return f, nil
+}
+
+// match compares strings case-insensitively like the caseExact=false attributes of the
+// core schema, ids are compared exactly.
+func match[T any](f *filter, attrs attributes[T], v T) bool {
+	if f == nil {
+		return true
+	}
+
+	got := attrs[f.attr](v)
+
+	gotString, ok := got.(string)
+	if !ok {
+		return got == f.value
+	}
+
+	want, ok := f.value.(string)
+	if !ok {
+		return false
+	}
+
+	if f.attr == "id" {
+		return gotString == want
+	}
+
+	return strings.EqualFold(gotString, want)
+}
+
+// attributeName lower-cases the attribute and drops the schema URN it may be qualified with.
+func attributeName(path string) string {
+	return strings.ToLower(path[strings.LastIndex(path, ":")+1:])
+}
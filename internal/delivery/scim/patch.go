@@ -0,0 +1,223 @@
+package scim
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type patchRequest struct {
+	Schemas    []string         `json:"schemas"`
+	Operations []patchOperation `json:"Operations"`
+}
+
+type patchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	Value json.RawMessage `json:"value"`
+}
+
+type patchError struct {
+	scimType string
+	detail   string
+}
+
+func (e *patchError) Error() string {
+	return e.detail
+}
+
+func patchErrorf(scimType string, format string, args ...any) error {
+	return &patchError{scimType: scimType, detail: fmt.Sprintf(format, args...)}
+}
+
+func writePatchError(c *gin.Context, err error) {
+	var patchErr *patchError
+	if !errors.As(err, &patchErr) {
+		patchErr = &patchError{scimType: scimTypeInvalidValue, detail: err.Error()}
+	}
+
+	writeError(c, http.StatusBadRequest, patchErr.scimType, patchErr.detail)
+}
+
+// op returns the lower-cased operation, RFC 7644 names them add, replace and remove but
+// some identity providers capitalize them.
+func (o patchOperation) op() (string, error) {
+	op := strings.ToLower(o.Op)
+	if op != "add" && op != "replace" && op != "remove" {
+		return "", patchErrorf(scimTypeInvalidSyntax, "unknown op %q", o.Op)
+	}
+
+	return op, nil
+}
+
+// values splits an operation without a path into one operation per attribute of its value.
+func (o patchOperation) values(op string) ([]patchOperation, error) {
+	if o.Path != "" {
+		return []patchOperation{o}, nil
+	}
+
+	if op == "remove" {
+		return nil, patchErrorf(scimTypeInvalidPath, "remove needs a path")
+	}
+
+	var attrs map[string]json.RawMessage
+	if err := json.Unmarshal(o.Value, &attrs); err != nil {
+		return nil, patchErrorf(scimTypeInvalidValue, "value of an operation without a path must be an object")
+	}
+
+	ops := make([]patchOperation, 0, len(attrs))
+	for path, value := range attrs {
+		ops = append(ops, patchOperation{Op: op, Path: path, Value: value})
+	}
+
+	return ops, nil
+}
+
+// applyUser changes displayName and active, attributes the service does not keep are
+// ignored so identity providers can send their whole mapping.
+func (r patchRequest) applyUser(u *user) error {
+	for _, operation := range r.Operations {
+		op, err := operation.op()
+		if err != nil {
+			return err
+		}
+
+		ops, err := operation.values(op)
+		if err != nil {
+			return err
+		}
+
+		for _, o := range ops {
+			switch attributeName(o.Path) {
+			case "active":
+				if op == "remove" {
+					return patchErrorf(scimTypeMutability, "active cannot be removed")
+				}
+
+				active, err := parseBool(o.Value)
+				if err != nil {
+					return err
+				}
+
+				u.Active = &active
+			case "displayname":
+				u.DisplayName = ""
+
+				if op != "remove" {
+					if err = json.Unmarshal(o.Value, &u.DisplayName); err != nil {
+						return patchErrorf(scimTypeInvalidValue, "displayName must be a string")
+					}
+				}
+			case "username":
+				var userName string
+				if op == "remove" || json.Unmarshal(o.Value, &userName) != nil || userName != u.UserName {
+					return patchErrorf(scimTypeMutability, "userName is the user id and cannot change")
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// applyGroup changes the members. Removing one member takes the members[value eq "id"]
+// path, removing members with a value lists them.
+func (r patchRequest) applyGroup(g *group) error {
+	for _, operation := range r.Operations {
+		op, err := operation.op()
+		if err != nil {
+			return err
+		}
+
+		ops, err := operation.values(op)
+		if err != nil {
+			return err
+		}
+
+		for _, o := range ops {
+			if err = o.applyGroup(op, g); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (o patchOperation) applyGroup(op string, g *group) error {
+	if i := strings.Index(o.Path, "["); i >= 0 && strings.HasSuffix(o.Path, "]") {
+		if attributeName(o.Path[:i]) != "members" || op != "remove" {
+			return patchErrorf(scimTypeInvalidPath, "only members can be removed by filter, got %q", o.Path)
+		}
+
+		f, err := parseFilter(o.Path[i+1:len(o.Path)-1], memberAttributes)
+		if err != nil {
+			return patchErrorf(scimTypeInvalidFilter, "%v", err)
+		}
+
+		g.Members = slices.DeleteFunc(g.Members, func(m member) bool { return match(f, memberAttributes, m) })
+
+		return nil
+	}
+
+	switch attributeName(o.Path) {
+	case "members":
+		var members []member
+		if len(o.Value) > 0 && json.Unmarshal(o.Value, &members) != nil {
+			return patchErrorf(scimTypeInvalidValue, "members must be a list of {\"value\": user id}")
+		}
+
+		switch op {
+		case "add":
+			for _, m := range members {
+				if !slices.ContainsFunc(g.Members, func(e member) bool { return e.Value == m.Value }) {
+					g.Members = append(g.Members, m)
+				}
+			}
+		case "replace":
+			g.Members = members
+		case "remove":
+			if len(members) == 0 {
+				g.Members = nil
+			}
+
+			for _, m := range members {
+				g.Members = slices.DeleteFunc(g.Members, func(e member) bool { return e.Value == m.Value })
+			}
+		}
+	case "displayname":
+		var displayName string
+		if op == "remove" || json.Unmarshal(o.Value, &displayName) != nil || displayName != g.DisplayName {
+			return patchErrorf(scimTypeMutability, "displayName is the team name and cannot change")
+		}
+	default:
+		return patchErrorf(scimTypeInvalidPath, "unsupported path %q", o.Path)
+	}
+
+	return nil
+}
+
+// parseBool takes a JSON boolean or a string like "False", which some identity providers send.
+func parseBool(raw json.RawMessage) (bool, error) {
+	var b bool
+	if err := json.Unmarshal(raw, &b); err == nil {
+		return b, nil
+	}
+
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		switch strings.ToLower(s) {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		}
+	}
+
+	return false, patchErrorf(scimTypeInvalidValue, "active must be a boolean, got %s", raw)
+}
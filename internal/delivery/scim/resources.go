@@ -0,0 +1,365 @@
+package scim
+
+import (
+	"net/http"
+	"strings"
+
+	"avito/internal/entity"
+	"github.com/gin-gonic/gin"
+)
+
+type meta struct {
+	ResourceType string `json:"resourceType"`
+	Location     string `json:"location,omitempty"`
+}
+
+type name struct {
+	Formatted  string `json:"formatted,omitempty"`
+	GivenName  string `json:"givenName,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+}
+
+type member struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+	Ref     string `json:"$ref,omitempty"`
+}
+
+// user is the part of the core User schema the service keeps. Other attributes, such as
+// emails, are accepted and dropped.
+type user struct {
+	Schemas     []string `json:"schemas"`
+	ID          string   `json:"id,omitempty"`
+	UserName    string   `json:"userName"`
+	Name        *name    `json:"name,omitempty"`
+	DisplayName string   `json:"displayName,omitempty"`
+	Active      *bool    `json:"active,omitempty"`
+	Groups      []member `json:"groups,omitempty"`
+	Meta        *meta    `json:"meta,omitempty"`
+}
+
+type group struct {
+	Schemas     []string `json:"schemas"`
+	ID          string   `json:"id,omitempty"`
+	DisplayName string   `json:"displayName"`
+	Members     []member `json:"members"`
+	Meta        *meta    `json:"meta,omitempty"`
+}
+
+type listResponse[T any] struct {
+	Schemas      []string `json:"schemas"`
+	TotalResults int      `json:"totalResults"`
+	StartIndex   int      `json:"startIndex"`
+	ItemsPerPage int      `json:"itemsPerPage"`
+	Resources    []T      `json:"Resources"`
+}
+
+type scimError struct {
+	Schemas  []string `json:"schemas"`
+	Status   string   `json:"status"`
+	ScimType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail,omitempty"`
+}
+
+func (h *handler) toUser(c *gin.Context, u entity.User) user {
+	return user{
+		Schemas:     []string{schemaUser},
+		ID:          u.UserId,
+		UserName:    u.UserId,
+		DisplayName: u.Username,
+		Active:      &u.IsActive,
+		Groups:      []member{{Value: u.TeamName, Display: u.TeamName, Ref: h.location(c, "Groups", u.TeamName)}},
+		Meta:        &meta{ResourceType: "User", Location: h.location(c, "Users", u.UserId)},
+	}
+}
+
+func (h *handler) toGroup(c *gin.Context, t entity.Team) group {
+	members := make([]member, 0, len(t.Members))
+	for _, m := range t.Members {
+		members = append(members, member{Value: m.UserId, Display: m.Username, Ref: h.location(c, "Users", m.UserId)})
+	}
+
+	return group{
+		Schemas:     []string{schemaGroup},
+		ID:          t.TeamName,
+		DisplayName: t.TeamName,
+		Members:     members,
+		Meta:        &meta{ResourceType: "Group", Location: h.location(c, "Groups", t.TeamName)},
+	}
+}
+
+// username is the display name of the user, identity providers fill different fields.
+func (u user) username() string {
+	switch {
+	case u.DisplayName != "":
+		return u.DisplayName
+	case u.Name != nil && u.Name.Formatted != "":
+		return u.Name.Formatted
+	case u.Name != nil && (u.Name.GivenName != "" || u.Name.FamilyName != ""):
+		return strings.TrimSpace(u.Name.GivenName + " " + u.Name.FamilyName)
+	default:
+		return u.UserName
+	}
+}
+
+// toEntity checks the user against the API schema, users are active unless told otherwise.
+func (h *handler) toEntity(c *gin.Context, u user) (*entity.User, bool) {
+	if !check(c, h.userID, "userName", u.UserName) || !check(c, h.username, "displayName", u.username()) {
+		return nil, false
+	}
+
+	isActive := u.Active == nil || *u.Active
+
+	return &entity.User{UserId: u.UserName, Username: u.username(), IsActive: isActive}, true
+}
+
+func (h *handler) listUsers(c *gin.Context) {
+	f, err := parseFilter(c.Query("filter"), userAttributes)
+	if err != nil {
+		writeError(c, http.StatusBadRequest, scimTypeInvalidFilter, err.Error())
+
+		return
+	}
+
+	users, err := h.directory.Users(c.Request.Context())
+	if err != nil {
+		writeServiceError(c, err)
+
+		return
+	}
+
+	resources := make([]user, 0, len(users))
+
+	for _, u := range users {
+		if match(f, userAttributes, u) {
+			resources = append(resources, h.toUser(c, u))
+		}
+	}
+
+	writeList(c, resources)
+}
+
+func (h *handler) createUser(c *gin.Context) {
+	var req user
+	if !decode(c, &req) {
+		return
+	}
+
+	u, ok := h.toEntity(c, req)
+	if !ok {
+		return
+	}
+
+	created, err := h.directory.CreateUser(c.Request.Context(), u)
+	if err != nil {
+		writeServiceError(c, err)
+
+		return
+	}
+
+	c.Header("Location", h.location(c, "Users", created.UserId))
+	write(c, http.StatusCreated, h.toUser(c, *created))
+}
+
+func (h *handler) getUser(c *gin.Context) {
+	u, err := h.directory.User(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		writeServiceError(c, err)
+
+		return
+	}
+
+	write(c, http.StatusOK, h.toUser(c, *u))
+}
+
+func (h *handler) replaceUser(c *gin.Context) {
+	var req user
+	if !decode(c, &req) {
+		return
+	}
+
+	if req.UserName != c.Param("id") {
+		writeError(c, http.StatusBadRequest, scimTypeMutability, "userName is the user id and cannot change")
+
+		return
+	}
+
+	h.updateUser(c, req)
+}
+
+func (h *handler) patchUser(c *gin.Context) {
+	var req patchRequest
+	if !decode(c, &req) {
+		return
+	}
+
+	current, err := h.directory.User(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		writeServiceError(c, err)
+
+		return
+	}
+
+	u := h.toUser(c, *current)
+
+	if err = req.applyUser(&u); err != nil {
+		writePatchError(c, err)
+
+		return
+	}
+
+	h.updateUser(c, u)
+}
+
+func (h *handler) updateUser(c *gin.Context, req user) {
+	u, ok := h.toEntity(c, req)
+	if !ok {
+		return
+	}
+
+	updated, err := h.directory.UpdateUser(c.Request.Context(), u)
+	if err != nil {
+		writeServiceError(c, err)
+
+		return
+	}
+
+	write(c, http.StatusOK, h.toUser(c, *updated))
+}
+
+func (h *handler) deleteUser(c *gin.Context) {
+	if err := h.directory.DeleteUser(c.Request.Context(), c.Param("id")); err != nil {
+		writeServiceError(c, err)
+
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *handler) listGroups(c *gin.Context) {
+	f, err := parseFilter(c.Query("filter"), groupAttributes)
+	if err != nil {
+		writeError(c, http.StatusBadRequest, scimTypeInvalidFilter, err.Error())
+
+		return
+	}
+
+	teams, err := h.directory.Teams(c.Request.Context())
+	if err != nil {
+		writeServiceError(c, err)
+
+		return
+	}
+
+	resources := make([]group, 0, len(teams))
+
+	for _, t := range teams {
+		if match(f, groupAttributes, t) {
+			resources = append(resources, h.toGroup(c, t))
+		}
+	}
+
+	writeList(c, resources)
+}
+
+func (h *handler) createGroup(c *gin.Context) {
+	var req group
+	if !decode(c, &req) {
+		return
+	}
+
+	if !check(c, h.teamName, "displayName", req.DisplayName) {
+		return
+	}
+
+	created, err := h.directory.CreateTeam(c.Request.Context(), req.DisplayName, memberIDs(req.Members))
+	if err != nil {
+		writeServiceError(c, err)
+
+		return
+	}
+
+	c.Header("Location", h.location(c, "Groups", created.TeamName))
+	write(c, http.StatusCreated, h.toGroup(c, *created))
+}
+
+func (h *handler) getGroup(c *gin.Context) {
+	t, err := h.directory.Team(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		writeServiceError(c, err)
+
+		return
+	}
+
+	write(c, http.StatusOK, h.toGroup(c, *t))
+}
+
+func (h *handler) replaceGroup(c *gin.Context) {
+	var req group
+	if !decode(c, &req) {
+		return
+	}
+
+	if req.DisplayName != c.Param("id") {
+		writeError(c, http.StatusBadRequest, scimTypeMutability, "displayName is the team name and cannot change")
+
+		return
+	}
+
+	h.setMembers(c, req)
+}
+
+func (h *handler) patchGroup(c *gin.Context) {
+	var req patchRequest
+	if !decode(c, &req) {
+		return
+	}
+
+	current, err := h.directory.Team(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		writeServiceError(c, err)
+
+		return
+	}
+
+	g := h.toGroup(c, *current)
+
+	if err = req.applyGroup(&g); err != nil {
+		writePatchError(c, err)
+
+		return
+	}
+
+	h.setMembers(c, g)
+}
+
+func (h *handler) setMembers(c *gin.Context, req group) {
+	updated, err := h.directory.SetMembers(c.Request.Context(), req.DisplayName, memberIDs(req.Members))
+	if err != nil {
+		writeServiceError(c, err)
+
+		return
+	}
+
+	write(c, http.StatusOK, h.toGroup(c, *updated))
+}
+
+func (h *handler) deleteGroup(c *gin.Context) {
+	if err := h.directory.DeleteTeam(c.Request.Context(), c.Param("id")); err != nil {
+		writeServiceError(c, err)
+
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func memberIDs(members []member) []string {
+	ids := make([]string, 0, len(members))
+	for _, m := range members {
+		ids = append(ids, m.Value)
+	}
+
+	return ids
+}
@@ -0,0 +1,277 @@
+// Package scim serves SCIM 2.0 (RFC 7643, RFC 7644) provisioning for identity providers.
+// Users map to users rows and Groups to teams, both keyed by the names the rest of the
+// API uses: a User id is its userName and user_id, a Group id is its displayName and
+// team_name. Neither can be renamed.
+package scim
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"avito/internal/cerr"
+	"avito/internal/gen"
+	"avito/internal/service"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	ContentType = "application/scim+json"
+
+	schemaUser         = "urn:ietf:params:scim:schemas:core:2.0:User"
+	schemaGroup        = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	schemaListResponse = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	schemaPatchOp      = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	schemaError        = "urn:ietf:params:scim:api:messages:2.0:Error"
+	schemaConfig       = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+)
+
+// maxResults caps the page size of list responses.
+const maxResults = 200
+
+// Error types of RFC 7644 section 3.12.
+const (
+	scimTypeInvalidFilter = "invalidFilter"
+	scimTypeInvalidSyntax = "invalidSyntax"
+	scimTypeInvalidPath   = "invalidPath"
+	scimTypeInvalidValue  = "invalidValue"
+	scimTypeMutability    = "mutability"
+	scimTypeUniqueness    = "uniqueness"
+)
+
+type handler struct {
+	directory service.Directory
+	base      string
+
+	userID   *openapi3.Schema
+	username *openapi3.Schema
+	teamName *openapi3.Schema
+}
+
+// InitHandler mounts the SCIM endpoints on g, every request needs the Bearer token.
+// Names are checked against the OpenAPI schema of /team/add, so SCIM accepts the same
+// ids as the rest of the API.
+func InitHandler(g *gin.RouterGroup, directory service.Directory, swagger *openapi3.T, token string) error {
+	if token == "" {
+		return errors.New("scim token is empty")
+	}
+
+	member, ok := swagger.Components.Schemas["TeamMember"]
+	if !ok {
+		return errors.New("TeamMember schema is missing")
+	}
+
+	team, ok := swagger.Components.Schemas["Team"]
+	if !ok {
+		return errors.New("Team schema is missing")
+	}
+
+	h := &handler{
+		directory: directory,
+		base:      g.BasePath(),
+		userID:    member.Value.Properties["user_id"].Value,
+		username:  member.Value.Properties["username"].Value,
+		teamName:  team.Value.Properties["team_name"].Value,
+	}
+
+	g.Use(bearer(token))
+
+	g.GET("/ServiceProviderConfig", h.serviceProviderConfig)
+
+	g.GET("/Users", h.listUsers)
+	g.POST("/Users", h.createUser)
+	g.GET("/Users/:id", h.getUser)
+	g.PUT("/Users/:id", h.replaceUser)
+	g.PATCH("/Users/:id", h.patchUser)
+	g.DELETE("/Users/:id", h.deleteUser)
+
+	g.GET("/Groups", h.listGroups)
+	g.POST("/Groups", h.createGroup)
+	g.GET("/Groups/:id", h.getGroup)
+	g.PUT("/Groups/:id", h.replaceGroup)
+	g.PATCH("/Groups/:id", h.patchGroup)
+	g.DELETE("/Groups/:id", h.deleteGroup)
+
+	return nil
+}
+
+func bearer(token string) gin.HandlerFunc {
+	want := []byte("Bearer " + token)
+
+	return func(c *gin.Context) {
+		if subtle.ConstantTimeCompare([]byte(c.GetHeader("Authorization")), want) != 1 {
+			c.Header("WWW-Authenticate", `Bearer realm="scim"`)
+			writeError(c, http.StatusUnauthorized, "", "a valid Bearer token is required")
+			c.Abort()
+
+			return
+		}
+
+		c.Next()
+	}
+}
+
+func (h *handler) serviceProviderConfig(c *gin.Context) {
+	supported := func(ok bool) map[string]bool { return map[string]bool{"supported": ok} }
+
+	write(c, http.StatusOK, map[string]any{
+		"schemas":        []string{schemaConfig},
+		"patch":          supported(true),
+		"bulk":           map[string]any{"supported": false, "maxOperations": 0, "maxPayloadSize": 0},
+		"filter":         map[string]any{"supported": true, "maxResults": maxResults},
+		"changePassword": supported(false),
+		"sort":           supported(false),
+		"etag":           supported(false),
+		"authenticationSchemes": []map[string]any{{
+			"type":        "oauthbearertoken",
+			"name":        "Bearer token",
+			"description": "The token set in SCIM_TOKEN",
+			"primary":     true,
+		}},
+	})
+}
+
+// location is the absolute URL of a resource, identity providers keep it as meta.location.
+func (h *handler) location(c *gin.Context, resource string, id string) string {
+	scheme := "http"
+	if c.Request.TLS != nil {
+		scheme = "https"
+	}
+
+	if proto := c.GetHeader("X-Forwarded-Proto"); proto != "" {
+		scheme = proto
+	}
+
+	return fmt.Sprintf("%s://%s%s/%s/%s", scheme, c.Request.Host, h.base, resource, id)
+}
+
+// page reads startIndex and count, which are 1-based and optional.
+func page(c *gin.Context) (int, int, error) {
+	startIndex, count := 1, maxResults
+
+	if v := c.Query("startIndex"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return 0, 0, fmt.Errorf("startIndex %q is not a number", v)
+		}
+
+		startIndex = max(n, 1)
+	}
+
+	if v := c.Query("count"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return 0, 0, fmt.Errorf("count %q is not a number", v)
+		}
+
+		count = min(max(n, 0), maxResults)
+	}
+
+	return startIndex, count, nil
+}
+
+func writeList[T any](c *gin.Context, resources []T) {
+	startIndex, count, err := page(c)
+	if err != nil {
+		writeError(c, http.StatusBadRequest, scimTypeInvalidValue, err.Error())
+
+		return
+	}
+
+	total := len(resources)
+	from := min(startIndex-1, total)
+	to := min(from+count, total)
+
+	write(c, http.StatusOK, listResponse[T]{
+		Schemas:      []string{schemaListResponse},
+		TotalResults: total,
+		StartIndex:   startIndex,
+		ItemsPerPage: to - from,
+		Resources:    append([]T{}, resources[from:to]...),
+	})
+}
+
+func write(c *gin.Context, code int, v any) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		writeServiceError(c, err)
+
+		return
+	}
+
+	c.Data(code, ContentType, data)
+}
+
+func writeError(c *gin.Context, code int, scimType string, detail string) {
+	write(c, code, scimError{
+		Schemas:  []string{schemaError},
+		Status:   strconv.Itoa(code),
+		ScimType: scimType,
+		Detail:   detail,
+	})
+}
+
+// writeServiceError goes through cerr.HandleErrsCtx like the other APIs, so the status,
+// the logging and the correlation ID of server errors are the same.
+func writeServiceError(c *gin.Context, err error) {
+	code, response := cerr.HandleErrsCtx(c.Request.Context(), err)
+
+	detail := response.Error.Message
+	scimType := ""
+
+	switch response.Error.Code {
+	case gen.TEAMEXISTS, gen.USEREXISTS:
+		code, scimType = http.StatusConflict, scimTypeUniqueness
+	case gen.VALIDATIONERROR:
+		scimType = scimTypeInvalidValue
+
+		if response.Error.Details != nil {
+			reasons := make([]string, 0, len(*response.Error.Details))
+			for _, d := range *response.Error.Details {
+				reasons = append(reasons, d.Field+": "+d.Reason)
+			}
+
+			detail = strings.Join(reasons, "; ")
+		}
+	case gen.SERVICEUNAVAILABLE:
+		c.Header("Retry-After", strconv.Itoa(cerr.RetryAfter))
+	}
+
+	if response.Error.CorrelationId != nil {
+		detail = fmt.Sprintf("%s, correlation_id %s", detail, *response.Error.CorrelationId)
+	}
+
+	writeError(c, code, scimType, detail)
+}
+
+// decode reads a JSON body, identity providers send it as application/scim+json.
+func decode(c *gin.Context, v any) bool {
+	if err := json.NewDecoder(c.Request.Body).Decode(v); err != nil {
+		writeError(c, http.StatusBadRequest, scimTypeInvalidSyntax, fmt.Sprintf("body is not valid JSON: %v", err))
+
+		return false
+	}
+
+	return true
+}
+
+// check validates a value against a schema of the OpenAPI spec.
+func check(c *gin.Context, schema *openapi3.Schema, field string, value string) bool {
+	if err := schema.VisitJSON(value); err != nil {
+		var schemaErr *openapi3.SchemaError
+		if errors.As(err, &schemaErr) {
+			err = errors.New(schemaErr.Reason)
+		}
+
+		writeError(c, http.StatusBadRequest, scimTypeInvalidValue, fmt.Sprintf("%s: %v", field, err))
+
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,283 @@
+package scim_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"avito/internal/delivery/scim"
+	"avito/internal/entity"
+	"avito/internal/gen"
+	"avito/internal/repo"
+	"avito/internal/repo/memory"
+	"avito/internal/service/directory"
+	"avito/internal/service/user"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const token = "secret"
+
+type server struct {
+	g     *gin.Engine
+	repos repo.Repos
+}
+
+func newServer(t *testing.T) *server {
+	t.Helper()
+
+	gin.SetMode(gin.TestMode)
+
+	store := memory.InitStore()
+	repos := repo.Repos{
+		Team:        memory.InitTeamRepo(store),
+		User:        memory.InitUserRepo(store),
+		PullRequest: memory.InitPullRequestRepo(store),
+	}
+
+	swagger, err := gen.GetSwagger()
+	require.NoError(t, err)
+
+	g := gin.New()
+	dir := directory.InitDirectoryServ(repos.Team, user.InitUserServ(repos.User), "unassigned")
+	require.NoError(t, scim.InitHandler(g.Group("/scim/v2"), dir, swagger, token))
+
+	return &server{g: g, repos: repos}
+}
+
+// do sends a request with the token and decodes the response body into a map.
+func (s *server) do(t *testing.T, method string, path string, body string) (int, map[string]any) {
+	t.Helper()
+
+	req := httptest.NewRequest(method, "/scim/v2"+path, bytes.NewBufferString(body))
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", scim.ContentType)
+
+	rec := httptest.NewRecorder()
+	s.g.ServeHTTP(rec, req)
+
+	var res map[string]any
+	if rec.Body.Len() > 0 {
+		assert.Equal(t, scim.ContentType, rec.Header().Get("Content-Type"))
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res), rec.Body.String())
+	}
+
+	return rec.Code, res
+}
+
+func groupOf(t *testing.T, u map[string]any) string {
+	t.Helper()
+
+	groups, ok := u["groups"].([]any)
+	require.True(t, ok, u)
+	require.Len(t, groups, 1)
+
+	return groups[0].(map[string]any)["value"].(string)
+}
+
+func memberIDs(g map[string]any) []string {
+	var ids []string
+	for _, m := range g["members"].([]any) {
+		ids = append(ids, m.(map[string]any)["value"].(string))
+	}
+
+	return ids
+}
+
+func TestAuth(t *testing.T) {
+	s := newServer(t)
+
+	for _, header := range []string{"", "Bearer wrong", token} {
+		req := httptest.NewRequest(http.MethodGet, "/scim/v2/Users", nil)
+		if header != "" {
+			req.Header.Set("Authorization", header)
+		}
+
+		rec := httptest.NewRecorder()
+		s.g.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code, header)
+	}
+
+	code, res := s.do(t, http.MethodGet, "/ServiceProviderConfig", "")
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, map[string]any{"supported": true}, res["patch"])
+}
+
+func TestUsers(t *testing.T) {
+	s := newServer(t)
+
+	code, res := s.do(t, http.MethodPost, "/Users", `{
+		"schemas": ["urn:ietf:params:scim:schemas:core:2.0:User"],
+		"userName": "alice", "name": {"givenName": "Alice", "familyName": "Smith"},
+		"emails": [{"value": "alice@example.com", "primary": true}]
+	}`)
+	require.Equal(t, http.StatusCreated, code, res)
+	assert.Equal(t, "alice", res["id"])
+	assert.Equal(t, "Alice Smith", res["displayName"])
+	assert.Equal(t, true, res["active"])
+	assert.Equal(t, "unassigned", groupOf(t, res))
+
+	code, res = s.do(t, http.MethodPost, "/Users", `{"userName": "alice"}`)
+	require.Equal(t, http.StatusConflict, code)
+	assert.Equal(t, "uniqueness", res["scimType"])
+	assert.Equal(t, "409", res["status"])
+
+	code, res = s.do(t, http.MethodPost, "/Users", `{"userName": "alice@example.com"}`)
+	require.Equal(t, http.StatusBadRequest, code)
+	assert.Equal(t, "invalidValue", res["scimType"])
+
+	code, _ = s.do(t, http.MethodPost, "/Users", `{"userName": "bob", "displayName": "Bob", "active": false}`)
+	require.Equal(t, http.StatusCreated, code)
+
+	code, res = s.do(t, http.MethodGet, `/Users?filter=userName%20eq%20%22ALICE%22`, "")
+	require.Equal(t, http.StatusOK, code)
+	assert.EqualValues(t, 1, res["totalResults"])
+	assert.Equal(t, "alice", res["Resources"].([]any)[0].(map[string]any)["id"])
+
+	code, res = s.do(t, http.MethodGet, `/Users?filter=active%20eq%20false`, "")
+	require.Equal(t, http.StatusOK, code)
+	assert.EqualValues(t, 1, res["totalResults"])
+
+	code, res = s.do(t, http.MethodGet, `/Users?filter=userName%20co%20%22a%22`, "")
+	require.Equal(t, http.StatusBadRequest, code)
+	assert.Equal(t, "invalidFilter", res["scimType"])
+
+	code, res = s.do(t, http.MethodGet, "/Users?startIndex=2&count=1", "")
+	require.Equal(t, http.StatusOK, code)
+	assert.EqualValues(t, 2, res["totalResults"])
+	assert.EqualValues(t, 1, res["itemsPerPage"])
+	assert.Equal(t, "bob", res["Resources"].([]any)[0].(map[string]any)["id"])
+
+	// Some identity providers send booleans as strings.
+	code, res = s.do(t, http.MethodPatch, "/Users/bob", `{
+		"schemas": ["urn:ietf:params:scim:api:messages:2.0:PatchOp"],
+		"Operations": [
+			{"op": "Replace", "path": "active", "value": "True"},
+			{"op": "replace", "value": {"displayName": "Robert", "title": "Engineer"}}
+		]
+	}`)
+	require.Equal(t, http.StatusOK, code, res)
+	assert.Equal(t, true, res["active"])
+	assert.Equal(t, "Robert", res["displayName"])
+
+	code, res = s.do(t, http.MethodPatch, "/Users/bob", `{"Operations": [{"op": "replace", "path": "userName", "value": "rob"}]}`)
+	require.Equal(t, http.StatusBadRequest, code)
+	assert.Equal(t, "mutability", res["scimType"])
+
+	code, res = s.do(t, http.MethodPut, "/Users/bob", `{"userName": "bob", "displayName": "Bob", "active": false}`)
+	require.Equal(t, http.StatusOK, code, res)
+	assert.Equal(t, false, res["active"])
+	assert.Equal(t, "Bob", res["displayName"])
+
+	code, _ = s.do(t, http.MethodGet, "/Users/missing", "")
+	assert.Equal(t, http.StatusNotFound, code)
+}
+
+func TestGroups(t *testing.T) {
+	s := newServer(t)
+
+	for _, name := range []string{"alice", "bob", "carol"} {
+		code, res := s.do(t, http.MethodPost, "/Users", `{"userName": "`+name+`"}`)
+		require.Equal(t, http.StatusCreated, code, res)
+	}
+
+	code, res := s.do(t, http.MethodPost, "/Groups", `{"displayName": "backend", "members": [{"value": "alice"}, {"value": "bob"}]}`)
+	require.Equal(t, http.StatusCreated, code, res)
+	assert.Equal(t, "backend", res["id"])
+	assert.ElementsMatch(t, []string{"alice", "bob"}, memberIDs(res))
+
+	code, res = s.do(t, http.MethodPost, "/Groups", `{"displayName": "backend"}`)
+	require.Equal(t, http.StatusConflict, code)
+	assert.Equal(t, "uniqueness", res["scimType"])
+
+	code, res = s.do(t, http.MethodPost, "/Groups", `{"displayName": "frontend", "members": [{"value": "nobody"}]}`)
+	require.Equal(t, http.StatusBadRequest, code)
+	assert.Contains(t, res["detail"], "nobody")
+
+	code, res = s.do(t, http.MethodGet, "/Users/alice", "")
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "backend", groupOf(t, res))
+
+	code, res = s.do(t, http.MethodPatch, "/Groups/backend", `{"Operations": [
+		{"op": "add", "path": "members", "value": [{"value": "carol"}]},
+		{"op": "remove", "path": "members[value eq \"bob\"]"}
+	]}`)
+	require.Equal(t, http.StatusOK, code, res)
+	assert.ElementsMatch(t, []string{"alice", "carol"}, memberIDs(res))
+
+	code, res = s.do(t, http.MethodGet, "/Users/bob", "")
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "unassigned", groupOf(t, res))
+
+	code, res = s.do(t, http.MethodGet, `/Groups?filter=displayName%20eq%20%22backend%22`, "")
+	require.Equal(t, http.StatusOK, code)
+	assert.EqualValues(t, 1, res["totalResults"])
+
+	code, res = s.do(t, http.MethodPatch, "/Groups/backend", `{"Operations": [{"op": "replace", "path": "displayName", "value": "platform"}]}`)
+	require.Equal(t, http.StatusBadRequest, code)
+	assert.Equal(t, "mutability", res["scimType"])
+
+	code, res = s.do(t, http.MethodPut, "/Groups/backend", `{"displayName": "backend", "members": [{"value": "alice"}]}`)
+	require.Equal(t, http.StatusOK, code, res)
+	assert.Equal(t, []string{"alice"}, memberIDs(res))
+
+	code, res = s.do(t, http.MethodDelete, "/Groups/unassigned", "")
+	require.Equal(t, http.StatusBadRequest, code)
+	assert.Equal(t, "invalidValue", res["scimType"])
+
+	code, _ = s.do(t, http.MethodDelete, "/Groups/backend", "")
+	require.Equal(t, http.StatusNoContent, code)
+
+	code, _ = s.do(t, http.MethodGet, "/Groups/backend", "")
+	assert.Equal(t, http.StatusNotFound, code)
+
+	code, res = s.do(t, http.MethodGet, "/Users/alice", "")
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "unassigned", groupOf(t, res))
+}
+
+func TestDeleteUserReassignsReviews(t *testing.T) {
+	s := newServer(t)
+	ctx := context.Background()
+
+	for _, name := range []string{"author", "u1", "u2", "u3"} {
+		code, res := s.do(t, http.MethodPost, "/Users", `{"userName": "`+name+`"}`)
+		require.Equal(t, http.StatusCreated, code, res)
+	}
+
+	code, res := s.do(t, http.MethodPost, "/Groups", `{"displayName": "backend", "members": [{"value": "author"}, {"value": "u1"}, {"value": "u2"}]}`)
+	require.Equal(t, http.StatusCreated, code, res)
+
+	pr, err := s.repos.PullRequest.Create(ctx, &entity.PullRequestCreate{PullRequestId: "pr-1", PullRequestName: "Add search", AuthorId: "author"})
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"u1", "u2"}, pr.AssignedReviewers)
+
+	code, res = s.do(t, http.MethodPatch, "/Groups/backend", `{"Operations": [{"op": "add", "path": "members", "value": [{"value": "u3"}]}]}`)
+	require.Equal(t, http.StatusOK, code, res)
+
+	code, _ = s.do(t, http.MethodDelete, "/Users/u1", "")
+	require.Equal(t, http.StatusNoContent, code)
+
+	code, res = s.do(t, http.MethodGet, "/Users/u1", "")
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, false, res["active"])
+
+	reviews, err := s.repos.User.GetReview(ctx, "u3")
+	require.NoError(t, err)
+	require.Len(t, reviews, 1)
+	assert.Equal(t, "pr-1", reviews[0].PullRequestId)
+
+	// Deactivation alone goes through SetIsActive and keeps the reviews.
+	code, res = s.do(t, http.MethodPatch, "/Users/u2", `{"Operations": [{"op": "replace", "path": "active", "value": false}]}`)
+	require.Equal(t, http.StatusOK, code, res)
+	assert.Equal(t, false, res["active"])
+
+	reviews, err = s.repos.User.GetReview(ctx, "u2")
+	require.NoError(t, err)
+	assert.Len(t, reviews, 1)
+}
@@ -62,3 +62,113 @@ type TeamStat struct {
 	UsersStat   []UserStat `json:"users_stat"`
 	AvgDuration float64    `json:"avg_duration"`
 }
+
+type History struct {
+	Teams        []Team               `json:"teams"`
+	PullRequests []PullRequestHistory `json:"pull_requests"`
+}
+
+type PullRequestHistory struct {
+	AssignedReviewers []string   `json:"assigned_reviewers"`
+	AuthorId          string     `json:"author_id"`
+	CreatedAt         time.Time  `json:"createdAt"`
+	MergedAt          *time.Time `json:"mergedAt"`
+	PullRequestId     string     `json:"pull_request_id"`
+}
+
+type TeamChangeAction string
+
+const (
+	ActionAddTeam      TeamChangeAction = "ADD_TEAM"
+	ActionAddMember    TeamChangeAction = "ADD_MEMBER"
+	ActionMoveMember   TeamChangeAction = "MOVE_MEMBER"
+	ActionRemoveMember TeamChangeAction = "REMOVE_MEMBER"
+	ActionActivate     TeamChangeAction = "ACTIVATE"
+	ActionDeactivate   TeamChangeAction = "DEACTIVATE"
+	ActionRename       TeamChangeAction = "RENAME"
+	// ActionRemoveTeam deletes a team that has no members left. The team sync never
+	// plans it, SCIM uses it to delete groups.
+	ActionRemoveTeam TeamChangeAction = "REMOVE_TEAM"
+)
+
+// TeamChange is one step of a TeamPlan. Member changes other than a removal carry the
+// whole desired state of the user, so applying them is an upsert of the user row.
+type TeamChange struct {
+	Action       TeamChangeAction `json:"action"`
+	TeamName     string           `json:"team_name"`
+	UserId       string           `json:"user_id"`
+	Username     string           `json:"username"`
+	IsActive     bool             `json:"is_active"`
+	FromTeam     string           `json:"from_team"`
+	FromUsername string           `json:"from_username"`
+}
+
+type Reassignment struct {
+	PullRequestId string  `json:"pull_request_id"`
+	OldReviewerId string  `json:"old_reviewer_id"`
+	NewReviewerId *string `json:"new_reviewer_id"`
+}
+
+type TeamPlan struct {
+	DryRun        bool           `json:"dry_run"`
+	Changes       []TeamChange   `json:"changes"`
+	Reassignments []Reassignment `json:"reassignments"`
+}
+
+// UserErasure records that a user was erased. The id the user had is not kept, only the
+// pseudonym their pull requests and reviews carry from then on.
+type UserErasure struct {
+	Pseudonym         string    `json:"pseudonym"`
+	TeamName          string    `json:"team_name"`
+	ErasedAt          time.Time `json:"erased_at"`
+	ReassignedReviews int       `json:"reassigned_reviews"`
+}
+
+// NotificationSettings are the mails a user gets. Without an email there are none, the
+// two switches are opt-outs and start on.
+type NotificationSettings struct {
+	UserId           string  `json:"user_id"`
+	Email            *string `json:"email"`
+	EmailAssignments bool    `json:"email_assignments"`
+	EmailDigest      bool    `json:"email_digest"`
+}
+
+// TeamSLA is how long reviewers of the team's pull requests have. After RemindAfter the
+// reviewer gets a reminder, after EscalateAfter the lead is told and, with AutoReassign,
+// the review goes to another teammate. Both count from the assignment.
+type TeamSLA struct {
+	TeamName      string        `json:"team_name"`
+	RemindAfter   time.Duration `json:"remind_after"`
+	EscalateAfter time.Duration `json:"escalate_after"`
+	LeadId        *string       `json:"lead_id"`
+	AutoReassign  bool          `json:"auto_reassign"`
+}
+
+// PendingReview is a review of an open pull request whose author's team has an SLA.
+type PendingReview struct {
+	PullRequestId   string     `json:"pull_request_id"`
+	PullRequestName string     `json:"pull_request_name"`
+	AuthorId        string     `json:"author_id"`
+	ReviewerId      string     `json:"reviewer_id"`
+	TeamName        string     `json:"team_name"`
+	AssignedAt      time.Time  `json:"assigned_at"`
+	RemindedAt      *time.Time `json:"reminded_at"`
+	EscalatedAt     *time.Time `json:"escalated_at"`
+}
+
+type SLAEventKind string
+
+const (
+	SLAReminder   SLAEventKind = "REMINDER"
+	SLAEscalation SLAEventKind = "ESCALATION"
+)
+
+type SLAEvent struct {
+	Kind   SLAEventKind  `json:"kind"`
+	Review PendingReview `json:"review"`
+	SLA    TeamSLA       `json:"sla"`
+	// Waited is how long the review has waited since the assignment.
+	Waited time.Duration `json:"waited"`
+	// ReassignedTo is the new reviewer when the escalation handed the review off.
+	ReassignedTo *string `json:"reassigned_to"`
+}
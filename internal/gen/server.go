@@ -16,6 +16,18 @@ import (
 
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// Вернуть PR из архива
+	// (POST /admin/pullRequests/restore)
+	PostAdminPullRequestsRestore(c *gin.Context)
+	// Привести команды и участников к заданному составу
+	// (POST /admin/teams/apply)
+	PostAdminTeamsApply(c *gin.Context, params PostAdminTeamsApplyParams)
+	// Стереть пользователя
+	// (POST /admin/users/erase)
+	PostAdminUsersErase(c *gin.Context)
+	// Журнал стираний
+	// (GET /admin/users/erasures)
+	GetAdminUsersErasures(c *gin.Context)
 	// Создать PR и автоматически назначить до 2 ревьюверов из команды автора
 	// (POST /pullRequest/create)
 	PostPullRequestCreate(c *gin.Context)
@@ -37,12 +49,27 @@ type ServerInterface interface {
 	// Получить команду с участниками
 	// (GET /team/get)
 	GetTeamGet(c *gin.Context, params GetTeamGetParams)
+	// Получить SLA ревью команды
+	// (GET /team/getSla)
+	GetTeamGetSla(c *gin.Context, params GetTeamGetSlaParams)
+	// Снять SLA ревью команды
+	// (POST /team/removeSla)
+	PostTeamRemoveSla(c *gin.Context)
+	// Задать SLA ревью команды
+	// (POST /team/setSla)
+	PostTeamSetSla(c *gin.Context)
+	// Получить настройки писем пользователя
+	// (GET /users/getNotifications)
+	GetUsersGetNotifications(c *gin.Context, params GetUsersGetNotificationsParams)
 	// Получить PR'ы, где пользователь назначен ревьювером
 	// (GET /users/getReview)
 	GetUsersGetReview(c *gin.Context, params GetUsersGetReviewParams)
 	// Установить флаг активности пользователя
 	// (POST /users/setIsActive)
 	PostUsersSetIsActive(c *gin.Context)
+	// Задать адрес и подписки на письма пользователя
+	// (POST /users/setNotifications)
+	PostUsersSetNotifications(c *gin.Context)
 }
 
 // ServerInterfaceWrapper converts contexts to parameters.
@@ -54,9 +81,100 @@ type ServerInterfaceWrapper struct {
 
 type MiddlewareFunc func(c *gin.Context)
 
+// PostAdminPullRequestsRestore operation middleware
+func (siw *ServerInterfaceWrapper) PostAdminPullRequestsRestore(c *gin.Context) {
+
+	c.Set(AdminTokenScopes, []string{})
+
+	c.Set(ApiKeyScopes, []string{})
+
+	c.Set(AdminTokenScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostAdminPullRequestsRestore(c)
+}
+
+// PostAdminTeamsApply operation middleware
+func (siw *ServerInterfaceWrapper) PostAdminTeamsApply(c *gin.Context) {
+
+	var err error
+
+	c.Set(AdminTokenScopes, []string{})
+
+	c.Set(ApiKeyScopes, []string{})
+
+	c.Set(AdminTokenScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostAdminTeamsApplyParams
+
+	// ------------- Optional query parameter "dry_run" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "dry_run", c.Request.URL.Query(), &params.DryRun)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter dry_run: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostAdminTeamsApply(c, params)
+}
+
+// PostAdminUsersErase operation middleware
+func (siw *ServerInterfaceWrapper) PostAdminUsersErase(c *gin.Context) {
+
+	c.Set(AdminTokenScopes, []string{})
+
+	c.Set(ApiKeyScopes, []string{})
+
+	c.Set(AdminTokenScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostAdminUsersErase(c)
+}
+
+// GetAdminUsersErasures operation middleware
+func (siw *ServerInterfaceWrapper) GetAdminUsersErasures(c *gin.Context) {
+
+	c.Set(AdminTokenScopes, []string{})
+
+	c.Set(ApiKeyScopes, []string{})
+
+	c.Set(AdminTokenScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetAdminUsersErasures(c)
+}
+
 // PostPullRequestCreate operation middleware
 func (siw *ServerInterfaceWrapper) PostPullRequestCreate(c *gin.Context) {
 
+	c.Set(ApiKeyScopes, []string{})
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 		if c.IsAborted() {
@@ -70,6 +188,8 @@ func (siw *ServerInterfaceWrapper) PostPullRequestCreate(c *gin.Context) {
 // PostPullRequestMerge operation middleware
 func (siw *ServerInterfaceWrapper) PostPullRequestMerge(c *gin.Context) {
 
+	c.Set(ApiKeyScopes, []string{})
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 		if c.IsAborted() {
@@ -83,6 +203,8 @@ func (siw *ServerInterfaceWrapper) PostPullRequestMerge(c *gin.Context) {
 // PostPullRequestReassign operation middleware
 func (siw *ServerInterfaceWrapper) PostPullRequestReassign(c *gin.Context) {
 
+	c.Set(ApiKeyScopes, []string{})
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 		if c.IsAborted() {
@@ -98,6 +220,8 @@ func (siw *ServerInterfaceWrapper) GetStatisticsTeam(c *gin.Context) {
 
 	var err error
 
+	c.Set(ApiKeyScopes, []string{})
+
 	// Parameter object where we will unmarshal all parameters from the context
 	var params GetStatisticsTeamParams
 
@@ -131,6 +255,8 @@ func (siw *ServerInterfaceWrapper) GetStatisticsUser(c *gin.Context) {
 
 	var err error
 
+	c.Set(ApiKeyScopes, []string{})
+
 	// Parameter object where we will unmarshal all parameters from the context
 	var params GetStatisticsUserParams
 
@@ -162,6 +288,8 @@ func (siw *ServerInterfaceWrapper) GetStatisticsUser(c *gin.Context) {
 // PostTeamAdd operation middleware
 func (siw *ServerInterfaceWrapper) PostTeamAdd(c *gin.Context) {
 
+	c.Set(ApiKeyScopes, []string{})
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 		if c.IsAborted() {
@@ -177,6 +305,8 @@ func (siw *ServerInterfaceWrapper) GetTeamGet(c *gin.Context) {
 
 	var err error
 
+	c.Set(ApiKeyScopes, []string{})
+
 	// Parameter object where we will unmarshal all parameters from the context
 	var params GetTeamGetParams
 
@@ -205,11 +335,113 @@ func (siw *ServerInterfaceWrapper) GetTeamGet(c *gin.Context) {
 	siw.Handler.GetTeamGet(c, params)
 }
 
+// GetTeamGetSla operation middleware
+func (siw *ServerInterfaceWrapper) GetTeamGetSla(c *gin.Context) {
+
+	var err error
+
+	c.Set(ApiKeyScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetTeamGetSlaParams
+
+	// ------------- Required query parameter "team_name" -------------
+
+	if paramValue := c.Query("team_name"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument team_name is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "team_name", c.Request.URL.Query(), &params.TeamName)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter team_name: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetTeamGetSla(c, params)
+}
+
+// PostTeamRemoveSla operation middleware
+func (siw *ServerInterfaceWrapper) PostTeamRemoveSla(c *gin.Context) {
+
+	c.Set(ApiKeyScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostTeamRemoveSla(c)
+}
+
+// PostTeamSetSla operation middleware
+func (siw *ServerInterfaceWrapper) PostTeamSetSla(c *gin.Context) {
+
+	c.Set(ApiKeyScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostTeamSetSla(c)
+}
+
+// GetUsersGetNotifications operation middleware
+func (siw *ServerInterfaceWrapper) GetUsersGetNotifications(c *gin.Context) {
+
+	var err error
+
+	c.Set(ApiKeyScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetUsersGetNotificationsParams
+
+	// ------------- Required query parameter "user_id" -------------
+
+	if paramValue := c.Query("user_id"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument user_id is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "user_id", c.Request.URL.Query(), &params.UserId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetUsersGetNotifications(c, params)
+}
+
 // GetUsersGetReview operation middleware
 func (siw *ServerInterfaceWrapper) GetUsersGetReview(c *gin.Context) {
 
 	var err error
 
+	c.Set(ApiKeyScopes, []string{})
+
 	// Parameter object where we will unmarshal all parameters from the context
 	var params GetUsersGetReviewParams
 
@@ -241,6 +473,8 @@ func (siw *ServerInterfaceWrapper) GetUsersGetReview(c *gin.Context) {
 // PostUsersSetIsActive operation middleware
 func (siw *ServerInterfaceWrapper) PostUsersSetIsActive(c *gin.Context) {
 
+	c.Set(ApiKeyScopes, []string{})
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 		if c.IsAborted() {
@@ -251,6 +485,21 @@ func (siw *ServerInterfaceWrapper) PostUsersSetIsActive(c *gin.Context) {
 	siw.Handler.PostUsersSetIsActive(c)
 }
 
+// PostUsersSetNotifications operation middleware
+func (siw *ServerInterfaceWrapper) PostUsersSetNotifications(c *gin.Context) {
+
+	c.Set(ApiKeyScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostUsersSetNotifications(c)
+}
+
 // GinServerOptions provides options for the Gin server.
 type GinServerOptions struct {
 	BaseURL      string
@@ -278,6 +527,10 @@ func RegisterHandlersWithOptions(router gin.IRouter, si ServerInterface, options
 		ErrorHandler:       errorHandler,
 	}
 
+	router.POST(options.BaseURL+"/admin/pullRequests/restore", wrapper.PostAdminPullRequestsRestore)
+	router.POST(options.BaseURL+"/admin/teams/apply", wrapper.PostAdminTeamsApply)
+	router.POST(options.BaseURL+"/admin/users/erase", wrapper.PostAdminUsersErase)
+	router.GET(options.BaseURL+"/admin/users/erasures", wrapper.GetAdminUsersErasures)
 	router.POST(options.BaseURL+"/pullRequest/create", wrapper.PostPullRequestCreate)
 	router.POST(options.BaseURL+"/pullRequest/merge", wrapper.PostPullRequestMerge)
 	router.POST(options.BaseURL+"/pullRequest/reassign", wrapper.PostPullRequestReassign)
@@ -285,285 +538,1616 @@ func RegisterHandlersWithOptions(router gin.IRouter, si ServerInterface, options
 	router.GET(options.BaseURL+"/statistics/user", wrapper.GetStatisticsUser)
 	router.POST(options.BaseURL+"/team/add", wrapper.PostTeamAdd)
 	router.GET(options.BaseURL+"/team/get", wrapper.GetTeamGet)
+	router.GET(options.BaseURL+"/team/getSla", wrapper.GetTeamGetSla)
+	router.POST(options.BaseURL+"/team/removeSla", wrapper.PostTeamRemoveSla)
+	router.POST(options.BaseURL+"/team/setSla", wrapper.PostTeamSetSla)
+	router.GET(options.BaseURL+"/users/getNotifications", wrapper.GetUsersGetNotifications)
 	router.GET(options.BaseURL+"/users/getReview", wrapper.GetUsersGetReview)
 	router.POST(options.BaseURL+"/users/setIsActive", wrapper.PostUsersSetIsActive)
+	router.POST(options.BaseURL+"/users/setNotifications", wrapper.PostUsersSetNotifications)
 }
 
-type PostPullRequestCreateRequestObject struct {
-	Body *PostPullRequestCreateJSONRequestBody
+type PostAdminPullRequestsRestoreRequestObject struct {
+	Body *PostAdminPullRequestsRestoreJSONRequestBody
 }
 
-type PostPullRequestCreateResponseObject interface {
-	VisitPostPullRequestCreateResponse(w http.ResponseWriter) error
+type PostAdminPullRequestsRestoreResponseObject interface {
+	VisitPostAdminPullRequestsRestoreResponse(w http.ResponseWriter) error
 }
 
-type PostPullRequestCreate201JSONResponse struct {
+type PostAdminPullRequestsRestore200JSONResponse struct {
 	Pr *PullRequest `json:"pr,omitempty"`
 }
 
-func (response PostPullRequestCreate201JSONResponse) VisitPostPullRequestCreateResponse(w http.ResponseWriter) error {
+func (response PostAdminPullRequestsRestore200JSONResponse) VisitPostAdminPullRequestsRestoreResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(201)
+	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type PostPullRequestCreate404JSONResponse ErrorResponse
+type PostAdminPullRequestsRestore400JSONResponse ErrorResponse
 
-func (response PostPullRequestCreate404JSONResponse) VisitPostPullRequestCreateResponse(w http.ResponseWriter) error {
+func (response PostAdminPullRequestsRestore400JSONResponse) VisitPostAdminPullRequestsRestoreResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type PostPullRequestCreate409JSONResponse ErrorResponse
+type PostAdminPullRequestsRestore401JSONResponse ErrorResponse
 
-func (response PostPullRequestCreate409JSONResponse) VisitPostPullRequestCreateResponse(w http.ResponseWriter) error {
+func (response PostAdminPullRequestsRestore401JSONResponse) VisitPostAdminPullRequestsRestoreResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(409)
+	w.WriteHeader(401)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type PostPullRequestMergeRequestObject struct {
-	Body *PostPullRequestMergeJSONRequestBody
+type PostAdminPullRequestsRestore404JSONResponse ErrorResponse
+
+func (response PostAdminPullRequestsRestore404JSONResponse) VisitPostAdminPullRequestsRestoreResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-type PostPullRequestMergeResponseObject interface {
-	VisitPostPullRequestMergeResponse(w http.ResponseWriter) error
+type PostAdminPullRequestsRestore409JSONResponse ErrorResponse
+
+func (response PostAdminPullRequestsRestore409JSONResponse) VisitPostAdminPullRequestsRestoreResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-type PostPullRequestMerge200JSONResponse struct {
-	Pr *PullRequest `json:"pr,omitempty"`
+type PostAdminPullRequestsRestore429ResponseHeaders struct {
+	RetryAfter int
 }
 
-func (response PostPullRequestMerge200JSONResponse) VisitPostPullRequestMergeResponse(w http.ResponseWriter) error {
+type PostAdminPullRequestsRestore429JSONResponse struct {
+	Body    ErrorResponse
+	Headers PostAdminPullRequestsRestore429ResponseHeaders
+}
+
+func (response PostAdminPullRequestsRestore429JSONResponse) VisitPostAdminPullRequestsRestoreResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
 
-	return json.NewEncoder(w).Encode(response)
+	return json.NewEncoder(w).Encode(response.Body)
 }
 
-type PostPullRequestMerge404JSONResponse ErrorResponse
+type PostAdminPullRequestsRestore500JSONResponse ErrorResponse
 
-func (response PostPullRequestMerge404JSONResponse) VisitPostPullRequestMergeResponse(w http.ResponseWriter) error {
+func (response PostAdminPullRequestsRestore500JSONResponse) VisitPostAdminPullRequestsRestoreResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type PostPullRequestReassignRequestObject struct {
-	Body *PostPullRequestReassignJSONRequestBody
+type PostAdminPullRequestsRestore503ResponseHeaders struct {
+	RetryAfter int
 }
 
-type PostPullRequestReassignResponseObject interface {
-	VisitPostPullRequestReassignResponse(w http.ResponseWriter) error
+type PostAdminPullRequestsRestore503JSONResponse struct {
+	Body    ErrorResponse
+	Headers PostAdminPullRequestsRestore503ResponseHeaders
 }
 
-type PostPullRequestReassign200JSONResponse struct {
-	Pr PullRequest `json:"pr"`
+func (response PostAdminPullRequestsRestore503JSONResponse) VisitPostAdminPullRequestsRestoreResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(503)
 
-	// ReplacedBy user_id нового ревьювера
-	ReplacedBy string `json:"replaced_by"`
+	return json.NewEncoder(w).Encode(response.Body)
 }
 
-func (response PostPullRequestReassign200JSONResponse) VisitPostPullRequestReassignResponse(w http.ResponseWriter) error {
+type PostAdminTeamsApplyRequestObject struct {
+	Params PostAdminTeamsApplyParams
+	Body   *PostAdminTeamsApplyJSONRequestBody
+}
+
+type PostAdminTeamsApplyResponseObject interface {
+	VisitPostAdminTeamsApplyResponse(w http.ResponseWriter) error
+}
+
+type PostAdminTeamsApply200JSONResponse TeamPlan
+
+func (response PostAdminTeamsApply200JSONResponse) VisitPostAdminTeamsApplyResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type PostPullRequestReassign404JSONResponse ErrorResponse
+type PostAdminTeamsApply400JSONResponse ErrorResponse
 
-func (response PostPullRequestReassign404JSONResponse) VisitPostPullRequestReassignResponse(w http.ResponseWriter) error {
+func (response PostAdminTeamsApply400JSONResponse) VisitPostAdminTeamsApplyResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type PostPullRequestReassign409JSONResponse ErrorResponse
+type PostAdminTeamsApply401JSONResponse ErrorResponse
 
-func (response PostPullRequestReassign409JSONResponse) VisitPostPullRequestReassignResponse(w http.ResponseWriter) error {
+func (response PostAdminTeamsApply401JSONResponse) VisitPostAdminTeamsApplyResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(409)
+	w.WriteHeader(401)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetStatisticsTeamRequestObject struct {
-	Params GetStatisticsTeamParams
+type PostAdminTeamsApply429ResponseHeaders struct {
+	RetryAfter int
 }
 
-type GetStatisticsTeamResponseObject interface {
-	VisitGetStatisticsTeamResponse(w http.ResponseWriter) error
+type PostAdminTeamsApply429JSONResponse struct {
+	Body    ErrorResponse
+	Headers PostAdminTeamsApply429ResponseHeaders
 }
 
-type GetStatisticsTeam200JSONResponse struct {
-	// AvgDuration Среднее время между create и merge у PR команды
-	AvgDuration float64    `json:"avg_duration"`
-	TeamName    string     `json:"team_name"`
-	UsersStat   []UserStat `json:"users_stat"`
+func (response PostAdminTeamsApply429JSONResponse) VisitPostAdminTeamsApplyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
 }
 
-func (response GetStatisticsTeam200JSONResponse) VisitGetStatisticsTeamResponse(w http.ResponseWriter) error {
+type PostAdminTeamsApply500JSONResponse ErrorResponse
+
+func (response PostAdminTeamsApply500JSONResponse) VisitPostAdminTeamsApplyResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetStatisticsTeam404JSONResponse ErrorResponse
+type PostAdminTeamsApply503ResponseHeaders struct {
+	RetryAfter int
+}
 
-func (response GetStatisticsTeam404JSONResponse) VisitGetStatisticsTeamResponse(w http.ResponseWriter) error {
+type PostAdminTeamsApply503JSONResponse struct {
+	Body    ErrorResponse
+	Headers PostAdminTeamsApply503ResponseHeaders
+}
+
+func (response PostAdminTeamsApply503JSONResponse) VisitPostAdminTeamsApplyResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(503)
 
-	return json.NewEncoder(w).Encode(response)
+	return json.NewEncoder(w).Encode(response.Body)
 }
 
-type GetStatisticsUserRequestObject struct {
-	Params GetStatisticsUserParams
+type PostAdminUsersEraseRequestObject struct {
+	Body *PostAdminUsersEraseJSONRequestBody
 }
 
-type GetStatisticsUserResponseObject interface {
-	VisitGetStatisticsUserResponse(w http.ResponseWriter) error
+type PostAdminUsersEraseResponseObject interface {
+	VisitPostAdminUsersEraseResponse(w http.ResponseWriter) error
 }
 
-type GetStatisticsUser200JSONResponse UserStat
+type PostAdminUsersErase200JSONResponse struct {
+	Erasure       UserErasure    `json:"erasure"`
+	Reassignments []Reassignment `json:"reassignments"`
+}
 
-func (response GetStatisticsUser200JSONResponse) VisitGetStatisticsUserResponse(w http.ResponseWriter) error {
+func (response PostAdminUsersErase200JSONResponse) VisitPostAdminUsersEraseResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetStatisticsUser404JSONResponse ErrorResponse
+type PostAdminUsersErase400JSONResponse ErrorResponse
 
-func (response GetStatisticsUser404JSONResponse) VisitGetStatisticsUserResponse(w http.ResponseWriter) error {
+func (response PostAdminUsersErase400JSONResponse) VisitPostAdminUsersEraseResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type PostTeamAddRequestObject struct {
-	Body *PostTeamAddJSONRequestBody
+type PostAdminUsersErase401JSONResponse ErrorResponse
+
+func (response PostAdminUsersErase401JSONResponse) VisitPostAdminUsersEraseResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-type PostTeamAddResponseObject interface {
-	VisitPostTeamAddResponse(w http.ResponseWriter) error
+type PostAdminUsersErase404JSONResponse ErrorResponse
+
+func (response PostAdminUsersErase404JSONResponse) VisitPostAdminUsersEraseResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-type PostTeamAdd201JSONResponse struct {
-	Team *Team `json:"team,omitempty"`
+type PostAdminUsersErase429ResponseHeaders struct {
+	RetryAfter int
 }
 
-func (response PostTeamAdd201JSONResponse) VisitPostTeamAddResponse(w http.ResponseWriter) error {
+type PostAdminUsersErase429JSONResponse struct {
+	Body    ErrorResponse
+	Headers PostAdminUsersErase429ResponseHeaders
+}
+
+func (response PostAdminUsersErase429JSONResponse) VisitPostAdminUsersEraseResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PostAdminUsersErase500JSONResponse ErrorResponse
+
+func (response PostAdminUsersErase500JSONResponse) VisitPostAdminUsersEraseResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAdminUsersErase503ResponseHeaders struct {
+	RetryAfter int
+}
+
+type PostAdminUsersErase503JSONResponse struct {
+	Body    ErrorResponse
+	Headers PostAdminUsersErase503ResponseHeaders
+}
+
+func (response PostAdminUsersErase503JSONResponse) VisitPostAdminUsersEraseResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetAdminUsersErasuresRequestObject struct {
+}
+
+type GetAdminUsersErasuresResponseObject interface {
+	VisitGetAdminUsersErasuresResponse(w http.ResponseWriter) error
+}
+
+type GetAdminUsersErasures200JSONResponse struct {
+	Erasures []UserErasure `json:"erasures"`
+}
+
+func (response GetAdminUsersErasures200JSONResponse) VisitGetAdminUsersErasuresResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAdminUsersErasures401JSONResponse ErrorResponse
+
+func (response GetAdminUsersErasures401JSONResponse) VisitGetAdminUsersErasuresResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAdminUsersErasures429ResponseHeaders struct {
+	RetryAfter int
+}
+
+type GetAdminUsersErasures429JSONResponse struct {
+	Body    ErrorResponse
+	Headers GetAdminUsersErasures429ResponseHeaders
+}
+
+func (response GetAdminUsersErasures429JSONResponse) VisitGetAdminUsersErasuresResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetAdminUsersErasures500JSONResponse ErrorResponse
+
+func (response GetAdminUsersErasures500JSONResponse) VisitGetAdminUsersErasuresResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAdminUsersErasures503ResponseHeaders struct {
+	RetryAfter int
+}
+
+type GetAdminUsersErasures503JSONResponse struct {
+	Body    ErrorResponse
+	Headers GetAdminUsersErasures503ResponseHeaders
+}
+
+func (response GetAdminUsersErasures503JSONResponse) VisitGetAdminUsersErasuresResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PostPullRequestCreateRequestObject struct {
+	Body *PostPullRequestCreateJSONRequestBody
+}
+
+type PostPullRequestCreateResponseObject interface {
+	VisitPostPullRequestCreateResponse(w http.ResponseWriter) error
+}
+
+type PostPullRequestCreate201JSONResponse struct {
+	Pr *PullRequest `json:"pr,omitempty"`
+}
+
+func (response PostPullRequestCreate201JSONResponse) VisitPostPullRequestCreateResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(201)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type PostTeamAdd400JSONResponse ErrorResponse
+type PostPullRequestCreate400JSONResponse ErrorResponse
 
-func (response PostTeamAdd400JSONResponse) VisitPostTeamAddResponse(w http.ResponseWriter) error {
+func (response PostPullRequestCreate400JSONResponse) VisitPostPullRequestCreateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostPullRequestCreate401JSONResponse ErrorResponse
+
+func (response PostPullRequestCreate401JSONResponse) VisitPostPullRequestCreateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostPullRequestCreate404JSONResponse ErrorResponse
+
+func (response PostPullRequestCreate404JSONResponse) VisitPostPullRequestCreateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostPullRequestCreate409JSONResponse ErrorResponse
+
+func (response PostPullRequestCreate409JSONResponse) VisitPostPullRequestCreateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostPullRequestCreate429ResponseHeaders struct {
+	RetryAfter int
+}
+
+type PostPullRequestCreate429JSONResponse struct {
+	Body    ErrorResponse
+	Headers PostPullRequestCreate429ResponseHeaders
+}
+
+func (response PostPullRequestCreate429JSONResponse) VisitPostPullRequestCreateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PostPullRequestCreate500JSONResponse ErrorResponse
+
+func (response PostPullRequestCreate500JSONResponse) VisitPostPullRequestCreateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostPullRequestCreate503ResponseHeaders struct {
+	RetryAfter int
+}
+
+type PostPullRequestCreate503JSONResponse struct {
+	Body    ErrorResponse
+	Headers PostPullRequestCreate503ResponseHeaders
+}
+
+func (response PostPullRequestCreate503JSONResponse) VisitPostPullRequestCreateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PostPullRequestMergeRequestObject struct {
+	Body *PostPullRequestMergeJSONRequestBody
+}
+
+type PostPullRequestMergeResponseObject interface {
+	VisitPostPullRequestMergeResponse(w http.ResponseWriter) error
+}
+
+type PostPullRequestMerge200JSONResponse struct {
+	Pr *PullRequest `json:"pr,omitempty"`
+}
+
+func (response PostPullRequestMerge200JSONResponse) VisitPostPullRequestMergeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostPullRequestMerge400JSONResponse ErrorResponse
+
+func (response PostPullRequestMerge400JSONResponse) VisitPostPullRequestMergeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostPullRequestMerge401JSONResponse ErrorResponse
+
+func (response PostPullRequestMerge401JSONResponse) VisitPostPullRequestMergeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostPullRequestMerge404JSONResponse ErrorResponse
+
+func (response PostPullRequestMerge404JSONResponse) VisitPostPullRequestMergeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostPullRequestMerge429ResponseHeaders struct {
+	RetryAfter int
+}
+
+type PostPullRequestMerge429JSONResponse struct {
+	Body    ErrorResponse
+	Headers PostPullRequestMerge429ResponseHeaders
+}
+
+func (response PostPullRequestMerge429JSONResponse) VisitPostPullRequestMergeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PostPullRequestMerge500JSONResponse ErrorResponse
+
+func (response PostPullRequestMerge500JSONResponse) VisitPostPullRequestMergeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostPullRequestMerge503ResponseHeaders struct {
+	RetryAfter int
+}
+
+type PostPullRequestMerge503JSONResponse struct {
+	Body    ErrorResponse
+	Headers PostPullRequestMerge503ResponseHeaders
+}
+
+func (response PostPullRequestMerge503JSONResponse) VisitPostPullRequestMergeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PostPullRequestReassignRequestObject struct {
+	Body *PostPullRequestReassignJSONRequestBody
+}
+
+type PostPullRequestReassignResponseObject interface {
+	VisitPostPullRequestReassignResponse(w http.ResponseWriter) error
+}
+
+type PostPullRequestReassign200JSONResponse struct {
+	Pr PullRequest `json:"pr"`
+
+	// ReplacedBy user_id нового ревьювера
+	ReplacedBy string `json:"replaced_by"`
+}
+
+func (response PostPullRequestReassign200JSONResponse) VisitPostPullRequestReassignResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostPullRequestReassign400JSONResponse ErrorResponse
+
+func (response PostPullRequestReassign400JSONResponse) VisitPostPullRequestReassignResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostPullRequestReassign401JSONResponse ErrorResponse
+
+func (response PostPullRequestReassign401JSONResponse) VisitPostPullRequestReassignResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostPullRequestReassign404JSONResponse ErrorResponse
+
+func (response PostPullRequestReassign404JSONResponse) VisitPostPullRequestReassignResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostPullRequestReassign409JSONResponse ErrorResponse
+
+func (response PostPullRequestReassign409JSONResponse) VisitPostPullRequestReassignResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostPullRequestReassign429ResponseHeaders struct {
+	RetryAfter int
+}
+
+type PostPullRequestReassign429JSONResponse struct {
+	Body    ErrorResponse
+	Headers PostPullRequestReassign429ResponseHeaders
+}
+
+func (response PostPullRequestReassign429JSONResponse) VisitPostPullRequestReassignResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PostPullRequestReassign500JSONResponse ErrorResponse
+
+func (response PostPullRequestReassign500JSONResponse) VisitPostPullRequestReassignResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostPullRequestReassign503ResponseHeaders struct {
+	RetryAfter int
+}
+
+type PostPullRequestReassign503JSONResponse struct {
+	Body    ErrorResponse
+	Headers PostPullRequestReassign503ResponseHeaders
+}
+
+func (response PostPullRequestReassign503JSONResponse) VisitPostPullRequestReassignResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetStatisticsTeamRequestObject struct {
+	Params GetStatisticsTeamParams
+}
+
+type GetStatisticsTeamResponseObject interface {
+	VisitGetStatisticsTeamResponse(w http.ResponseWriter) error
+}
+
+type GetStatisticsTeam200JSONResponse struct {
+	// AvgDuration Среднее время между create и merge у PR команды
+	AvgDuration float64    `json:"avg_duration"`
+	TeamName    string     `json:"team_name"`
+	UsersStat   []UserStat `json:"users_stat"`
+}
+
+func (response GetStatisticsTeam200JSONResponse) VisitGetStatisticsTeamResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetStatisticsTeam400JSONResponse ErrorResponse
+
+func (response GetStatisticsTeam400JSONResponse) VisitGetStatisticsTeamResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetStatisticsTeam401JSONResponse ErrorResponse
+
+func (response GetStatisticsTeam401JSONResponse) VisitGetStatisticsTeamResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetStatisticsTeam404JSONResponse ErrorResponse
+
+func (response GetStatisticsTeam404JSONResponse) VisitGetStatisticsTeamResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetStatisticsTeam429ResponseHeaders struct {
+	RetryAfter int
+}
+
+type GetStatisticsTeam429JSONResponse struct {
+	Body    ErrorResponse
+	Headers GetStatisticsTeam429ResponseHeaders
+}
+
+func (response GetStatisticsTeam429JSONResponse) VisitGetStatisticsTeamResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetStatisticsTeam500JSONResponse ErrorResponse
+
+func (response GetStatisticsTeam500JSONResponse) VisitGetStatisticsTeamResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetStatisticsTeam503ResponseHeaders struct {
+	RetryAfter int
+}
+
+type GetStatisticsTeam503JSONResponse struct {
+	Body    ErrorResponse
+	Headers GetStatisticsTeam503ResponseHeaders
+}
+
+func (response GetStatisticsTeam503JSONResponse) VisitGetStatisticsTeamResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetStatisticsUserRequestObject struct {
+	Params GetStatisticsUserParams
+}
+
+type GetStatisticsUserResponseObject interface {
+	VisitGetStatisticsUserResponse(w http.ResponseWriter) error
+}
+
+type GetStatisticsUser200JSONResponse UserStat
+
+func (response GetStatisticsUser200JSONResponse) VisitGetStatisticsUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetStatisticsUser400JSONResponse ErrorResponse
+
+func (response GetStatisticsUser400JSONResponse) VisitGetStatisticsUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetStatisticsUser401JSONResponse ErrorResponse
+
+func (response GetStatisticsUser401JSONResponse) VisitGetStatisticsUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetStatisticsUser404JSONResponse ErrorResponse
+
+func (response GetStatisticsUser404JSONResponse) VisitGetStatisticsUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetStatisticsUser429ResponseHeaders struct {
+	RetryAfter int
+}
+
+type GetStatisticsUser429JSONResponse struct {
+	Body    ErrorResponse
+	Headers GetStatisticsUser429ResponseHeaders
+}
+
+func (response GetStatisticsUser429JSONResponse) VisitGetStatisticsUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetStatisticsUser500JSONResponse ErrorResponse
+
+func (response GetStatisticsUser500JSONResponse) VisitGetStatisticsUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetStatisticsUser503ResponseHeaders struct {
+	RetryAfter int
+}
+
+type GetStatisticsUser503JSONResponse struct {
+	Body    ErrorResponse
+	Headers GetStatisticsUser503ResponseHeaders
+}
+
+func (response GetStatisticsUser503JSONResponse) VisitGetStatisticsUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PostTeamAddRequestObject struct {
+	Body *PostTeamAddJSONRequestBody
+}
+
+type PostTeamAddResponseObject interface {
+	VisitPostTeamAddResponse(w http.ResponseWriter) error
+}
+
+type PostTeamAdd201JSONResponse struct {
+	Team *Team `json:"team,omitempty"`
+}
+
+func (response PostTeamAdd201JSONResponse) VisitPostTeamAddResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostTeamAdd400JSONResponse ErrorResponse
+
+func (response PostTeamAdd400JSONResponse) VisitPostTeamAddResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostTeamAdd401JSONResponse ErrorResponse
+
+func (response PostTeamAdd401JSONResponse) VisitPostTeamAddResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostTeamAdd429ResponseHeaders struct {
+	RetryAfter int
+}
+
+type PostTeamAdd429JSONResponse struct {
+	Body    ErrorResponse
+	Headers PostTeamAdd429ResponseHeaders
+}
+
+func (response PostTeamAdd429JSONResponse) VisitPostTeamAddResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PostTeamAdd500JSONResponse ErrorResponse
+
+func (response PostTeamAdd500JSONResponse) VisitPostTeamAddResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostTeamAdd503ResponseHeaders struct {
+	RetryAfter int
+}
+
+type PostTeamAdd503JSONResponse struct {
+	Body    ErrorResponse
+	Headers PostTeamAdd503ResponseHeaders
+}
+
+func (response PostTeamAdd503JSONResponse) VisitPostTeamAddResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetTeamGetRequestObject struct {
+	Params GetTeamGetParams
+}
+
+type GetTeamGetResponseObject interface {
+	VisitGetTeamGetResponse(w http.ResponseWriter) error
+}
+
+type GetTeamGet200JSONResponse Team
+
+func (response GetTeamGet200JSONResponse) VisitGetTeamGetResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetTeamGet400JSONResponse ErrorResponse
+
+func (response GetTeamGet400JSONResponse) VisitGetTeamGetResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetTeamGet401JSONResponse ErrorResponse
+
+func (response GetTeamGet401JSONResponse) VisitGetTeamGetResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetTeamGet404JSONResponse ErrorResponse
+
+func (response GetTeamGet404JSONResponse) VisitGetTeamGetResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetTeamGet429ResponseHeaders struct {
+	RetryAfter int
+}
+
+type GetTeamGet429JSONResponse struct {
+	Body    ErrorResponse
+	Headers GetTeamGet429ResponseHeaders
+}
+
+func (response GetTeamGet429JSONResponse) VisitGetTeamGetResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetTeamGet500JSONResponse ErrorResponse
+
+func (response GetTeamGet500JSONResponse) VisitGetTeamGetResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetTeamGet503ResponseHeaders struct {
+	RetryAfter int
+}
+
+type GetTeamGet503JSONResponse struct {
+	Body    ErrorResponse
+	Headers GetTeamGet503ResponseHeaders
+}
+
+func (response GetTeamGet503JSONResponse) VisitGetTeamGetResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetTeamGetSlaRequestObject struct {
+	Params GetTeamGetSlaParams
+}
+
+type GetTeamGetSlaResponseObject interface {
+	VisitGetTeamGetSlaResponse(w http.ResponseWriter) error
+}
+
+type GetTeamGetSla200JSONResponse TeamSLA
+
+func (response GetTeamGetSla200JSONResponse) VisitGetTeamGetSlaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetTeamGetSla400JSONResponse ErrorResponse
+
+func (response GetTeamGetSla400JSONResponse) VisitGetTeamGetSlaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetTeamGetSla401JSONResponse ErrorResponse
+
+func (response GetTeamGetSla401JSONResponse) VisitGetTeamGetSlaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetTeamGetSla404JSONResponse ErrorResponse
+
+func (response GetTeamGetSla404JSONResponse) VisitGetTeamGetSlaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetTeamGetSla429ResponseHeaders struct {
+	RetryAfter int
+}
+
+type GetTeamGetSla429JSONResponse struct {
+	Body    ErrorResponse
+	Headers GetTeamGetSla429ResponseHeaders
+}
+
+func (response GetTeamGetSla429JSONResponse) VisitGetTeamGetSlaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetTeamGetSla500JSONResponse ErrorResponse
+
+func (response GetTeamGetSla500JSONResponse) VisitGetTeamGetSlaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetTeamGetSla503ResponseHeaders struct {
+	RetryAfter int
+}
+
+type GetTeamGetSla503JSONResponse struct {
+	Body    ErrorResponse
+	Headers GetTeamGetSla503ResponseHeaders
+}
+
+func (response GetTeamGetSla503JSONResponse) VisitGetTeamGetSlaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PostTeamRemoveSlaRequestObject struct {
+	Body *PostTeamRemoveSlaJSONRequestBody
+}
+
+type PostTeamRemoveSlaResponseObject interface {
+	VisitPostTeamRemoveSlaResponse(w http.ResponseWriter) error
+}
+
+type PostTeamRemoveSla200JSONResponse struct {
+	TeamName string `json:"team_name"`
+}
+
+func (response PostTeamRemoveSla200JSONResponse) VisitPostTeamRemoveSlaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostTeamRemoveSla400JSONResponse ErrorResponse
+
+func (response PostTeamRemoveSla400JSONResponse) VisitPostTeamRemoveSlaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostTeamRemoveSla401JSONResponse ErrorResponse
+
+func (response PostTeamRemoveSla401JSONResponse) VisitPostTeamRemoveSlaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostTeamRemoveSla404JSONResponse ErrorResponse
+
+func (response PostTeamRemoveSla404JSONResponse) VisitPostTeamRemoveSlaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostTeamRemoveSla429ResponseHeaders struct {
+	RetryAfter int
+}
+
+type PostTeamRemoveSla429JSONResponse struct {
+	Body    ErrorResponse
+	Headers PostTeamRemoveSla429ResponseHeaders
+}
+
+func (response PostTeamRemoveSla429JSONResponse) VisitPostTeamRemoveSlaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PostTeamRemoveSla500JSONResponse ErrorResponse
+
+func (response PostTeamRemoveSla500JSONResponse) VisitPostTeamRemoveSlaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostTeamRemoveSla503ResponseHeaders struct {
+	RetryAfter int
+}
+
+type PostTeamRemoveSla503JSONResponse struct {
+	Body    ErrorResponse
+	Headers PostTeamRemoveSla503ResponseHeaders
+}
+
+func (response PostTeamRemoveSla503JSONResponse) VisitPostTeamRemoveSlaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PostTeamSetSlaRequestObject struct {
+	Body *PostTeamSetSlaJSONRequestBody
+}
+
+type PostTeamSetSlaResponseObject interface {
+	VisitPostTeamSetSlaResponse(w http.ResponseWriter) error
+}
+
+type PostTeamSetSla200JSONResponse TeamSLA
+
+func (response PostTeamSetSla200JSONResponse) VisitPostTeamSetSlaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostTeamSetSla400JSONResponse ErrorResponse
+
+func (response PostTeamSetSla400JSONResponse) VisitPostTeamSetSlaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostTeamSetSla401JSONResponse ErrorResponse
+
+func (response PostTeamSetSla401JSONResponse) VisitPostTeamSetSlaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostTeamSetSla404JSONResponse ErrorResponse
+
+func (response PostTeamSetSla404JSONResponse) VisitPostTeamSetSlaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostTeamSetSla429ResponseHeaders struct {
+	RetryAfter int
+}
+
+type PostTeamSetSla429JSONResponse struct {
+	Body    ErrorResponse
+	Headers PostTeamSetSla429ResponseHeaders
+}
+
+func (response PostTeamSetSla429JSONResponse) VisitPostTeamSetSlaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PostTeamSetSla500JSONResponse ErrorResponse
+
+func (response PostTeamSetSla500JSONResponse) VisitPostTeamSetSlaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostTeamSetSla503ResponseHeaders struct {
+	RetryAfter int
+}
+
+type PostTeamSetSla503JSONResponse struct {
+	Body    ErrorResponse
+	Headers PostTeamSetSla503ResponseHeaders
+}
+
+func (response PostTeamSetSla503JSONResponse) VisitPostTeamSetSlaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetUsersGetNotificationsRequestObject struct {
+	Params GetUsersGetNotificationsParams
+}
+
+type GetUsersGetNotificationsResponseObject interface {
+	VisitGetUsersGetNotificationsResponse(w http.ResponseWriter) error
+}
+
+type GetUsersGetNotifications200JSONResponse NotificationSettings
+
+func (response GetUsersGetNotifications200JSONResponse) VisitGetUsersGetNotificationsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUsersGetNotifications400JSONResponse ErrorResponse
+
+func (response GetUsersGetNotifications400JSONResponse) VisitGetUsersGetNotificationsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUsersGetNotifications401JSONResponse ErrorResponse
+
+func (response GetUsersGetNotifications401JSONResponse) VisitGetUsersGetNotificationsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUsersGetNotifications404JSONResponse ErrorResponse
+
+func (response GetUsersGetNotifications404JSONResponse) VisitGetUsersGetNotificationsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUsersGetNotifications429ResponseHeaders struct {
+	RetryAfter int
+}
+
+type GetUsersGetNotifications429JSONResponse struct {
+	Body    ErrorResponse
+	Headers GetUsersGetNotifications429ResponseHeaders
+}
+
+func (response GetUsersGetNotifications429JSONResponse) VisitGetUsersGetNotificationsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetUsersGetNotifications500JSONResponse ErrorResponse
+
+func (response GetUsersGetNotifications500JSONResponse) VisitGetUsersGetNotificationsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUsersGetNotifications503ResponseHeaders struct {
+	RetryAfter int
+}
+
+type GetUsersGetNotifications503JSONResponse struct {
+	Body    ErrorResponse
+	Headers GetUsersGetNotifications503ResponseHeaders
+}
+
+func (response GetUsersGetNotifications503JSONResponse) VisitGetUsersGetNotificationsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetUsersGetReviewRequestObject struct {
+	Params GetUsersGetReviewParams
+}
+
+type GetUsersGetReviewResponseObject interface {
+	VisitGetUsersGetReviewResponse(w http.ResponseWriter) error
+}
+
+type GetUsersGetReview200JSONResponse struct {
+	PullRequests []PullRequestShort `json:"pull_requests"`
+	UserId       string             `json:"user_id"`
+}
+
+func (response GetUsersGetReview200JSONResponse) VisitGetUsersGetReviewResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUsersGetReview400JSONResponse ErrorResponse
+
+func (response GetUsersGetReview400JSONResponse) VisitGetUsersGetReviewResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUsersGetReview401JSONResponse ErrorResponse
+
+func (response GetUsersGetReview401JSONResponse) VisitGetUsersGetReviewResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUsersGetReview404JSONResponse ErrorResponse
+
+func (response GetUsersGetReview404JSONResponse) VisitGetUsersGetReviewResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUsersGetReview429ResponseHeaders struct {
+	RetryAfter int
+}
+
+type GetUsersGetReview429JSONResponse struct {
+	Body    ErrorResponse
+	Headers GetUsersGetReview429ResponseHeaders
+}
+
+func (response GetUsersGetReview429JSONResponse) VisitGetUsersGetReviewResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetUsersGetReview500JSONResponse ErrorResponse
+
+func (response GetUsersGetReview500JSONResponse) VisitGetUsersGetReviewResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUsersGetReview503ResponseHeaders struct {
+	RetryAfter int
+}
+
+type GetUsersGetReview503JSONResponse struct {
+	Body    ErrorResponse
+	Headers GetUsersGetReview503ResponseHeaders
+}
+
+func (response GetUsersGetReview503JSONResponse) VisitGetUsersGetReviewResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PostUsersSetIsActiveRequestObject struct {
+	Body *PostUsersSetIsActiveJSONRequestBody
+}
+
+type PostUsersSetIsActiveResponseObject interface {
+	VisitPostUsersSetIsActiveResponse(w http.ResponseWriter) error
+}
+
+type PostUsersSetIsActive200JSONResponse struct {
+	User *User `json:"user,omitempty"`
+}
+
+func (response PostUsersSetIsActive200JSONResponse) VisitPostUsersSetIsActiveResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostUsersSetIsActive400JSONResponse ErrorResponse
+
+func (response PostUsersSetIsActive400JSONResponse) VisitPostUsersSetIsActiveResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostUsersSetIsActive401JSONResponse ErrorResponse
+
+func (response PostUsersSetIsActive401JSONResponse) VisitPostUsersSetIsActiveResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostUsersSetIsActive404JSONResponse ErrorResponse
+
+func (response PostUsersSetIsActive404JSONResponse) VisitPostUsersSetIsActiveResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostUsersSetIsActive429ResponseHeaders struct {
+	RetryAfter int
+}
+
+type PostUsersSetIsActive429JSONResponse struct {
+	Body    ErrorResponse
+	Headers PostUsersSetIsActive429ResponseHeaders
+}
+
+func (response PostUsersSetIsActive429JSONResponse) VisitPostUsersSetIsActiveResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PostUsersSetIsActive500JSONResponse ErrorResponse
+
+func (response PostUsersSetIsActive500JSONResponse) VisitPostUsersSetIsActiveResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostUsersSetIsActive503ResponseHeaders struct {
+	RetryAfter int
+}
+
+type PostUsersSetIsActive503JSONResponse struct {
+	Body    ErrorResponse
+	Headers PostUsersSetIsActive503ResponseHeaders
+}
+
+func (response PostUsersSetIsActive503JSONResponse) VisitPostUsersSetIsActiveResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(503)
 
-	return json.NewEncoder(w).Encode(response)
+	return json.NewEncoder(w).Encode(response.Body)
 }
 
-type GetTeamGetRequestObject struct {
-	Params GetTeamGetParams
+type PostUsersSetNotificationsRequestObject struct {
+	Body *PostUsersSetNotificationsJSONRequestBody
 }
 
-type GetTeamGetResponseObject interface {
-	VisitGetTeamGetResponse(w http.ResponseWriter) error
+type PostUsersSetNotificationsResponseObject interface {
+	VisitPostUsersSetNotificationsResponse(w http.ResponseWriter) error
 }
 
-type GetTeamGet200JSONResponse Team
+type PostUsersSetNotifications200JSONResponse NotificationSettings
 
-func (response GetTeamGet200JSONResponse) VisitGetTeamGetResponse(w http.ResponseWriter) error {
+func (response PostUsersSetNotifications200JSONResponse) VisitPostUsersSetNotificationsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetTeamGet404JSONResponse ErrorResponse
+type PostUsersSetNotifications400JSONResponse ErrorResponse
 
-func (response GetTeamGet404JSONResponse) VisitGetTeamGetResponse(w http.ResponseWriter) error {
+func (response PostUsersSetNotifications400JSONResponse) VisitPostUsersSetNotificationsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetUsersGetReviewRequestObject struct {
-	Params GetUsersGetReviewParams
-}
-
-type GetUsersGetReviewResponseObject interface {
-	VisitGetUsersGetReviewResponse(w http.ResponseWriter) error
-}
-
-type GetUsersGetReview200JSONResponse struct {
-	PullRequests []PullRequestShort `json:"pull_requests"`
-	UserId       string             `json:"user_id"`
-}
+type PostUsersSetNotifications401JSONResponse ErrorResponse
 
-func (response GetUsersGetReview200JSONResponse) VisitGetUsersGetReviewResponse(w http.ResponseWriter) error {
+func (response PostUsersSetNotifications401JSONResponse) VisitPostUsersSetNotificationsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	w.WriteHeader(401)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetUsersGetReview404JSONResponse ErrorResponse
+type PostUsersSetNotifications404JSONResponse ErrorResponse
 
-func (response GetUsersGetReview404JSONResponse) VisitGetUsersGetReviewResponse(w http.ResponseWriter) error {
+func (response PostUsersSetNotifications404JSONResponse) VisitPostUsersSetNotificationsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type PostUsersSetIsActiveRequestObject struct {
-	Body *PostUsersSetIsActiveJSONRequestBody
+type PostUsersSetNotifications429ResponseHeaders struct {
+	RetryAfter int
 }
 
-type PostUsersSetIsActiveResponseObject interface {
-	VisitPostUsersSetIsActiveResponse(w http.ResponseWriter) error
+type PostUsersSetNotifications429JSONResponse struct {
+	Body    ErrorResponse
+	Headers PostUsersSetNotifications429ResponseHeaders
 }
 
-type PostUsersSetIsActive200JSONResponse struct {
-	User *User `json:"user,omitempty"`
+func (response PostUsersSetNotifications429JSONResponse) VisitPostUsersSetNotificationsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
 }
 
-func (response PostUsersSetIsActive200JSONResponse) VisitPostUsersSetIsActiveResponse(w http.ResponseWriter) error {
+type PostUsersSetNotifications500JSONResponse ErrorResponse
+
+func (response PostUsersSetNotifications500JSONResponse) VisitPostUsersSetNotificationsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type PostUsersSetIsActive404JSONResponse ErrorResponse
+type PostUsersSetNotifications503ResponseHeaders struct {
+	RetryAfter int
+}
 
-func (response PostUsersSetIsActive404JSONResponse) VisitPostUsersSetIsActiveResponse(w http.ResponseWriter) error {
+type PostUsersSetNotifications503JSONResponse struct {
+	Body    ErrorResponse
+	Headers PostUsersSetNotifications503ResponseHeaders
+}
+
+func (response PostUsersSetNotifications503JSONResponse) VisitPostUsersSetNotificationsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(503)
 
-	return json.NewEncoder(w).Encode(response)
+	return json.NewEncoder(w).Encode(response.Body)
 }
 
 // StrictServerInterface represents all server handlers.
 type StrictServerInterface interface {
+	// Вернуть PR из архива
+	// (POST /admin/pullRequests/restore)
+	PostAdminPullRequestsRestore(ctx context.Context, request PostAdminPullRequestsRestoreRequestObject) (PostAdminPullRequestsRestoreResponseObject, error)
+	// Привести команды и участников к заданному составу
+	// (POST /admin/teams/apply)
+	PostAdminTeamsApply(ctx context.Context, request PostAdminTeamsApplyRequestObject) (PostAdminTeamsApplyResponseObject, error)
+	// Стереть пользователя
+	// (POST /admin/users/erase)
+	PostAdminUsersErase(ctx context.Context, request PostAdminUsersEraseRequestObject) (PostAdminUsersEraseResponseObject, error)
+	// Журнал стираний
+	// (GET /admin/users/erasures)
+	GetAdminUsersErasures(ctx context.Context, request GetAdminUsersErasuresRequestObject) (GetAdminUsersErasuresResponseObject, error)
 	// Создать PR и автоматически назначить до 2 ревьюверов из команды автора
 	// (POST /pullRequest/create)
 	PostPullRequestCreate(ctx context.Context, request PostPullRequestCreateRequestObject) (PostPullRequestCreateResponseObject, error)
@@ -585,12 +2169,27 @@ type StrictServerInterface interface {
 	// Получить команду с участниками
 	// (GET /team/get)
 	GetTeamGet(ctx context.Context, request GetTeamGetRequestObject) (GetTeamGetResponseObject, error)
+	// Получить SLA ревью команды
+	// (GET /team/getSla)
+	GetTeamGetSla(ctx context.Context, request GetTeamGetSlaRequestObject) (GetTeamGetSlaResponseObject, error)
+	// Снять SLA ревью команды
+	// (POST /team/removeSla)
+	PostTeamRemoveSla(ctx context.Context, request PostTeamRemoveSlaRequestObject) (PostTeamRemoveSlaResponseObject, error)
+	// Задать SLA ревью команды
+	// (POST /team/setSla)
+	PostTeamSetSla(ctx context.Context, request PostTeamSetSlaRequestObject) (PostTeamSetSlaResponseObject, error)
+	// Получить настройки писем пользователя
+	// (GET /users/getNotifications)
+	GetUsersGetNotifications(ctx context.Context, request GetUsersGetNotificationsRequestObject) (GetUsersGetNotificationsResponseObject, error)
 	// Получить PR'ы, где пользователь назначен ревьювером
 	// (GET /users/getReview)
 	GetUsersGetReview(ctx context.Context, request GetUsersGetReviewRequestObject) (GetUsersGetReviewResponseObject, error)
 	// Установить флаг активности пользователя
 	// (POST /users/setIsActive)
 	PostUsersSetIsActive(ctx context.Context, request PostUsersSetIsActiveRequestObject) (PostUsersSetIsActiveResponseObject, error)
+	// Задать адрес и подписки на письма пользователя
+	// (POST /users/setNotifications)
+	PostUsersSetNotifications(ctx context.Context, request PostUsersSetNotificationsRequestObject) (PostUsersSetNotificationsResponseObject, error)
 }
 
 type StrictHandlerFunc = strictgin.StrictGinHandlerFunc
@@ -605,6 +2204,132 @@ type strictHandler struct {
 	middlewares []StrictMiddlewareFunc
 }
 
+// PostAdminPullRequestsRestore operation middleware
+func (sh *strictHandler) PostAdminPullRequestsRestore(ctx *gin.Context) {
+	var request PostAdminPullRequestsRestoreRequestObject
+
+	var body PostAdminPullRequestsRestoreJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostAdminPullRequestsRestore(ctx, request.(PostAdminPullRequestsRestoreRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostAdminPullRequestsRestore")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PostAdminPullRequestsRestoreResponseObject); ok {
+		if err := validResponse.VisitPostAdminPullRequestsRestoreResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostAdminTeamsApply operation middleware
+func (sh *strictHandler) PostAdminTeamsApply(ctx *gin.Context, params PostAdminTeamsApplyParams) {
+	var request PostAdminTeamsApplyRequestObject
+
+	request.Params = params
+
+	var body PostAdminTeamsApplyJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostAdminTeamsApply(ctx, request.(PostAdminTeamsApplyRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostAdminTeamsApply")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PostAdminTeamsApplyResponseObject); ok {
+		if err := validResponse.VisitPostAdminTeamsApplyResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostAdminUsersErase operation middleware
+func (sh *strictHandler) PostAdminUsersErase(ctx *gin.Context) {
+	var request PostAdminUsersEraseRequestObject
+
+	var body PostAdminUsersEraseJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostAdminUsersErase(ctx, request.(PostAdminUsersEraseRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostAdminUsersErase")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PostAdminUsersEraseResponseObject); ok {
+		if err := validResponse.VisitPostAdminUsersEraseResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetAdminUsersErasures operation middleware
+func (sh *strictHandler) GetAdminUsersErasures(ctx *gin.Context) {
+	var request GetAdminUsersErasuresRequestObject
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetAdminUsersErasures(ctx, request.(GetAdminUsersErasuresRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetAdminUsersErasures")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetAdminUsersErasuresResponseObject); ok {
+		if err := validResponse.VisitGetAdminUsersErasuresResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // PostPullRequestCreate operation middleware
 func (sh *strictHandler) PostPullRequestCreate(ctx *gin.Context) {
 	var request PostPullRequestCreateRequestObject
@@ -818,6 +2543,126 @@ func (sh *strictHandler) GetTeamGet(ctx *gin.Context, params GetTeamGetParams) {
 	}
 }
 
+// GetTeamGetSla operation middleware
+func (sh *strictHandler) GetTeamGetSla(ctx *gin.Context, params GetTeamGetSlaParams) {
+	var request GetTeamGetSlaRequestObject
+
+	request.Params = params
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetTeamGetSla(ctx, request.(GetTeamGetSlaRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetTeamGetSla")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetTeamGetSlaResponseObject); ok {
+		if err := validResponse.VisitGetTeamGetSlaResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostTeamRemoveSla operation middleware
+func (sh *strictHandler) PostTeamRemoveSla(ctx *gin.Context) {
+	var request PostTeamRemoveSlaRequestObject
+
+	var body PostTeamRemoveSlaJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostTeamRemoveSla(ctx, request.(PostTeamRemoveSlaRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostTeamRemoveSla")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PostTeamRemoveSlaResponseObject); ok {
+		if err := validResponse.VisitPostTeamRemoveSlaResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostTeamSetSla operation middleware
+func (sh *strictHandler) PostTeamSetSla(ctx *gin.Context) {
+	var request PostTeamSetSlaRequestObject
+
+	var body PostTeamSetSlaJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostTeamSetSla(ctx, request.(PostTeamSetSlaRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostTeamSetSla")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PostTeamSetSlaResponseObject); ok {
+		if err := validResponse.VisitPostTeamSetSlaResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetUsersGetNotifications operation middleware
+func (sh *strictHandler) GetUsersGetNotifications(ctx *gin.Context, params GetUsersGetNotificationsParams) {
+	var request GetUsersGetNotificationsRequestObject
+
+	request.Params = params
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetUsersGetNotifications(ctx, request.(GetUsersGetNotificationsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetUsersGetNotifications")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetUsersGetNotificationsResponseObject); ok {
+		if err := validResponse.VisitGetUsersGetNotificationsResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // GetUsersGetReview operation middleware
 func (sh *strictHandler) GetUsersGetReview(ctx *gin.Context, params GetUsersGetReviewParams) {
 	var request GetUsersGetReviewRequestObject
@@ -877,3 +2722,36 @@ func (sh *strictHandler) PostUsersSetIsActive(ctx *gin.Context) {
 		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
 	}
 }
+
+// PostUsersSetNotifications operation middleware
+func (sh *strictHandler) PostUsersSetNotifications(ctx *gin.Context) {
+	var request PostUsersSetNotificationsRequestObject
+
+	var body PostUsersSetNotificationsJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostUsersSetNotifications(ctx, request.(PostUsersSetNotificationsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostUsersSetNotifications")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PostUsersSetNotificationsResponseObject); ok {
+		if err := validResponse.VisitPostUsersSetNotificationsResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
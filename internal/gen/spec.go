@@ -18,46 +18,116 @@ import (
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/9xa3U4jRxZ+lVLtSplIHmyYYaX1nTNDZrkYxmuItFqErMJdQCd2t6ermg1ClgBnN7vL",
-	"KGzuVislo1FewOPBwQPYvMKpN1pVVf/a7XaD+Ulyg0x3ddWpU+d85ztf9z6u2Y2mbVGLM1zcx03ikAbl",
-	"1FH/rVHSWCEN+meXOnvygkFZzTGb3LQtXMTwEwygD+fQgQvxBgYwhB6CPlyKEwTnMIRL6MAATsUxzmFT",
-	"PvFaTZTDFmlQXMSckkZV/c5hh752TYcauMgdl+Ywq+3QBpGL8r2mHMy4Y1rbuNXK4S8YdZaNSVb9F06h",
-	"BwNxBH3xjbZPHMFQHCC4gqEy9QyG0FWXe3AhTiaY5zLqVE3jWsa1/JvKgUuOYzsVypq2xai8QL8mjWZd",
-	"/5T35I+abcgpVl6tVT9/9cXKc5zDDcoY2ZZXHcps16lRZNkcbdmuZSgPNB27SR1uUhabKn5ZT7yPqeU2",
-	"cHEdry2VXlaX/rK8uraKc7hcif1+uVR5sSTXlnaUVleXX6x4/1aflVaeLz8vrS15d7WVG7nRzUfsTjq1",
-	"0Inr2rRwfDiXvfklrfGx8XqH48NyuOzW6xX62qWMj3uAMGZuW9SoOnTXpH/zwjoeL94pIxhAB87kX/Gt",
-	"jB8YiGPxdyQOoAdd8UZ8B13oiQMZOehRYW5u4VMZNpw2WMJ2A0OJ45A9+T9x+Y6twilpdM2hhFOjpPaw",
-	"ZTsNwnERG4TTx9xU+WG59TrZlKGjQzDB9872bDM03Xq96mhfTjI0NkbnScIoxgl3WTT2XpWXVnAOe1E2",
-	"Hjsj5z1qStLCUZ8GS+aSznxK3Kzu2E5S8KSe2G/BWUl+kZg/7osGbWx62ROE/O8duoWL+Hf5sITkPezL",
-	"y1leqmeSciHE/alAES0RvhGTzPYWHDPeZFVS4+ZudLlN265TYslHfZhPOht5L5uhYbEInslFVk6yWZax",
-	"a1ub5rs73Uv0JKbva5WTpJTa3a4arkM09I5V7ncKbE9hAD3JJLrqX8UmLqEHP8OpaCONlAj6SAEeEm1U",
-	"riD4IIs+giEMELwXx3CB/PTHuQgc2q4EwIlYaLl+yNZs1+LVphNxlmlxuq3v3jiibu7oiEkJ1VLaZG3Z",
-	"akWTy33hcgVVPBegksLEBrU4WqXOrlmj6NEaZRytEfZVDn1O6nW0UFhYlCVtlzpMn8f8XGGuIPdjN6lF",
-	"miYu4idzhbknEmAI31Enmm+GOJrXZ6OO3dblWB6+Ou1lQ5pkMx7B3Wd6uHYJZfwz29jTvMXi1FLPk2az",
-	"btbUDPkvmQ6aCIeKQDR253ECKuOm83i+UJhPBMUiLhkGYpQ4tR3citK6h6gEM6J6clTEmau6oNmo2thC",
-	"Yf56DtcJkUSr1rG7IOP4iTTkNs8lLJC6LrZSDkqbl1aYonSx1Up0WRyUyhUkDmEIZ3Aquxl5mE8LTzN4",
-	"LbQxzZ54h5CwPvwHurp9yUd7KuhIwtrTrPWj1/Eca+v+eL0zHW1Eoo1B2IiUK8g0EKk7lBh7iH5tMs5G",
-	"zmKmfUo/t+Fn6CFxKNriX9ATh+IIuqINPXGkV3IbDSJbPgzv/BMRR+KNKgB9BB3tKeki1fx9K+eAc3kr",
-	"yu376hk4hSFaSKb30IezkQ42mF0cQEeCNNlWQR+JJ4Y3pJUxRFQ1KjMgvlSjZ8DDyWmWljRT8WsKMt0M",
-	"eQr3gzxhW4RlhXs8X3i88HRtfqH45Glx8Q9/vTVs8sj6/aMTdBVAqWwZihMlyfSRb849o5W0ZxSWRnP3",
-	"rcqrnkpRP3vPoQPnntHoEfTVk5dwBUOl0QzEkUreE8nwrlSedsQ/oC9OPs2eiw7V0ZM5HSv+AzNkpF03",
-	"qgEd1IF6oySNzXMjEjKVXkSXePiUlizTXbxzMiH30KyTGjWqmzI63UV8exk8MnmK8jSELgzhAwzHC5Kq",
-	"NulH6eD4ShsZkAPeqtl7Y7JXX7dex1osVb3YAIYPgiR9uJDFO1m1fZOENNekP562ISuEMj0EqR/0GnDm",
-	"d58DcaJ5g8SkQ7iAHgok011SdydRqWBQSKVqxLJsjnw8Qrale1kDlSvaFZb9jFiGaXjdVNwucaTIiwR8",
-	"0YYrT6dUECqJYV/TIumrNNNGdN3QOstGuuVEXkiptrHm24NMC8kO1TeUl7z8HTH0beqh6RZ9NPaS2Nhl",
-	"+iZiWnVUNvc6X5Mp5dwHGcRtxHdM5nn69ugr/AAdcSDa4p9hEp3qQhdIyXAl0xm6Mq6RV8YS8k+cjFfM",
-	"8aEei5UkdQDn8raqkZNARPkawam0UQ5RwzTP7enfoy9sUqqqxFCTcbPG8tyTC7dpQj19QflqMFQJi7nY",
-	"G6b1ZKeHQ/LxN1CtjVlLTlyAejq3GBPU8CapfUUtX49hVaZUrPXE50KFaDEmCGlBKVLz53FrI01cuDNN",
-	"bPREx2WwMdlrurroOyWjEBxogWMycIrQG1knF/dPpsr2Thx5HaDkxOoF4Kgz7r2L/1966w6dJJZ8IdpB",
-	"pqu9xHYl2qoYxbYGvUjmBrk3nrauJz1PT1slUl83baMvaO8kaa+TfFkxPgzVrDE16XXyvUfX2+wMaaYo",
-	"i1eaS9FG4js4kxVGtFMCT+Z2nhhGeuclsb5kGLN0W8FrqvX9KVERfR2CS3WzRnErl/7QQvyhz+xNFdvR",
-	"+tEkew31KUXmoFsLqNQtK7V+YX5ol/glNU0Y8W3N4Kgs+B/H2qh6K2FW5mZhNoU0/iFFyDqDfd+hTjq6",
-	"u5tqprGC2EbiECkY6KgZ/M96LqGPHoUOFN+LozwM4b3XsV6IE92OJAIh9OBjVJ6RJ8iiiOAVn0k1SI5/",
-	"QfmDc8ZfTAZdH1NGQudHeC/+DT04l2f2aydEWQN4QgQqjilDUL8kTQtESQzYi2Dkw5KhqMCll79TfWxj",
-	"JFozvkfI/qXI2Hc4Cd+L3OB9etyYbG0DXEnaA0M4R+XKJ/pN0G+P4pUrn4jjXPDFRIq4lkmb8XNLJUks",
-	"txjly6wUfCYxmfipR1cjo2dggBGs3SJ1RrOH7+1/05H2lcwdyOl+TzfugskSR0oVSnGVv9K0biojX/sx",
-	"Qii+12oZfJwYmb+i1PtJt1Pe5rwO6xu4gA58QNCRdRj60JX3dbuV9oXyWKK1gmv7/hfLusBJzuFd0IMj",
-	"F2JqXuT6nyip853olbCJa220/h8AAP//FwgGfSguAAA=",
+	"H4sIAAAAAAAC/+x9bXPbxrX/V9nBvzNN+ocebedBM3emjM04mtiySime3qgqC5ErCQ0JsADoRtejGUtK",
+	"0vTajepO772dzrSZ3ry4b2lajClZor7C4ivcT3LnnF0AC2BBUdaDrXRfJKZIYB/Onj37+51zdvehUXOb",
+	"LdehTuAbMw+NluVZTRpQD/9apFZzzmrSn7WptwFf1Klf8+xWYLuOMWOw79gR67MD1mEvwyfsiA1Yj7A+",
+	"Owx3CTtgA3bIOuyI7YWPDdOw4Y3fYEGm4VhNaswYAbWaVfxsGh79Tdv2aN2YCbw2NQ2/tk6bFlTatD6/",
+	"Q521YN2YmZp+zzSathP/bRrBRguK8gPPdtaMzU3T+MSn3my9qM1/YXusx47CbdYPv+CtD7fZIHxE2DEb",
+	"YEdesAHr4tc99jLcLWh826de1a6P2vR3rmdb3rKCgHpQ8i+XSmOfWmP/Njn2/nLysTo+tvyTHxn5Lm5G",
+	"VeAglT3P9SrUb7mOT+EL+rnVbDX4R/gNPtTcOhQxd2+x+uG9T+ZuGabRpL5vrcG3HvXdtlejxHEDsuq2",
+	"nTrKseW5LeoFNvVTRaW/5gU/NKjTbhozS8ZiuXS3Wv757MLigmEanyyUK8lf8+nPd8uV22VoCbSqtLAw",
+	"e3tO/Fm9WZq7NXurtFg2zFSb75fuwNez9+aq5UrlXsUwjdm5xXJlrnQn/mKhXLk/e7Nc/WSudL80e6f0",
+	"wR0opVJaLFfvzN6dXcQ6PpkrfbL40b3K7KflW8ZyTsSmUXM9jzYs0BoY5FPp0R5oDVenfrgFvxA2CL9m",
+	"ffaMHbA+YV3CXrIBe8464ZfkLfF8uiOE9YmiJ28birbWaWDZDV/RyH+wY2zCgB0QdsR6oNbsJeuzPXYU",
+	"Pg6/FDrPemyfvBVuC/0/YIOoE1mBQ/12QJtY2Y88umrMGP9vIrEhE0IxJz60aaOOqmlsxk22PM/aMDYl",
+	"3XuomL/JdFri6pU8n4yUu/JrWgtyz3MtzT9mGlKDckq8Cr8pxPdtuBNuh09AGIPIPOzi8L1gHXYcPmKD",
+	"cIv1TJAt/7vPDlkvfESatLlCPX9pank8sRO5gfOo5bvOyVLg7YufV3Vvzg3sVbuGCrtAg8B21vx8R2nT",
+	"shuKjv6R7YWPWC/ckpQX9KbHDk3CnrEee8H15zkbSL/hd+E22MR2o2GtNGhkAnNdxZqrlu/ba04zWm2y",
+	"4oZywyfsECsBaXLBvoD/h7+D6cb6rE+gqawbPgm/YV2QNqw0MF+id47xy94J72LZBDoe7kC/4L9kkFZc",
+	"t0EtJ2l63V6jfqBo9X+w71kPJhQUjLXtknCLddmA7UVzf5sdhI/Cx+E2TrqkDcr6IoU5US0SzeLjqpJy",
+	"pvkq1ZlvNxoV+pu26F5aY3hZtF716AOb/lYAg7QIRDvyg3WU6W48XF3y1uT4+HTKmuRUJms0rHaw7hZI",
+	"xjRqHrUCWi9hH1Zdr2kFxoxRtwI6FthNOoqONqm3drYSWu1Go+pxWRY1NPUMxxKKp/zACtq+vLLemy/P",
+	"GaYhVs1l8wTlyDZFVbEs07hKUzXmJ+jNwrrrqZRn6Ij9EISlkkuFJhMwLxOH/jaWqxpZ/A0mSPiY7edm",
+	"jom2JNzCZWkr3GbdcAcMsEnAdsPCzlemQ27u+NoFdumAW+u86STRKgaLP5ito3AXDXpOxm6jnm33K4zo",
+	"iXLPVqMSMNCSvGDFkgsfR0IoUMpdfAfnvu3M8rem8pYn4SmvQEfk/sqEJ2pvUQ9vrlvOGs3306pxRUmU",
+	"vXTrVhVwt2Hix7vlux+UAQnfvXe/nPxVKaf/Lt1cnL3PIfatsvRHpTxXultWguJVz21WAyH9HFYC1foe",
+	"FShN/1hHjS2l5rxtFNUGa0sk+aIaWU/inQUkTt0E3ldl7akxz9T811TnoEqcfdiIF2L28Rm4ayZVSf0l",
+	"//vozxkZmfgyfsl5BBuABdiB36AAmOfhFmCLnfB3rIMzFtm3qvEShFBAWj7ZRbsk5VEWUyCCvwyX9lCZ",
+	"GCdNE6Hi8iAUzRIxg3OzxParUMoDecFQY6wL4ehp2Z3FZCQgLy7RlHpXJJf5huUo2DralNPZSGGHFICs",
+	"7m1UvbajRPIvQa9x8SEJMWJH4VN2pAS9Hh3ODf4uY2jWk3F8PEeeJpAzM0dAO0dlrqnlO9frzOhEIjBj",
+	"0Wa7UjRAC3dKSsDkVqP3C0xen4R/4F4FEHL4FevLnGcPJmH4RJaPRHEOw52cbMKdvLMuPz7Ur1kNK6BV",
+	"azWgXnXdbauYAPsf0YwXBJuYGFxeJ0J/2TbkKBoYOGlok572sROREeROjCFNd9rRyt6gVl1tC/+aSORL",
+	"ZGv9cDsn3HA3YcBYKTI65I7pFQVY8ddJAyMfzyi0waNN26lfqmg5/At3xOyUBMB9GSjVPn4GLempJJta",
+	"IkcHPoreFuhXMnhmZmqoZhX4fU+9EAzrwzAqnjbwr2DCZYkMN+fQr7Jn+W1PgQapZ/m0XrWKSWsepfu0",
+	"XXedjeZpvJqmhEw4N4GlHq0OJxnAIXZBfdSQ4AlwFHQfzVfSRKTIMZbioGrnpjwLhnhZCHsWPobZmDKT",
+	"0GYAJ8CaIuIje4BsJ6Brp1b0RLjpIU7GSdm7onFfCCwVr36wVq23PSsiAlnBiC4KYNzFPxGuHSJgBrvJ",
+	"3SUwEuj1IOEODsxzGH+0b0JoJCJjhimpl9sGc1Zo2RITUXPbTlBteZLgJLmODtJGnWBJiVLdSq+xT2tt",
+	"zw42FmDZ52It1Zu2s+h+RlVC/W9wosPcIKzD9oRxBH/ldvgomiSsw0F86dbd2bnq4r2Py3PgGw530EV4",
+	"BHLlCihWFnZIJiyoFJeLPTYQ7niBlGEkDmDevQy/CX8HthrqeC5M8oto8R8n7CnrpavNVMX6cUVHEXh6",
+	"Hrcel9bwG75yjf/CiQJe69Sq48Bz1Td+PoYSGuMiSpBRy/6YIiAs8U+qlRa7QErzswWd4IK7V7ldLc3P",
+	"Vj8u/+tC3C35S5ip0KsuNJ7gmnXEBkU2BxfKPtsHNY/EyCWQDArvL4I/VD9qedRLOrceBC0ecrOdVRdV",
+	"0Q5A4Y35CqmIuUFKMeAjC9R7YNcoeWuR+gFZtPzPTPKh1WiQ6cnpG8A0H1DP52KZGp8cn0TfSos6Vss2",
+	"Zoxr45Pj1wzkHeuok3zYJlqJr82f8KgfuGIxcFU+6fmKCYI6DJ+Gj3gvARfzge+wo/AJABWCuOCQlCo3",
+	"P5q9X66WPlwsVyLY0GF7Jgm/QKDDRbrLXUt7iCc6spMdEAcChy5hnfBR+CXrs+4MB0nCKQ8jxN/9Jtzm",
+	"Qz0Bk9afWKMBlyKM0W3Paq3/7I5JuG0+ZoMxfD1i+TF87SML6LJOpLVQ+QT45Gw/sGv+xE/keAA4056h",
+	"2vWUzuhxwv6E6xdg4o7o8ctYbPtoGcGf/wJtaSf8PeskdHyLO87gdyA5ivBEB78WywwXJ3aOCyV8mpVd",
+	"PEnjsTKjwYJ/BArMjJuE/LoFnelHsxvWElw8ZuugLa4f4MSWHLp+RegYN7TUDz5w6xs84OsEwrFptVoN",
+	"EXKa+LUIZEnB55w30Gh5Y1OTk1PGphwjTy9tChfixQTRhzsiFUvGZjbQj1/wsDs2fXpy8pQC8ooiLEtG",
+	"exrg4jVjWXY7zxjtKSMV6jDAqoxNTo1NTy5Ovj8zOTkzOfmpIYcykieuL05Nz1y7PnPjnU8Nc8joKL3u",
+	"RqleJz61vNp64v2eiRzsm8NG1DuJcUt6hyUpJJ+1b4VKDrp1/bQDkU2TUKQZxDH2pThcrPBeRwFdUFK7",
+	"2W4Srm6kgQpLbJ9MGZvL6dwLfJ08sBp2HRtIVi27QesZmQ6TXzoDRCEv9rds6J/tc3MuvP9calNnk1oq",
+	"p0LuY9P2fZCC65G285nj/tYhVssmn9GN8+5kBuBH8B4XDwAbYI+3EB0cJYBgOCiJ3+e2nMsujYRQeNdH",
+	"EN459XS+IgLv6WWD9XhL3j/bMMpZOskYzleIXSdWw6NWfYPQz20/8M9z9OYrJNwiOJsBsh1CbRyiET5m",
+	"4NPaEkMrRiTpOXr9sPfTZ+x9Jk9ImqhAlxp20w4I/bxGaf2cp+i3AjY8Dr/m6vmSw4lwOzVTuZvnAH/l",
+	"RL1jmAKpo7mt0MDbGCuBF+VUTqQtCBOGO+BH42BiF0F2DHIA3zzjgDvi/4IQDc2Ay5I+7PqNsxroXNJX",
+	"Nl3LuLY6VXvfmqbvrlyvX6eTVmosoTWeYzWIT70H1CO8+PMczT8BwUByxVEkxsSSHLBOisewjoFCuXY2",
+	"oagT304nGV+wl4A2W65neXZjg7Qd64Flc55/njJ6ypkGEc4YkZB2hDMcI9LhDjsGPmKmZgB4MQbs+5gi",
+	"sK4g3xjvvpjJkK4j1ZzTar/kekAwITsdlpYTDr20vGlmfwXo4LebTcvb4ErGVySepIZ+NfBSJ2YRTENg",
+	"rSGgxJKMZahfcEpwT/kToGQbxVSShxxwWTzkrCfKGRQoAuglBHuAncEX4RYfPNYBQ9VFPf8y5abnTAjp",
+	"TRcL7kYFcwchDPEz1I0B2x8ncT5EL+Ps5xRPxfyxAuRrewlFNIsf5nKLwiT9XHtTXBf8hKI8EdRCtw+w",
+	"ZZDDcyivww7Ahcq64pVt+BLsp0Cq4W7sbiHsu0ysqp/2tYpJwVd7WbqYeAju2qS2lCeHvIVdyfpbSVSC",
+	"zJ25L4irOOu/bRLsE1Q9KA68pYJOcWGxGCHjRBJE+JgdcvYud7bDDlPChmHtxNOtM07YX7Lh43RAcTfV",
+	"jcghBOsTJ9A4Vw9iT9k/iAjZ/QvM1SG0HhqwTTgAOY7CmSIOhNMfLTdP5kVtDXeGkmuI+fklnGxmKs1+",
+	"SelqlMJKMFzo8hChvag5BXnpSUgysUN1umq1G4Exs2o1fJqP720ucyv2Sjwf7Qj2I07BWUrFXbhVjL25",
+	"nMQm4ROj1LBrEGAe/tK19Es3Lc9tIJmSvPPGilX7jEL++vIQRioafIoY+AkZQopgl385LoQ4oL+UJAal",
+	"k4BU0hlBrqZUXjZz6MQip9NFfuCu4EDF+QJCCzMx/yVFchxvXi73jFcxxMG0PDJOiZMllKA8mviZHBa2",
+	"b+bSGqIFMaGM4fZleiNQ6yDhPMo9n5Rzz2PPBHxF2lPgi2jYfkDrxHZI0/UoCdYth7gOgD+rqd0UP0g3",
+	"habHmh5reqzp8T8DPebMNbKlfQV7VKUJYshdRDpB7FGOmES8wp2hvJoHNjHhYwiv/jPPk83ZLkwsExmN",
+	"SRoAT7TZ4olnfLct1wdJOcYJ+1YdB4+YL3YXqWS4Ff+5Hy20xziuXVAvzveBHR0K8Q1IvMunywYJsUeJ",
+	"PMP8vK9gO6MJv4sli0eNpbBmnyzcKZlxqna8VUHKbs63AgbAJLiLSZEOjYFuJJHHRX1XUOTkbdaJ3A6v",
+	"meki6e+A8vGodUYH+kl6eFLA+CjkHm1IgVRxJftDuC2UPBNBx0SJzAABhYZ+CnKNyhnTYR6TT+VAfh/u",
+	"AByBnsT0WQ7pP080i+csjJPcNGp71B/KrSFTyi/jhDsDj00RmCH08aJzxwuSmy6FT9Ikz1DKLBQx5Omx",
+	"yWuLU5NJlFnKJBSPj91YnaxNW+/X35tauXadvvuuOt1tZkrNIjfPRAqLmzAyU8wnVwppDFvL5QRNVSr7",
+	"+aedRw07OdlcSWrVhhIxxdPw0eXSVgVF1cFzHTy/sOB5MUY6irLm93kStCbMmjBrwqwJ8z8NYQZgjz2I",
+	"wkxFxyGNwn0BtEOT16iK/f4JRELCLZlKhLsFKwnPB45iwF1Ogo7Cr1hvPMcJbtMMJcB2nBMs5sjltQDj",
+	"EWDq6FgzA1hHgZojYsv/TOiehkXaWa+xh8YeGnto7HEy9pAWjgwsYPsFiEPaEzXBt0TI7va8q1DaZnCT",
+	"P34GZ2FuU8YZ9lMMWdpTJyhd0EkVl7XlpuA0J6mu6RvvnO5wjNOe2/RqPtSpy9nWcz47cvDkq8vfj5Pk",
+	"W17uHhztPvyB4uTLdwz+MVoZJzKnV+U8g+HjK77Thm+swYPkfs96maPkuhwP42bTfrynNL/RSNMTTU80",
+	"PdH05I2mJ5J3M94QEu+WiRMwwNgj54Dt9Vv8kG75/KR+cgz0tPo8W0V2Riq9Q+Iw8jZ7BZXBrdsjM5m7",
+	"+LTepf/m7dLPbsGfmvzBbcFPsuJgDeNpSlFzNAXQFOCqUQCxsV5nAWioq6GuhrpXFup+izC0F26Lkucr",
+	"UXIxX51hZzBat0Ock9tizvO7K7KH1L09OnSVT64dCb1GSY9nAbCQB5rZe/hKmDZVzpV3to900P6lphYP",
+	"xdG4CfXGhbvFoQ+thlWj9eoKzJT2DeP8YHOm8CG3pAxw3cOzDnJnxZ08lJ6RrmnEpN+iO3F6/IA8+eTJ",
+	"gYbvGr5fTfgumnx8qiTf0zjyxV0jwO2x6cmq+zdeB3sRnbSLRwqLTDrhR4/vvHtgNdpFQYH4oUQJapbj",
+	"uAGJFljiOvzc3jqZr3BROO5Ny6nDLKD5dgHcT8EfjooORIijz91i4pT8wqZlLuZLWue4hB/bQIRdwpNQ",
+	"a1F7YIc531POGxqUxCKQaejwzGx+HHHWgCkvAxveidRlg7ItEYe52j5ehBitVCRwSbBu+0LS5zrlOnhe",
+	"y9eJJd7jyC0BrsfinJw+e5lMT8XR7pqmapqqaaqmqVeLpubNmagDM7xhNy6y2KNCtKy6xFHEYsSu3tz9",
+	"IMVUVtr9Gl1yJbLXcznmC/GjeDxQ7kQnlXIkj0ykL1bmpy+dhVulLwS4Pn5j2CE9ftXHWwWWlO8lJ/bf",
+	"ME84xGloUvrF3VGQHdH8tQSnuqkkLZRTZNCDDpyYPp/UnKrHTMtnJAr3D34CmDiqDbeUZ4WhaZumbVcv",
+	"8eqvw7OtWEeDWw1uNbjV4PaqxWBexpeV8NMV0ss3Xr6WgaisJ0HUGGTm8Wlb3HV2Mj4FpHJqfAovzdYv",
+	"EJ2eBmWOqpcJJhsVPBXtcNUwSsOoqwej9MEWGk5pOKXhlIZTWd8hHuD4DXvBr7wdgrDAWzNh1evDE1jA",
+	"e1eq18+StHKhJ6UXHL8tewRb1gY/MexU52RfyHbJyNX6ukUSH7VxwsnxIwpqFI9e2vEhb6EUPo+zmn+4",
+	"2F+1xyzu9wXuM8v2rnjPWQy1UsbrKIU741C9BpoqoKnRnEZzGs1pNHcl9+Klglg7eA+g4vBk1idvJUsk",
+	"3Jo7kb5SSbqcKsuCWY/ty3nMi/yGlgTzCT9akTsNnr9Ng9ce531jMNLpUWNGCf/OnoX/juq2rYOY2vum",
+	"g5gap2mcpnGaxmlvtNdtVKR2AtRaaFgjoC146rUn1rUDt5psa+MCpH7NalgBrVowctV1tw2Nu/6eaTSo",
+	"VZcgmEebtlNPPzZ9/Rwg1cKdkkpnF+6UXiOYklPdNJzScOpMffgud5gKvwMXNV8DKQ2kNJDSQOrqAilY",
+	"qeVbu4p2KeTQk0eb7gMqANTwEGUlfvSMlzsPhSr5AJnqZNep6fdOd7JrUtClbE4/SzfP1pE8ggu3+M5R",
+	"jd00dtPYTWM3jd00dtPY7XUGK6ODHF4Ztfk0yEC2/LKPcYL9dF4Mbm7N3ks7X5FPFi08fLQPuTaIN+Ur",
+	"WQei2Oz5AYq9teNEkn7elZV7I3/LMFZ0jM3qsyOxMPVMOFg6KlflSwOn4pdoxsCyhn/AkYd1X5xIxe0u",
+	"OCDhbqhwi6S8dGbhxb1Psxf3iszAjAsz3MnI0oSxwduaD9ggFnt0ZzBIOXwqegsCZ0e8+/H1u9GJP3BB",
+	"Mx8xbBSYGOwHvtwl4ReYuIgXD3dgQLugcNWbH5VvflxFa3q/dKfoHlz0CkY+07PcavE63J25Oy/kRsBc",
+	"WbXajcCYWbUaPo1R9IrrNqiFVyWpmwhdqzXavv2A3uVQNupS0/qc//neu+9MmhHQNWYmFXuH406ecFKX",
+	"025w+yvqePUzwlRyPJ++XAA9Uza3YEQuhcppl/3otE8pFpkBtv2ArFCyhjvxPRKsW45iKTDJihusEysg",
+	"DWr5AbGIT2suXlunaaKmiaNlTIjm47AVXrmhqaOmjpo6aup4Rajjf7FOnOX6KuSRX6e7RoM5N7BXhbL4",
+	"w3In8N7b29kXXu/+b9q07AYC9pWfiq/Ha27TMPkvVQ7V+H6kCK/hD3V7jfoJ7k/lqo6sqbIkFmgQ2M6a",
+	"X3gc3xZO6gHb53eAHOP87bFDnZSqIZbeEq7BlQZXGlxpcPWGJqceDVnAiw97SZAXYqcs8qrgscCjQC7x",
+	"5OvFWvKJ+Lz6Cz1Qf3kYKiu+M8wf+aBF6ZjOhXXXUxy4KLXgpFyMBFmlGzPa8YtCmQbsgMxXfswXE32C",
+	"kIaLGi5quKjhooaLGi5eKbg4X/kxhvafg0kbemPISBdOFINInwazfknsuh6WtIuvLkhPnyGSL230PtF7",
+	"lsZp0psPFaH2i74iqxCyJe26lDBydMJmXpDFJ6sP2Ug/ROBRTSedbTnioUJ/l85EeMqOIrxVoN8aqWqk",
+	"qpGqRqoaqWqkqpHqm4FUv+OHW4pVnJcefgH5r+w5gWtc8cDLLvyOT/ZfzbnpK8LK6vRkVWj0Be7055fc",
+	"fRNZhK+gWjwGYMAOTfIrDOP+ikBqpMhj5mtJlB/cBaEKL234BMLh48rs1ggZZ6ParwyPLz4kncZ4or4U",
+	"Zp6+kQbN14bnkP7yp7/4hb/8/38afVCmjiparwLx6b68UTCfS0rVk0yzL4UFXJHUBRXm7ykCIpeaWyoG",
+	"8mSsf01jfY31NdbXWF9jfY31/4kzROEjtAe6KDD9ngg6H3CL3Ulh5VPBfqiW1tqeHWzgMl1q2R9T+Li8",
+	"aT7cXI7feGgItyXPQ9004y94UdIXqSs0pe8/olYjWJe/Sc7Zl74s1Zu2A5dI/t8AAaZWAoXsAAA=",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
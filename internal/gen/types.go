@@ -7,14 +7,25 @@ import (
 	"time"
 )
 
+const (
+	AdminTokenScopes = "AdminToken.Scopes"
+	ApiKeyScopes     = "ApiKey.Scopes"
+)
+
 // Defines values for ErrorResponseErrorCode.
 const (
-	NOCANDIDATE ErrorResponseErrorCode = "NO_CANDIDATE"
-	NOTASSIGNED ErrorResponseErrorCode = "NOT_ASSIGNED"
-	NOTFOUND    ErrorResponseErrorCode = "NOT_FOUND"
-	PREXISTS    ErrorResponseErrorCode = "PR_EXISTS"
-	PRMERGED    ErrorResponseErrorCode = "PR_MERGED"
-	TEAMEXISTS  ErrorResponseErrorCode = "TEAM_EXISTS"
+	INTERNALERROR      ErrorResponseErrorCode = "INTERNAL_ERROR"
+	NOCANDIDATE        ErrorResponseErrorCode = "NO_CANDIDATE"
+	NOTASSIGNED        ErrorResponseErrorCode = "NOT_ASSIGNED"
+	NOTFOUND           ErrorResponseErrorCode = "NOT_FOUND"
+	PREXISTS           ErrorResponseErrorCode = "PR_EXISTS"
+	PRMERGED           ErrorResponseErrorCode = "PR_MERGED"
+	RATELIMITED        ErrorResponseErrorCode = "RATE_LIMITED"
+	SERVICEUNAVAILABLE ErrorResponseErrorCode = "SERVICE_UNAVAILABLE"
+	TEAMEXISTS         ErrorResponseErrorCode = "TEAM_EXISTS"
+	UNAUTHORIZED       ErrorResponseErrorCode = "UNAUTHORIZED"
+	USEREXISTS         ErrorResponseErrorCode = "USER_EXISTS"
+	VALIDATIONERROR    ErrorResponseErrorCode = "VALIDATION_ERROR"
 )
 
 // Defines values for PullRequestStatus.
@@ -29,17 +40,54 @@ const (
 	PullRequestShortStatusOPEN   PullRequestShortStatus = "OPEN"
 )
 
+// Defines values for TeamChangeAction.
+const (
+	ACTIVATE     TeamChangeAction = "ACTIVATE"
+	ADDMEMBER    TeamChangeAction = "ADD_MEMBER"
+	ADDTEAM      TeamChangeAction = "ADD_TEAM"
+	DEACTIVATE   TeamChangeAction = "DEACTIVATE"
+	MOVEMEMBER   TeamChangeAction = "MOVE_MEMBER"
+	REMOVEMEMBER TeamChangeAction = "REMOVE_MEMBER"
+	RENAME       TeamChangeAction = "RENAME"
+)
+
 // ErrorResponse defines model for ErrorResponse.
 type ErrorResponse struct {
 	Error struct {
-		Code    ErrorResponseErrorCode `json:"code"`
-		Message string                 `json:"message"`
+		Code ErrorResponseErrorCode `json:"code"`
+
+		// CorrelationId Идентификатор для поиска ошибки в логах (для INTERNAL_ERROR и SERVICE_UNAVAILABLE)
+		CorrelationId *string `json:"correlation_id,omitempty"`
+
+		// Details Список невалидных полей (только для VALIDATION_ERROR)
+		Details *[]FieldError `json:"details,omitempty"`
+		Message string        `json:"message"`
 	} `json:"error"`
 }
 
 // ErrorResponseErrorCode defines model for ErrorResponse.Error.Code.
 type ErrorResponseErrorCode string
 
+// FieldError defines model for FieldError.
+type FieldError struct {
+	// Field Путь до поля в запросе, например members[1].user_id
+	Field  string `json:"field"`
+	Reason string `json:"reason"`
+}
+
+// NotificationSettings defines model for NotificationSettings.
+type NotificationSettings struct {
+	// Email Адрес для писем, без него писем нет
+	Email *string `json:"email"`
+
+	// EmailAssignments Письмо при назначении ревьювером и при переназначении ревью на другого
+	EmailAssignments bool `json:"email_assignments"`
+
+	// EmailDigest Ежедневная сводка открытых ревью
+	EmailDigest bool   `json:"email_digest"`
+	UserId      string `json:"user_id"`
+}
+
 // PullRequest defines model for PullRequest.
 type PullRequest struct {
 	// AssignedReviewers user_id назначенных ревьюверов (0..2)
@@ -66,12 +114,43 @@ type PullRequestShort struct {
 // PullRequestShortStatus defines model for PullRequestShort.Status.
 type PullRequestShortStatus string
 
+// Reassignment defines model for Reassignment.
+type Reassignment struct {
+	// NewReviewerId Новый ревьювер, отсутствует, если заменить некем и ревьювер просто снят
+	NewReviewerId *string `json:"new_reviewer_id,omitempty"`
+	OldReviewerId string  `json:"old_reviewer_id"`
+	PullRequestId string  `json:"pull_request_id"`
+}
+
 // Team defines model for Team.
 type Team struct {
 	Members  []TeamMember `json:"members"`
 	TeamName string       `json:"team_name"`
 }
 
+// TeamChange defines model for TeamChange.
+type TeamChange struct {
+	Action TeamChangeAction `json:"action"`
+
+	// FromTeam Прежняя команда (только для MOVE_MEMBER)
+	FromTeam *string `json:"from_team,omitempty"`
+
+	// FromUsername Прежнее имя пользователя (только для RENAME)
+	FromUsername *string `json:"from_username,omitempty"`
+
+	// TeamName Команда после изменения, для REMOVE_MEMBER — команда, из которой удаляется участник
+	TeamName string `json:"team_name"`
+
+	// UserId Пусто для ADD_TEAM
+	UserId *string `json:"user_id,omitempty"`
+
+	// Username Имя пользователя после изменения
+	Username *string `json:"username,omitempty"`
+}
+
+// TeamChangeAction defines model for TeamChange.Action.
+type TeamChangeAction string
+
 // TeamMember defines model for TeamMember.
 type TeamMember struct {
 	IsActive bool   `json:"is_active"`
@@ -79,6 +158,33 @@ type TeamMember struct {
 	Username string `json:"username"`
 }
 
+// TeamPlan defines model for TeamPlan.
+type TeamPlan struct {
+	Changes []TeamChange `json:"changes"`
+
+	// DryRun План не применён
+	DryRun bool `json:"dry_run"`
+
+	// Reassignments Открытые ревью удалённых участников
+	Reassignments []Reassignment `json:"reassignments"`
+}
+
+// TeamSLA defines model for TeamSLA.
+type TeamSLA struct {
+	// AutoReassign При эскалации передать ревью другому участнику команды
+	AutoReassign bool `json:"auto_reassign"`
+
+	// EscalateAfterHours Через сколько часов после назначения ревью эскалируется лиду команды
+	EscalateAfterHours float32 `json:"escalate_after_hours"`
+
+	// LeadId Кому уходит эскалация, без лида она только пишется в лог
+	LeadId *string `json:"lead_id"`
+
+	// RemindAfterHours Через сколько часов после назначения ревьюверу приходит напоминание
+	RemindAfterHours float32 `json:"remind_after_hours"`
+	TeamName         string  `json:"team_name"`
+}
+
 // User defines model for User.
 type User struct {
 	IsActive bool   `json:"is_active"`
@@ -87,6 +193,18 @@ type User struct {
 	Username string `json:"username"`
 }
 
+// UserErasure defines model for UserErasure.
+type UserErasure struct {
+	ErasedAt time.Time `json:"erased_at"`
+
+	// Pseudonym Идентификатор, который теперь носят пользователь, его PR и ревью
+	Pseudonym string `json:"pseudonym"`
+
+	// ReassignedReviews Сколько открытых ревью было передано или снято
+	ReassignedReviews int    `json:"reassigned_reviews"`
+	TeamName          string `json:"team_name"`
+}
+
 // UserStat defines model for UserStat.
 type UserStat struct {
 	// AvgDuration Среднее время между create и merge у PR где он был reviewer
@@ -102,6 +220,27 @@ type TeamNameQuery = string
 // UserIdQuery defines model for UserIdQuery.
 type UserIdQuery = string
 
+// PostAdminPullRequestsRestoreJSONBody defines parameters for PostAdminPullRequestsRestore.
+type PostAdminPullRequestsRestoreJSONBody struct {
+	PullRequestId string `json:"pull_request_id"`
+}
+
+// PostAdminTeamsApplyJSONBody defines parameters for PostAdminTeamsApply.
+type PostAdminTeamsApplyJSONBody struct {
+	Teams []Team `json:"teams"`
+}
+
+// PostAdminTeamsApplyParams defines parameters for PostAdminTeamsApply.
+type PostAdminTeamsApplyParams struct {
+	// DryRun Только посчитать план
+	DryRun *bool `form:"dry_run,omitempty" json:"dry_run,omitempty"`
+}
+
+// PostAdminUsersEraseJSONBody defines parameters for PostAdminUsersErase.
+type PostAdminUsersEraseJSONBody struct {
+	UserId string `json:"user_id"`
+}
+
 // PostPullRequestCreateJSONBody defines parameters for PostPullRequestCreate.
 type PostPullRequestCreateJSONBody struct {
 	AuthorId        string `json:"author_id"`
@@ -138,6 +277,32 @@ type GetTeamGetParams struct {
 	TeamName TeamNameQuery `form:"team_name" json:"team_name"`
 }
 
+// GetTeamGetSlaParams defines parameters for GetTeamGetSla.
+type GetTeamGetSlaParams struct {
+	// TeamName Уникальное имя команды
+	TeamName TeamNameQuery `form:"team_name" json:"team_name"`
+}
+
+// PostTeamRemoveSlaJSONBody defines parameters for PostTeamRemoveSla.
+type PostTeamRemoveSlaJSONBody struct {
+	TeamName string `json:"team_name"`
+}
+
+// PostTeamSetSlaJSONBody defines parameters for PostTeamSetSla.
+type PostTeamSetSlaJSONBody struct {
+	AutoReassign       *bool   `json:"auto_reassign,omitempty"`
+	EscalateAfterHours float32 `json:"escalate_after_hours"`
+	LeadId             *string `json:"lead_id"`
+	RemindAfterHours   float32 `json:"remind_after_hours"`
+	TeamName           string  `json:"team_name"`
+}
+
+// GetUsersGetNotificationsParams defines parameters for GetUsersGetNotifications.
+type GetUsersGetNotificationsParams struct {
+	// UserId Идентификатор пользователя
+	UserId UserIdQuery `form:"user_id" json:"user_id"`
+}
+
 // GetUsersGetReviewParams defines parameters for GetUsersGetReview.
 type GetUsersGetReviewParams struct {
 	// UserId Идентификатор пользователя
@@ -150,6 +315,23 @@ type PostUsersSetIsActiveJSONBody struct {
 	UserId   string `json:"user_id"`
 }
 
+// PostUsersSetNotificationsJSONBody defines parameters for PostUsersSetNotifications.
+type PostUsersSetNotificationsJSONBody struct {
+	Email            *string `json:"email"`
+	EmailAssignments bool    `json:"email_assignments"`
+	EmailDigest      bool    `json:"email_digest"`
+	UserId           string  `json:"user_id"`
+}
+
+// PostAdminPullRequestsRestoreJSONRequestBody defines body for PostAdminPullRequestsRestore for application/json ContentType.
+type PostAdminPullRequestsRestoreJSONRequestBody PostAdminPullRequestsRestoreJSONBody
+
+// PostAdminTeamsApplyJSONRequestBody defines body for PostAdminTeamsApply for application/json ContentType.
+type PostAdminTeamsApplyJSONRequestBody PostAdminTeamsApplyJSONBody
+
+// PostAdminUsersEraseJSONRequestBody defines body for PostAdminUsersErase for application/json ContentType.
+type PostAdminUsersEraseJSONRequestBody PostAdminUsersEraseJSONBody
+
 // PostPullRequestCreateJSONRequestBody defines body for PostPullRequestCreate for application/json ContentType.
 type PostPullRequestCreateJSONRequestBody PostPullRequestCreateJSONBody
 
@@ -162,5 +344,14 @@ type PostPullRequestReassignJSONRequestBody PostPullRequestReassignJSONBody
 // PostTeamAddJSONRequestBody defines body for PostTeamAdd for application/json ContentType.
 type PostTeamAddJSONRequestBody = Team
 
+// PostTeamRemoveSlaJSONRequestBody defines body for PostTeamRemoveSla for application/json ContentType.
+type PostTeamRemoveSlaJSONRequestBody PostTeamRemoveSlaJSONBody
+
+// PostTeamSetSlaJSONRequestBody defines body for PostTeamSetSla for application/json ContentType.
+type PostTeamSetSlaJSONRequestBody PostTeamSetSlaJSONBody
+
 // PostUsersSetIsActiveJSONRequestBody defines body for PostUsersSetIsActive for application/json ContentType.
 type PostUsersSetIsActiveJSONRequestBody PostUsersSetIsActiveJSONBody
+
+// PostUsersSetNotificationsJSONRequestBody defines body for PostUsersSetNotifications for application/json ContentType.
+type PostUsersSetNotificationsJSONRequestBody PostUsersSetNotificationsJSONBody
@@ -0,0 +1,218 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	StatusOK   = "ok"
+	StatusFail = "fail"
+)
+
+type Database interface {
+	Ping(ctx context.Context) error
+	MigrationVersion(ctx context.Context) (int64, error)
+}
+
+type Check struct {
+	Status  string         `json:"status"`
+	Error   string         `json:"error,omitempty"`
+	Details map[string]any `json:"details,omitempty"`
+}
+
+type Report struct {
+	Status string           `json:"status"`
+	Checks map[string]Check `json:"checks,omitempty"`
+}
+
+// BeatInterval is how often a worker beats while it waits for its next run, so the
+// timeout of a worker need not cover the wait.
+const BeatInterval = 10 * time.Second
+
+type Worker struct {
+	name     string
+	timeout  time.Duration
+	lastBeat atomic.Int64
+	stopped  atomic.Bool
+}
+
+func (w *Worker) Beat() {
+	w.lastBeat.Store(time.Now().UnixNano())
+}
+
+func (w *Worker) Stop() {
+	w.stopped.Store(true)
+}
+
+type workerKey struct{}
+
+// WithWorker stores the worker in ctx for the loop that runs under it.
+func WithWorker(ctx context.Context, w *Worker) context.Context {
+	return context.WithValue(ctx, workerKey{}, w)
+}
+
+// Beat marks the worker of ctx alive. Contexts without one, like the ones of tests and
+// of the CLI commands, are left alone.
+func Beat(ctx context.Context) {
+	if w, ok := ctx.Value(workerKey{}).(*Worker); ok {
+		w.Beat()
+	}
+}
+
+type Checker struct {
+	db              Database
+	expectedVersion int64
+	shuttingDown    atomic.Bool
+
+	mu      sync.RWMutex
+	workers map[string]*Worker
+}
+
+func InitChecker(db Database, expectedVersion int64) *Checker {
+	return &Checker{
+		db:              db,
+		expectedVersion: expectedVersion,
+		workers:         make(map[string]*Worker),
+	}
+}
+
+func (c *Checker) RegisterWorker(name string, timeout time.Duration) *Worker {
+	worker := &Worker{name: name, timeout: timeout}
+	worker.Beat()
+
+	c.mu.Lock()
+	c.workers[name] = worker
+	c.mu.Unlock()
+
+	return worker
+}
+
+func (c *Checker) SetShuttingDown() {
+	c.shuttingDown.Store(true)
+}
+
+func (c *Checker) IsShuttingDown() bool {
+	return c.shuttingDown.Load()
+}
+
+func (c *Checker) Liveness() Report {
+	return Report{Status: StatusOK}
+}
+
+func (c *Checker) Readiness(ctx context.Context) Report {
+	report := Report{
+		Status: StatusOK,
+		Checks: map[string]Check{
+			"shutdown":   c.checkShutdown(),
+			"database":   c.checkDatabase(ctx),
+			"migrations": c.checkMigrations(ctx),
+			"workers":    c.checkWorkers(),
+		},
+	}
+
+	for _, check := range report.Checks {
+		if check.Status != StatusOK {
+			report.Status = StatusFail
+		}
+	}
+
+	return report
+}
+
+func (c *Checker) checkShutdown() Check {
+	if c.IsShuttingDown() {
+		return Check{Status: StatusFail, Error: "service is shutting down"}
+	}
+
+	return Check{Status: StatusOK}
+}
+
+func (c *Checker) checkDatabase(ctx context.Context) Check {
+	start := time.Now()
+
+	if err := c.db.Ping(ctx); err != nil {
+		return Check{Status: StatusFail, Error: err.Error()}
+	}
+
+	return Check{
+		Status:  StatusOK,
+		Details: map[string]any{"latency_ms": time.Since(start).Milliseconds()},
+	}
+}
+
+func (c *Checker) checkMigrations(ctx context.Context) Check {
+	version, err := c.db.MigrationVersion(ctx)
+	if err != nil {
+		return Check{Status: StatusFail, Error: err.Error()}
+	}
+
+	details := map[string]any{
+		"current":  version,
+		"expected": c.expectedVersion,
+	}
+
+	if version != c.expectedVersion {
+		return Check{
+			Status:  StatusFail,
+			Error:   fmt.Sprintf("schema version %d, expected %d", version, c.expectedVersion),
+			Details: details,
+		}
+	}
+
+	return Check{Status: StatusOK, Details: details}
+}
+
+func (c *Checker) checkWorkers() Check {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	check := Check{Status: StatusOK}
+
+	if len(c.workers) == 0 {
+		return check
+	}
+
+	names := make([]string, 0, len(c.workers))
+	for name := range c.workers {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	check.Details = make(map[string]any, len(names))
+
+	var failed []string
+
+	for _, name := range names {
+		worker := c.workers[name]
+		silence := time.Since(time.Unix(0, worker.lastBeat.Load()))
+
+		status := StatusOK
+
+		switch {
+		case worker.stopped.Load():
+			status = "stopped"
+		case silence > worker.timeout:
+			status = StatusFail
+
+			failed = append(failed, name)
+		}
+
+		check.Details[name] = map[string]any{
+			"status":          status,
+			"last_beat_ago_s": int64(silence.Seconds()),
+		}
+	}
+
+	if len(failed) != 0 {
+		check.Status = StatusFail
+		check.Error = fmt.Sprintf("workers not responding: %v", failed)
+	}
+
+	return check
+}
@@ -0,0 +1,375 @@
+// Package loadgen drives a running instance of the service with a weighted mix of calls
+// and measures throughput, latency and errors.
+package loadgen
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"avito/internal/gen"
+)
+
+const codeTransport = "TRANSPORT"
+
+type Config struct {
+	BaseURL string
+	// Token is sent as a Bearer token, the rate limiter keys clients by it.
+	Token        string
+	Teams        int
+	UsersPerTeam int
+	Concurrency  int
+	// The run stops after Duration or after Requests calls, whichever comes first.
+	// Zero Requests means no limit.
+	Duration time.Duration
+	Requests int
+	Mix      Mix
+	// RunID prefixes the ids of seeded teams, users and pull requests, so runs against
+	// the same database do not collide.
+	RunID  string
+	Client *http.Client
+}
+
+type openPR struct {
+	id        string
+	reviewers []string
+}
+
+type runner struct {
+	cfg   Config
+	users []string
+	teams []string
+
+	mu   sync.Mutex
+	open []openPR
+
+	prSeq atomic.Int64
+	calls atomic.Int64
+}
+
+type sample struct {
+	latency time.Duration
+	code    string
+}
+
+// Run seeds the teams and then runs the load. Seeding is not measured, a failed seed
+// aborts the run.
+func Run(ctx context.Context, cfg Config) (*Report, error) {
+	if cfg.Client == nil {
+		cfg.Client = http.DefaultClient
+	}
+
+	if cfg.Concurrency < 1 || cfg.Teams < 1 || cfg.UsersPerTeam < 1 {
+		return nil, errors.New("concurrency, teams and users per team must be positive")
+	}
+
+	if cfg.Duration <= 0 && cfg.Requests <= 0 {
+		return nil, errors.New("either duration or requests must be set")
+	}
+
+	r := &runner{cfg: cfg}
+
+	if err := r.seed(ctx); err != nil {
+		return nil, fmt.Errorf("seeding teams: %w", err)
+	}
+
+	if cfg.Duration > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, cfg.Duration)
+		defer cancel()
+	}
+
+	samples := make([]map[string][]sample, cfg.Concurrency)
+
+	var wg sync.WaitGroup
+
+	start := time.Now()
+
+	for i := range cfg.Concurrency {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			samples[i] = r.work(ctx, rand.New(rand.NewPCG(uint64(start.UnixNano()), uint64(i))))
+		}()
+	}
+
+	wg.Wait()
+
+	return buildReport(time.Since(start), cfg.Mix, samples), nil
+}
+
+func (r *runner) seed(ctx context.Context) error {
+	for i := range r.cfg.Teams {
+		team := gen.Team{TeamName: fmt.Sprintf("%s-team-%d", r.cfg.RunID, i)}
+
+		for j := range r.cfg.UsersPerTeam {
+			id := fmt.Sprintf("%s-u%d-%d", r.cfg.RunID, i, j)
+			team.Members = append(team.Members, gen.TeamMember{UserId: id, Username: id, IsActive: true})
+			r.users = append(r.users, id)
+		}
+
+		r.teams = append(r.teams, team.TeamName)
+
+		status, code, err := r.call(ctx, http.MethodPost, "/team/add", team, nil)
+		if err != nil {
+			return err
+		}
+
+		if status != http.StatusCreated {
+			return fmt.Errorf("team %s: %d %s", team.TeamName, status, code)
+		}
+	}
+
+	return nil
+}
+
+func (r *runner) work(ctx context.Context, rnd *rand.Rand) map[string][]sample {
+	samples := make(map[string][]sample)
+
+	for ctx.Err() == nil {
+		if r.cfg.Requests > 0 && r.calls.Add(1) > int64(r.cfg.Requests) {
+			break
+		}
+
+		op := r.cfg.Mix.pick(rnd)
+
+		start := time.Now()
+
+		op, code := r.do(ctx, rnd, op)
+
+		latency := time.Since(start)
+
+		// Calls cut off by the end of the run say nothing about the service.
+		if code == codeTransport && ctx.Err() != nil {
+			break
+		}
+
+		samples[op] = append(samples[op], sample{latency: latency, code: code})
+	}
+
+	return samples
+}
+
+// do runs one call and returns the operation it actually made: merge and reassign fall
+// back to create while there is no open pull request to work on.
+func (r *runner) do(ctx context.Context, rnd *rand.Rand, op string) (string, string) {
+	switch op {
+	case OpMerge:
+		pr, ok := r.takeOpen(rnd)
+		if !ok {
+			return r.do(ctx, rnd, OpCreate)
+		}
+
+		_, code, _ := r.call(ctx, http.MethodPost, "/pullRequest/merge", gen.PostPullRequestMergeJSONBody{PullRequestId: pr.id}, nil)
+
+		return op, code
+	case OpReassign:
+		pr, ok := r.peekOpen(rnd)
+		if !ok || len(pr.reviewers) == 0 {
+			return r.do(ctx, rnd, OpCreate)
+		}
+
+		old := pr.reviewers[rnd.IntN(len(pr.reviewers))]
+
+		var resp gen.PostPullRequestReassign200JSONResponse
+
+		status, code, _ := r.call(ctx, http.MethodPost, "/pullRequest/reassign",
+			gen.PostPullRequestReassignJSONBody{PullRequestId: pr.id, OldUserId: old}, &resp)
+		if status == http.StatusOK {
+			r.replaceReviewer(pr.id, old, resp.ReplacedBy)
+		}
+
+		return op, code
+	case OpGetReview:
+		user := r.users[rnd.IntN(len(r.users))]
+		_, code, _ := r.call(ctx, http.MethodGet, "/users/getReview?user_id="+url.QueryEscape(user), nil, nil)
+
+		return op, code
+	case OpStatistics:
+		path := "/statistics/team?team_name=" + url.QueryEscape(r.teams[rnd.IntN(len(r.teams))])
+		if rnd.IntN(2) == 0 {
+			path = "/statistics/user?user_id=" + url.QueryEscape(r.users[rnd.IntN(len(r.users))])
+		}
+
+		_, code, _ := r.call(ctx, http.MethodGet, path, nil, nil)
+
+		return op, code
+	default:
+		body := gen.PostPullRequestCreateJSONBody{
+			AuthorId:        r.users[rnd.IntN(len(r.users))],
+			PullRequestId:   fmt.Sprintf("%s-pr-%d", r.cfg.RunID, r.prSeq.Add(1)),
+			PullRequestName: "loadgen",
+		}
+
+		var resp gen.PostPullRequestCreate201JSONResponse
+
+		status, code, _ := r.call(ctx, http.MethodPost, "/pullRequest/create", body, &resp)
+		if status == http.StatusCreated && resp.Pr != nil {
+			r.addOpen(openPR{id: resp.Pr.PullRequestId, reviewers: resp.Pr.AssignedReviewers})
+		}
+
+		return OpCreate, code
+	}
+}
+
+// call returns the status and, for failed calls, the error code. A successful call has
+// an empty code.
+func (r *runner) call(ctx context.Context, method string, path string, body any, out any) (int, string, error) {
+	var reader io.Reader
+
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return 0, codeTransport, err
+		}
+
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, r.cfg.BaseURL+path, reader)
+	if err != nil {
+		return 0, codeTransport, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	if r.cfg.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+r.cfg.Token)
+	}
+
+	resp, err := r.cfg.Client.Do(req)
+	if err != nil {
+		return 0, codeTransport, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return resp.StatusCode, codeTransport, err
+	}
+
+	if resp.StatusCode < 300 {
+		if out != nil {
+			_ = json.Unmarshal(data, out)
+		}
+
+		return resp.StatusCode, "", nil
+	}
+
+	var errResp gen.ErrorResponse
+
+	if json.Unmarshal(data, &errResp) != nil || errResp.Error.Code == "" {
+		return resp.StatusCode, fmt.Sprintf("HTTP_%d", resp.StatusCode), nil
+	}
+
+	return resp.StatusCode, string(errResp.Error.Code), nil
+}
+
+func (r *runner) addOpen(pr openPR) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.open = append(r.open, pr)
+}
+
+func (r *runner) takeOpen(rnd *rand.Rand) (openPR, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.open) == 0 {
+		return openPR{}, false
+	}
+
+	i := rnd.IntN(len(r.open))
+	pr := r.open[i]
+	r.open[i] = r.open[len(r.open)-1]
+	r.open = r.open[:len(r.open)-1]
+
+	return pr, true
+}
+
+func (r *runner) peekOpen(rnd *rand.Rand) (openPR, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.open) == 0 {
+		return openPR{}, false
+	}
+
+	pr := r.open[rnd.IntN(len(r.open))]
+
+	return openPR{id: pr.id, reviewers: slices.Clone(pr.reviewers)}, true
+}
+
+func (r *runner) replaceReviewer(id string, old string, replacedBy string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, pr := range r.open {
+		if pr.id != id {
+			continue
+		}
+
+		if i := slices.Index(pr.reviewers, old); i >= 0 {
+			pr.reviewers[i] = replacedBy
+		}
+	}
+}
+
+func buildReport(elapsed time.Duration, mix Mix, perWorker []map[string][]sample) *Report {
+	report := &Report{Elapsed: elapsed}
+
+	for _, op := range Ops {
+		var samples []sample
+		for _, worker := range perWorker {
+			samples = append(samples, worker[op]...)
+		}
+
+		listed := slices.ContainsFunc(mix, func(w Weight) bool { return w.Op == op && w.Weight > 0 })
+		if len(samples) == 0 && !listed {
+			continue
+		}
+
+		opReport := OpReport{Op: op, Requests: len(samples), Codes: make(map[string]int)}
+
+		latencies := make([]time.Duration, 0, len(samples))
+
+		for _, s := range samples {
+			latencies = append(latencies, s.latency)
+
+			if s.code != "" {
+				opReport.Errors++
+				opReport.Codes[s.code]++
+			}
+		}
+
+		slices.Sort(latencies)
+
+		opReport.P50 = percentile(latencies, 50)
+		opReport.P90 = percentile(latencies, 90)
+		opReport.P99 = percentile(latencies, 99)
+		opReport.Max = percentile(latencies, 100)
+		opReport.RPS = float64(opReport.Requests) / elapsed.Seconds()
+
+		report.Requests += opReport.Requests
+		report.Errors += opReport.Errors
+		report.Ops = append(report.Ops, opReport)
+	}
+
+	report.RPS = float64(report.Requests) / elapsed.Seconds()
+
+	return report
+}
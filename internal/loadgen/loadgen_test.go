@@ -0,0 +1,156 @@
+package loadgen_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	delivery "avito/internal/delivery/http"
+	"avito/internal/gen"
+	"avito/internal/loadgen"
+	"avito/internal/repo"
+	"avito/internal/repo/memory"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	gin.SetMode(gin.TestMode)
+
+	store := memory.InitStore()
+
+	g := gin.New()
+	gen.RegisterHandlers(g, delivery.InitServer(repo.Repos{
+		Team:        memory.InitTeamRepo(store),
+		User:        memory.InitUserRepo(store),
+		PullRequest: memory.InitPullRequestRepo(store),
+		Stat:        memory.InitStatRepo(store),
+		History:     memory.InitHistoryRepo(store),
+	}))
+
+	srv := httptest.NewServer(g)
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func TestParseMix(t *testing.T) {
+	mix, err := loadgen.ParseMix(loadgen.DefaultMix)
+	require.NoError(t, err)
+	assert.Len(t, mix, len(loadgen.Ops))
+
+	for _, bad := range []string{"", "create", "create=x", "create=-1", "deploy=1", "create=0", "create=1,create=2"} {
+		_, err = loadgen.ParseMix(bad)
+		assert.Error(t, err, bad)
+	}
+}
+
+func TestRun(t *testing.T) {
+	srv := newServer(t)
+
+	mix, err := loadgen.ParseMix(loadgen.DefaultMix)
+	require.NoError(t, err)
+
+	report, err := loadgen.Run(context.Background(), loadgen.Config{
+		BaseURL:      srv.URL,
+		Teams:        2,
+		UsersPerTeam: 3,
+		Concurrency:  4,
+		Requests:     200,
+		Duration:     time.Minute,
+		Mix:          mix,
+		RunID:        "test",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 200, report.Requests)
+	assert.Greater(t, report.RPS, 0.0)
+
+	byOp := make(map[string]loadgen.OpReport)
+	for _, op := range report.Ops {
+		byOp[op.Op] = op
+
+		assert.LessOrEqual(t, op.P50, op.P99, op.Op)
+		assert.LessOrEqual(t, op.P99, op.Max, op.Op)
+	}
+
+	require.Contains(t, byOp, loadgen.OpCreate)
+	assert.Zero(t, byOp[loadgen.OpCreate].Errors, byOp[loadgen.OpCreate].Codes)
+	assert.Zero(t, byOp[loadgen.OpGetReview].Errors)
+	assert.Zero(t, byOp[loadgen.OpStatistics].Errors)
+
+	// With three users per team a reassign of a fully staffed pull request has nobody
+	// left to pick, and concurrent merges race reassigns.
+	for code := range byOp[loadgen.OpReassign].Codes {
+		assert.Contains(t, []string{string(gen.NOCANDIDATE), string(gen.PRMERGED), string(gen.NOTASSIGNED)}, code)
+	}
+
+	var text bytes.Buffer
+
+	require.NoError(t, report.Write(&text, loadgen.FormatText))
+	assert.Contains(t, text.String(), "200 requests")
+	assert.Regexp(t, `create\s+\d+\s+0`, text.String())
+}
+
+func TestRunSeedConflict(t *testing.T) {
+	srv := newServer(t)
+
+	mix, err := loadgen.ParseMix("getReview=1")
+	require.NoError(t, err)
+
+	cfg := loadgen.Config{BaseURL: srv.URL, Teams: 1, UsersPerTeam: 1, Concurrency: 1, Requests: 1, Mix: mix, RunID: "same"}
+
+	_, err = loadgen.Run(context.Background(), cfg)
+	require.NoError(t, err)
+
+	_, err = loadgen.Run(context.Background(), cfg)
+	assert.ErrorContains(t, err, "TEAM_EXISTS")
+}
+
+func TestErrorCodes(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/team/add" {
+			w.WriteHeader(http.StatusCreated)
+
+			return
+		}
+
+		if r.URL.Path == "/statistics/user" {
+			w.WriteHeader(http.StatusBadGateway)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte(`{"error":{"code":"RATE_LIMITED","message":"slow down"}}`))
+	}))
+	defer srv.Close()
+
+	mix, err := loadgen.ParseMix("getReview=1,statistics=1")
+	require.NoError(t, err)
+
+	report, err := loadgen.Run(context.Background(), loadgen.Config{
+		BaseURL: srv.URL, Teams: 1, UsersPerTeam: 1, Concurrency: 2, Requests: 100, Mix: mix, RunID: "codes",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 100, report.Errors)
+
+	for _, op := range report.Ops {
+		switch op.Op {
+		case loadgen.OpGetReview:
+			assert.Equal(t, map[string]int{"RATE_LIMITED": op.Requests}, op.Codes)
+		case loadgen.OpStatistics:
+			for code := range op.Codes {
+				assert.Contains(t, []string{"RATE_LIMITED", "HTTP_502"}, code)
+			}
+		}
+	}
+}
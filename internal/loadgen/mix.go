@@ -0,0 +1,85 @@
+package loadgen
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	OpCreate     = "create"
+	OpMerge      = "merge"
+	OpReassign   = "reassign"
+	OpGetReview  = "getReview"
+	OpStatistics = "statistics"
+)
+
+// Ops lists every operation a mix can contain, in report order.
+var Ops = []string{OpCreate, OpMerge, OpReassign, OpGetReview, OpStatistics}
+
+const DefaultMix = "create=4,merge=2,reassign=1,getReview=2,statistics=1"
+
+type Weight struct {
+	Op     string
+	Weight int
+}
+
+type Mix []Weight
+
+// ParseMix parses "op=weight" pairs separated by commas.
+func ParseMix(s string) (Mix, error) {
+	var (
+		mix   Mix
+		total int
+	)
+
+	for _, pair := range strings.Split(s, ",") {
+		op, value, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok {
+			return nil, fmt.Errorf("mix entry %q is not op=weight", pair)
+		}
+
+		if !slices.Contains(Ops, op) {
+			return nil, fmt.Errorf("unknown operation %q", op)
+		}
+
+		if slices.ContainsFunc(mix, func(w Weight) bool { return w.Op == op }) {
+			return nil, fmt.Errorf("operation %q is listed twice", op)
+		}
+
+		weight, err := strconv.Atoi(value)
+		if err != nil || weight < 0 {
+			return nil, fmt.Errorf("weight of %q must be a non-negative integer", op)
+		}
+
+		mix = append(mix, Weight{Op: op, Weight: weight})
+		total += weight
+	}
+
+	if total == 0 {
+		return nil, fmt.Errorf("mix %q has no weight", s)
+	}
+
+	return mix, nil
+}
+
+func (m Mix) pick(rnd *rand.Rand) string {
+	var total int
+	for _, w := range m {
+		total += w.Weight
+	}
+
+	n := rnd.IntN(total)
+
+	for _, w := range m {
+		if n < w.Weight {
+			return w.Op
+		}
+
+		n -= w.Weight
+	}
+
+	return m[len(m)-1].Op
+}
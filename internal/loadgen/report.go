@@ -0,0 +1,100 @@
+package loadgen
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"text/tabwriter"
+	"time"
+)
+
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+type OpReport struct {
+	Op       string        `json:"op"`
+	Requests int           `json:"requests"`
+	Errors   int           `json:"errors"`
+	RPS      float64       `json:"rps"`
+	P50      time.Duration `json:"p50_ns"`
+	P90      time.Duration `json:"p90_ns"`
+	P99      time.Duration `json:"p99_ns"`
+	Max      time.Duration `json:"max_ns"`
+	// Codes counts failed requests by ErrorResponse code. Responses without one are
+	// counted as HTTP_<status>, requests that got no response as TRANSPORT.
+	Codes map[string]int `json:"codes,omitempty"`
+}
+
+type Report struct {
+	Elapsed  time.Duration `json:"elapsed_ns"`
+	Requests int           `json:"requests"`
+	Errors   int           `json:"errors"`
+	RPS      float64       `json:"rps"`
+	Ops      []OpReport    `json:"ops"`
+}
+
+func (r *Report) Write(w io.Writer, format string) error {
+	switch format {
+	case FormatText:
+		return r.writeText(w)
+	case FormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+
+		return enc.Encode(r)
+	default:
+		return fmt.Errorf("unknown format %q", format)
+	}
+}
+
+func (r *Report) writeText(w io.Writer) error {
+	fmt.Fprintf(w, "%d requests in %v, %.1f req/s, %d errors\n\n", r.Requests, r.Elapsed.Round(time.Millisecond), r.RPS, r.Errors)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(tw, "op\trequests\terrors\treq/s\tp50\tp90\tp99\tmax\t")
+
+	for _, op := range r.Ops {
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%.1f\t%v\t%v\t%v\t%v\t\n", op.Op, op.Requests, op.Errors, op.RPS,
+			roundLatency(op.P50), roundLatency(op.P90), roundLatency(op.P99), roundLatency(op.Max))
+	}
+
+	if r.Errors > 0 {
+		fmt.Fprintln(tw, "\t")
+		fmt.Fprintln(tw, "op\tcode\tcount\t")
+
+		for _, op := range r.Ops {
+			codes := make([]string, 0, len(op.Codes))
+			for code := range op.Codes {
+				codes = append(codes, code)
+			}
+
+			slices.Sort(codes)
+
+			for _, code := range codes {
+				fmt.Fprintf(tw, "%s\t%s\t%d\t\n", op.Op, code, op.Codes[code])
+			}
+		}
+	}
+
+	return tw.Flush()
+}
+
+func roundLatency(d time.Duration) time.Duration {
+	return d.Round(10 * time.Microsecond)
+}
+
+// percentile uses the nearest-rank method on sorted latencies.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+
+	rank := int(math.Ceil(p/100*float64(len(sorted)))) - 1
+
+	return sorted[min(max(rank, 0), len(sorted)-1)]
+}
@@ -1,43 +1,127 @@
 package log
 
 import (
+	"context"
+	"fmt"
+	"io"
 	"os"
 
 	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel/trace"
 )
 
+const (
+	FormatJSON    = "json"
+	FormatConsole = "console"
+)
+
+const timeFormat = "15:04:05 02-01-2006"
+
 var Log = MustInitLogger()
 
 type Logger struct {
-	infoLogger  *zerolog.Logger
-	errorLogger *zerolog.Logger
-	infoLevel   zerolog.Level
-	errorLevel  zerolog.Level
+	logger zerolog.Logger
+}
+
+func (l *Logger) Debug(s string) {
+	l.logger.Debug().Caller(1).Msg(s)
 }
 
 func (l *Logger) Info(s string) {
-	l.infoLogger.WithLevel(l.infoLevel).Caller(1).Msg(s)
+	l.logger.Info().Caller(1).Msg(s)
+}
+
+func (l *Logger) Warn(s string) {
+	l.logger.Warn().Caller(1).Msg(s)
 }
 
 func (l *Logger) Error(s error) {
-	l.errorLogger.WithLevel(l.errorLevel).Caller(1).Msg(s.Error())
+	e := l.logger.Error()
+
+	if chain := errorChain(s); len(chain) > 1 {
+		e = e.Strs("chain", chain)
+	}
+
+	e.Caller(1).Msg(s.Error())
 }
 
-func MustInitLogger() *Logger {
-	zerolog.TimeFieldFormat = "15:04:05 02-01-2006"
+// With returns a child logger that adds the field to every entry.
+func (l *Logger) With(key string, value any) *Logger {
+	return &Logger{logger: l.logger.With().Interface(key, value).Logger()}
+}
+
+type loggerKey struct{}
+
+func WithLogger(ctx context.Context, l *Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, l)
+}
+
+// Ctx returns the request logger stored in ctx, or the global one, with the current
+// trace and span IDs attached.
+func Ctx(ctx context.Context) *Logger {
+	l, ok := ctx.Value(loggerKey{}).(*Logger)
+	if !ok {
+		l = Log
+
+		if requestID := RequestID(ctx); requestID != "" {
+			l = l.With("request_id", requestID)
+		}
+	}
 
-	infoLevel := zerolog.InfoLevel
-	errorLevel := zerolog.ErrorLevel
+	spanCtx := trace.SpanContextFromContext(ctx)
+	if !spanCtx.IsValid() {
+		return l
+	}
 
-	infoLogger := zerolog.New(os.Stdout).With().Timestamp().Logger()
-	errorLogger := zerolog.New(os.Stdout).With().Timestamp().Logger()
+	return &Logger{logger: l.logger.With().
+		Str("trace_id", spanCtx.TraceID().String()).
+		Str("span_id", spanCtx.SpanID().String()).
+		Logger()}
+}
+
+// AddField enriches the request logger in ctx in place, so the field also shows up
+// in the access log written once the request is done.
+func AddField(ctx context.Context, key string, value string) {
+	l, ok := ctx.Value(loggerKey{}).(*Logger)
+	if !ok {
+		return
+	}
+
+	l.logger.UpdateContext(func(c zerolog.Context) zerolog.Context {
+		return c.Str(key, value)
+	})
+}
+
+// Configure sets the level and output format of the global logger. It is meant to be
+// called once on startup, before any request logger is derived from it.
+func Configure(level string, format string) error {
+	lvl, err := zerolog.ParseLevel(level)
+	if err != nil {
+		return fmt.Errorf("parsing log level: %w", err)
+	}
 
-	log := &Logger{
-		infoLogger:  &infoLogger,
-		errorLogger: &errorLogger,
-		infoLevel:   infoLevel,
-		errorLevel:  errorLevel,
+	if lvl == zerolog.NoLevel {
+		lvl = zerolog.InfoLevel
 	}
 
-	return log
+	var out io.Writer
+
+	switch format {
+	case FormatJSON, "":
+		out = os.Stdout
+	case FormatConsole:
+		out = zerolog.ConsoleWriter{Out: os.Stdout, TimeFormat: timeFormat}
+	default:
+		return fmt.Errorf("unknown log format %q", format)
+	}
+
+	Log.logger = zerolog.New(out).Level(lvl).With().Timestamp().Logger()
+
+	return nil
+}
+
+func MustInitLogger() *Logger {
+	zerolog.TimeFieldFormat = timeFormat
+
+	return &Logger{logger: zerolog.New(os.Stdout).Level(zerolog.InfoLevel).With().Timestamp().Logger()}
 }
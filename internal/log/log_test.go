@@ -0,0 +1,48 @@
+package log
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestLoggerCarriesFields(t *testing.T) {
+	var buf bytes.Buffer
+
+	prev := Log.logger
+	Log.logger = zerolog.New(&buf)
+
+	t.Cleanup(func() { Log.logger = prev })
+
+	ctx := WithLogger(context.Background(), Log.With("request_id", "abc"))
+
+	AddField(ctx, "user_id", "u1")
+	Ctx(ctx).Error(fmt.Errorf("select reviewers: %w", errors.New("conn reset")))
+
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+
+	assert.Equal(t, "abc", entry["request_id"])
+	assert.Equal(t, "u1", entry["user_id"])
+	assert.Equal(t, "error", entry["level"])
+	assert.Equal(t, []any{"select reviewers: conn reset", "conn reset"}, entry["chain"])
+}
+
+func TestConfigure(t *testing.T) {
+	prev := Log.logger
+
+	t.Cleanup(func() { Log.logger = prev })
+
+	require.NoError(t, Configure("debug", FormatConsole))
+	assert.Equal(t, zerolog.DebugLevel, Log.logger.GetLevel())
+
+	assert.Error(t, Configure("loud", FormatJSON))
+	assert.Error(t, Configure("info", "xml"))
+}
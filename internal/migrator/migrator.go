@@ -0,0 +1,87 @@
+package migrator
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"strings"
+
+	"github.com/pressly/goose/v3"
+)
+
+var ErrResetNotAllowed = errors.New("reset is only allowed with -confirm=<database name> on a database named *_test or *_dev")
+
+type Migrator struct {
+	dbName   string
+	provider *goose.Provider
+}
+
+func New(dialect goose.Dialect, db *sql.DB, migrations fs.FS, dbName string) (*Migrator, error) {
+	provider, err := goose.NewProvider(dialect, db, migrations)
+	if err != nil {
+		return nil, fmt.Errorf("init migrations: %w", err)
+	}
+
+	return &Migrator{dbName: dbName, provider: provider}, nil
+}
+
+func (m *Migrator) Close() error {
+	return m.provider.Close()
+}
+
+func (m *Migrator) Up(ctx context.Context) ([]*goose.MigrationResult, error) {
+	return m.provider.Up(ctx)
+}
+
+func (m *Migrator) Down(ctx context.Context) (*goose.MigrationResult, error) {
+	return m.provider.Down(ctx)
+}
+
+func (m *Migrator) Redo(ctx context.Context) ([]*goose.MigrationResult, error) {
+	down, err := m.provider.Down(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	up, err := m.provider.UpByOne(ctx)
+	if err != nil {
+		return []*goose.MigrationResult{down}, err
+	}
+
+	return []*goose.MigrationResult{down, up}, nil
+}
+
+func (m *Migrator) Status(ctx context.Context) ([]*goose.MigrationStatus, error) {
+	return m.provider.Status(ctx)
+}
+
+func (m *Migrator) Reset(ctx context.Context, confirm string) ([]*goose.MigrationResult, error) {
+	if confirm != m.dbName || !IsDisposableDB(m.dbName) {
+		return nil, ErrResetNotAllowed
+	}
+
+	return m.provider.DownTo(ctx, 0)
+}
+
+func (m *Migrator) Versions(ctx context.Context) (current, expected int64, err error) {
+	return m.provider.GetVersions(ctx)
+}
+
+func (m *Migrator) CheckSchema(ctx context.Context) error {
+	current, expected, err := m.Versions(ctx)
+	if err != nil {
+		return err
+	}
+
+	if current < expected {
+		return fmt.Errorf("schema version %d is behind %d, run `migrate up`", current, expected)
+	}
+
+	return nil
+}
+
+func IsDisposableDB(name string) bool {
+	return strings.HasSuffix(name, "_test") || strings.HasSuffix(name, "_dev")
+}
@@ -0,0 +1,30 @@
+package migrator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResetRefused(t *testing.T) {
+	tests := []struct {
+		description string
+		dbName      string
+		confirm     string
+	}{
+		{description: "no confirmation", dbName: "avito_test", confirm: ""},
+		{description: "wrong confirmation", dbName: "avito_test", confirm: "avito_dev"},
+		{description: "production database", dbName: "postgres", confirm: "postgres"},
+		{description: "suffix in the middle", dbName: "avito_test_prod", confirm: "avito_test_prod"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			migrator := &Migrator{dbName: test.dbName}
+
+			_, err := migrator.Reset(context.Background(), test.confirm)
+			require.ErrorIs(t, err, ErrResetNotAllowed)
+		})
+	}
+}
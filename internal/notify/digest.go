@@ -0,0 +1,154 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"avito/internal/entity"
+	"avito/internal/health"
+	"avito/internal/log"
+	"avito/internal/org"
+	"avito/internal/repo"
+	"avito/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// SendDigests mails every subscriber the reviews they have open. Users with none get
+// nothing, a failure for one user does not stop the others.
+func (n *Notifier) SendDigests(ctx context.Context) error {
+	ctx, span := tracer.Start(ctx, "Notifier.SendDigests")
+	defer span.End()
+
+	subscribers, err := n.repo.Subscribers(ctx)
+	if err != nil {
+		tracing.RecordError(span, err)
+
+		return fmt.Errorf("digest subscribers: %w", err)
+	}
+
+	var errs []error
+
+	sent := 0
+
+	for _, s := range subscribers {
+		reviews, err := n.users.GetReview(ctx, s.UserId)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("digest of %v: %w", s.UserId, err))
+
+			continue
+		}
+
+		var open []entity.PullRequestShort
+
+		for _, pr := range reviews {
+			if pr.Status == entity.PRStatusOPEN {
+				open = append(open, pr)
+			}
+		}
+
+		if len(open) == 0 {
+			continue
+		}
+
+		if err = n.mailer.Send(ctx, digestMessage(*s.Email, open)); err != nil {
+			errs = append(errs, fmt.Errorf("digest of %v: %w", s.UserId, err))
+
+			continue
+		}
+
+		sent++
+	}
+
+	span.SetAttributes(attribute.Int("digest.subscribers", len(subscribers)), attribute.Int("digest.sent", sent))
+
+	err = errors.Join(errs...)
+	if err != nil {
+		tracing.RecordError(span, err)
+	}
+
+	return err
+}
+
+func digestMessage(to string, open []entity.PullRequestShort) Message {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "You have %d open reviews:\n\n", len(open))
+
+	for _, pr := range open {
+		fmt.Fprintf(&b, "  %s %q by %s\n", pr.PullRequestId, pr.PullRequestName, pr.AuthorId)
+	}
+
+	return Message{
+		To:      to,
+		Subject: fmt.Sprintf("%d open reviews", len(open)),
+		Body:    b.String(),
+	}
+}
+
+// Digests returns the worker that sends the digests of every organization every day at
+// the given time after midnight, in the local time zone of the server.
+func (n *Notifier) Digests(orgs repo.Organization, at time.Duration) func(ctx context.Context) {
+	return func(ctx context.Context) {
+		timer := time.NewTimer(time.Until(NextDigest(time.Now(), at)))
+		defer timer.Stop()
+
+		beat := time.NewTicker(health.BeatInterval)
+		defer beat.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-beat.C:
+				health.Beat(ctx)
+			case <-timer.C:
+				health.Beat(ctx)
+
+				timer.Reset(time.Until(NextDigest(time.Now(), at)))
+
+				orgIDs, err := orgs.List(ctx)
+				if err != nil {
+					log.Log.Error(fmt.Errorf("digest organizations: %w", err))
+
+					continue
+				}
+
+				for _, orgID := range orgIDs {
+					health.Beat(ctx)
+
+					if err = n.SendDigests(org.With(ctx, orgID)); err != nil {
+						log.Log.Error(fmt.Errorf("digests of %v: %w", orgID, err))
+					}
+				}
+			}
+		}
+	}
+}
+
+// NextDigest returns the first time after now that is at past midnight. The wall clock
+// is kept across daylight saving changes.
+func NextDigest(now time.Time, at time.Duration) time.Time {
+	hour, minute := int(at/time.Hour), int(at%time.Hour/time.Minute)
+
+	y, m, d := now.Date()
+
+	next := time.Date(y, m, d, hour, minute, 0, 0, now.Location())
+	if !next.After(now) {
+		next = time.Date(y, m, d+1, hour, minute, 0, 0, now.Location())
+	}
+
+	return next
+}
+
+// ParseTimeOfDay reads a time like "09:00" as the time after midnight.
+func ParseTimeOfDay(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("time of day %q is not HH:MM", s)
+	}
+
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
@@ -0,0 +1,270 @@
+// Package notify emails reviewers when they are assigned to a pull request or replaced
+// on one, and once a day with the reviews they still have open. Who gets what is kept
+// in the notification settings of the user.
+package notify
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"avito/internal/entity"
+	"avito/internal/health"
+	"avito/internal/log"
+	"avito/internal/org"
+	"avito/internal/repo"
+	"avito/internal/tracing"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("avito/internal/notify")
+
+// queueSize bounds the events waiting for the mailer. Requests never wait for the SMTP
+// server, past the bound their mails are dropped.
+const queueSize = 1000
+
+// sendTimeout bounds the delivery of the mails of one event.
+const sendTimeout = 30 * time.Second
+
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// event is a change of the reviewers of a pull request: the assigned users join it and
+// replaced, if set, left it for replacedBy. Or, with sla set, an overdue review. The
+// organization is the one of the request that queued it.
+type event struct {
+	orgID      string
+	pr         entity.PullRequest
+	assigned   []string
+	replaced   string
+	replacedBy string
+	sla        *entity.SLAEvent
+}
+
+type Notifier struct {
+	repo   repo.Notification
+	users  repo.User
+	mailer Mailer
+	queue  chan event
+}
+
+func InitNotifier(notification repo.Notification, user repo.User, mailer Mailer) *Notifier {
+	return &Notifier{
+		repo:   notification,
+		users:  user,
+		mailer: mailer,
+		queue:  make(chan event, queueSize),
+	}
+}
+
+// PullRequestRepo wraps the repo so that Create and Reassign queue the mails, whichever
+// API called them.
+func (n *Notifier) PullRequestRepo(r repo.PullRequest) repo.PullRequest {
+	return pullRequestRepo{PullRequest: r, notifier: n}
+}
+
+type pullRequestRepo struct {
+	repo.PullRequest
+	notifier *Notifier
+}
+
+func (r pullRequestRepo) Create(ctx context.Context, pullRequestCreate *entity.PullRequestCreate) (*entity.PullRequest, error) {
+	pr, err := r.PullRequest.Create(ctx, pullRequestCreate)
+	if err == nil {
+		r.notifier.enqueue(ctx, event{pr: *pr, assigned: pr.AssignedReviewers})
+	}
+
+	return pr, err
+}
+
+func (r pullRequestRepo) Reassign(ctx context.Context, pullRequestID string, oldUserID string) (*entity.PullRequest, string, error) {
+	pr, newUserID, err := r.PullRequest.Reassign(ctx, pullRequestID, oldUserID)
+	if err == nil {
+		r.notifier.enqueue(ctx, event{pr: *pr, assigned: []string{newUserID}, replaced: oldUserID, replacedBy: newUserID})
+	}
+
+	return pr, newUserID, err
+}
+
+func (n *Notifier) enqueue(ctx context.Context, e event) {
+	e.orgID = org.FromContext(ctx)
+
+	select {
+	case n.queue <- e:
+	default:
+		log.Ctx(ctx).Warn(fmt.Sprintf("notification queue is full, mails about %v dropped", e.pr.PullRequestId))
+	}
+}
+
+// Emit queues the mail about an overdue review: the reviewer gets the reminder, the team
+// lead the escalation. Both follow the assignment mails setting of the recipient.
+func (n *Notifier) Emit(ctx context.Context, e entity.SLAEvent) {
+	n.enqueue(ctx, event{pr: entity.PullRequest{PullRequestId: e.Review.PullRequestId}, sla: &e})
+}
+
+// Run sends the queued mails until ctx is done. Workers are stopped after the HTTP server
+// has drained, so the events queued by then are still sent.
+func (n *Notifier) Run(ctx context.Context) {
+	beat := time.NewTicker(health.BeatInterval)
+	defer beat.Stop()
+
+	for {
+		select {
+		case <-beat.C:
+			health.Beat(ctx)
+		case e := <-n.queue:
+			health.Beat(ctx)
+			n.send(ctx, e)
+		case <-ctx.Done():
+			for {
+				select {
+				case e := <-n.queue:
+					n.send(context.WithoutCancel(ctx), e)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+func (n *Notifier) send(ctx context.Context, e event) {
+	// The request that queued the event is gone, its organization and pull request go on
+	// the logger of the worker instead.
+	ctx = log.WithLogger(org.With(ctx, e.orgID), log.Log.With("org_id", e.orgID).With("pull_request_id", e.pr.PullRequestId))
+
+	ctx, cancel := context.WithTimeout(ctx, sendTimeout)
+	defer cancel()
+
+	ctx, span := tracer.Start(ctx, "Notifier.Send")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("pr.id", e.pr.PullRequestId))
+
+	if e.sla != nil {
+		n.sendSLA(ctx, *e.sla)
+
+		return
+	}
+
+	userIDs := e.assigned
+	if e.replaced != "" {
+		userIDs = append([]string{e.replaced}, userIDs...)
+	}
+
+	settings, err := n.repo.Get(ctx, userIDs)
+	if err != nil {
+		tracing.RecordError(span, err)
+		log.Ctx(ctx).Error(fmt.Errorf("mails about %v: %w", e.pr.PullRequestId, err))
+
+		return
+	}
+
+	for _, s := range settings {
+		if s.Email == nil || !s.EmailAssignments {
+			continue
+		}
+
+		msg := assignedMessage(*s.Email, e.pr)
+		if s.UserId == e.replaced {
+			msg = replacedMessage(*s.Email, e.pr, e.replacedBy)
+		}
+
+		if err = n.mailer.Send(ctx, msg); err != nil {
+			tracing.RecordError(span, err)
+			log.Ctx(ctx).Error(fmt.Errorf("mail to %v about %v: %w", s.UserId, e.pr.PullRequestId, err))
+		}
+	}
+}
+
+func assignedMessage(to string, pr entity.PullRequest) Message {
+	return Message{
+		To:      to,
+		Subject: fmt.Sprintf("Review requested: %s (%s)", pr.PullRequestName, pr.PullRequestId),
+		Body: fmt.Sprintf("You were assigned to review %s %q by %s.\nReviewers: %s.\n",
+			pr.PullRequestId, pr.PullRequestName, pr.AuthorId, strings.Join(pr.AssignedReviewers, ", ")),
+	}
+}
+
+func replacedMessage(to string, pr entity.PullRequest, replacedBy string) Message {
+	return Message{
+		To:      to,
+		Subject: fmt.Sprintf("Review reassigned: %s (%s)", pr.PullRequestName, pr.PullRequestId),
+		Body: fmt.Sprintf("You no longer review %s %q by %s, it was reassigned to %s.\n",
+			pr.PullRequestId, pr.PullRequestName, pr.AuthorId, replacedBy),
+	}
+}
+
+func (n *Notifier) sendSLA(ctx context.Context, e entity.SLAEvent) {
+	span := trace.SpanFromContext(ctx)
+
+	recipient, msg := e.Review.ReviewerId, reminderMessage
+	if e.Kind == entity.SLAEscalation {
+		if e.SLA.LeadId == nil {
+			return
+		}
+
+		recipient, msg = *e.SLA.LeadId, escalationMessage
+	}
+
+	settings, err := n.repo.Get(ctx, []string{recipient})
+	if err != nil {
+		tracing.RecordError(span, err)
+		log.Ctx(ctx).Error(fmt.Errorf("%v mail about %v: %w", e.Kind, e.Review.PullRequestId, err))
+
+		return
+	}
+
+	for _, s := range settings {
+		if s.Email == nil || !s.EmailAssignments {
+			continue
+		}
+
+		if err = n.mailer.Send(ctx, msg(*s.Email, e)); err != nil {
+			tracing.RecordError(span, err)
+			log.Ctx(ctx).Error(fmt.Errorf("%v mail to %v about %v: %w", e.Kind, s.UserId, e.Review.PullRequestId, err))
+		}
+	}
+}
+
+func reminderMessage(to string, e entity.SLAEvent) Message {
+	return Message{
+		To:      to,
+		Subject: fmt.Sprintf("Review waiting: %s (%s)", e.Review.PullRequestName, e.Review.PullRequestId),
+		Body: fmt.Sprintf("%s %q by %s has waited %s for your review, the %s team asks for one within %s.\n",
+			e.Review.PullRequestId, e.Review.PullRequestName, e.Review.AuthorId, hours(e.Waited), e.SLA.TeamName, hours(e.SLA.RemindAfter)),
+	}
+}
+
+func escalationMessage(to string, e entity.SLAEvent) Message {
+	body := fmt.Sprintf("%s %q by %s has waited %s for the review of %s, past the %s escalation threshold of the %s team.\n",
+		e.Review.PullRequestId, e.Review.PullRequestName, e.Review.AuthorId, hours(e.Waited), e.Review.ReviewerId,
+		hours(e.SLA.EscalateAfter), e.SLA.TeamName)
+
+	if e.ReassignedTo != nil {
+		body += fmt.Sprintf("The review was reassigned to %s.\n", *e.ReassignedTo)
+	}
+
+	return Message{
+		To:      to,
+		Subject: fmt.Sprintf("Review overdue: %s (%s)", e.Review.PullRequestName, e.Review.PullRequestId),
+		Body:    body,
+	}
+}
+
+// hours formats a duration the way the SLA is set, in hours to a tenth.
+func hours(d time.Duration) string {
+	return strconv.FormatFloat(math.Round(d.Hours()*10)/10, 'f', -1, 64) + "h"
+}
@@ -0,0 +1,332 @@
+package notify_test
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"mime"
+	"net"
+	"net/mail"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"avito/internal/entity"
+	"avito/internal/notify"
+	"avito/internal/repo"
+	"avito/internal/repo/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// catcher is a mail catcher speaking just enough SMTP for net/smtp, without STARTTLS.
+type catcher struct {
+	ln   net.Listener
+	mu   sync.Mutex
+	rcpt []string
+	data []string
+}
+
+func newCatcher(t *testing.T) *catcher {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	c := &catcher{ln: ln}
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+
+			go c.serve(conn)
+		}
+	}()
+
+	t.Cleanup(func() { ln.Close() })
+
+	return c
+}
+
+func (c *catcher) serve(conn net.Conn) {
+	defer conn.Close()
+
+	r := bufio.NewReader(conn)
+	reply := func(line string) { _, _ = io.WriteString(conn, line+"\r\n") }
+
+	reply("220 catcher ready")
+
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+
+		cmd := strings.ToUpper(strings.TrimSpace(line))
+
+		switch {
+		case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+			reply("250-catcher")
+			reply("250 8BITMIME")
+		case strings.HasPrefix(cmd, "RCPT TO:"):
+			c.mu.Lock()
+			c.rcpt = append(c.rcpt, strings.Trim(strings.TrimSpace(line)[len("RCPT TO:"):], "<>"))
+			c.mu.Unlock()
+			reply("250 ok")
+		case cmd == "DATA":
+			reply("354 go ahead")
+
+			var data strings.Builder
+
+			for {
+				l, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+
+				if l == ".\r\n" {
+					break
+				}
+
+				data.WriteString(l)
+			}
+
+			c.mu.Lock()
+			c.data = append(c.data, data.String())
+			c.mu.Unlock()
+			reply("250 queued")
+		case cmd == "QUIT":
+			reply("221 bye")
+
+			return
+		default:
+			reply("250 ok")
+		}
+	}
+}
+
+func TestSMTP(t *testing.T) {
+	c := newCatcher(t)
+	mailer := notify.InitSMTP(c.ln.Addr().String(), "reviewers@example.com", "", "")
+
+	err := mailer.Send(context.Background(), notify.Message{
+		To:      "alice@example.com",
+		Subject: "Review requested: Поиск (pr-1)",
+		Body:    "You were assigned to review pr-1 \"Поиск\" by author.\n.\n",
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"alice@example.com"}, c.rcpt)
+	require.Len(t, c.data, 1)
+
+	msg, err := mail.ReadMessage(strings.NewReader(c.data[0]))
+	require.NoError(t, err)
+
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	require.NoError(t, err)
+	assert.Equal(t, "Review requested: Поиск (pr-1)", subject)
+	assert.Equal(t, "reviewers@example.com", msg.Header.Get("From"))
+
+	// net/mail leaves the transfer encoding to the caller, the catcher gets the body as sent.
+	body, err := io.ReadAll(msg.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), "assigned to review pr-1")
+	assert.Contains(t, string(body), "=D0=9F")
+}
+
+type recorder struct {
+	mu   sync.Mutex
+	sent []notify.Message
+}
+
+func (r *recorder) Send(_ context.Context, msg notify.Message) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.sent = append(r.sent, msg)
+
+	return nil
+}
+
+// to returns the subjects of the mails to the address.
+func (r *recorder) to(address string) []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var subjects []string
+
+	for _, msg := range r.sent {
+		if msg.To == address {
+			subjects = append(subjects, msg.Subject)
+		}
+	}
+
+	return subjects
+}
+
+func newRepos(t *testing.T) repo.Repos {
+	t.Helper()
+
+	store := memory.InitStore()
+	repos := repo.Repos{
+		Team:         memory.InitTeamRepo(store),
+		User:         memory.InitUserRepo(store),
+		PullRequest:  memory.InitPullRequestRepo(store),
+		Notification: memory.InitNotificationRepo(store),
+	}
+
+	require.NoError(t, repos.Team.Create(context.Background(), &entity.Team{TeamName: "backend", Members: []entity.TeamMember{
+		{UserId: "author", Username: "Author", IsActive: true},
+		{UserId: "u1", Username: "U1", IsActive: true},
+		{UserId: "u2", Username: "U2", IsActive: true},
+		{UserId: "u3", Username: "U3", IsActive: false},
+	}}))
+
+	return repos
+}
+
+func subscribe(t *testing.T, repos repo.Repos, userID string, assignments bool, digest bool) {
+	t.Helper()
+
+	email := userID + "@example.com"
+
+	_, err := repos.Notification.Set(context.Background(), &entity.NotificationSettings{
+		UserId: userID, Email: &email, EmailAssignments: assignments, EmailDigest: digest,
+	})
+	require.NoError(t, err)
+}
+
+func TestAssignmentMails(t *testing.T) {
+	ctx := context.Background()
+	repos := newRepos(t)
+	mailer := &recorder{}
+	notifier := notify.InitNotifier(repos.Notification, repos.User, mailer)
+	prs := notifier.PullRequestRepo(repos.PullRequest)
+
+	subscribe(t, repos, "u1", true, true)
+	subscribe(t, repos, "u2", false, true)
+	subscribe(t, repos, "u3", true, true)
+
+	pr, err := prs.Create(ctx, &entity.PullRequestCreate{PullRequestId: "pr-1", PullRequestName: "Add search", AuthorId: "author"})
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"u1", "u2"}, pr.AssignedReviewers)
+
+	_, err = repos.User.SetIsActive(ctx, "u3", true)
+	require.NoError(t, err)
+
+	_, newUserID, err := prs.Reassign(ctx, "pr-1", "u1")
+	require.NoError(t, err)
+	require.Equal(t, "u3", newUserID)
+
+	// A failed call sends nothing.
+	_, _, err = prs.Reassign(ctx, "pr-1", "u1")
+	require.Error(t, err)
+
+	runCtx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+
+	go func() {
+		notifier.Run(runCtx)
+		close(done)
+	}()
+
+	// The queue is drained after cancellation, like on shutdown.
+	cancel()
+	<-done
+
+	assert.Equal(t, []string{"Review requested: Add search (pr-1)", "Review reassigned: Add search (pr-1)"}, mailer.to("u1@example.com"))
+	assert.Empty(t, mailer.to("u2@example.com"))
+	assert.Equal(t, []string{"Review requested: Add search (pr-1)"}, mailer.to("u3@example.com"))
+
+	for _, msg := range mailer.sent {
+		if msg.Subject == "Review reassigned: Add search (pr-1)" {
+			assert.Contains(t, msg.Body, "reassigned to u3")
+		}
+	}
+}
+
+func TestSLAMails(t *testing.T) {
+	ctx := context.Background()
+	repos := newRepos(t)
+	mailer := &recorder{}
+	notifier := notify.InitNotifier(repos.Notification, repos.User, mailer)
+
+	subscribe(t, repos, "u1", true, false)
+	subscribe(t, repos, "u2", false, false)
+	subscribe(t, repos, "author", true, false)
+
+	lead := "author"
+	review := entity.PendingReview{PullRequestId: "pr-1", PullRequestName: "Add search", AuthorId: "author", ReviewerId: "u1", TeamName: "backend"}
+	sla := entity.TeamSLA{TeamName: "backend", RemindAfter: 4 * time.Hour, EscalateAfter: 24 * time.Hour, LeadId: &lead}
+
+	notifier.Emit(ctx, entity.SLAEvent{Kind: entity.SLAReminder, Review: review, SLA: sla, Waited: 4*time.Hour + 3*time.Minute})
+	notifier.Emit(ctx, entity.SLAEvent{Kind: entity.SLAEscalation, Review: review, SLA: sla, Waited: 25 * time.Hour, ReassignedTo: ptr("u3")})
+
+	// u2 opted out of the mails about their reviews.
+	review.ReviewerId = "u2"
+	notifier.Emit(ctx, entity.SLAEvent{Kind: entity.SLAReminder, Review: review, SLA: sla, Waited: 5 * time.Hour})
+
+	runCtx, cancel := context.WithCancel(ctx)
+	cancel()
+	notifier.Run(runCtx)
+
+	require.Len(t, mailer.sent, 2)
+
+	assert.Equal(t, "u1@example.com", mailer.sent[0].To)
+	assert.Equal(t, "Review waiting: Add search (pr-1)", mailer.sent[0].Subject)
+	assert.Contains(t, mailer.sent[0].Body, "has waited 4.1h for your review, the backend team asks for one within 4h")
+
+	assert.Equal(t, "author@example.com", mailer.sent[1].To)
+	assert.Equal(t, "Review overdue: Add search (pr-1)", mailer.sent[1].Subject)
+	assert.Contains(t, mailer.sent[1].Body, "waited 25h for the review of u1, past the 24h escalation threshold")
+	assert.Contains(t, mailer.sent[1].Body, "reassigned to u3")
+}
+
+func ptr(s string) *string {
+	return &s
+}
+
+func TestSendDigests(t *testing.T) {
+	ctx := context.Background()
+	repos := newRepos(t)
+	mailer := &recorder{}
+	notifier := notify.InitNotifier(repos.Notification, repos.User, mailer)
+
+	subscribe(t, repos, "u1", false, true)
+	subscribe(t, repos, "u2", true, false)
+	subscribe(t, repos, "author", true, true)
+
+	for _, id := range []string{"pr-1", "pr-2"} {
+		_, err := repos.PullRequest.Create(ctx, &entity.PullRequestCreate{PullRequestId: id, PullRequestName: "name-" + id, AuthorId: "author"})
+		require.NoError(t, err)
+	}
+
+	_, err := repos.PullRequest.Merge(ctx, "pr-2")
+	require.NoError(t, err)
+
+	require.NoError(t, notifier.SendDigests(ctx))
+
+	require.Len(t, mailer.sent, 1)
+	assert.Equal(t, "u1@example.com", mailer.sent[0].To)
+	assert.Equal(t, "1 open reviews", mailer.sent[0].Subject)
+	assert.Contains(t, mailer.sent[0].Body, `pr-1 "name-pr-1" by author`)
+	assert.NotContains(t, mailer.sent[0].Body, "pr-2")
+}
+
+func TestNextDigest(t *testing.T) {
+	at, err := notify.ParseTimeOfDay("09:30")
+	require.NoError(t, err)
+
+	loc := time.FixedZone("MSK", 3*60*60)
+
+	assert.Equal(t, time.Date(2025, 11, 3, 9, 30, 0, 0, loc), notify.NextDigest(time.Date(2025, 11, 3, 8, 0, 0, 0, loc), at))
+	assert.Equal(t, time.Date(2025, 11, 4, 9, 30, 0, 0, loc), notify.NextDigest(time.Date(2025, 11, 3, 9, 30, 0, 0, loc), at))
+	assert.Equal(t, time.Date(2026, 1, 1, 9, 30, 0, 0, loc), notify.NextDigest(time.Date(2025, 12, 31, 23, 0, 0, 0, loc), at))
+
+	_, err = notify.ParseTimeOfDay("9am")
+	assert.Error(t, err)
+}
@@ -0,0 +1,76 @@
+// Package org carries the organization a request acts in. Every repo scopes its queries
+// by it, so an organization sees only its own teams, users and pull requests and the
+// same ids can be used in several organizations.
+package org
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Default is the organization of single-tenant deployments, the data that predates
+// organizations was moved into it.
+const Default = "default"
+
+type orgKey struct{}
+
+func With(ctx context.Context, orgID string) context.Context {
+	return context.WithValue(ctx, orgKey{}, orgID)
+}
+
+// FromContext returns the organization of ctx. Contexts that were given none, like the
+// ones of the CLI commands, act in the default organization.
+func FromContext(ctx context.Context) string {
+	if orgID, ok := ctx.Value(orgKey{}).(string); ok {
+		return orgID
+	}
+
+	return Default
+}
+
+// Keys maps API keys, sent as Bearer tokens, to the organization they act in.
+type Keys map[string]string
+
+// ParseKeys reads the API keys in the form "key1=org-a,key2=org-b".
+func ParseKeys(s string) (Keys, error) {
+	keys := Keys{}
+
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		key, orgID, ok := strings.Cut(pair, "=")
+
+		key, orgID = strings.TrimSpace(key), strings.TrimSpace(orgID)
+		if !ok || key == "" || orgID == "" {
+			return nil, fmt.Errorf("api key %q is not key=org", pair)
+		}
+
+		if _, ok = keys[key]; ok {
+			return nil, fmt.Errorf("api key %q is listed twice", key)
+		}
+
+		keys[key] = orgID
+	}
+
+	return keys, nil
+}
+
+// Orgs returns the organizations the keys act in, each once.
+func (k Keys) Orgs() []string {
+	seen := make(map[string]struct{}, len(k))
+
+	var orgs []string
+
+	for _, orgID := range k {
+		if _, ok := seen[orgID]; !ok {
+			seen[orgID] = struct{}{}
+			orgs = append(orgs, orgID)
+		}
+	}
+
+	return orgs
+}
@@ -0,0 +1,12 @@
+package postgres
+
+import (
+	"avito/internal/migrator"
+	"avito/migrations"
+	"github.com/jackc/pgx/v5/stdlib"
+	"github.com/pressly/goose/v3"
+)
+
+func (p *Pg) Migrator() (*migrator.Migrator, error) {
+	return migrator.New(goose.DialectPostgres, stdlib.OpenDBFromPool(p.Pool), migrations.FS, p.dbName)
+}
@@ -2,7 +2,6 @@ package postgres
 
 import (
 	"context"
-	"database/sql"
 	"fmt"
 	"time"
 
@@ -10,14 +9,13 @@ import (
 	"avito/internal/log"
 	"github.com/exaring/otelpgx"
 	"github.com/jackc/pgx/v5/pgxpool"
-	_ "github.com/lib/pq" // Register postgres driver.
-	"github.com/pressly/goose/v3"
 )
 
 type Pg struct {
 	maxPoolSize  int32
 	connAttempts int
 	connTimeout  time.Duration
+	dbName       string
 	Pool         *pgxpool.Pool
 }
 
@@ -34,6 +32,7 @@ func MustInitPg(cfg *config.Config) *Pg {
 		maxPoolSize:  cfg.MaxPool,
 		connAttempts: cfg.ConnAttempts,
 		connTimeout:  cfg.PGTimeout,
+		dbName:       cfg.PGName,
 	}
 
 	poolConfig, err := pgxpool.ParseConfig(connString)
@@ -65,23 +64,6 @@ func MustInitPg(cfg *config.Config) *Pg {
 		panic(fmt.Sprintf("error while connecting to db: %v", err.Error()))
 	}
 
-	db, err := sql.Open("postgres", connString)
-	if err != nil {
-		log.Log.Error(err)
-	}
-
-	if cfg.IsTest {
-		if err = goose.Reset(db, "./migrations"); err != nil {
-			log.Log.Error(err)
-		}
-	}
-
-	if err = goose.Up(db, "./migrations"); err != nil {
-		log.Log.Error(err)
-	}
-
-	defer db.Close()
-
 	return pg
 }
 
@@ -90,3 +72,19 @@ func (p *Pg) Close() {
 		p.Pool.Close()
 	}
 }
+
+func (p *Pg) Ping(ctx context.Context) error {
+	return p.Pool.Ping(ctx)
+}
+
+func (p *Pg) MigrationVersion(ctx context.Context) (int64, error) {
+	migrator, err := p.Migrator()
+	if err != nil {
+		return 0, err
+	}
+	defer migrator.Close()
+
+	current, _, err := migrator.Versions(ctx)
+
+	return current, err
+}
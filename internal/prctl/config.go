@@ -0,0 +1,79 @@
+package prctl
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/goccy/go-yaml"
+)
+
+const (
+	DefaultURL     = "http://localhost:8080"
+	DefaultTimeout = 10 * time.Second
+)
+
+// Config is read from the config file, PRCTL_* variables override it and flags override
+// both.
+type Config struct {
+	URL        string        `yaml:"url"`
+	Token      string        `yaml:"token"`
+	AdminToken string        `yaml:"admin_token"`
+	Output     string        `yaml:"output"`
+	Timeout    time.Duration `yaml:"timeout"`
+}
+
+// DefaultConfigPath is $XDG_CONFIG_HOME/prctl/config.yaml or its OS equivalent.
+func DefaultConfigPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+
+	return filepath.Join(dir, "prctl", "config.yaml")
+}
+
+// LoadConfig reads path. A missing file is only an error when the path was given
+// explicitly, the default location is optional.
+func LoadConfig(path string, explicit bool) (Config, error) {
+	cfg := Config{
+		URL:     DefaultURL,
+		Output:  FormatTable,
+		Timeout: DefaultTimeout,
+	}
+
+	if path != "" {
+		data, err := os.ReadFile(path)
+
+		switch {
+		case err == nil:
+			if err = yaml.Unmarshal(data, &cfg); err != nil {
+				return cfg, fmt.Errorf("config %s: %w", path, err)
+			}
+		case errors.Is(err, fs.ErrNotExist) && !explicit:
+		default:
+			return cfg, err
+		}
+	}
+
+	if url := os.Getenv("PRCTL_URL"); url != "" {
+		cfg.URL = url
+	}
+
+	if token := os.Getenv("PRCTL_TOKEN"); token != "" {
+		cfg.Token = token
+	}
+
+	if adminToken := os.Getenv("PRCTL_ADMIN_TOKEN"); adminToken != "" {
+		cfg.AdminToken = adminToken
+	}
+
+	if output := os.Getenv("PRCTL_OUTPUT"); output != "" {
+		cfg.Output = output
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,187 @@
+package prctl
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/goccy/go-yaml"
+
+	"avito/client"
+	"avito/client/api"
+)
+
+const (
+	FormatTable = "table"
+	FormatJSON  = "json"
+	FormatYAML  = "yaml"
+)
+
+var Formats = []string{FormatTable, FormatJSON, FormatYAML}
+
+// ReassignResult is what pr reassign prints, the same shape as the API response.
+type ReassignResult struct {
+	Pr         api.PullRequest `json:"pr"`
+	ReplacedBy string          `json:"replaced_by"`
+}
+
+// write prints v as JSON or YAML with the API field names, or as a table for the types
+// the commands return.
+func write(w io.Writer, format string, v any) error {
+	switch format {
+	case FormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+
+		return enc.Encode(v)
+	case FormatYAML:
+		// Going through JSON keeps the API field names and drops the Go time type.
+		data, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+
+		out, err := yaml.JSONToYAML(data)
+		if err != nil {
+			return err
+		}
+
+		_, err = w.Write(out)
+
+		return err
+	case FormatTable:
+		return writeTable(w, v)
+	default:
+		return fmt.Errorf("unknown output %q, want one of %v", format, Formats)
+	}
+}
+
+func writeTable(w io.Writer, v any) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	switch v := v.(type) {
+	case *api.Team:
+		fmt.Fprintf(tw, "TEAM\t%s\t\n\n", v.TeamName)
+		fmt.Fprintln(tw, "USER_ID\tUSERNAME\tACTIVE\t")
+
+		for _, m := range v.Members {
+			fmt.Fprintf(tw, "%s\t%s\t%t\t\n", m.UserId, m.Username, m.IsActive)
+		}
+	case *api.TeamPlan:
+		writeTeamPlan(tw, v)
+	case *api.User:
+		fmt.Fprintln(tw, "USER_ID\tUSERNAME\tTEAM\tACTIVE\t")
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%t\t\n", v.UserId, v.Username, v.TeamName, v.IsActive)
+	case *api.PullRequest:
+		writePullRequests(tw, *v)
+	case *ReassignResult:
+		writePullRequests(tw, v.Pr)
+		fmt.Fprintf(tw, "\nREPLACED_BY\t%s\t\n", v.ReplacedBy)
+	case []api.PullRequestShort:
+		fmt.Fprintln(tw, "PULL_REQUEST_ID\tNAME\tAUTHOR\tSTATUS\t")
+
+		for _, pr := range v {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t\n", pr.PullRequestId, pr.PullRequestName, pr.AuthorId, pr.Status)
+		}
+	case *api.UserStat:
+		writeUserStats(tw, *v)
+	case *client.TeamStat:
+		avg := "-"
+		if v.AvgDuration >= 0 {
+			avg = formatFloat(v.AvgDuration)
+		}
+
+		fmt.Fprintf(tw, "TEAM\t%s\t\nAVG_DURATION\t%s\t\n\n", v.TeamName, avg)
+		writeUserStats(tw, v.UsersStat...)
+	default:
+		return fmt.Errorf("no table layout for %T", v)
+	}
+
+	return tw.Flush()
+}
+
+// writeTeamPlan prints one line per change and then the open reviews taken off the
+// removed members.
+func writeTeamPlan(tw io.Writer, plan *api.TeamPlan) {
+	if len(plan.Changes) == 0 {
+		fmt.Fprintln(tw, "no changes")
+
+		return
+	}
+
+	fmt.Fprintln(tw, "ACTION\tTEAM\tUSER_ID\tUSERNAME\tFROM\t")
+
+	for _, c := range plan.Changes {
+		from := "-"
+		if c.FromTeam != nil {
+			from = *c.FromTeam
+		} else if c.FromUsername != nil {
+			from = *c.FromUsername
+		}
+
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t\n", c.Action, c.TeamName, orDash(c.UserId), orDash(c.Username), from)
+	}
+
+	if len(plan.Reassignments) > 0 {
+		fmt.Fprintln(tw, "\nPULL_REQUEST_ID\tOLD_REVIEWER\tNEW_REVIEWER\t")
+
+		for _, r := range plan.Reassignments {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t\n", r.PullRequestId, r.OldReviewerId, orDash(r.NewReviewerId))
+		}
+	}
+
+	if plan.DryRun {
+		fmt.Fprintln(tw, "\ndry run, nothing applied")
+	}
+}
+
+func writePullRequests(tw io.Writer, prs ...api.PullRequest) {
+	fmt.Fprintln(tw, "PULL_REQUEST_ID\tNAME\tAUTHOR\tSTATUS\tREVIEWERS\tCREATED\tMERGED\t")
+
+	for _, pr := range prs {
+		reviewers := strings.Join(pr.AssignedReviewers, ",")
+		if reviewers == "" {
+			reviewers = "-"
+		}
+
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t\n", pr.PullRequestId, pr.PullRequestName, pr.AuthorId,
+			pr.Status, reviewers, formatTime(pr.CreatedAt), formatTime(pr.MergedAt))
+	}
+}
+
+func writeUserStats(tw io.Writer, stats ...api.UserStat) {
+	fmt.Fprintln(tw, "USER_ID\tACTIVE\tREVIEWS\tAVG_DURATION\t")
+
+	for _, s := range stats {
+		avg := "-"
+		if s.AvgDuration != nil {
+			avg = formatFloat(*s.AvgDuration)
+		}
+
+		fmt.Fprintf(tw, "%s\t%t\t%d\t%s\t\n", s.UserId, s.IsActive, s.CountPr, avg)
+	}
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+
+	return t.Local().Format(time.DateTime)
+}
+
+func orDash(s *string) string {
+	if s == nil {
+		return "-"
+	}
+
+	return *s
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', 2, 64)
+}
@@ -0,0 +1,408 @@
+// Package prctl is an admin command line for the service built on the Go client.
+package prctl
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+
+	"avito/client"
+	"avito/client/api"
+)
+
+// Exit codes of Run.
+const (
+	ExitOK    = 0
+	ExitError = 1
+	ExitUsage = 2
+)
+
+type env struct {
+	client *client.Client
+	stdin  io.Reader
+}
+
+type command struct {
+	name  string
+	args  string
+	about string
+	run   func(ctx context.Context, e *env, args []string) (any, error)
+}
+
+var commands = []command{
+	{"team add", "-f team.yaml", "create a team with its members, - reads stdin", teamAdd},
+	{"team get", "<team>", "show a team and its members", teamGet},
+	{"team apply", "-f teams.yaml [-dry-run]", "sync all teams with the file, removed members are deactivated", teamApply},
+	{"user activate", "<user-id>", "let the user be picked as a reviewer", userSetActive(true)},
+	{"user deactivate", "<user-id>", "stop picking the user as a reviewer", userSetActive(false)},
+	{"pr create", "<pr-id> -name <name> -author <user-id>", "open a pull request and assign reviewers", prCreate},
+	{"pr merge", "<pr-id>", "merge a pull request", prMerge},
+	{"pr reassign", "<pr-id> -old <user-id>", "replace a reviewer", prReassign},
+	{"review list", "-user <user-id>", "pull requests the user reviews", reviewList},
+	{"stats team", "<team>", "review statistics of a team", statsTeam},
+	{"stats user", "<user-id>", "review statistics of a user", statsUser},
+}
+
+// usageError is a mistake in the command line rather than a failed call.
+type usageError struct {
+	msg string
+}
+
+func (e *usageError) Error() string {
+	return e.msg
+}
+
+func usagef(format string, args ...any) error {
+	return &usageError{msg: fmt.Sprintf(format, args...)}
+}
+
+func Usage(w io.Writer) {
+	fmt.Fprintf(w, `usage: prctl [-config file] [-url url] [-token token] [-admin-token token] [-o %s] <command> [arguments]
+
+commands:
+`, strings.Join(Formats, "|"))
+
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  %-52s %s\n", cmd.name+" "+cmd.args, cmd.about)
+	}
+
+	fmt.Fprintf(w, `
+The config file (default %s) holds url, token, admin_token, output and timeout,
+PRCTL_URL, PRCTL_TOKEN, PRCTL_ADMIN_TOKEN and PRCTL_OUTPUT override it.
+The admin commands need the admin token besides the token.
+`, DefaultConfigPath())
+}
+
+// Run executes one command and returns the process exit code.
+func Run(ctx context.Context, args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("prctl", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+
+	configPath := flags.String("config", "", "config file")
+	url := flags.String("url", "", "base URL of the service")
+	token := flags.String("token", "", "Bearer token")
+	adminToken := flags.String("admin-token", "", "admin token for the admin commands")
+	output := flags.String("o", "", "output format: "+strings.Join(Formats, ", "))
+	timeout := flags.Duration("timeout", 0, "deadline of each call")
+
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			Usage(stdout)
+
+			return ExitOK
+		}
+
+		return fail(stderr, usagef("%v", err))
+	}
+
+	path, explicit := *configPath, *configPath != ""
+	if !explicit {
+		path = DefaultConfigPath()
+	}
+
+	cfg, err := LoadConfig(path, explicit)
+	if err != nil {
+		return fail(stderr, err)
+	}
+
+	flags.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "url":
+			cfg.URL = *url
+		case "token":
+			cfg.Token = *token
+		case "admin-token":
+			cfg.AdminToken = *adminToken
+		case "o":
+			cfg.Output = *output
+		case "timeout":
+			cfg.Timeout = *timeout
+		}
+	})
+
+	if !slices.Contains(Formats, cfg.Output) {
+		return fail(stderr, usagef("unknown output %q, want one of %v", cfg.Output, Formats))
+	}
+
+	rest := flags.Args()
+	if len(rest) < 2 {
+		Usage(stderr)
+
+		return ExitUsage
+	}
+
+	name := rest[0] + " " + rest[1]
+
+	i := slices.IndexFunc(commands, func(c command) bool { return c.name == name })
+	if i < 0 {
+		return fail(stderr, usagef("unknown command %q, see prctl -h", name))
+	}
+
+	opts := []client.Option{client.WithTimeout(cfg.Timeout)}
+	if cfg.Token != "" {
+		opts = append(opts, client.WithToken(cfg.Token))
+	}
+
+	if cfg.AdminToken != "" {
+		opts = append(opts, client.WithAdminToken(cfg.AdminToken))
+	}
+
+	c, err := client.New(cfg.URL, opts...)
+	if err != nil {
+		return fail(stderr, err)
+	}
+
+	res, err := commands[i].run(ctx, &env{client: c, stdin: stdin}, rest[2:])
+	if err != nil {
+		return fail(stderr, err)
+	}
+
+	if err = write(stdout, cfg.Output, res); err != nil {
+		return fail(stderr, err)
+	}
+
+	return ExitOK
+}
+
+func fail(w io.Writer, err error) int {
+	fmt.Fprintf(w, "prctl: %v\n", err)
+
+	var apiErr *client.Error
+	if errors.As(err, &apiErr) {
+		for _, d := range apiErr.Details {
+			fmt.Fprintf(w, "  %s: %s\n", d.Field, d.Reason)
+		}
+	}
+
+	var uerr *usageError
+	if errors.As(err, &uerr) {
+		return ExitUsage
+	}
+
+	return ExitError
+}
+
+// parseArgs lets flags and positional arguments come in any order, so both
+// "pr create pr-1 -author u1" and "pr create -author u1 pr-1" work.
+func parseArgs(flags *flag.FlagSet, args []string, positional ...string) ([]string, error) {
+	flags.SetOutput(io.Discard)
+
+	var values []string
+
+	for {
+		if err := flags.Parse(args); err != nil {
+			return nil, usagef("%s: %v", flags.Name(), err)
+		}
+
+		args = flags.Args()
+		if len(args) == 0 {
+			break
+		}
+
+		values = append(values, args[0])
+		args = args[1:]
+	}
+
+	if len(values) != len(positional) {
+		return nil, usagef("%s: want %d argument(s) %v, got %d", flags.Name(), len(positional), positional, len(values))
+	}
+
+	return values, nil
+}
+
+func required(flags *flag.FlagSet, names ...string) error {
+	for _, name := range names {
+		if flags.Lookup(name).Value.String() == "" {
+			return usagef("%s: -%s is required", flags.Name(), name)
+		}
+	}
+
+	return nil
+}
+
+func teamAdd(ctx context.Context, e *env, args []string) (any, error) {
+	flags := flag.NewFlagSet("team add", flag.ContinueOnError)
+	file := flags.String("f", "", "team file in YAML or JSON, - for stdin")
+
+	if _, err := parseArgs(flags, args); err != nil {
+		return nil, err
+	}
+
+	if err := required(flags, "f"); err != nil {
+		return nil, err
+	}
+
+	team, err := readTeam(e.stdin, *file)
+	if err != nil {
+		return nil, err
+	}
+
+	return e.client.CreateTeam(ctx, team)
+}
+
+// readTeam decodes a team in the request body shape, the field names are the API ones:
+// team_name and members with user_id, username and is_active.
+func readTeam(stdin io.Reader, file string) (api.Team, error) {
+	var team api.Team
+
+	err := readFile(stdin, file, &team)
+
+	return team, err
+}
+
+func teamApply(ctx context.Context, e *env, args []string) (any, error) {
+	flags := flag.NewFlagSet("team apply", flag.ContinueOnError)
+	file := flags.String("f", "", "teams file in YAML or JSON, - for stdin")
+	dryRun := flags.Bool("dry-run", false, "print the plan without applying it")
+
+	if _, err := parseArgs(flags, args); err != nil {
+		return nil, err
+	}
+
+	if err := required(flags, "f"); err != nil {
+		return nil, err
+	}
+
+	// The file is the request body: a teams list of teams in the team add shape.
+	var body api.PostAdminTeamsApplyJSONRequestBody
+	if err := readFile(e.stdin, *file, &body); err != nil {
+		return nil, err
+	}
+
+	return e.client.ApplyTeams(ctx, body.Teams, *dryRun)
+}
+
+func readFile(stdin io.Reader, file string, v any) error {
+	var (
+		data []byte
+		err  error
+	)
+
+	if file == "-" {
+		data, err = io.ReadAll(stdin)
+	} else {
+		data, err = os.ReadFile(file)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if err = yaml.UnmarshalWithOptions(data, v, yaml.DisallowUnknownField()); err != nil {
+		return fmt.Errorf("file %s: %w", file, err)
+	}
+
+	return nil
+}
+
+func teamGet(ctx context.Context, e *env, args []string) (any, error) {
+	values, err := parseArgs(flag.NewFlagSet("team get", flag.ContinueOnError), args, "team")
+	if err != nil {
+		return nil, err
+	}
+
+	return e.client.GetTeam(ctx, values[0])
+}
+
+func userSetActive(isActive bool) func(ctx context.Context, e *env, args []string) (any, error) {
+	name := "user deactivate"
+	if isActive {
+		name = "user activate"
+	}
+
+	return func(ctx context.Context, e *env, args []string) (any, error) {
+		values, err := parseArgs(flag.NewFlagSet(name, flag.ContinueOnError), args, "user-id")
+		if err != nil {
+			return nil, err
+		}
+
+		return e.client.SetIsActive(ctx, values[0], isActive)
+	}
+}
+
+func prCreate(ctx context.Context, e *env, args []string) (any, error) {
+	flags := flag.NewFlagSet("pr create", flag.ContinueOnError)
+	name := flags.String("name", "", "pull request name")
+	author := flags.String("author", "", "author user id")
+
+	values, err := parseArgs(flags, args, "pr-id")
+	if err != nil {
+		return nil, err
+	}
+
+	if err = required(flags, "name", "author"); err != nil {
+		return nil, err
+	}
+
+	return e.client.CreatePullRequest(ctx, values[0], *name, *author)
+}
+
+func prMerge(ctx context.Context, e *env, args []string) (any, error) {
+	values, err := parseArgs(flag.NewFlagSet("pr merge", flag.ContinueOnError), args, "pr-id")
+	if err != nil {
+		return nil, err
+	}
+
+	return e.client.MergePullRequest(ctx, values[0])
+}
+
+func prReassign(ctx context.Context, e *env, args []string) (any, error) {
+	flags := flag.NewFlagSet("pr reassign", flag.ContinueOnError)
+	old := flags.String("old", "", "reviewer to replace")
+
+	values, err := parseArgs(flags, args, "pr-id")
+	if err != nil {
+		return nil, err
+	}
+
+	if err = required(flags, "old"); err != nil {
+		return nil, err
+	}
+
+	pr, replacedBy, err := e.client.Reassign(ctx, values[0], *old)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReassignResult{Pr: *pr, ReplacedBy: replacedBy}, nil
+}
+
+func reviewList(ctx context.Context, e *env, args []string) (any, error) {
+	flags := flag.NewFlagSet("review list", flag.ContinueOnError)
+	user := flags.String("user", "", "reviewer user id")
+
+	if _, err := parseArgs(flags, args); err != nil {
+		return nil, err
+	}
+
+	if err := required(flags, "user"); err != nil {
+		return nil, err
+	}
+
+	return e.client.GetReview(ctx, *user)
+}
+
+func statsTeam(ctx context.Context, e *env, args []string) (any, error) {
+	values, err := parseArgs(flag.NewFlagSet("stats team", flag.ContinueOnError), args, "team")
+	if err != nil {
+		return nil, err
+	}
+
+	return e.client.TeamStat(ctx, values[0])
+}
+
+func statsUser(ctx context.Context, e *env, args []string) (any, error) {
+	values, err := parseArgs(flag.NewFlagSet("stats user", flag.ContinueOnError), args, "user-id")
+	if err != nil {
+		return nil, err
+	}
+
+	return e.client.UserStat(ctx, values[0])
+}
@@ -0,0 +1,270 @@
+package prctl_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"avito/client/api"
+	delivery "avito/internal/delivery/http"
+	"avito/internal/delivery/http/middleware"
+	"avito/internal/gen"
+	"avito/internal/prctl"
+	"avito/internal/repo"
+	"avito/internal/repo/memory"
+	"github.com/gin-gonic/gin"
+	"github.com/goccy/go-yaml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const teamYAML = `team_name: backend
+members:
+  - user_id: u1
+    username: Alice
+    is_active: true
+  - user_id: u2
+    username: Bob
+    is_active: true
+`
+
+const adminToken = "admin-secret"
+
+func newServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	gin.SetMode(gin.TestMode)
+
+	store := memory.InitStore()
+
+	swagger, err := gen.GetSwagger()
+	require.NoError(t, err)
+
+	validation, err := middleware.Validation(swagger)
+	require.NoError(t, err)
+
+	g := gin.New()
+	g.Use(validation)
+	delivery.RegisterHandlers(g, delivery.InitServer(repo.Repos{
+		Team:        memory.InitTeamRepo(store),
+		User:        memory.InitUserRepo(store),
+		PullRequest: memory.InitPullRequestRepo(store),
+		Stat:        memory.InitStatRepo(store),
+		History:     memory.InitHistoryRepo(store),
+	}), middleware.Admin(adminToken))
+
+	srv := httptest.NewServer(g)
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+// isolate keeps the developer's own config and PRCTL_* variables out of the tests.
+func isolate(t *testing.T) string {
+	t.Helper()
+
+	dir := t.TempDir()
+
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Setenv("HOME", dir)
+	t.Setenv("PRCTL_URL", "")
+	t.Setenv("PRCTL_TOKEN", "")
+	t.Setenv("PRCTL_ADMIN_TOKEN", "")
+	t.Setenv("PRCTL_OUTPUT", "")
+
+	return dir
+}
+
+type result struct {
+	code   int
+	stdout string
+	stderr string
+}
+
+func run(stdin string, args ...string) result {
+	var stdout, stderr bytes.Buffer
+
+	code := prctl.Run(context.Background(), args, strings.NewReader(stdin), &stdout, &stderr)
+
+	return result{code: code, stdout: stdout.String(), stderr: stderr.String()}
+}
+
+func TestCommands(t *testing.T) {
+	dir := isolate(t)
+	url := newServer(t).URL
+
+	file := filepath.Join(dir, "team.yaml")
+	require.NoError(t, os.WriteFile(file, []byte(teamYAML), 0o600))
+
+	res := run("", "-url", url, "team", "add", "-f", file)
+	require.Equal(t, prctl.ExitOK, res.code, res.stderr)
+	assert.Contains(t, res.stdout, "backend")
+	assert.Contains(t, res.stdout, "Alice")
+
+	res = run(teamYAML, "-url", url, "team", "add", "-f", "-")
+	assert.Equal(t, prctl.ExitError, res.code)
+	assert.Contains(t, res.stderr, "TEAM_EXISTS")
+
+	res = run("", "-url", url, "-o", "json", "team", "get", "backend")
+	require.Equal(t, prctl.ExitOK, res.code, res.stderr)
+
+	var team api.Team
+
+	require.NoError(t, json.Unmarshal([]byte(res.stdout), &team))
+	assert.Equal(t, "backend", team.TeamName)
+	assert.Len(t, team.Members, 2)
+
+	res = run("", "-url", url, "pr", "create", "pr-1", "-name", "Add search", "-author", "u1")
+	require.Equal(t, prctl.ExitOK, res.code, res.stderr)
+	assert.Contains(t, res.stdout, "OPEN")
+
+	res = run("", "-url", url, "-o", "yaml", "review", "list", "--user", "u2")
+	require.Equal(t, prctl.ExitOK, res.code, res.stderr)
+
+	var reviews []api.PullRequestShort
+
+	require.NoError(t, yaml.Unmarshal([]byte(res.stdout), &reviews))
+	assert.Equal(t, []api.PullRequestShort{{
+		AuthorId:        "u1",
+		PullRequestId:   "pr-1",
+		PullRequestName: "Add search",
+		Status:          api.PullRequestShortStatusOPEN,
+	}}, reviews)
+
+	res = run("", "-url", url, "pr", "reassign", "pr-1", "-old", "u2")
+	assert.Equal(t, prctl.ExitError, res.code)
+	assert.Contains(t, res.stderr, "NO_CANDIDATE")
+
+	res = run("", "-url", url, "user", "deactivate", "u2")
+	require.Equal(t, prctl.ExitOK, res.code, res.stderr)
+	assert.Contains(t, res.stdout, "false")
+
+	res = run("", "-url", url, "pr", "merge", "pr-1")
+	require.Equal(t, prctl.ExitOK, res.code, res.stderr)
+	assert.Contains(t, res.stdout, "MERGED")
+
+	res = run("", "-url", url, "stats", "team", "backend")
+	require.Equal(t, prctl.ExitOK, res.code, res.stderr)
+	assert.Contains(t, res.stdout, "AVG_DURATION")
+	assert.Contains(t, res.stdout, "u2")
+}
+
+func TestTeamApply(t *testing.T) {
+	isolate(t)
+	url := newServer(t).URL
+
+	res := run(teamYAML, "-url", url, "team", "add", "-f", "-")
+	require.Equal(t, prctl.ExitOK, res.code, res.stderr)
+
+	res = run("", "-url", url, "pr", "create", "pr-1", "-name", "Add search", "-author", "u1")
+	require.Equal(t, prctl.ExitOK, res.code, res.stderr)
+
+	const teams = `teams:
+  - team_name: backend
+    members:
+      - {user_id: u1, username: Alice, is_active: true}
+  - team_name: frontend
+    members:
+      - {user_id: u3, username: Carol, is_active: true}
+`
+
+	res = run(teams, "-url", url, "team", "apply", "-f", "-", "-dry-run")
+	assert.Equal(t, prctl.ExitError, res.code)
+	assert.Contains(t, res.stderr, "UNAUTHORIZED")
+
+	res = run(teams, "-url", url, "-admin-token", adminToken, "team", "apply", "-f", "-", "-dry-run")
+	require.Equal(t, prctl.ExitOK, res.code, res.stderr)
+	assert.Contains(t, res.stdout, "REMOVE_MEMBER")
+	assert.Contains(t, res.stdout, "pr-1")
+	assert.Contains(t, res.stdout, "dry run")
+
+	res = run("", "-url", url, "-o", "json", "team", "get", "backend")
+	require.Equal(t, prctl.ExitOK, res.code, res.stderr)
+	assert.Contains(t, res.stdout, `"is_active": true`)
+	assert.NotContains(t, res.stdout, `"is_active": false`)
+
+	t.Setenv("PRCTL_ADMIN_TOKEN", adminToken)
+
+	res = run(teams, "-url", url, "-o", "json", "team", "apply", "-f", "-")
+	require.Equal(t, prctl.ExitOK, res.code, res.stderr)
+
+	var plan api.TeamPlan
+
+	require.NoError(t, json.Unmarshal([]byte(res.stdout), &plan))
+	assert.False(t, plan.DryRun)
+	assert.Len(t, plan.Changes, 3)
+	assert.Equal(t, []api.Reassignment{{PullRequestId: "pr-1", OldReviewerId: "u2"}}, plan.Reassignments)
+
+	res = run(teams, "-url", url, "team", "apply", "-f", "-")
+	require.Equal(t, prctl.ExitOK, res.code, res.stderr)
+	assert.Contains(t, res.stdout, "no changes")
+}
+
+func TestConfig(t *testing.T) {
+	dir := isolate(t)
+	url := newServer(t).URL
+
+	res := run("", "-url", url, "team", "get", "missing")
+	assert.Equal(t, prctl.ExitError, res.code)
+	assert.Contains(t, res.stderr, "NOT_FOUND")
+
+	config := filepath.Join(dir, "prctl", "config.yaml")
+	require.NoError(t, os.MkdirAll(filepath.Dir(config), 0o700))
+	require.NoError(t, os.WriteFile(config, []byte("url: "+url+"\noutput: json\ntimeout: 5s\n"), 0o600))
+
+	res = run("", "-config", config, "stats", "user", "missing")
+	assert.Equal(t, prctl.ExitError, res.code)
+	assert.Contains(t, res.stderr, "NOT_FOUND")
+
+	// The default location is picked up without -config and flags win over it.
+	res = run(teamYAML, "team", "add", "-f", "-")
+	require.Equal(t, prctl.ExitOK, res.code, res.stderr)
+	assert.True(t, json.Valid([]byte(res.stdout)), res.stdout)
+
+	res = run("", "-o", "table", "team", "get", "backend")
+	require.Equal(t, prctl.ExitOK, res.code, res.stderr)
+	assert.Contains(t, res.stdout, "USER_ID")
+
+	t.Setenv("PRCTL_URL", "http://127.0.0.1:1")
+
+	res = run("", "team", "get", "backend")
+	assert.Equal(t, prctl.ExitError, res.code)
+
+	res = run("", "-config", filepath.Join(dir, "absent.yaml"), "team", "get", "backend")
+	assert.Equal(t, prctl.ExitError, res.code)
+}
+
+func TestUsage(t *testing.T) {
+	isolate(t)
+
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{name: "no command", args: nil},
+		{name: "unknown command", args: []string{"team", "remove", "backend"}},
+		{name: "missing argument", args: []string{"pr", "merge"}},
+		{name: "extra argument", args: []string{"team", "get", "a", "b"}},
+		{name: "missing flag", args: []string{"pr", "create", "pr-1", "-author", "u1"}},
+		{name: "missing file", args: []string{"team", "apply", "-dry-run"}},
+		{name: "unknown flag", args: []string{"review", "list", "-team", "backend"}},
+		{name: "unknown output", args: []string{"-o", "xml", "team", "get", "backend"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := run("", tt.args...)
+			assert.Equal(t, prctl.ExitUsage, res.code)
+			assert.NotEmpty(t, res.stderr)
+		})
+	}
+
+	res := run("", "-h")
+	assert.Equal(t, prctl.ExitOK, res.code)
+	assert.Contains(t, res.stdout, "review list")
+}
@@ -0,0 +1,52 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+
+	"avito/internal/cerr"
+	"avito/internal/postgres"
+)
+
+// Pg keeps buckets in the rate_limits table so every replica charges the same counter.
+type Pg struct {
+	db *postgres.Pg
+}
+
+func InitPgLimiter(db *postgres.Pg) *Pg {
+	return &Pg{db: db}
+}
+
+func (p *Pg) Allow(ctx context.Context, key string, limit Limit) (Result, error) {
+	query := `INSERT INTO rate_limits AS rl (key, tokens, allowed, updated_at)
+VALUES ($1, $2::float8 - 1, true, now())
+ON CONFLICT (key) DO UPDATE SET
+    allowed = LEAST($2::float8, rl.tokens + EXTRACT(EPOCH FROM now() - rl.updated_at)::float8 * $3::float8) >= 1,
+    tokens = LEAST($2::float8, rl.tokens + EXTRACT(EPOCH FROM now() - rl.updated_at)::float8 * $3::float8)
+        - CASE WHEN LEAST($2::float8, rl.tokens + EXTRACT(EPOCH FROM now() - rl.updated_at)::float8 * $3::float8) >= 1 THEN 1 ELSE 0 END,
+    updated_at = now()
+RETURNING tokens, allowed;`
+
+	var tokens float64
+
+	var allowed bool
+
+	err := p.db.Pool.QueryRow(ctx, query, key, limit.Burst, limit.Rate).Scan(&tokens, &allowed)
+	if err != nil {
+		return Result{}, cerr.HandlePgErr(err)
+	}
+
+	return result(limit, tokens, allowed), nil
+}
+
+// Prune removes buckets untouched for longer than idle; by then they would be full anyway.
+func (p *Pg) Prune(ctx context.Context, idle time.Duration) (int64, error) {
+	query := `DELETE FROM rate_limits WHERE updated_at < now() - make_interval(secs => $1);`
+
+	tag, err := p.db.Pool.Exec(ctx, query, idle.Seconds())
+	if err != nil {
+		return 0, cerr.HandlePgErr(err)
+	}
+
+	return tag.RowsAffected(), nil
+}
@@ -0,0 +1,212 @@
+package archive
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"avito/internal/cerr"
+	"avito/internal/entity"
+	"avito/internal/log"
+	"avito/internal/org"
+	"avito/internal/postgres"
+	"avito/internal/repo"
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var tracer = otel.Tracer("avito/internal/repo/archive")
+
+type Repo struct {
+	db *postgres.Pg
+}
+
+func InitArchiveRepo(db *postgres.Pg) repo.Archive {
+	return Repo{db: db}
+}
+
+func (r Repo) Archive(ctx context.Context, mergedBefore time.Time, limit int) (int, error) {
+	ctx, span := tracer.Start(ctx, "ArchiveRepo.Archive")
+	defer span.End()
+
+	tx, err := r.db.Pool.Begin(ctx)
+	if err != nil {
+		return 0, cerr.HandlePgErr(err)
+	}
+
+	ids, err := archive(ctx, tx, mergedBefore, limit)
+	if err != nil {
+		if txErr := tx.Rollback(ctx); txErr != nil {
+			return 0, cerr.HandlePgErr(txErr)
+		}
+
+		return 0, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return 0, cerr.HandlePgErr(err)
+	}
+
+	span.SetAttributes(attribute.Int("archive.moved", len(ids)))
+
+	if len(ids) > 0 {
+		log.Ctx(ctx).Debug(fmt.Sprintf("Pull requests archived: %v", ids))
+	}
+
+	return len(ids), nil
+}
+
+// archive copies the pull requests, their reviewers and the totals of the reviewers and
+// then deletes the pull requests, the reviewers go with them. Locked rows are left to the
+// instance that holds them.
+func archive(ctx context.Context, tx pgx.Tx, mergedBefore time.Time, limit int) ([]string, error) {
+	orgID := org.FromContext(ctx)
+
+	selectQuery := `SELECT id FROM pull_requests
+WHERE org_id = $1 AND merged_at < $2 AND (restored_at IS NULL OR restored_at < $2)
+ORDER BY merged_at
+LIMIT $3
+FOR UPDATE SKIP LOCKED`
+
+	rows, err := tx.Query(ctx, selectQuery, orgID, mergedBefore, limit)
+	if err != nil {
+		return nil, cerr.HandlePgErr(err)
+	}
+
+	ids, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, cerr.HandlePgErr(err)
+	}
+
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	prQuery := `INSERT INTO archived_pull_requests (org_id, id, name, author_id, create_at, merged_at)
+SELECT org_id, id, name, author_id, create_at, merged_at FROM pull_requests WHERE org_id = $1 AND id = ANY($2)`
+
+	reviewersQuery := `INSERT INTO archived_reviewers (org_id, pull_request_id, reviewer_id, assigned_at)
+SELECT org_id, pull_request_id, reviewer_id, assigned_at FROM reviewers WHERE org_id = $1 AND pull_request_id = ANY($2)`
+
+	statsQuery := `INSERT INTO archived_review_stats (org_id, reviewer_id, review_count, merged_hours)
+SELECT r.org_id, r.reviewer_id, COUNT(*), SUM(EXTRACT(EPOCH FROM pr.merged_at - pr.create_at) / 3600)::float8
+FROM reviewers AS r
+    INNER JOIN pull_requests AS pr ON pr.org_id = r.org_id AND pr.id = r.pull_request_id
+WHERE r.org_id = $1 AND r.pull_request_id = ANY($2)
+GROUP BY r.org_id, r.reviewer_id
+ON CONFLICT (org_id, reviewer_id) DO UPDATE SET review_count = archived_review_stats.review_count + excluded.review_count,
+    merged_hours = archived_review_stats.merged_hours + excluded.merged_hours`
+
+	deleteQuery := `DELETE FROM pull_requests WHERE org_id = $1 AND id = ANY($2)`
+
+	for _, query := range []string{prQuery, reviewersQuery, statsQuery, deleteQuery} {
+		if _, err = tx.Exec(ctx, query, orgID, ids); err != nil {
+			return nil, cerr.HandlePgErr(err)
+		}
+	}
+
+	return ids, nil
+}
+
+func (r Repo) Restore(ctx context.Context, pullRequestID string) (*entity.PullRequest, error) {
+	ctx, span := tracer.Start(ctx, "ArchiveRepo.Restore")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("pr.id", pullRequestID))
+
+	tx, err := r.db.Pool.Begin(ctx)
+	if err != nil {
+		return nil, cerr.HandlePgErr(err)
+	}
+
+	pullRequest, err := restore(ctx, tx, pullRequestID)
+	if err != nil {
+		if txErr := tx.Rollback(ctx); txErr != nil {
+			return nil, cerr.HandlePgErr(txErr)
+		}
+
+		return nil, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, cerr.HandlePgErr(err)
+	}
+
+	return pullRequest, nil
+}
+
+// restore puts the pull request and its reviewers back and takes its reviews out of the
+// totals, a reviewer whose reviews are all back loses the row. The archived rows go last,
+// the reviewers are read from them.
+func restore(ctx context.Context, tx pgx.Tx, pullRequestID string) (*entity.PullRequest, error) {
+	orgID := org.FromContext(ctx)
+
+	pullRequest := entity.PullRequest{
+		PullRequestId:     pullRequestID,
+		Status:            entity.PRStatusMERGED,
+		AssignedReviewers: []string{},
+	}
+
+	selectQuery := `SELECT name, author_id, create_at, merged_at FROM archived_pull_requests
+WHERE org_id = $1 AND id = $2
+FOR UPDATE`
+
+	err := tx.QueryRow(ctx, selectQuery, orgID, pullRequestID).
+		Scan(&pullRequest.PullRequestName, &pullRequest.AuthorId, &pullRequest.CreatedAt, &pullRequest.MergedAt)
+	if err != nil {
+		return nil, cerr.HandlePgErr(err)
+	}
+
+	prQuery := `INSERT INTO pull_requests (org_id, id, name, author_id, status_id, create_at, merged_at, restored_at)
+VALUES ($1, $2, $3, $4, (SELECT id FROM statuses WHERE name = $5), $6, $7, $8)`
+
+	_, err = tx.Exec(ctx, prQuery, orgID, pullRequestID, pullRequest.PullRequestName, pullRequest.AuthorId,
+		entity.PRStatusMERGED, pullRequest.CreatedAt, pullRequest.MergedAt, time.Now().UTC())
+	if err != nil {
+		return nil, cerr.HandlePgErr(err)
+	}
+
+	reviewersQuery := `INSERT INTO reviewers (org_id, pull_request_id, reviewer_id, assigned_at)
+SELECT org_id, pull_request_id, reviewer_id, assigned_at FROM archived_reviewers WHERE org_id = $1 AND pull_request_id = $2
+RETURNING reviewer_id`
+
+	rows, err := tx.Query(ctx, reviewersQuery, orgID, pullRequestID)
+	if err != nil {
+		return nil, cerr.HandlePgErr(err)
+	}
+
+	reviewers, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, cerr.HandlePgErr(err)
+	}
+
+	pullRequest.AssignedReviewers = append(pullRequest.AssignedReviewers, reviewers...)
+
+	// A reviewer whose reviews are all back loses the row. The delete goes first, otherwise
+	// a count the decrement has just brought down to one would be deleted too.
+	emptyQuery := `DELETE FROM archived_review_stats WHERE org_id = $1 AND reviewer_id = ANY($2) AND review_count = 1`
+
+	if _, err = tx.Exec(ctx, emptyQuery, orgID, reviewers); err != nil {
+		return nil, cerr.HandlePgErr(err)
+	}
+
+	statsQuery := `UPDATE archived_review_stats SET review_count = review_count - 1, merged_hours = merged_hours - $3
+WHERE org_id = $1 AND reviewer_id = ANY($2)`
+
+	hours := pullRequest.MergedAt.Sub(*pullRequest.CreatedAt).Hours()
+
+	if _, err = tx.Exec(ctx, statsQuery, orgID, reviewers, hours); err != nil {
+		return nil, cerr.HandlePgErr(err)
+	}
+
+	deleteQuery := `DELETE FROM archived_pull_requests WHERE org_id = $1 AND id = $2`
+
+	if _, err = tx.Exec(ctx, deleteQuery, orgID, pullRequestID); err != nil {
+		return nil, cerr.HandlePgErr(err)
+	}
+
+	return &pullRequest, nil
+}
@@ -0,0 +1,109 @@
+package history
+
+import (
+	"context"
+
+	"avito/internal/cerr"
+	"avito/internal/entity"
+	"avito/internal/org"
+	"avito/internal/postgres"
+	"avito/internal/repo"
+	"go.opentelemetry.io/otel"
+)
+
+var tracer = otel.Tracer("avito/internal/repo/history")
+
+type Repo struct {
+	db *postgres.Pg
+}
+
+func InitHistoryRepo(db *postgres.Pg) repo.History {
+	return Repo{db: db}
+}
+
+func (r Repo) Load(ctx context.Context) (*entity.History, error) {
+	ctx, span := tracer.Start(ctx, "HistoryRepo.Load")
+	defer span.End()
+
+	var history entity.History
+
+	teamIndex := make(map[string]int)
+
+	orgID := org.FromContext(ctx)
+
+	usersQuery := `SELECT t.name, u.id, u.username, u.is_active FROM teams AS t
+    INNER JOIN users AS u ON u.org_id = t.org_id AND u.team_name = t.name
+    WHERE t.org_id = $1
+    ORDER BY t.created_at, t.name, u.id`
+
+	rows, err := r.db.Pool.Query(ctx, usersQuery, orgID)
+	if err != nil {
+		return nil, cerr.HandlePgErr(err)
+	}
+
+	for rows.Next() {
+		var teamName string
+
+		var member entity.TeamMember
+
+		if err = rows.Scan(&teamName, &member.UserId, &member.Username, &member.IsActive); err != nil {
+			rows.Close()
+
+			return nil, cerr.HandlePgErr(err)
+		}
+
+		i, ok := teamIndex[teamName]
+		if !ok {
+			i = len(history.Teams)
+			teamIndex[teamName] = i
+			history.Teams = append(history.Teams, entity.Team{TeamName: teamName})
+		}
+
+		history.Teams[i].Members = append(history.Teams[i].Members, member)
+	}
+
+	rows.Close()
+
+	if err = rows.Err(); err != nil {
+		return nil, cerr.HandlePgErr(err)
+	}
+
+	// The archived pull requests are part of the history too, a replay without them would
+	// start from lighter loads than the reviewers really had.
+	prQuery := `SELECT pr.id, pr.author_id, pr.create_at, pr.merged_at,
+       COALESCE(array_agg(r.reviewer_id ORDER BY r.reviewer_id) FILTER (WHERE r.reviewer_id IS NOT NULL), '{}')
+FROM pull_requests AS pr
+    LEFT JOIN reviewers AS r ON r.org_id = pr.org_id AND r.pull_request_id = pr.id
+WHERE pr.org_id = $1
+GROUP BY pr.org_id, pr.id
+UNION ALL
+SELECT pr.id, pr.author_id, pr.create_at, pr.merged_at,
+       COALESCE(array_agg(r.reviewer_id ORDER BY r.reviewer_id) FILTER (WHERE r.reviewer_id IS NOT NULL), '{}')
+FROM archived_pull_requests AS pr
+    LEFT JOIN archived_reviewers AS r ON r.org_id = pr.org_id AND r.pull_request_id = pr.id
+WHERE pr.org_id = $1
+GROUP BY pr.org_id, pr.id
+ORDER BY create_at, id`
+
+	rows, err = r.db.Pool.Query(ctx, prQuery, orgID)
+	if err != nil {
+		return nil, cerr.HandlePgErr(err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var pr entity.PullRequestHistory
+
+		if err = rows.Scan(&pr.PullRequestId, &pr.AuthorId, &pr.CreatedAt, &pr.MergedAt, &pr.AssignedReviewers); err != nil {
+			return nil, cerr.HandlePgErr(err)
+		}
+
+		history.PullRequests = append(history.PullRequests, pr)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, cerr.HandlePgErr(err)
+	}
+
+	return &history, nil
+}
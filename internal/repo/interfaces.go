@@ -2,6 +2,7 @@ package repo
 
 import (
 	"context"
+	"time"
 
 	"avito/internal/entity"
 )
@@ -10,6 +11,14 @@ type Team interface {
 	CheckTeamName(ctx context.Context, teamName string) (bool, error)
 	Create(ctx context.Context, team *entity.Team) error
 	Get(ctx context.Context, teamName string) (*entity.Team, error)
+	// List returns every team with all its members, active or not.
+	List(ctx context.Context) ([]entity.Team, error)
+	// Apply makes the changes in one transaction. Removed members are deactivated and
+	// their open reviews go to the least loaded active teammate of the author, or are
+	// dropped when there is none. A removed team must have no members left by then.
+	// With dryRun the transaction is rolled back and the reassignments are the ones the
+	// changes would make.
+	Apply(ctx context.Context, changes []entity.TeamChange, dryRun bool) ([]entity.Reassignment, error)
 }
 
 type User interface {
@@ -17,6 +26,31 @@ type User interface {
 	GetReview(ctx context.Context, userID string) ([]entity.PullRequestShort, error)
 }
 
+// Notification keeps the email settings on the user rows.
+type Notification interface {
+	// Get returns the settings of the users that exist, in no particular order.
+	Get(ctx context.Context, userIDs []string) ([]entity.NotificationSettings, error)
+	Set(ctx context.Context, settings *entity.NotificationSettings) (*entity.NotificationSettings, error)
+	// Subscribers returns the users that have an email and get the daily digest.
+	Subscribers(ctx context.Context) ([]entity.NotificationSettings, error)
+}
+
+// SLA keeps the review SLAs of teams and the reminders and escalations sent for reviews.
+type SLA interface {
+	Get(ctx context.Context, teamName string) (*entity.TeamSLA, error)
+	// Set replaces the SLA of the team, NOT_FOUND when the team or the lead does not exist.
+	Set(ctx context.Context, sla *entity.TeamSLA) (*entity.TeamSLA, error)
+	Delete(ctx context.Context, teamName string) error
+	List(ctx context.Context) ([]entity.TeamSLA, error)
+	// Pending returns the reviews of open pull requests whose author's team has an SLA,
+	// oldest assignment first.
+	Pending(ctx context.Context) ([]entity.PendingReview, error)
+	// MarkReminded and MarkEscalated record the event unless it was recorded already and
+	// report whether this call did, so one of several instances sends it.
+	MarkReminded(ctx context.Context, pullRequestID string, reviewerID string, at time.Time) (bool, error)
+	MarkEscalated(ctx context.Context, pullRequestID string, reviewerID string, at time.Time) (bool, error)
+}
+
 type PullRequest interface {
 	Create(ctx context.Context, PullRequestCreate *entity.PullRequestCreate) (*entity.PullRequest, error)
 	Merge(ctx context.Context, PullRequestID string) (*entity.PullRequest, error)
@@ -27,3 +61,63 @@ type Stat interface {
 	User(ctx context.Context, userID string) (*entity.UserStat, error)
 	Team(ctx context.Context, teamName string) (*entity.TeamStat, error)
 }
+
+type History interface {
+	Load(ctx context.Context) (*entity.History, error)
+}
+
+// Lookup reads many rows in one query per call, it backs the GraphQL dataloaders. Keys
+// that do not exist are left out of the result, whose order is not defined.
+type Lookup interface {
+	Users(ctx context.Context, userIDs []string) ([]entity.User, error)
+	Teams(ctx context.Context, teamNames []string) ([]entity.Team, error)
+	PullRequests(ctx context.Context, pullRequestIDs []string) ([]entity.PullRequest, error)
+	// Reviews returns the pull requests any of the users reviews, with all their reviewers.
+	Reviews(ctx context.Context, userIDs []string) ([]entity.PullRequest, error)
+	UserStats(ctx context.Context, userIDs []string) ([]entity.UserStat, error)
+}
+
+// Organization keeps the organizations that data can belong to. The other repos act in
+// the organization of the context.
+type Organization interface {
+	// Ensure creates the organizations that do not exist yet.
+	Ensure(ctx context.Context, orgIDs []string) error
+	List(ctx context.Context) ([]string, error)
+}
+
+// Archive moves merged pull requests out of the tables the API works on. What the
+// statistics and the reviewer choice need of their reviews is kept in per-reviewer
+// totals, so both stay the same after a pull request is archived.
+type Archive interface {
+	// Archive moves up to limit pull requests merged before mergedBefore, oldest first,
+	// and returns how many it moved.
+	Archive(ctx context.Context, mergedBefore time.Time, limit int) (int, error)
+	// Restore moves an archived pull request back with its reviewers.
+	Restore(ctx context.Context, pullRequestID string) (*entity.PullRequest, error)
+}
+
+// Erasure anonymizes users who asked to be deleted. The user rows cannot go, the pull
+// requests and reviews refer to them, so the user is renamed instead.
+type Erasure interface {
+	// Erase renames the user to pseudonym in every table, replaces the username with it,
+	// drops the email and the SLA leads of the user and deactivates the user. The open
+	// reviews go to teammates of the author like those of a removed member, see
+	// Team.Apply. The erasure is recorded without the old id, all in one transaction.
+	Erase(ctx context.Context, userID string, pseudonym string) (*entity.UserErasure, []entity.Reassignment, error)
+	// List returns the erasures, oldest first.
+	List(ctx context.Context) ([]entity.UserErasure, error)
+}
+
+type Repos struct {
+	Team         Team
+	User         User
+	PullRequest  PullRequest
+	Stat         Stat
+	History      History
+	Lookup       Lookup
+	Notification Notification
+	SLA          SLA
+	Organization Organization
+	Archive      Archive
+	Erasure      Erasure
+}
@@ -0,0 +1,186 @@
+package lookup
+
+import (
+	"context"
+	"time"
+
+	"avito/internal/cerr"
+	"avito/internal/entity"
+	"avito/internal/org"
+	"avito/internal/postgres"
+	"avito/internal/repo"
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var tracer = otel.Tracer("avito/internal/repo/lookup")
+
+type Repo struct {
+	db *postgres.Pg
+}
+
+func InitLookupRepo(db *postgres.Pg) repo.Lookup {
+	return Repo{db: db}
+}
+
+func (r Repo) Users(ctx context.Context, userIDs []string) ([]entity.User, error) {
+	ctx, span := tracer.Start(ctx, "LookupRepo.Users")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int("lookup.keys", len(userIDs)))
+
+	query := `SELECT id, username, team_name, is_active FROM users WHERE org_id = $1 AND id = ANY($2)`
+
+	rows, err := r.db.Pool.Query(ctx, query, org.FromContext(ctx), userIDs)
+	if err != nil {
+		return nil, cerr.HandlePgErr(err)
+	}
+
+	users, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (entity.User, error) {
+		var user entity.User
+
+		err := row.Scan(&user.UserId, &user.Username, &user.TeamName, &user.IsActive)
+
+		return user, err
+	})
+	if err != nil {
+		return nil, cerr.HandlePgErr(err)
+	}
+
+	return users, nil
+}
+
+func (r Repo) Teams(ctx context.Context, teamNames []string) ([]entity.Team, error) {
+	ctx, span := tracer.Start(ctx, "LookupRepo.Teams")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int("lookup.keys", len(teamNames)))
+
+	query := `SELECT t.name, u.id, u.username, u.is_active FROM teams AS t
+    INNER JOIN users AS u ON u.org_id = t.org_id AND u.team_name = t.name
+    WHERE t.org_id = $1 AND t.name = ANY($2)
+    ORDER BY t.name, u.id`
+
+	rows, err := r.db.Pool.Query(ctx, query, org.FromContext(ctx), teamNames)
+	if err != nil {
+		return nil, cerr.HandlePgErr(err)
+	}
+	defer rows.Close()
+
+	var teams []entity.Team
+
+	for rows.Next() {
+		var teamName string
+
+		var member entity.TeamMember
+
+		if err = rows.Scan(&teamName, &member.UserId, &member.Username, &member.IsActive); err != nil {
+			return nil, cerr.HandlePgErr(err)
+		}
+
+		if len(teams) == 0 || teams[len(teams)-1].TeamName != teamName {
+			teams = append(teams, entity.Team{TeamName: teamName})
+		}
+
+		teams[len(teams)-1].Members = append(teams[len(teams)-1].Members, member)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, cerr.HandlePgErr(err)
+	}
+
+	return teams, nil
+}
+
+const pullRequestsQuery = `SELECT pr.id, pr.name, pr.author_id, s.name, pr.create_at, pr.merged_at,
+       COALESCE(array_agg(r.reviewer_id ORDER BY r.reviewer_id) FILTER (WHERE r.reviewer_id IS NOT NULL), '{}')
+FROM pull_requests AS pr
+    INNER JOIN statuses AS s ON s.id = pr.status_id
+    LEFT JOIN reviewers AS r ON r.org_id = pr.org_id AND r.pull_request_id = pr.id
+`
+
+func (r Repo) PullRequests(ctx context.Context, pullRequestIDs []string) ([]entity.PullRequest, error) {
+	ctx, span := tracer.Start(ctx, "LookupRepo.PullRequests")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int("lookup.keys", len(pullRequestIDs)))
+
+	query := pullRequestsQuery + `WHERE pr.org_id = $1 AND pr.id = ANY($2)
+GROUP BY pr.org_id, pr.id, s.name`
+
+	return r.pullRequests(ctx, query, pullRequestIDs)
+}
+
+func (r Repo) Reviews(ctx context.Context, userIDs []string) ([]entity.PullRequest, error) {
+	ctx, span := tracer.Start(ctx, "LookupRepo.Reviews")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int("lookup.keys", len(userIDs)))
+
+	query := pullRequestsQuery + `WHERE pr.org_id = $1
+  AND pr.id IN (SELECT pull_request_id FROM reviewers WHERE org_id = $1 AND reviewer_id = ANY($2))
+GROUP BY pr.org_id, pr.id, s.name
+ORDER BY pr.create_at, pr.id`
+
+	return r.pullRequests(ctx, query, userIDs)
+}
+
+func (r Repo) pullRequests(ctx context.Context, query string, keys []string) ([]entity.PullRequest, error) {
+	rows, err := r.db.Pool.Query(ctx, query, org.FromContext(ctx), keys)
+	if err != nil {
+		return nil, cerr.HandlePgErr(err)
+	}
+
+	prs, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (entity.PullRequest, error) {
+		var pr entity.PullRequest
+
+		var createdAt time.Time
+
+		err := row.Scan(&pr.PullRequestId, &pr.PullRequestName, &pr.AuthorId, &pr.Status, &createdAt, &pr.MergedAt, &pr.AssignedReviewers)
+		pr.CreatedAt = &createdAt
+
+		return pr, err
+	})
+	if err != nil {
+		return nil, cerr.HandlePgErr(err)
+	}
+
+	return prs, nil
+}
+
+func (r Repo) UserStats(ctx context.Context, userIDs []string) ([]entity.UserStat, error) {
+	ctx, span := tracer.Start(ctx, "LookupRepo.UserStats")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int("lookup.keys", len(userIDs)))
+
+	// The archived reviews are all merged, they add to both the count and the average.
+	query := `SELECT u.id, u.is_active, COUNT(pr.id) + COALESCE(MAX(a.review_count), 0),
+       ((COALESCE(SUM(EXTRACT(EPOCH FROM pr.merged_at - pr.create_at) / 3600), 0) + COALESCE(MAX(a.merged_hours), 0))
+           / NULLIF(COUNT(pr.merged_at) + COALESCE(MAX(a.review_count), 0), 0))::float8
+FROM users AS u
+    LEFT JOIN archived_review_stats AS a ON a.org_id = u.org_id AND a.reviewer_id = u.id
+    LEFT JOIN reviewers AS r ON r.org_id = u.org_id AND r.reviewer_id = u.id
+    LEFT JOIN pull_requests AS pr ON pr.org_id = r.org_id AND pr.id = r.pull_request_id
+WHERE u.org_id = $1 AND u.id = ANY($2)
+GROUP BY u.org_id, u.id`
+
+	rows, err := r.db.Pool.Query(ctx, query, org.FromContext(ctx), userIDs)
+	if err != nil {
+		return nil, cerr.HandlePgErr(err)
+	}
+
+	stats, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (entity.UserStat, error) {
+		var stat entity.UserStat
+
+		err := row.Scan(&stat.UserId, &stat.IsActive, &stat.CountPr, &stat.AvgDuration)
+
+		return stat, err
+	})
+	if err != nil {
+		return nil, cerr.HandlePgErr(err)
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,144 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"avito/internal/cerr"
+	"avito/internal/entity"
+	"avito/internal/repo"
+)
+
+type ArchiveRepo struct {
+	store *Store
+}
+
+func InitArchiveRepo(store *Store) repo.Archive {
+	return ArchiveRepo{store: store}
+}
+
+func (r ArchiveRepo) Archive(ctx context.Context, mergedBefore time.Time, limit int) (int, error) {
+	s := r.store.org(ctx)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var due []*pullRequest
+
+	for _, pr := range s.prs {
+		if pr.mergedAt == nil || !pr.mergedAt.Before(mergedBefore) {
+			continue
+		}
+
+		if pr.restoredAt != nil && !pr.restoredAt.Before(mergedBefore) {
+			continue
+		}
+
+		due = append(due, pr)
+	}
+
+	slices.SortFunc(due, func(a, b *pullRequest) int {
+		return cmp.Or(a.mergedAt.Compare(*b.mergedAt), strings.Compare(a.id, b.id))
+	})
+
+	if len(due) > limit {
+		due = due[:limit]
+	}
+
+	moved := make(map[string]*archivedPullRequest, len(due))
+
+	for _, pr := range due {
+		archived := &archivedPullRequest{pr: *pr}
+		archived.pr.restoredAt = nil
+
+		moved[pr.id] = archived
+		s.archived[pr.id] = archived
+		delete(s.prs, pr.id)
+	}
+
+	kept := s.reviewers[:0]
+
+	for _, rev := range s.reviewers {
+		archived, ok := moved[rev.pullRequestID]
+		if !ok {
+			kept = append(kept, rev)
+
+			continue
+		}
+
+		archived.reviewers = append(archived.reviewers, rev)
+
+		stat := s.archivedStats[rev.reviewerID]
+		stat.count++
+		stat.hours += archived.pr.mergedAt.Sub(archived.pr.createdAt).Hours()
+		s.archivedStats[rev.reviewerID] = stat
+	}
+
+	s.reviewers = kept
+
+	return len(due), nil
+}
+
+func (r ArchiveRepo) Restore(ctx context.Context, pullRequestID string) (*entity.PullRequest, error) {
+	s := r.store.org(ctx)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	archived, ok := s.archived[pullRequestID]
+	if !ok {
+		return nil, notFound()
+	}
+
+	if _, exists := s.prs[pullRequestID]; exists {
+		return nil, cerr.CustomError{
+			Err:     fmt.Errorf("%w: pull_requests_pkey %q", errDuplicate, pullRequestID),
+			ErrType: cerr.PR_EXISTS,
+		}
+	}
+
+	restoredAt := s.timestamp()
+
+	pr := archived.pr
+	pr.restoredAt = &restoredAt
+
+	s.prs[pullRequestID] = &pr
+	delete(s.archived, pullRequestID)
+
+	hours := pr.mergedAt.Sub(pr.createdAt).Hours()
+
+	reviewers := []string{}
+
+	for _, rev := range archived.reviewers {
+		s.reviewers = append(s.reviewers, rev)
+		reviewers = append(reviewers, rev.reviewerID)
+
+		// A reviewer whose reviews are all back loses the entry.
+		stat := s.archivedStats[rev.reviewerID]
+		if stat.count <= 1 {
+			delete(s.archivedStats, rev.reviewerID)
+
+			continue
+		}
+
+		stat.count--
+		stat.hours -= hours
+		s.archivedStats[rev.reviewerID] = stat
+	}
+
+	createdAt, mergedAt := pr.createdAt, *pr.mergedAt
+
+	return &entity.PullRequest{
+		AssignedReviewers: reviewers,
+		AuthorId:          pr.authorID,
+		CreatedAt:         &createdAt,
+		MergedAt:          &mergedAt,
+		PullRequestId:     pullRequestID,
+		PullRequestName:   pr.name,
+		Status:            entity.PRStatusMERGED,
+	}, nil
+}
@@ -0,0 +1,111 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+
+	"avito/internal/cerr"
+	"avito/internal/entity"
+	"avito/internal/repo"
+)
+
+type ErasureRepo struct {
+	store *Store
+}
+
+func InitErasureRepo(store *Store) repo.Erasure {
+	return ErasureRepo{store: store}
+}
+
+// Erase renames the user everywhere the foreign keys in Postgres would, then hands off the
+// reviews under the pseudonym.
+func (r ErasureRepo) Erase(ctx context.Context, userID string, pseudonym string) (*entity.UserErasure, []entity.Reassignment, error) {
+	s := r.store.org(ctx)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	user, ok := s.users[userID]
+	if !ok {
+		return nil, nil, notFound()
+	}
+
+	if _, exists := s.users[pseudonym]; exists {
+		return nil, nil, cerr.CustomError{
+			Err:     fmt.Errorf("%w: users_pkey %q", errDuplicate, pseudonym),
+			ErrType: cerr.SERVER,
+		}
+	}
+
+	user.UserId, user.Username, user.IsActive = pseudonym, pseudonym, false
+
+	delete(s.users, userID)
+	s.users[pseudonym] = user
+
+	for i, id := range s.userOrder {
+		if id == userID {
+			s.userOrder[i] = pseudonym
+		}
+	}
+
+	for _, pr := range s.prs {
+		if pr.authorID == userID {
+			pr.authorID = pseudonym
+		}
+	}
+
+	for i := range s.reviewers {
+		if s.reviewers[i].reviewerID == userID {
+			s.reviewers[i].reviewerID = pseudonym
+		}
+	}
+
+	for _, archived := range s.archived {
+		if archived.pr.authorID == userID {
+			archived.pr.authorID = pseudonym
+		}
+
+		for i := range archived.reviewers {
+			if archived.reviewers[i].reviewerID == userID {
+				archived.reviewers[i].reviewerID = pseudonym
+			}
+		}
+	}
+
+	if stat, ok := s.archivedStats[userID]; ok {
+		delete(s.archivedStats, userID)
+		s.archivedStats[pseudonym] = stat
+	}
+
+	// The settings go with the email, the pseudonym starts out without one.
+	delete(s.notifications, userID)
+
+	for name, sla := range s.slas {
+		if sla.LeadId != nil && *sla.LeadId == userID {
+			sla.LeadId = nil
+			s.slas[name] = sla
+		}
+	}
+
+	reassignments := reassignReviews(s, pseudonym)
+
+	erasure := entity.UserErasure{
+		Pseudonym:         pseudonym,
+		TeamName:          user.TeamName,
+		ErasedAt:          s.timestamp(),
+		ReassignedReviews: len(reassignments),
+	}
+
+	s.erasures = append(s.erasures, erasure)
+
+	return &erasure, reassignments, nil
+}
+
+func (r ErasureRepo) List(ctx context.Context) ([]entity.UserErasure, error) {
+	s := r.store.org(ctx)
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return append([]entity.UserErasure(nil), s.erasures...), nil
+}
@@ -0,0 +1,79 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+
+	"avito/internal/entity"
+	"avito/internal/repo"
+)
+
+type HistoryRepo struct {
+	store *Store
+}
+
+func InitHistoryRepo(store *Store) repo.History {
+	return HistoryRepo{store: store}
+}
+
+func (r HistoryRepo) Load(ctx context.Context) (*entity.History, error) {
+	s := r.store.org(ctx)
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var history entity.History
+
+	teamIndex := make(map[string]int)
+
+	for _, id := range s.userOrder {
+		user := s.users[id]
+
+		i, ok := teamIndex[user.TeamName]
+		if !ok {
+			i = len(history.Teams)
+			teamIndex[user.TeamName] = i
+			history.Teams = append(history.Teams, entity.Team{TeamName: user.TeamName})
+		}
+
+		history.Teams[i].Members = append(history.Teams[i].Members, entity.TeamMember{
+			IsActive: user.IsActive,
+			UserId:   user.UserId,
+			Username: user.Username,
+		})
+	}
+
+	for _, pr := range s.prs {
+		history.PullRequests = append(history.PullRequests, pullRequestHistory(pr, s.reviewersOf(pr.id)))
+	}
+
+	// The archived pull requests are part of the history too.
+	for _, archived := range s.archived {
+		reviewers := make([]string, 0, len(archived.reviewers))
+		for _, rev := range archived.reviewers {
+			reviewers = append(reviewers, rev.reviewerID)
+		}
+
+		history.PullRequests = append(history.PullRequests, pullRequestHistory(&archived.pr, reviewers))
+	}
+
+	slices.SortFunc(history.PullRequests, func(a, b entity.PullRequestHistory) int {
+		return cmp.Or(a.CreatedAt.Compare(b.CreatedAt), cmp.Compare(a.PullRequestId, b.PullRequestId))
+	})
+
+	return &history, nil
+}
+
+func pullRequestHistory(pr *pullRequest, reviewers []string) entity.PullRequestHistory {
+	reviewers = append([]string{}, reviewers...)
+	slices.Sort(reviewers)
+
+	return entity.PullRequestHistory{
+		AssignedReviewers: reviewers,
+		AuthorId:          pr.authorID,
+		CreatedAt:         pr.createdAt,
+		MergedAt:          pr.mergedAt,
+		PullRequestId:     pr.id,
+	}
+}
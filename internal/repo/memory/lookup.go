@@ -0,0 +1,174 @@
+package memory
+
+import (
+	"context"
+
+	"avito/internal/entity"
+	"avito/internal/repo"
+)
+
+type LookupRepo struct {
+	store *Store
+}
+
+func InitLookupRepo(store *Store) repo.Lookup {
+	return LookupRepo{store: store}
+}
+
+func (r LookupRepo) Users(ctx context.Context, userIDs []string) ([]entity.User, error) {
+	s := r.store.org(ctx)
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var users []entity.User
+
+	for _, id := range unique(userIDs) {
+		if user, ok := s.users[id]; ok {
+			users = append(users, *user)
+		}
+	}
+
+	return users, nil
+}
+
+func (r LookupRepo) Teams(ctx context.Context, teamNames []string) ([]entity.Team, error) {
+	s := r.store.org(ctx)
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var teams []entity.Team
+
+	for _, name := range unique(teamNames) {
+		if _, ok := s.teams[name]; !ok {
+			continue
+		}
+
+		team := entity.Team{TeamName: name}
+
+		for _, id := range s.userOrder {
+			user := s.users[id]
+			if user.TeamName == name {
+				team.Members = append(team.Members, entity.TeamMember{
+					IsActive: user.IsActive,
+					UserId:   user.UserId,
+					Username: user.Username,
+				})
+			}
+		}
+
+		teams = append(teams, team)
+	}
+
+	return teams, nil
+}
+
+func (r LookupRepo) PullRequests(ctx context.Context, pullRequestIDs []string) ([]entity.PullRequest, error) {
+	s := r.store.org(ctx)
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var prs []entity.PullRequest
+
+	for _, id := range unique(pullRequestIDs) {
+		if pr, ok := s.prs[id]; ok {
+			prs = append(prs, r.pullRequest(s, pr))
+		}
+	}
+
+	return prs, nil
+}
+
+func (r LookupRepo) Reviews(ctx context.Context, userIDs []string) ([]entity.PullRequest, error) {
+	s := r.store.org(ctx)
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	wanted := make(map[string]struct{}, len(userIDs))
+	for _, id := range userIDs {
+		wanted[id] = struct{}{}
+	}
+
+	var prs []entity.PullRequest
+
+	seen := make(map[string]struct{})
+
+	for _, rev := range s.reviewers {
+		if _, ok := wanted[rev.reviewerID]; !ok {
+			continue
+		}
+
+		if _, ok := seen[rev.pullRequestID]; ok {
+			continue
+		}
+
+		seen[rev.pullRequestID] = struct{}{}
+		prs = append(prs, r.pullRequest(s, s.prs[rev.pullRequestID]))
+	}
+
+	return prs, nil
+}
+
+func (r LookupRepo) UserStats(ctx context.Context, userIDs []string) ([]entity.UserStat, error) {
+	s := r.store.org(ctx)
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	stats := StatRepo(r)
+
+	var result []entity.UserStat
+
+	for _, id := range unique(userIDs) {
+		if _, ok := s.users[id]; !ok {
+			continue
+		}
+
+		stat, err := stats.userStat(s, id)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, *stat)
+	}
+
+	return result, nil
+}
+
+func (r LookupRepo) pullRequest(s *Store, pr *pullRequest) entity.PullRequest {
+	createdAt := pr.createdAt
+
+	result := entity.PullRequest{
+		AssignedReviewers: s.reviewersOf(pr.id),
+		AuthorId:          pr.authorID,
+		CreatedAt:         &createdAt,
+		PullRequestId:     pr.id,
+		PullRequestName:   pr.name,
+		Status:            entity.PRStatusOPEN,
+	}
+
+	if pr.mergedAt != nil {
+		mergedAt := *pr.mergedAt
+		result.MergedAt = &mergedAt
+		result.Status = entity.PRStatusMERGED
+	}
+
+	return result
+}
+
+func unique(keys []string) []string {
+	seen := make(map[string]struct{}, len(keys))
+	result := make([]string, 0, len(keys))
+
+	for _, key := range keys {
+		if _, ok := seen[key]; !ok {
+			seen[key] = struct{}{}
+			result = append(result, key)
+		}
+	}
+
+	return result
+}
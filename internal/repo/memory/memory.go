@@ -0,0 +1,223 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"sync"
+	"time"
+
+	"avito/internal/cerr"
+	"avito/internal/entity"
+	"avito/internal/org"
+)
+
+var (
+	errNoRows    = errors.New("memory: no rows in result set")
+	errDuplicate = errors.New("memory: duplicate key")
+)
+
+type pullRequest struct {
+	id        string
+	name      string
+	authorID  string
+	createdAt time.Time
+	mergedAt  *time.Time
+	// restoredAt is set when the pull request came back from the archive.
+	restoredAt *time.Time
+}
+
+type reviewer struct {
+	pullRequestID string
+	reviewerID    string
+	assignedAt    time.Time
+	remindedAt    *time.Time
+	escalatedAt   *time.Time
+}
+
+// archivedPullRequest is a merged pull request moved out of prs with its reviewers.
+type archivedPullRequest struct {
+	pr        pullRequest
+	reviewers []reviewer
+}
+
+// archivedStat holds the reviews a reviewer has in the archive, all of them merged.
+type archivedStat struct {
+	count int
+	hours float64
+}
+
+// Store holds the data shared by the in-memory repos. Every repo call takes the lock for
+// its whole duration, which gives the same all-or-nothing behaviour as the transactions
+// in the Postgres repos. Rows keep insertion order so results are deterministic.
+//
+// The store returned by InitStore holds the default organization, every other one gets
+// a store of its own, see org.
+type Store struct {
+	mu sync.RWMutex
+
+	teams     map[string]struct{}
+	users     map[string]*entity.User
+	userOrder []string
+	prs       map[string]*pullRequest
+	reviewers []reviewer
+	// notifications holds the settings users changed, see notificationsOf.
+	notifications map[string]entity.NotificationSettings
+	slas          map[string]entity.TeamSLA
+	// archived holds the archived pull requests, archivedStats what the statistics keep
+	// of them.
+	archived      map[string]*archivedPullRequest
+	archivedStats map[string]archivedStat
+	erasures      []entity.UserErasure
+
+	orgsMu sync.Mutex
+	orgs   map[string]*Store
+
+	now func() time.Time
+}
+
+func InitStore() *Store {
+	return &Store{
+		teams: make(map[string]struct{}),
+		users: make(map[string]*entity.User),
+		prs:   make(map[string]*pullRequest),
+
+		notifications: make(map[string]entity.NotificationSettings),
+		slas:          make(map[string]entity.TeamSLA),
+
+		archived:      make(map[string]*archivedPullRequest),
+		archivedStats: make(map[string]archivedStat),
+
+		orgs: make(map[string]*Store),
+
+		now: time.Now,
+	}
+}
+
+// org returns the store of the organization of ctx. The store of an organization is
+// created on first use, it starts out empty like a new organization in Postgres.
+func (s *Store) org(ctx context.Context) *Store {
+	orgID := org.FromContext(ctx)
+	if orgID == org.Default {
+		return s
+	}
+
+	s.orgsMu.Lock()
+	defer s.orgsMu.Unlock()
+
+	store, ok := s.orgs[orgID]
+	if !ok {
+		store = InitStore()
+		store.now = s.now
+		s.orgs[orgID] = store
+	}
+
+	return store
+}
+
+// clone copies the store for a dry run. Pull requests, notification settings and the
+// archive are shared, nothing that runs on a clone changes them.
+func (s *Store) clone() *Store {
+	c := &Store{
+		teams:     make(map[string]struct{}, len(s.teams)),
+		users:     make(map[string]*entity.User, len(s.users)),
+		userOrder: append([]string(nil), s.userOrder...),
+		prs:       s.prs,
+		reviewers: append([]reviewer(nil), s.reviewers...),
+
+		notifications: s.notifications,
+		slas:          maps.Clone(s.slas),
+
+		archived:      s.archived,
+		archivedStats: s.archivedStats,
+
+		now: s.now,
+	}
+
+	for name := range s.teams {
+		c.teams[name] = struct{}{}
+	}
+
+	for id, user := range s.users {
+		copied := *user
+		c.users[id] = &copied
+	}
+
+	return c
+}
+
+// timestamp mirrors the microsecond precision of Postgres timestamps.
+func (s *Store) timestamp() time.Time {
+	return s.now().UTC().Truncate(time.Microsecond)
+}
+
+// reassign hands a review to another reviewer, who starts the SLA afresh.
+func (s *Store) reassign(i int, reviewerID string) {
+	s.reviewers[i] = reviewer{
+		pullRequestID: s.reviewers[i].pullRequestID,
+		reviewerID:    reviewerID,
+		assignedAt:    s.timestamp(),
+	}
+}
+
+func (s *Store) reviewersOf(pullRequestID string) []string {
+	var ids []string
+
+	for _, r := range s.reviewers {
+		if r.pullRequestID == pullRequestID {
+			ids = append(ids, r.reviewerID)
+		}
+	}
+
+	return ids
+}
+
+// candidates returns active teammates of the author, excluding the author and the given
+// users, ordered by how many reviews they were ever assigned, archived ones included,
+// like the Postgres query.
+func (s *Store) candidates(authorID string, exclude ...string) []string {
+	author, ok := s.users[authorID]
+	if !ok {
+		return nil
+	}
+
+	load := make(map[string]int)
+	for _, r := range s.reviewers {
+		load[r.reviewerID]++
+	}
+
+	for id, stat := range s.archivedStats {
+		load[id] += stat.count
+	}
+
+	skip := make(map[string]struct{}, len(exclude)+1)
+	skip[authorID] = struct{}{}
+
+	for _, id := range exclude {
+		skip[id] = struct{}{}
+	}
+
+	var ids []string
+
+	for _, id := range s.userOrder {
+		user := s.users[id]
+		if _, ok := skip[id]; ok || user.TeamName != author.TeamName || !user.IsActive {
+			continue
+		}
+
+		ids = append(ids, id)
+	}
+
+	// Insertion sort keeps ties in insertion order and the lists are team-sized.
+	for i := 1; i < len(ids); i++ {
+		for j := i; j > 0 && load[ids[j]] < load[ids[j-1]]; j-- {
+			ids[j], ids[j-1] = ids[j-1], ids[j]
+		}
+	}
+
+	return ids
+}
+
+func notFound() error {
+	return cerr.CustomError{Err: errNoRows, ErrType: cerr.NOT_FOUND}
+}
@@ -0,0 +1,76 @@
+package memory_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"avito/internal/entity"
+	"avito/internal/repo"
+	"avito/internal/repo/memory"
+	"avito/internal/repo/repotest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConformance(t *testing.T) {
+	repotest.Run(t, func(_ *testing.T) repo.Repos {
+		store := memory.InitStore()
+
+		return repo.Repos{
+			Team:         memory.InitTeamRepo(store),
+			User:         memory.InitUserRepo(store),
+			PullRequest:  memory.InitPullRequestRepo(store),
+			Stat:         memory.InitStatRepo(store),
+			History:      memory.InitHistoryRepo(store),
+			Lookup:       memory.InitLookupRepo(store),
+			Notification: memory.InitNotificationRepo(store),
+			SLA:          memory.InitSLARepo(store),
+			Organization: memory.InitOrganizationRepo(store),
+			Archive:      memory.InitArchiveRepo(store),
+			Erasure:      memory.InitErasureRepo(store),
+		}
+	})
+}
+
+func TestConcurrentCreate(t *testing.T) {
+	ctx := context.Background()
+	store := memory.InitStore()
+	prs := memory.InitPullRequestRepo(store)
+
+	require.NoError(t, memory.InitTeamRepo(store).Create(ctx, &entity.Team{
+		TeamName: "backend",
+		Members: []entity.TeamMember{
+			{UserId: "author", IsActive: true},
+			{UserId: "u1", IsActive: true},
+			{UserId: "u2", IsActive: true},
+			{UserId: "u3", IsActive: true},
+		},
+	}))
+
+	var wg sync.WaitGroup
+
+	for i := range 30 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			_, err := prs.Create(ctx, &entity.PullRequestCreate{PullRequestId: fmt.Sprintf("pr-%d", i), AuthorId: "author"})
+			assert.NoError(t, err)
+		}()
+	}
+
+	wg.Wait()
+
+	// 60 assignments over three reviewers: the least-loaded rule keeps them even.
+	stat, err := memory.InitStatRepo(store).Team(ctx, "backend")
+	require.NoError(t, err)
+
+	for _, user := range stat.UsersStat {
+		if user.UserId != "author" {
+			assert.Equal(t, 20, user.CountPr, user.UserId)
+		}
+	}
+}
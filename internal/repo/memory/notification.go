@@ -0,0 +1,91 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+
+	"avito/internal/entity"
+	"avito/internal/repo"
+)
+
+type NotificationRepo struct {
+	store *Store
+}
+
+func InitNotificationRepo(store *Store) repo.Notification {
+	return NotificationRepo{store: store}
+}
+
+func (r NotificationRepo) Get(ctx context.Context, userIDs []string) ([]entity.NotificationSettings, error) {
+	s := r.store.org(ctx)
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var settings []entity.NotificationSettings
+
+	for _, id := range unique(userIDs) {
+		if _, ok := s.users[id]; ok {
+			settings = append(settings, s.notificationsOf(id))
+		}
+	}
+
+	return settings, nil
+}
+
+func (r NotificationRepo) Set(ctx context.Context, settings *entity.NotificationSettings) (*entity.NotificationSettings, error) {
+	s := r.store.org(ctx)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.users[settings.UserId]; !ok {
+		return nil, notFound()
+	}
+
+	s.notifications[settings.UserId] = copySettings(*settings)
+
+	result := s.notificationsOf(settings.UserId)
+
+	return &result, nil
+}
+
+func (r NotificationRepo) Subscribers(ctx context.Context) ([]entity.NotificationSettings, error) {
+	s := r.store.org(ctx)
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var settings []entity.NotificationSettings
+
+	for _, id := range s.userOrder {
+		if s := s.notificationsOf(id); s.Email != nil && s.EmailDigest {
+			settings = append(settings, s)
+		}
+	}
+
+	slices.SortFunc(settings, func(a, b entity.NotificationSettings) int { return cmp.Compare(a.UserId, b.UserId) })
+
+	return settings, nil
+}
+
+// notificationsOf returns the settings of a user, users start with no email and both
+// switches on like the column defaults.
+func (s *Store) notificationsOf(userID string) entity.NotificationSettings {
+	if settings, ok := s.notifications[userID]; ok {
+		return copySettings(settings)
+	}
+
+	return entity.NotificationSettings{UserId: userID, EmailAssignments: true, EmailDigest: true}
+}
+
+// copySettings keeps callers from changing the stored email through the pointer.
+func copySettings(settings entity.NotificationSettings) entity.NotificationSettings {
+	if settings.Email != nil {
+		email := *settings.Email
+		settings.Email = &email
+	}
+
+	return settings
+}
@@ -0,0 +1,40 @@
+package memory
+
+import (
+	"context"
+	"slices"
+
+	"avito/internal/org"
+	"avito/internal/repo"
+)
+
+type OrganizationRepo struct {
+	store *Store
+}
+
+func InitOrganizationRepo(store *Store) repo.Organization {
+	return OrganizationRepo{store: store}
+}
+
+func (r OrganizationRepo) Ensure(ctx context.Context, orgIDs []string) error {
+	for _, orgID := range orgIDs {
+		r.store.org(org.With(ctx, orgID))
+	}
+
+	return nil
+}
+
+func (r OrganizationRepo) List(_ context.Context) ([]string, error) {
+	r.store.orgsMu.Lock()
+	defer r.store.orgsMu.Unlock()
+
+	orgIDs := []string{org.Default}
+
+	for orgID := range r.store.orgs {
+		orgIDs = append(orgIDs, orgID)
+	}
+
+	slices.Sort(orgIDs)
+
+	return orgIDs, nil
+}
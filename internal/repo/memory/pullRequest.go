@@ -0,0 +1,166 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"avito/internal/cerr"
+	"avito/internal/entity"
+	"avito/internal/repo"
+)
+
+type PullRequestRepo struct {
+	store *Store
+}
+
+func InitPullRequestRepo(store *Store) repo.PullRequest {
+	return PullRequestRepo{store: store}
+}
+
+func (r PullRequestRepo) Create(ctx context.Context, pullRequestCreate *entity.PullRequestCreate) (*entity.PullRequest, error) {
+	s := r.store.org(ctx)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// An archived pull request keeps its id, it can be restored.
+	_, archived := s.archived[pullRequestCreate.PullRequestId]
+	if _, exists := s.prs[pullRequestCreate.PullRequestId]; exists || archived {
+		return nil, cerr.CustomError{
+			Err:     fmt.Errorf("%w: pull_requests_pkey %q", errDuplicate, pullRequestCreate.PullRequestId),
+			ErrType: cerr.PR_EXISTS,
+		}
+	}
+
+	if _, ok := s.users[pullRequestCreate.AuthorId]; !ok {
+		return nil, cerr.CustomError{
+			Err:     fmt.Errorf("memory: pull_requests_author_id_fkey %q", pullRequestCreate.AuthorId),
+			ErrType: cerr.NOT_FOUND,
+		}
+	}
+
+	createdAt := s.timestamp()
+
+	s.prs[pullRequestCreate.PullRequestId] = &pullRequest{
+		id:        pullRequestCreate.PullRequestId,
+		name:      pullRequestCreate.PullRequestName,
+		authorID:  pullRequestCreate.AuthorId,
+		createdAt: createdAt,
+	}
+
+	pr := entity.PullRequest{
+		AuthorId:        pullRequestCreate.AuthorId,
+		PullRequestName: pullRequestCreate.PullRequestName,
+		PullRequestId:   pullRequestCreate.PullRequestId,
+		Status:          entity.PRStatusOPEN,
+		CreatedAt:       &createdAt,
+	}
+
+	candidates := s.candidates(pullRequestCreate.AuthorId)
+	if len(candidates) > 2 {
+		candidates = candidates[:2]
+	}
+
+	for _, id := range candidates {
+		s.reviewers = append(s.reviewers, reviewer{pullRequestID: pr.PullRequestId, reviewerID: id, assignedAt: createdAt})
+		pr.AssignedReviewers = append(pr.AssignedReviewers, id)
+	}
+
+	return &pr, nil
+}
+
+func (r PullRequestRepo) Merge(ctx context.Context, pullRequestID string) (*entity.PullRequest, error) {
+	s := r.store.org(ctx)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored, ok := s.prs[pullRequestID]
+	if !ok {
+		return nil, notFound()
+	}
+
+	mergedAt := s.timestamp()
+	stored.mergedAt = &mergedAt
+
+	createdAt := stored.createdAt
+
+	return &entity.PullRequest{
+		AssignedReviewers: s.reviewersOf(pullRequestID),
+		AuthorId:          stored.authorID,
+		CreatedAt:         &createdAt,
+		MergedAt:          &mergedAt,
+		PullRequestId:     pullRequestID,
+		PullRequestName:   stored.name,
+		Status:            entity.PRStatusMERGED,
+	}, nil
+}
+
+func (r PullRequestRepo) Reassign(ctx context.Context, pullRequestID string, oldUserID string) (*entity.PullRequest, string, error) {
+	s := r.store.org(ctx)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.users[oldUserID]; !ok {
+		return nil, "", notFound()
+	}
+
+	stored, ok := s.prs[pullRequestID]
+	if !ok {
+		return nil, "", notFound()
+	}
+
+	if stored.mergedAt != nil {
+		return nil, "", cerr.CustomError{
+			Err:     errors.New("memory: pull request is merged"),
+			ErrType: cerr.PR_MERGED,
+		}
+	}
+
+	current := s.reviewersOf(pullRequestID)
+
+	var secondUser string
+
+	assigned := false
+
+	for _, id := range current {
+		if id == oldUserID {
+			assigned = true
+		} else {
+			secondUser = id
+		}
+	}
+
+	if !assigned {
+		return nil, "", cerr.CustomError{
+			Err:     errors.New("memory: reviewer is not assigned"),
+			ErrType: cerr.NOT_ASSIGNED,
+		}
+	}
+
+	candidates := s.candidates(stored.authorID, current...)
+	if len(candidates) == 0 {
+		return nil, "", cerr.CustomError{
+			Err:     errNoRows,
+			ErrType: cerr.NO_CANDIDATE,
+		}
+	}
+
+	newUser := candidates[0]
+
+	for i, rev := range s.reviewers {
+		if rev.pullRequestID == pullRequestID && rev.reviewerID == oldUserID {
+			s.reassign(i, newUser)
+		}
+	}
+
+	return &entity.PullRequest{
+		AssignedReviewers: []string{newUser, secondUser},
+		AuthorId:          stored.authorID,
+		PullRequestId:     pullRequestID,
+		PullRequestName:   stored.name,
+		Status:            entity.PRStatusOPEN,
+	}, newUser, nil
+}
@@ -0,0 +1,185 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"avito/internal/cerr"
+	"avito/internal/entity"
+	"avito/internal/repo"
+)
+
+type SLARepo struct {
+	store *Store
+}
+
+func InitSLARepo(store *Store) repo.SLA {
+	return SLARepo{store: store}
+}
+
+func (r SLARepo) Get(ctx context.Context, teamName string) (*entity.TeamSLA, error) {
+	s := r.store.org(ctx)
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	sla, ok := s.slas[teamName]
+	if !ok {
+		return nil, notFound()
+	}
+
+	sla = copySLA(sla)
+
+	return &sla, nil
+}
+
+func (r SLARepo) Set(ctx context.Context, sla *entity.TeamSLA) (*entity.TeamSLA, error) {
+	s := r.store.org(ctx)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.teams[sla.TeamName]; !ok {
+		return nil, cerr.CustomError{Err: errors.New("memory: team_slas_team_name_fkey"), ErrType: cerr.NOT_FOUND}
+	}
+
+	if sla.LeadId != nil {
+		if _, ok := s.users[*sla.LeadId]; !ok {
+			return nil, cerr.CustomError{Err: errors.New("memory: team_slas_lead_id_fkey"), ErrType: cerr.NOT_FOUND}
+		}
+	}
+
+	stored := copySLA(*sla)
+	stored.RemindAfter = stored.RemindAfter.Truncate(time.Second)
+	stored.EscalateAfter = stored.EscalateAfter.Truncate(time.Second)
+	s.slas[sla.TeamName] = stored
+
+	result := copySLA(stored)
+
+	return &result, nil
+}
+
+func (r SLARepo) Delete(ctx context.Context, teamName string) error {
+	s := r.store.org(ctx)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.slas[teamName]; !ok {
+		return notFound()
+	}
+
+	delete(s.slas, teamName)
+
+	return nil
+}
+
+func (r SLARepo) List(ctx context.Context) ([]entity.TeamSLA, error) {
+	s := r.store.org(ctx)
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var slas []entity.TeamSLA
+
+	for _, sla := range s.slas {
+		slas = append(slas, copySLA(sla))
+	}
+
+	slices.SortFunc(slas, func(a, b entity.TeamSLA) int { return cmp.Compare(a.TeamName, b.TeamName) })
+
+	return slas, nil
+}
+
+func (r SLARepo) Pending(ctx context.Context) ([]entity.PendingReview, error) {
+	s := r.store.org(ctx)
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var reviews []entity.PendingReview
+
+	for _, rev := range s.reviewers {
+		pr := s.prs[rev.pullRequestID]
+		if pr.mergedAt != nil {
+			continue
+		}
+
+		author := s.users[pr.authorID]
+		if _, ok := s.slas[author.TeamName]; !ok {
+			continue
+		}
+
+		reviews = append(reviews, entity.PendingReview{
+			PullRequestId:   pr.id,
+			PullRequestName: pr.name,
+			AuthorId:        pr.authorID,
+			ReviewerId:      rev.reviewerID,
+			TeamName:        author.TeamName,
+			AssignedAt:      rev.assignedAt,
+			RemindedAt:      copyTime(rev.remindedAt),
+			EscalatedAt:     copyTime(rev.escalatedAt),
+		})
+	}
+
+	slices.SortStableFunc(reviews, func(a, b entity.PendingReview) int {
+		return cmp.Or(a.AssignedAt.Compare(b.AssignedAt), cmp.Compare(a.PullRequestId, b.PullRequestId),
+			cmp.Compare(a.ReviewerId, b.ReviewerId))
+	})
+
+	return reviews, nil
+}
+
+func (r SLARepo) MarkReminded(ctx context.Context, pullRequestID string, reviewerID string, at time.Time) (bool, error) {
+	return r.mark(r.store.org(ctx), pullRequestID, reviewerID, at, func(rev *reviewer) **time.Time { return &rev.remindedAt })
+}
+
+func (r SLARepo) MarkEscalated(ctx context.Context, pullRequestID string, reviewerID string, at time.Time) (bool, error) {
+	return r.mark(r.store.org(ctx), pullRequestID, reviewerID, at, func(rev *reviewer) **time.Time { return &rev.escalatedAt })
+}
+
+func (r SLARepo) mark(s *Store, pullRequestID string, reviewerID string, at time.Time, field func(rev *reviewer) **time.Time) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range s.reviewers {
+		rev := &s.reviewers[i]
+		if rev.pullRequestID != pullRequestID || rev.reviewerID != reviewerID {
+			continue
+		}
+
+		if marked := field(rev); *marked == nil {
+			at = at.UTC().Truncate(time.Microsecond)
+			*marked = &at
+
+			return true, nil
+		}
+
+		return false, nil
+	}
+
+	return false, nil
+}
+
+// copySLA keeps callers from changing the stored lead through the pointer.
+func copySLA(sla entity.TeamSLA) entity.TeamSLA {
+	if sla.LeadId != nil {
+		lead := *sla.LeadId
+		sla.LeadId = &lead
+	}
+
+	return sla
+}
+
+func copyTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+
+	copied := *t
+
+	return &copied
+}
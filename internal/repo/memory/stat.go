@@ -0,0 +1,105 @@
+package memory
+
+import (
+	"context"
+
+	"avito/internal/entity"
+	"avito/internal/repo"
+)
+
+type StatRepo struct {
+	store *Store
+}
+
+func InitStatRepo(store *Store) repo.Stat {
+	return StatRepo{store: store}
+}
+
+func (r StatRepo) User(ctx context.Context, userID string) (*entity.UserStat, error) {
+	s := r.store.org(ctx)
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return r.userStat(s, userID)
+}
+
+func (r StatRepo) Team(ctx context.Context, teamName string) (*entity.TeamStat, error) {
+	s := r.store.org(ctx)
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	users := []entity.UserStat{}
+
+	var cntMerged, duration float64
+
+	for _, id := range s.userOrder {
+		if s.users[id].TeamName != teamName {
+			continue
+		}
+
+		user, err := r.userStat(s, id)
+		if err != nil {
+			return nil, err
+		}
+
+		if user.AvgDuration != nil {
+			duration += *user.AvgDuration
+			cntMerged++
+		}
+
+		users = append(users, *user)
+	}
+
+	avgDuration := -1.0
+	if cntMerged != 0 {
+		avgDuration = duration / cntMerged
+	}
+
+	return &entity.TeamStat{
+		TeamName:    teamName,
+		UsersStat:   users,
+		AvgDuration: avgDuration,
+	}, nil
+}
+
+func (r StatRepo) userStat(s *Store, userID string) (*entity.UserStat, error) {
+	user, ok := s.users[userID]
+	if !ok {
+		return nil, notFound()
+	}
+
+	stat := entity.UserStat{
+		IsActive: user.IsActive,
+		UserId:   user.UserId,
+	}
+
+	var cntMerged, duration float64
+
+	for _, rev := range s.reviewers {
+		if rev.reviewerID != userID {
+			continue
+		}
+
+		stat.CountPr++
+
+		pr := s.prs[rev.pullRequestID]
+		if pr.mergedAt != nil {
+			duration += pr.mergedAt.Sub(pr.createdAt).Hours()
+			cntMerged++
+		}
+	}
+
+	archived := s.archivedStats[userID]
+	stat.CountPr += archived.count
+	duration += archived.hours
+	cntMerged += float64(archived.count)
+
+	if cntMerged != 0 {
+		avg := duration / cntMerged
+		stat.AvgDuration = &avg
+	}
+
+	return &stat, nil
+}
@@ -0,0 +1,241 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	"avito/internal/cerr"
+	"avito/internal/entity"
+	"avito/internal/repo"
+)
+
+type TeamRepo struct {
+	store *Store
+}
+
+func InitTeamRepo(store *Store) repo.Team {
+	return TeamRepo{store: store}
+}
+
+func (r TeamRepo) CheckTeamName(ctx context.Context, teamName string) (bool, error) {
+	s := r.store.org(ctx)
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	_, exists := s.teams[teamName]
+
+	return !exists, nil
+}
+
+func (r TeamRepo) Create(ctx context.Context, team *entity.Team) error {
+	s := r.store.org(ctx)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.teams[team.TeamName]; exists {
+		return cerr.CustomError{
+			Err:     fmt.Errorf("%w: teams_pkey %q", errDuplicate, team.TeamName),
+			ErrType: cerr.TEAM_EXISTS,
+		}
+	}
+
+	seen := make(map[string]struct{}, len(team.Members))
+
+	for _, member := range team.Members {
+		_, inStore := s.users[member.UserId]
+		_, inTeam := seen[member.UserId]
+
+		if inStore || inTeam {
+			return cerr.CustomError{
+				Err:     fmt.Errorf("%w: users_pkey %q", errDuplicate, member.UserId),
+				ErrType: cerr.SERVER,
+			}
+		}
+
+		seen[member.UserId] = struct{}{}
+	}
+
+	s.teams[team.TeamName] = struct{}{}
+
+	for _, member := range team.Members {
+		s.users[member.UserId] = &entity.User{
+			IsActive: member.IsActive,
+			TeamName: team.TeamName,
+			UserId:   member.UserId,
+			Username: member.Username,
+		}
+		s.userOrder = append(s.userOrder, member.UserId)
+	}
+
+	return nil
+}
+
+func (r TeamRepo) Get(ctx context.Context, teamName string) (*entity.Team, error) {
+	s := r.store.org(ctx)
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	team := entity.Team{TeamName: teamName}
+
+	for _, id := range s.userOrder {
+		user := s.users[id]
+		if user.TeamName != teamName {
+			continue
+		}
+
+		team.Members = append(team.Members, entity.TeamMember{
+			IsActive: user.IsActive,
+			UserId:   user.UserId,
+			Username: user.Username,
+		})
+	}
+
+	return &team, nil
+}
+
+// List orders the teams by their first member like the Postgres repo orders them by
+// creation, teams left without members come last.
+func (r TeamRepo) List(ctx context.Context) ([]entity.Team, error) {
+	s := r.store.org(ctx)
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var teams []entity.Team
+
+	teamIndex := make(map[string]int)
+
+	for _, id := range s.userOrder {
+		user := s.users[id]
+
+		i, ok := teamIndex[user.TeamName]
+		if !ok {
+			i = len(teams)
+			teamIndex[user.TeamName] = i
+			teams = append(teams, entity.Team{TeamName: user.TeamName})
+		}
+
+		teams[i].Members = append(teams[i].Members, entity.TeamMember{
+			IsActive: user.IsActive,
+			UserId:   user.UserId,
+			Username: user.Username,
+		})
+	}
+
+	var empty []string
+
+	for name := range s.teams {
+		if _, ok := teamIndex[name]; !ok {
+			empty = append(empty, name)
+		}
+	}
+
+	slices.Sort(empty)
+
+	for _, name := range empty {
+		teams = append(teams, entity.Team{TeamName: name})
+	}
+
+	return teams, nil
+}
+
+// Apply runs a dry run on a copy of the store.
+func (r TeamRepo) Apply(ctx context.Context, changes []entity.TeamChange, dryRun bool) ([]entity.Reassignment, error) {
+	s := r.store.org(ctx)
+
+	if dryRun {
+		s.mu.RLock()
+		defer s.mu.RUnlock()
+
+		return apply(s.clone(), changes), nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return apply(s, changes), nil
+}
+
+func apply(s *Store, changes []entity.TeamChange) []entity.Reassignment {
+	var removed []string
+
+	for _, change := range changes {
+		switch change.Action {
+		case entity.ActionAddTeam:
+			s.teams[change.TeamName] = struct{}{}
+		case entity.ActionRemoveTeam:
+			delete(s.teams, change.TeamName)
+			delete(s.slas, change.TeamName)
+		case entity.ActionRemoveMember:
+			if user, ok := s.users[change.UserId]; ok {
+				user.IsActive = false
+				removed = append(removed, change.UserId)
+			}
+		default:
+			if _, ok := s.users[change.UserId]; !ok {
+				s.userOrder = append(s.userOrder, change.UserId)
+			}
+
+			s.users[change.UserId] = &entity.User{
+				IsActive: change.IsActive,
+				TeamName: change.TeamName,
+				UserId:   change.UserId,
+				Username: change.Username,
+			}
+		}
+	}
+
+	var reassignments []entity.Reassignment
+
+	for _, userID := range removed {
+		reassignments = append(reassignments, reassignReviews(s, userID)...)
+	}
+
+	return reassignments
+}
+
+func reassignReviews(s *Store, userID string) []entity.Reassignment {
+	var prs []*pullRequest
+
+	for _, rev := range s.reviewers {
+		if pr := s.prs[rev.pullRequestID]; rev.reviewerID == userID && pr.mergedAt == nil {
+			prs = append(prs, pr)
+		}
+	}
+
+	slices.SortFunc(prs, func(a, b *pullRequest) int {
+		return cmp.Or(a.createdAt.Compare(b.createdAt), cmp.Compare(a.id, b.id))
+	})
+
+	reassignments := make([]entity.Reassignment, 0, len(prs))
+
+	for _, pr := range prs {
+		reassignment := entity.Reassignment{PullRequestId: pr.id, OldReviewerId: userID}
+
+		candidates := s.candidates(pr.authorID, s.reviewersOf(pr.id)...)
+
+		for i, rev := range s.reviewers {
+			if rev.pullRequestID != pr.id || rev.reviewerID != userID {
+				continue
+			}
+
+			if len(candidates) == 0 {
+				s.reviewers = slices.Delete(s.reviewers, i, i+1)
+			} else {
+				reassignment.NewReviewerId = &candidates[0]
+				s.reassign(i, candidates[0])
+			}
+
+			break
+		}
+
+		reassignments = append(reassignments, reassignment)
+	}
+
+	return reassignments
+}
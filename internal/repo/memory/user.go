@@ -0,0 +1,68 @@
+package memory
+
+import (
+	"context"
+
+	"avito/internal/entity"
+	"avito/internal/repo"
+)
+
+type UserRepo struct {
+	store *Store
+}
+
+func InitUserRepo(store *Store) repo.User {
+	return UserRepo{store: store}
+}
+
+func (r UserRepo) SetIsActive(ctx context.Context, userID string, isActive bool) (*entity.User, error) {
+	s := r.store.org(ctx)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	user, ok := s.users[userID]
+	if !ok {
+		return nil, notFound()
+	}
+
+	user.IsActive = isActive
+	result := *user
+
+	return &result, nil
+}
+
+func (r UserRepo) GetReview(ctx context.Context, userID string) ([]entity.PullRequestShort, error) {
+	s := r.store.org(ctx)
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if _, ok := s.users[userID]; !ok {
+		return nil, notFound()
+	}
+
+	var prs []entity.PullRequestShort
+
+	for _, rev := range s.reviewers {
+		if rev.reviewerID != userID {
+			continue
+		}
+
+		pr := s.prs[rev.pullRequestID]
+
+		status := entity.PRStatusOPEN
+		if pr.mergedAt != nil {
+			status = entity.PRStatusMERGED
+		}
+
+		prs = append(prs, entity.PullRequestShort{
+			AuthorId:        pr.authorID,
+			PullRequestId:   pr.id,
+			PullRequestName: pr.name,
+			Status:          status,
+		})
+	}
+
+	return prs, nil
+}
@@ -0,0 +1,83 @@
+package notification
+
+import (
+	"context"
+
+	"avito/internal/cerr"
+	"avito/internal/entity"
+	"avito/internal/org"
+	"avito/internal/postgres"
+	"avito/internal/repo"
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+)
+
+var tracer = otel.Tracer("avito/internal/repo/notification")
+
+type Repo struct {
+	db *postgres.Pg
+}
+
+func InitNotificationRepo(db *postgres.Pg) repo.Notification {
+	return Repo{db: db}
+}
+
+func (r Repo) Get(ctx context.Context, userIDs []string) ([]entity.NotificationSettings, error) {
+	ctx, span := tracer.Start(ctx, "NotificationRepo.Get")
+	defer span.End()
+
+	query := `SELECT id, email, email_assignments, email_digest FROM users WHERE org_id = $1 AND id = ANY($2)`
+
+	return r.query(ctx, query, org.FromContext(ctx), userIDs)
+}
+
+func (r Repo) Set(ctx context.Context, settings *entity.NotificationSettings) (*entity.NotificationSettings, error) {
+	ctx, span := tracer.Start(ctx, "NotificationRepo.Set")
+	defer span.End()
+
+	var result entity.NotificationSettings
+
+	query := `UPDATE users SET email = $1, email_assignments = $2, email_digest = $3
+    WHERE org_id = $4 AND id = $5
+    RETURNING id, email, email_assignments, email_digest`
+
+	err := r.db.Pool.QueryRow(ctx, query, settings.Email, settings.EmailAssignments, settings.EmailDigest,
+		org.FromContext(ctx), settings.UserId).
+		Scan(&result.UserId, &result.Email, &result.EmailAssignments, &result.EmailDigest)
+	if err != nil {
+		return nil, cerr.HandlePgErr(err)
+	}
+
+	return &result, nil
+}
+
+func (r Repo) Subscribers(ctx context.Context) ([]entity.NotificationSettings, error) {
+	ctx, span := tracer.Start(ctx, "NotificationRepo.Subscribers")
+	defer span.End()
+
+	query := `SELECT id, email, email_assignments, email_digest FROM users
+    WHERE org_id = $1 AND email IS NOT NULL AND email_digest
+    ORDER BY id`
+
+	return r.query(ctx, query, org.FromContext(ctx))
+}
+
+func (r Repo) query(ctx context.Context, query string, args ...any) ([]entity.NotificationSettings, error) {
+	rows, err := r.db.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, cerr.HandlePgErr(err)
+	}
+
+	settings, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (entity.NotificationSettings, error) {
+		var s entity.NotificationSettings
+
+		err := row.Scan(&s.UserId, &s.Email, &s.EmailAssignments, &s.EmailDigest)
+
+		return s, err
+	})
+	if err != nil {
+		return nil, cerr.HandlePgErr(err)
+	}
+
+	return settings, nil
+}
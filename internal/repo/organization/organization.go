@@ -0,0 +1,54 @@
+package organization
+
+import (
+	"context"
+
+	"avito/internal/cerr"
+	"avito/internal/postgres"
+	"avito/internal/repo"
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var tracer = otel.Tracer("avito/internal/repo/organization")
+
+type Repo struct {
+	db *postgres.Pg
+}
+
+func InitOrganizationRepo(db *postgres.Pg) repo.Organization {
+	return Repo{db: db}
+}
+
+func (r Repo) Ensure(ctx context.Context, orgIDs []string) error {
+	ctx, span := tracer.Start(ctx, "OrganizationRepo.Ensure")
+	defer span.End()
+
+	span.SetAttributes(attribute.StringSlice("org.ids", orgIDs))
+
+	query := `INSERT INTO organizations (id) SELECT unnest($1::varchar[]) ON CONFLICT DO NOTHING`
+
+	if _, err := r.db.Pool.Exec(ctx, query, orgIDs); err != nil {
+		return cerr.HandlePgErr(err)
+	}
+
+	return nil
+}
+
+func (r Repo) List(ctx context.Context) ([]string, error) {
+	ctx, span := tracer.Start(ctx, "OrganizationRepo.List")
+	defer span.End()
+
+	rows, err := r.db.Pool.Query(ctx, `SELECT id FROM organizations ORDER BY id`)
+	if err != nil {
+		return nil, cerr.HandlePgErr(err)
+	}
+
+	orgIDs, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, cerr.HandlePgErr(err)
+	}
+
+	return orgIDs, nil
+}
@@ -3,15 +3,22 @@ package pullRequest
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
 	"avito/internal/cerr"
 	"avito/internal/entity"
+	"avito/internal/log"
+	"avito/internal/org"
 	"avito/internal/postgres"
 	"avito/internal/repo"
 	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
 )
 
+var tracer = otel.Tracer("avito/internal/repo/pullRequest")
+
 type Repo struct {
 	db *postgres.Pg
 }
@@ -21,6 +28,9 @@ func InitPullRequestRepo(db *postgres.Pg) repo.PullRequest {
 }
 
 func (r Repo) Create(ctx context.Context, pullRequestCreate *entity.PullRequestCreate) (*entity.PullRequest, error) {
+	ctx, span := tracer.Start(ctx, "PullRequestRepo.Create")
+	defer span.End()
+
 	creatAt := time.Now().UTC()
 
 	pullRequest := entity.PullRequest{
@@ -31,33 +41,67 @@ func (r Repo) Create(ctx context.Context, pullRequestCreate *entity.PullRequestC
 		CreatedAt:       &creatAt,
 	}
 
+	orgID := org.FromContext(ctx)
+
 	tx, err := r.db.Pool.Begin(ctx)
 	if err != nil {
 		return nil, cerr.HandlePgErr(err)
 	}
 
+	// An archived pull request keeps its id, it can be restored.
+	createQuery := `INSERT INTO pull_requests (org_id, id, name, author_id, status_id, create_at)
+SELECT $1, $2, $3, $4, (SELECT id FROM statuses WHERE name = $5), $6
+WHERE NOT EXISTS (SELECT 1 FROM archived_pull_requests WHERE org_id = $1 AND id = $2);`
+
+	tag, err := tx.Exec(ctx, createQuery, orgID, pullRequestCreate.PullRequestId, pullRequestCreate.PullRequestName, pullRequestCreate.AuthorId, entity.PRStatusOPEN, creatAt)
+	if err == nil && tag.RowsAffected() == 0 {
+		err = cerr.CustomError{
+			Err:     fmt.Errorf("pull request %v is archived", pullRequestCreate.PullRequestId),
+			ErrType: cerr.PR_EXISTS,
+		}
+	}
+
+	if err != nil {
+		if txErr := tx.Rollback(ctx); txErr != nil {
+			return nil, cerr.HandlePgErr(txErr)
+		}
+
+		return nil, cerr.HandlePgErr(err)
+	}
+
 	choiceQuery := `SELECT u.id
 FROM users AS u
-    LEFT JOIN reviewers AS r ON u.id = r.reviewer_id
-    LEFT JOIN pull_requests AS pr ON r.pull_request_id = pr.id AND pr.merged_at IS NULL
-WHERE u.team_name = (SELECT team_name FROM users WHERE id = $1) AND u.id != $1 AND u.is_active = true
+    LEFT JOIN reviewers AS r ON r.org_id = u.org_id AND u.id = r.reviewer_id
+    LEFT JOIN pull_requests AS pr ON pr.org_id = r.org_id AND r.pull_request_id = pr.id AND pr.merged_at IS NULL
+    LEFT JOIN archived_review_stats AS a ON a.org_id = u.org_id AND a.reviewer_id = u.id
+WHERE u.org_id = $1 AND u.team_name = (SELECT team_name FROM users WHERE org_id = $1 AND id = $2) AND u.id != $2 AND u.is_active = true
 GROUP BY u.id
-ORDER BY COUNT(r.reviewer_id)
+ORDER BY COUNT(r.reviewer_id) + COALESCE(MAX(a.review_count), 0)
 LIMIT 2;`
 
-	rows, err := r.db.Pool.Query(ctx, choiceQuery, pullRequestCreate.AuthorId)
+	selectCtx, selectSpan := tracer.Start(ctx, "PullRequestRepo.selectReviewers")
+
+	rows, err := r.db.Pool.Query(selectCtx, choiceQuery, orgID, pullRequestCreate.AuthorId)
 	if err != nil {
+		selectSpan.End()
+
+		if txErr := tx.Rollback(ctx); txErr != nil {
+			return nil, cerr.HandlePgErr(txErr)
+		}
+
 		return nil, cerr.HandlePgErr(err)
 	}
 	defer rows.Close()
 
-	assignQuery := `INSERT INTO reviewers (pull_request_id, reviewer_id) VALUES ($1, $2);`
+	assignQuery := `INSERT INTO reviewers (org_id, pull_request_id, reviewer_id, assigned_at) VALUES ($1, $2, $3, $4);`
 
 	for rows.Next() {
 		var userID string
 
 		err = rows.Scan(&userID)
 		if err != nil {
+			selectSpan.End()
+
 			if txErr := tx.Rollback(ctx); txErr != nil {
 				return nil, cerr.HandlePgErr(txErr)
 			}
@@ -65,8 +109,10 @@ LIMIT 2;`
 			return nil, cerr.HandlePgErr(err)
 		}
 
-		_, err = tx.Exec(ctx, assignQuery, pullRequestCreate.PullRequestId, userID)
+		_, err = tx.Exec(ctx, assignQuery, orgID, pullRequestCreate.PullRequestId, userID, creatAt)
 		if err != nil {
+			selectSpan.End()
+
 			if txErr := tx.Rollback(ctx); txErr != nil {
 				return nil, cerr.HandlePgErr(txErr)
 			}
@@ -77,16 +123,10 @@ LIMIT 2;`
 		pullRequest.AssignedReviewers = append(pullRequest.AssignedReviewers, userID)
 	}
 
-	createQuery := `INSERT INTO pull_requests (id, name, author_id, status_id, create_at) VALUES ($1, $2, $3, (SELECT id FROM statuses WHERE name = $4), $5);`
-
-	_, err = tx.Exec(ctx, createQuery, pullRequestCreate.PullRequestId, pullRequestCreate.PullRequestName, pullRequestCreate.AuthorId, entity.PRStatusOPEN, creatAt)
-	if err != nil {
-		if txErr := tx.Rollback(ctx); txErr != nil {
-			return nil, cerr.HandlePgErr(txErr)
-		}
+	selectSpan.SetAttributes(attribute.StringSlice("reviewers.assigned", pullRequest.AssignedReviewers))
+	selectSpan.End()
 
-		return nil, cerr.HandlePgErr(err)
-	}
+	log.Ctx(ctx).Debug(fmt.Sprintf("Reviewers assigned: %v", pullRequest.AssignedReviewers))
 
 	err = tx.Commit(ctx)
 	if err != nil {
@@ -97,6 +137,9 @@ LIMIT 2;`
 }
 
 func (r Repo) Merge(ctx context.Context, pullRequestID string) (*entity.PullRequest, error) {
+	ctx, span := tracer.Start(ctx, "PullRequestRepo.Merge")
+	defer span.End()
+
 	mergedAt := time.Now().UTC()
 	pullRequest := entity.PullRequest{
 		PullRequestId: pullRequestID,
@@ -104,15 +147,17 @@ func (r Repo) Merge(ctx context.Context, pullRequestID string) (*entity.PullRequ
 		Status:        entity.PRStatusMERGED,
 	}
 
+	orgID := org.FromContext(ctx)
+
 	tx, err := r.db.Pool.Begin(ctx)
 	if err != nil {
 		return nil, cerr.HandlePgErr(err)
 	}
 
-	updateQuery := `UPDATE pull_requests as pr SET status_id=(SELECT id FROM statuses WHERE name = $1), merged_at = $2 WHERE pr.id = $3
+	updateQuery := `UPDATE pull_requests as pr SET status_id=(SELECT id FROM statuses WHERE name = $1), merged_at = $2 WHERE pr.org_id = $3 AND pr.id = $4
                                       returning name, author_id, create_at`
 
-	err = tx.QueryRow(ctx, updateQuery, entity.PRStatusMERGED, mergedAt, pullRequestID).Scan(&pullRequest.PullRequestName, &pullRequest.AuthorId, &pullRequest.CreatedAt)
+	err = tx.QueryRow(ctx, updateQuery, entity.PRStatusMERGED, mergedAt, orgID, pullRequestID).Scan(&pullRequest.PullRequestName, &pullRequest.AuthorId, &pullRequest.CreatedAt)
 	if err != nil {
 		if txErr := tx.Rollback(ctx); txErr != nil {
 			return nil, cerr.HandlePgErr(txErr)
@@ -123,9 +168,9 @@ func (r Repo) Merge(ctx context.Context, pullRequestID string) (*entity.PullRequ
 
 	var reviewer string
 
-	query := `SELECT reviewer_id FROM reviewers WHERE pull_request_id = $1`
+	query := `SELECT reviewer_id FROM reviewers WHERE org_id = $1 AND pull_request_id = $2`
 
-	rows, err := r.db.Pool.Query(ctx, query, pullRequestID)
+	rows, err := r.db.Pool.Query(ctx, query, orgID, pullRequestID)
 	if err != nil {
 		return nil, cerr.HandlePgErr(err)
 	}
@@ -150,10 +195,15 @@ func (r Repo) Merge(ctx context.Context, pullRequestID string) (*entity.PullRequ
 }
 
 func (r Repo) Reassign(ctx context.Context, pullRequestID string, oldUserID string) (*entity.PullRequest, string, error) {
+	ctx, span := tracer.Start(ctx, "PullRequestRepo.Reassign")
+	defer span.End()
+
 	pullRequest := entity.PullRequest{
 		PullRequestId: pullRequestID,
 	}
 
+	orgID := org.FromContext(ctx)
+
 	tx, err := r.db.Pool.Begin(ctx)
 	if err != nil {
 		return nil, "", cerr.HandlePgErr(err)
@@ -161,9 +211,9 @@ func (r Repo) Reassign(ctx context.Context, pullRequestID string, oldUserID stri
 
 	var cnt int
 
-	checkUserQuery := `SELECT COUNT(*) from users where id=$1`
+	checkUserQuery := `SELECT COUNT(*) from users where org_id = $1 AND id=$2`
 
-	err = tx.QueryRow(ctx, checkUserQuery, oldUserID).Scan(&cnt)
+	err = tx.QueryRow(ctx, checkUserQuery, orgID, oldUserID).Scan(&cnt)
 	if err != nil {
 		if txErr := tx.Rollback(ctx); txErr != nil {
 			return nil, "", cerr.HandlePgErr(txErr)
@@ -173,12 +223,16 @@ func (r Repo) Reassign(ctx context.Context, pullRequestID string, oldUserID stri
 	}
 
 	if cnt == 0 {
+		if txErr := tx.Rollback(ctx); txErr != nil {
+			return nil, "", cerr.HandlePgErr(txErr)
+		}
+
 		return nil, "", cerr.CustomError{Err: err, ErrType: cerr.NOT_FOUND}
 	}
 
-	PRQuery := `SELECT merged_at, name, author_id from pull_requests where id = $1`
+	PRQuery := `SELECT merged_at, name, author_id from pull_requests where org_id = $1 AND id = $2`
 
-	err = r.db.Pool.QueryRow(ctx, PRQuery, pullRequestID).Scan(&pullRequest.MergedAt, &pullRequest.PullRequestName, &pullRequest.AuthorId)
+	err = r.db.Pool.QueryRow(ctx, PRQuery, orgID, pullRequestID).Scan(&pullRequest.MergedAt, &pullRequest.PullRequestName, &pullRequest.AuthorId)
 	if err != nil {
 		if txErr := tx.Rollback(ctx); txErr != nil {
 			return nil, "", cerr.HandlePgErr(txErr)
@@ -200,7 +254,7 @@ func (r Repo) Reassign(ctx context.Context, pullRequestID string, oldUserID stri
 
 	pullRequest.Status = entity.PRStatusOPEN
 
-	OldReviewersQuery := `SELECT reviewer_id from reviewers where pull_request_id = $1`
+	OldReviewersQuery := `SELECT reviewer_id from reviewers where org_id = $1 AND pull_request_id = $2`
 	haveOldReviewer := false
 
 	var OldReviewers []string
@@ -209,7 +263,7 @@ func (r Repo) Reassign(ctx context.Context, pullRequestID string, oldUserID stri
 
 	var newUser string
 
-	rows, err := r.db.Pool.Query(ctx, OldReviewersQuery, pullRequestID)
+	rows, err := r.db.Pool.Query(ctx, OldReviewersQuery, orgID, pullRequestID)
 	if err != nil {
 		if txErr := tx.Rollback(ctx); txErr != nil {
 			return nil, "", cerr.HandlePgErr(txErr)
@@ -258,14 +312,20 @@ func (r Repo) Reassign(ctx context.Context, pullRequestID string, oldUserID stri
 
 	choiceQuery := `SELECT u.id
 FROM users AS u
-    LEFT JOIN reviewers AS r ON u.id = r.reviewer_id
-    LEFT JOIN pull_requests AS pr ON r.pull_request_id = pr.id AND pr.merged_at IS NULL
-WHERE u.team_name = (SELECT team_name FROM users WHERE id = $1) AND u.id != $1 And u.id != $2 and u.id != $3 AND u.is_active = true
+    LEFT JOIN reviewers AS r ON r.org_id = u.org_id AND u.id = r.reviewer_id
+    LEFT JOIN pull_requests AS pr ON pr.org_id = r.org_id AND r.pull_request_id = pr.id AND pr.merged_at IS NULL
+    LEFT JOIN archived_review_stats AS a ON a.org_id = u.org_id AND a.reviewer_id = u.id
+WHERE u.org_id = $4 AND u.team_name = (SELECT team_name FROM users WHERE org_id = $4 AND id = $1)
+  AND u.id != $1 And u.id != $2 and u.id != $3 AND u.is_active = true
 GROUP BY u.id
-ORDER BY COUNT(r.reviewer_id)
+ORDER BY COUNT(r.reviewer_id) + COALESCE(MAX(a.review_count), 0)
 LIMIT 1;`
 
-	err = r.db.Pool.QueryRow(ctx, choiceQuery, pullRequest.AuthorId, OldReviewers[0], OldReviewers[1]).Scan(&newUser)
+	selectCtx, selectSpan := tracer.Start(ctx, "PullRequestRepo.selectReplacement")
+	err = r.db.Pool.QueryRow(selectCtx, choiceQuery, pullRequest.AuthorId, OldReviewers[0], OldReviewers[1], orgID).Scan(&newUser)
+	selectSpan.SetAttributes(attribute.String("reviewer.new_id", newUser))
+	selectSpan.End()
+
 	if err != nil {
 		if txErr := tx.Rollback(ctx); txErr != nil {
 			return nil, "", cerr.HandlePgErr(txErr)
@@ -283,9 +343,10 @@ LIMIT 1;`
 
 	defer rows.Close()
 
-	assignQuery := `UPDATE reviewers SET reviewer_id = $1 WHERE pull_request_id = $2 AND reviewer_id=$3;`
+	assignQuery := `UPDATE reviewers SET reviewer_id = $1, assigned_at = now(), reminded_at = NULL, escalated_at = NULL
+    WHERE org_id = $2 AND pull_request_id = $3 AND reviewer_id=$4;`
 
-	_, err = tx.Exec(ctx, assignQuery, newUser, pullRequestID, oldUserID)
+	_, err = tx.Exec(ctx, assignQuery, newUser, orgID, pullRequestID, oldUserID)
 	if err != nil {
 		if txErr := tx.Rollback(ctx); txErr != nil {
 			return nil, "", cerr.HandlePgErr(txErr)
@@ -296,6 +357,8 @@ LIMIT 1;`
 
 	pullRequest.AssignedReviewers = []string{newUser, secondUser}
 
+	log.Ctx(ctx).Debug(fmt.Sprintf("Reviewer %v replaced by %v", oldUserID, newUser))
+
 	err = tx.Commit(ctx)
 	if err != nil {
 		if txErr := tx.Rollback(ctx); txErr != nil {
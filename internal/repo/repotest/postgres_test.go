@@ -0,0 +1,66 @@
+//go:build e2e
+
+package repotest_test
+
+import (
+	"context"
+	"testing"
+
+	"avito/internal/config"
+	"avito/internal/migrator"
+	"avito/internal/postgres"
+	"avito/internal/repo"
+	archiveRepo "avito/internal/repo/archive"
+	historyRepo "avito/internal/repo/history"
+	lookupRepo "avito/internal/repo/lookup"
+	notificationRepo "avito/internal/repo/notification"
+	organizationRepo "avito/internal/repo/organization"
+	PRRepo "avito/internal/repo/pullRequest"
+	"avito/internal/repo/repotest"
+	slaRepo "avito/internal/repo/sla"
+	statRepo "avito/internal/repo/stat"
+	teamRepo "avito/internal/repo/team"
+	userRepo "avito/internal/repo/user"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPostgres needs a database named *_test or *_dev, every case starts by truncating it.
+func TestPostgres(t *testing.T) {
+	cfg := config.InitConfig()
+	if !migrator.IsDisposableDB(cfg.PGName) {
+		t.Skipf("database %q is not disposable", cfg.PGName)
+	}
+
+	db := postgres.MustInitPg(cfg)
+	defer db.Close()
+
+	migrator, err := db.Migrator()
+	require.NoError(t, err)
+
+	defer migrator.Close()
+
+	_, err = migrator.Up(context.Background())
+	require.NoError(t, err)
+
+	repotest.Run(t, func(t *testing.T) repo.Repos {
+		_, err := db.Pool.Exec(context.Background(), `TRUNCATE teams, users, pull_requests, reviewers, user_erasures CASCADE`)
+		require.NoError(t, err)
+
+		_, err = db.Pool.Exec(context.Background(), `DELETE FROM organizations WHERE id != 'default'`)
+		require.NoError(t, err)
+
+		return repo.Repos{
+			Team:         teamRepo.InitTeamRepo(db),
+			User:         userRepo.InitUserRepo(db),
+			PullRequest:  PRRepo.InitPullRequestRepo(db),
+			Stat:         statRepo.InitStatRepo(db),
+			History:      historyRepo.InitHistoryRepo(db),
+			Lookup:       lookupRepo.InitLookupRepo(db),
+			Notification: notificationRepo.InitNotificationRepo(db),
+			SLA:          slaRepo.InitSLARepo(db),
+			Organization: organizationRepo.InitOrganizationRepo(db),
+			Archive:      archiveRepo.InitArchiveRepo(db),
+			Erasure:      teamRepo.InitErasureRepo(db),
+		}
+	})
+}
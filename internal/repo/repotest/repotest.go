@@ -0,0 +1,1089 @@
+// Package repotest is a conformance suite for the repo interfaces. Every storage backend
+// runs it, so they all behave like the Postgres one, down to the cerr error types.
+package repotest
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"avito/internal/cerr"
+	"avito/internal/entity"
+	"avito/internal/org"
+	"avito/internal/repo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Run executes the suite. newRepos must return repos over an empty store on every call.
+func Run(t *testing.T, newRepos func(t *testing.T) repo.Repos) {
+	tests := []struct {
+		description string
+		run         func(t *testing.T, r repo.Repos)
+	}{
+		{"team create and get", testTeam},
+		{"set is active", testSetIsActive},
+		{"create pull request", testCreate},
+		{"reviewers are balanced", testBalance},
+		{"merge", testMerge},
+		{"reassign", testReassign},
+		{"get review", testGetReview},
+		{"statistics", testStat},
+		{"history", testHistory},
+		{"lookup", testLookup},
+		{"team apply", testTeamApply},
+		{"notification settings", testNotification},
+		{"review sla", testSLA},
+		{"organizations", testOrganizations},
+		{"archive", testArchive},
+		{"erasure", testErasure},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			test.run(t, newRepos(t))
+		})
+	}
+}
+
+func requireErrType(t *testing.T, err error, errType cerr.ErrorType) {
+	t.Helper()
+
+	var customErr cerr.CustomError
+
+	require.Error(t, err)
+	require.True(t, errors.As(err, &customErr), "not a cerr.CustomError: %v", err)
+	require.Equal(t, errType, customErr.ErrType, err.Error())
+}
+
+func addTeam(t *testing.T, r repo.Repos, name string, members ...entity.TeamMember) {
+	t.Helper()
+
+	require.NoError(t, r.Team.Create(context.Background(), &entity.Team{TeamName: name, Members: members}))
+}
+
+func member(id string, active bool) entity.TeamMember {
+	return entity.TeamMember{UserId: id, Username: "name-" + id, IsActive: active}
+}
+
+func createPR(t *testing.T, r repo.Repos, id string, author string) *entity.PullRequest {
+	t.Helper()
+
+	pr, err := r.PullRequest.Create(context.Background(), &entity.PullRequestCreate{
+		PullRequestId:   id,
+		PullRequestName: "name-" + id,
+		AuthorId:        author,
+	})
+	require.NoError(t, err)
+
+	return pr
+}
+
+func testTeam(t *testing.T, r repo.Repos) {
+	ctx := context.Background()
+
+	free, err := r.Team.CheckTeamName(ctx, "backend")
+	require.NoError(t, err)
+	assert.True(t, free)
+
+	addTeam(t, r, "backend", member("u1", true), member("u2", false))
+
+	free, err = r.Team.CheckTeamName(ctx, "backend")
+	require.NoError(t, err)
+	assert.False(t, free)
+
+	team, err := r.Team.Get(ctx, "backend")
+	require.NoError(t, err)
+	assert.Equal(t, "backend", team.TeamName)
+	assert.ElementsMatch(t, []entity.TeamMember{member("u1", true), member("u2", false)}, team.Members)
+
+	err = r.Team.Create(ctx, &entity.Team{TeamName: "backend", Members: []entity.TeamMember{member("u3", true)}})
+	requireErrType(t, err, cerr.TEAM_EXISTS)
+
+	err = r.Team.Create(ctx, &entity.Team{TeamName: "frontend", Members: []entity.TeamMember{member("u4", true), member("u1", true)}})
+	requireErrType(t, err, cerr.SERVER)
+
+	// The failed create must not leave the team or its first member behind.
+	free, err = r.Team.CheckTeamName(ctx, "frontend")
+	require.NoError(t, err)
+	assert.True(t, free)
+
+	_, err = r.User.SetIsActive(ctx, "u4", true)
+	requireErrType(t, err, cerr.NOT_FOUND)
+
+	team, err = r.Team.Get(ctx, "unknown")
+	require.NoError(t, err)
+	assert.Empty(t, team.Members)
+}
+
+func testSetIsActive(t *testing.T, r repo.Repos) {
+	ctx := context.Background()
+
+	addTeam(t, r, "backend", member("u1", true))
+
+	user, err := r.User.SetIsActive(ctx, "u1", false)
+	require.NoError(t, err)
+	assert.Equal(t, entity.User{UserId: "u1", Username: "name-u1", TeamName: "backend", IsActive: false}, *user)
+
+	team, err := r.Team.Get(ctx, "backend")
+	require.NoError(t, err)
+	assert.False(t, team.Members[0].IsActive)
+
+	_, err = r.User.SetIsActive(ctx, "unknown", true)
+	requireErrType(t, err, cerr.NOT_FOUND)
+}
+
+func testCreate(t *testing.T, r repo.Repos) {
+	ctx := context.Background()
+
+	addTeam(t, r, "backend", member("author", true), member("u1", true), member("u2", false), member("u3", true))
+	addTeam(t, r, "frontend", member("other", true))
+	addTeam(t, r, "solo", member("lonely", true))
+
+	pr := createPR(t, r, "pr-1", "author")
+	assert.Equal(t, entity.PRStatusOPEN, pr.Status)
+	assert.Equal(t, "author", pr.AuthorId)
+	assert.Equal(t, "name-pr-1", pr.PullRequestName)
+	assert.NotNil(t, pr.CreatedAt)
+	assert.Nil(t, pr.MergedAt)
+	assert.ElementsMatch(t, []string{"u1", "u3"}, pr.AssignedReviewers)
+
+	pr = createPR(t, r, "pr-2", "lonely")
+	assert.Empty(t, pr.AssignedReviewers)
+
+	_, err := r.PullRequest.Create(ctx, &entity.PullRequestCreate{PullRequestId: "pr-1", PullRequestName: "again", AuthorId: "author"})
+	requireErrType(t, err, cerr.PR_EXISTS)
+
+	_, err = r.PullRequest.Create(ctx, &entity.PullRequestCreate{PullRequestId: "pr-3", PullRequestName: "ghost", AuthorId: "unknown"})
+	requireErrType(t, err, cerr.NOT_FOUND)
+}
+
+func testBalance(t *testing.T, r repo.Repos) {
+	addTeam(t, r, "backend", member("author", true), member("u1", true), member("u2", true), member("u3", true))
+
+	first := createPR(t, r, "pr-1", "author")
+	require.Len(t, first.AssignedReviewers, 2)
+
+	var idle string
+
+	for _, id := range []string{"u1", "u2", "u3"} {
+		if !slices.Contains(first.AssignedReviewers, id) {
+			idle = id
+		}
+	}
+
+	second := createPR(t, r, "pr-2", "author")
+	require.Len(t, second.AssignedReviewers, 2)
+	assert.Contains(t, second.AssignedReviewers, idle, "the reviewer without reviews must be picked first")
+}
+
+func testMerge(t *testing.T, r repo.Repos) {
+	ctx := context.Background()
+
+	addTeam(t, r, "backend", member("author", true), member("u1", true))
+
+	created := createPR(t, r, "pr-1", "author")
+
+	merged, err := r.PullRequest.Merge(ctx, "pr-1")
+	require.NoError(t, err)
+	assert.Equal(t, entity.PRStatusMERGED, merged.Status)
+	assert.Equal(t, "author", merged.AuthorId)
+	assert.Equal(t, "name-pr-1", merged.PullRequestName)
+	assert.Equal(t, []string{"u1"}, merged.AssignedReviewers)
+	require.NotNil(t, merged.MergedAt)
+	require.NotNil(t, merged.CreatedAt)
+	assert.WithinDuration(t, *created.CreatedAt, *merged.CreatedAt, time.Millisecond, "created_at must survive the merge")
+	assert.False(t, merged.MergedAt.Before(*merged.CreatedAt))
+
+	_, err = r.PullRequest.Merge(ctx, "unknown")
+	requireErrType(t, err, cerr.NOT_FOUND)
+
+	_, _, err = r.PullRequest.Reassign(ctx, "pr-1", "u1")
+	requireErrType(t, err, cerr.PR_MERGED)
+}
+
+func testReassign(t *testing.T, r repo.Repos) {
+	ctx := context.Background()
+
+	addTeam(t, r, "backend", member("author", true), member("u1", true), member("u2", true), member("u3", true))
+	addTeam(t, r, "frontend", member("other", true))
+
+	pr := createPR(t, r, "pr-1", "author")
+	require.Len(t, pr.AssignedReviewers, 2)
+
+	old, kept := pr.AssignedReviewers[0], pr.AssignedReviewers[1]
+
+	reassigned, newReviewer, err := r.PullRequest.Reassign(ctx, "pr-1", old)
+	require.NoError(t, err)
+	assert.NotContains(t, []string{"author", old, kept}, newReviewer)
+	assert.Contains(t, []string{"u1", "u2", "u3"}, newReviewer)
+	assert.ElementsMatch(t, []string{newReviewer, kept}, reassigned.AssignedReviewers)
+	assert.Equal(t, entity.PRStatusOPEN, reassigned.Status)
+
+	reviews, err := r.User.GetReview(ctx, old)
+	require.NoError(t, err)
+	assert.Empty(t, reviews)
+
+	// The only teammate left is inactive.
+	_, err = r.User.SetIsActive(ctx, old, false)
+	require.NoError(t, err)
+
+	_, _, err = r.PullRequest.Reassign(ctx, "pr-1", kept)
+	requireErrType(t, err, cerr.NO_CANDIDATE)
+
+	_, _, err = r.PullRequest.Reassign(ctx, "pr-1", "other")
+	requireErrType(t, err, cerr.NOT_ASSIGNED)
+
+	_, _, err = r.PullRequest.Reassign(ctx, "pr-1", "unknown")
+	requireErrType(t, err, cerr.NOT_FOUND)
+
+	_, _, err = r.PullRequest.Reassign(ctx, "unknown", "u1")
+	requireErrType(t, err, cerr.NOT_FOUND)
+}
+
+func testGetReview(t *testing.T, r repo.Repos) {
+	ctx := context.Background()
+
+	addTeam(t, r, "backend", member("author", true), member("u1", true))
+
+	reviews, err := r.User.GetReview(ctx, "u1")
+	require.NoError(t, err)
+	assert.Empty(t, reviews)
+
+	createPR(t, r, "pr-1", "author")
+	createPR(t, r, "pr-2", "author")
+
+	_, err = r.PullRequest.Merge(ctx, "pr-2")
+	require.NoError(t, err)
+
+	reviews, err = r.User.GetReview(ctx, "u1")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []entity.PullRequestShort{
+		{AuthorId: "author", PullRequestId: "pr-1", PullRequestName: "name-pr-1", Status: entity.PRStatusOPEN},
+		{AuthorId: "author", PullRequestId: "pr-2", PullRequestName: "name-pr-2", Status: entity.PRStatusMERGED},
+	}, reviews)
+
+	_, err = r.User.GetReview(ctx, "unknown")
+	requireErrType(t, err, cerr.NOT_FOUND)
+}
+
+func testStat(t *testing.T, r repo.Repos) {
+	ctx := context.Background()
+
+	addTeam(t, r, "backend", member("author", true), member("u1", true))
+
+	stat, err := r.Stat.User(ctx, "u1")
+	require.NoError(t, err)
+	assert.Equal(t, entity.UserStat{UserId: "u1", IsActive: true}, *stat)
+
+	team, err := r.Stat.Team(ctx, "backend")
+	require.NoError(t, err)
+	assert.Equal(t, -1.0, team.AvgDuration)
+	assert.Len(t, team.UsersStat, 2)
+
+	createPR(t, r, "pr-1", "author")
+	createPR(t, r, "pr-2", "author")
+
+	_, err = r.PullRequest.Merge(ctx, "pr-1")
+	require.NoError(t, err)
+
+	stat, err = r.Stat.User(ctx, "u1")
+	require.NoError(t, err)
+	assert.Equal(t, 2, stat.CountPr)
+	require.NotNil(t, stat.AvgDuration)
+	assert.GreaterOrEqual(t, *stat.AvgDuration, 0.0)
+
+	team, err = r.Stat.Team(ctx, "backend")
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, team.AvgDuration, 0.0)
+
+	_, err = r.Stat.User(ctx, "unknown")
+	requireErrType(t, err, cerr.NOT_FOUND)
+
+	team, err = r.Stat.Team(ctx, "unknown")
+	require.NoError(t, err)
+	assert.Empty(t, team.UsersStat)
+}
+
+func testHistory(t *testing.T, r repo.Repos) {
+	ctx := context.Background()
+
+	history, err := r.History.Load(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, history.Teams)
+	assert.Empty(t, history.PullRequests)
+
+	addTeam(t, r, "backend", member("author", true), member("u1", true), member("u2", false))
+	addTeam(t, r, "solo", member("lonely", true))
+
+	first := createPR(t, r, "pr-1", "author")
+	createPR(t, r, "pr-2", "lonely")
+
+	_, err = r.PullRequest.Merge(ctx, "pr-1")
+	require.NoError(t, err)
+
+	history, err = r.History.Load(ctx)
+	require.NoError(t, err)
+
+	require.Len(t, history.Teams, 2)
+
+	for _, team := range history.Teams {
+		switch team.TeamName {
+		case "backend":
+			assert.ElementsMatch(t, []entity.TeamMember{member("author", true), member("u1", true), member("u2", false)}, team.Members)
+		case "solo":
+			assert.Equal(t, []entity.TeamMember{member("lonely", true)}, team.Members)
+		default:
+			t.Errorf("unexpected team %q", team.TeamName)
+		}
+	}
+
+	require.Len(t, history.PullRequests, 2)
+
+	byID := make(map[string]entity.PullRequestHistory)
+	for _, pr := range history.PullRequests {
+		byID[pr.PullRequestId] = pr
+	}
+
+	merged := byID["pr-1"]
+	assert.Equal(t, "author", merged.AuthorId)
+	assert.Equal(t, []string{"u1"}, merged.AssignedReviewers)
+	assert.WithinDuration(t, *first.CreatedAt, merged.CreatedAt, time.Millisecond)
+	require.NotNil(t, merged.MergedAt)
+	assert.False(t, merged.MergedAt.Before(merged.CreatedAt))
+
+	open := byID["pr-2"]
+	assert.Equal(t, "lonely", open.AuthorId)
+	assert.Empty(t, open.AssignedReviewers)
+	assert.Nil(t, open.MergedAt)
+
+	// An archived pull request stays in the history, the replay would miss its review.
+	moved, err := r.Archive.Archive(ctx, time.Now().Add(time.Hour), 10)
+	require.NoError(t, err)
+	require.Equal(t, 1, moved)
+
+	history, err = r.History.Load(ctx)
+	require.NoError(t, err)
+	require.Len(t, history.PullRequests, 2)
+
+	for _, pr := range history.PullRequests {
+		want := byID[pr.PullRequestId]
+
+		assert.Equal(t, want.AuthorId, pr.AuthorId, pr.PullRequestId)
+		assert.Equal(t, want.AssignedReviewers, pr.AssignedReviewers, pr.PullRequestId)
+		assert.WithinDuration(t, want.CreatedAt, pr.CreatedAt, time.Millisecond, pr.PullRequestId)
+		assert.Equal(t, want.MergedAt == nil, pr.MergedAt == nil, pr.PullRequestId)
+	}
+}
+
+func testLookup(t *testing.T, r repo.Repos) {
+	ctx := context.Background()
+
+	users, err := r.Lookup.Users(ctx, []string{"u1"})
+	require.NoError(t, err)
+	assert.Empty(t, users)
+
+	addTeam(t, r, "backend", member("author", true), member("u1", true), member("u2", true))
+	addTeam(t, r, "solo", member("lonely", true))
+
+	createPR(t, r, "pr-1", "author")
+	createPR(t, r, "pr-2", "lonely")
+
+	_, err = r.PullRequest.Merge(ctx, "pr-1")
+	require.NoError(t, err)
+
+	users, err = r.Lookup.Users(ctx, []string{"u1", "lonely", "missing", "u1"})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []entity.User{
+		{UserId: "u1", Username: "name-u1", TeamName: "backend", IsActive: true},
+		{UserId: "lonely", Username: "name-lonely", TeamName: "solo", IsActive: true},
+	}, users)
+
+	teams, err := r.Lookup.Teams(ctx, []string{"backend", "solo", "missing"})
+	require.NoError(t, err)
+	require.Len(t, teams, 2)
+
+	for _, team := range teams {
+		switch team.TeamName {
+		case "backend":
+			assert.ElementsMatch(t, []entity.TeamMember{member("author", true), member("u1", true), member("u2", true)}, team.Members)
+		case "solo":
+			assert.Equal(t, []entity.TeamMember{member("lonely", true)}, team.Members)
+		default:
+			t.Errorf("unexpected team %q", team.TeamName)
+		}
+	}
+
+	prs, err := r.Lookup.PullRequests(ctx, []string{"pr-1", "pr-2", "missing"})
+	require.NoError(t, err)
+	require.Len(t, prs, 2)
+
+	byID := make(map[string]entity.PullRequest)
+	for _, pr := range prs {
+		byID[pr.PullRequestId] = pr
+	}
+
+	merged := byID["pr-1"]
+	assert.Equal(t, "author", merged.AuthorId)
+	assert.Equal(t, entity.PRStatusMERGED, merged.Status)
+	assert.ElementsMatch(t, []string{"u1", "u2"}, merged.AssignedReviewers)
+	require.NotNil(t, merged.CreatedAt)
+	require.NotNil(t, merged.MergedAt)
+
+	open := byID["pr-2"]
+	assert.Equal(t, entity.PRStatusOPEN, open.Status)
+	assert.Empty(t, open.AssignedReviewers)
+	assert.Nil(t, open.MergedAt)
+
+	reviews, err := r.Lookup.Reviews(ctx, []string{"u1", "u2", "lonely"})
+	require.NoError(t, err)
+	require.Len(t, reviews, 1)
+	assert.Equal(t, "pr-1", reviews[0].PullRequestId)
+	assert.ElementsMatch(t, []string{"u1", "u2"}, reviews[0].AssignedReviewers)
+
+	stats, err := r.Lookup.UserStats(ctx, []string{"u1", "lonely", "missing"})
+	require.NoError(t, err)
+	require.Len(t, stats, 2)
+
+	for _, stat := range stats {
+		switch stat.UserId {
+		case "u1":
+			assert.Equal(t, 1, stat.CountPr)
+			assert.NotNil(t, stat.AvgDuration)
+		case "lonely":
+			assert.Equal(t, 0, stat.CountPr)
+			assert.Nil(t, stat.AvgDuration)
+		default:
+			t.Errorf("unexpected user %q", stat.UserId)
+		}
+	}
+}
+
+func testTeamApply(t *testing.T, r repo.Repos) {
+	ctx := context.Background()
+
+	teams, err := r.Team.List(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, teams)
+
+	addTeam(t, r, "backend", member("author", true), member("u1", true), member("u2", true))
+	addTeam(t, r, "frontend", member("f1", true))
+
+	pr := createPR(t, r, "pr-1", "author")
+	require.ElementsMatch(t, []string{"u1", "u2"}, pr.AssignedReviewers)
+
+	teams, err = r.Team.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, teams, 2)
+	assert.Equal(t, "backend", teams[0].TeamName)
+	assert.ElementsMatch(t, []entity.TeamMember{member("author", true), member("u1", true), member("u2", true)}, teams[0].Members)
+	assert.Equal(t, "frontend", teams[1].TeamName)
+
+	changes := []entity.TeamChange{
+		{Action: entity.ActionAddTeam, TeamName: "platform"},
+		{Action: entity.ActionAddMember, TeamName: "platform", UserId: "p1", Username: "name-p1", IsActive: true},
+		{Action: entity.ActionMoveMember, TeamName: "backend", UserId: "f1", Username: "name-f1", IsActive: false, FromTeam: "frontend"},
+		{Action: entity.ActionAddMember, TeamName: "backend", UserId: "u3", Username: "name-u3", IsActive: true},
+		{Action: entity.ActionRemoveMember, TeamName: "backend", UserId: "u1", Username: "name-u1"},
+	}
+
+	// u3 is the only active teammate of the author who is not reviewing pr-1 yet.
+	want := []entity.Reassignment{{PullRequestId: "pr-1", OldReviewerId: "u1", NewReviewerId: ptr("u3")}}
+
+	reassignments, err := r.Team.Apply(ctx, changes, true)
+	require.NoError(t, err)
+	assert.Equal(t, want, reassignments)
+
+	// A dry run leaves nothing behind.
+	free, err := r.Team.CheckTeamName(ctx, "platform")
+	require.NoError(t, err)
+	assert.True(t, free)
+
+	reviews, err := r.User.GetReview(ctx, "u1")
+	require.NoError(t, err)
+	assert.Len(t, reviews, 1)
+
+	team, err := r.Team.Get(ctx, "frontend")
+	require.NoError(t, err)
+	assert.Equal(t, []entity.TeamMember{member("f1", true)}, team.Members)
+
+	reassignments, err = r.Team.Apply(ctx, changes, false)
+	require.NoError(t, err)
+	assert.Equal(t, want, reassignments)
+
+	team, err = r.Team.Get(ctx, "backend")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []entity.TeamMember{
+		member("author", true), member("u1", false), member("u2", true), member("u3", true), member("f1", false),
+	}, team.Members)
+
+	team, err = r.Team.Get(ctx, "platform")
+	require.NoError(t, err)
+	assert.Equal(t, []entity.TeamMember{member("p1", true)}, team.Members)
+
+	team, err = r.Team.Get(ctx, "frontend")
+	require.NoError(t, err)
+	assert.Empty(t, team.Members)
+
+	reviews, err = r.User.GetReview(ctx, "u3")
+	require.NoError(t, err)
+	require.Len(t, reviews, 1)
+	assert.Equal(t, "pr-1", reviews[0].PullRequestId)
+
+	// Nobody is left to take over from u2, the review is dropped.
+	reassignments, err = r.Team.Apply(ctx, []entity.TeamChange{
+		{Action: entity.ActionRemoveMember, TeamName: "backend", UserId: "u2", Username: "name-u2"},
+		{Action: entity.ActionRemoveMember, TeamName: "backend", UserId: "u3", Username: "name-u3"},
+	}, false)
+	require.NoError(t, err)
+	assert.Equal(t, []entity.Reassignment{
+		{PullRequestId: "pr-1", OldReviewerId: "u2"},
+		{PullRequestId: "pr-1", OldReviewerId: "u3"},
+	}, reassignments)
+
+	reviews, err = r.User.GetReview(ctx, "u2")
+	require.NoError(t, err)
+	assert.Empty(t, reviews)
+
+	teams, err = r.Team.List(ctx)
+	require.NoError(t, err)
+
+	names := make([]string, 0, len(teams))
+	for _, team := range teams {
+		names = append(names, team.TeamName)
+	}
+
+	assert.ElementsMatch(t, []string{"backend", "frontend", "platform"}, names)
+
+	// Moving the last member out lets the team go in the same call.
+	_, err = r.Team.Apply(ctx, []entity.TeamChange{
+		{Action: entity.ActionMoveMember, TeamName: "backend", UserId: "p1", Username: "name-p1", IsActive: true, FromTeam: "platform"},
+		{Action: entity.ActionRemoveTeam, TeamName: "platform"},
+		{Action: entity.ActionRemoveTeam, TeamName: "frontend"},
+	}, false)
+	require.NoError(t, err)
+
+	teams, err = r.Team.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, teams, 1)
+	assert.Equal(t, "backend", teams[0].TeamName)
+
+	free, err = r.Team.CheckTeamName(ctx, "platform")
+	require.NoError(t, err)
+	assert.True(t, free)
+}
+
+func ptr(s string) *string {
+	return &s
+}
+
+func testNotification(t *testing.T, r repo.Repos) {
+	ctx := context.Background()
+
+	_, err := r.Notification.Set(ctx, &entity.NotificationSettings{UserId: "missing"})
+	requireErrType(t, err, cerr.NOT_FOUND)
+
+	addTeam(t, r, "backend", member("u1", true), member("u2", true), member("u3", false))
+
+	settings, err := r.Notification.Get(ctx, []string{"u1", "missing"})
+	require.NoError(t, err)
+	assert.Equal(t, []entity.NotificationSettings{{UserId: "u1", EmailAssignments: true, EmailDigest: true}}, settings)
+
+	subscribers, err := r.Notification.Subscribers(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, subscribers)
+
+	u1 := entity.NotificationSettings{UserId: "u1", Email: ptr("u1@example.com"), EmailAssignments: false, EmailDigest: true}
+	u2 := entity.NotificationSettings{UserId: "u2", Email: ptr("u2@example.com"), EmailAssignments: true, EmailDigest: false}
+	u3 := entity.NotificationSettings{UserId: "u3", Email: ptr("u3@example.com"), EmailAssignments: true, EmailDigest: true}
+
+	for _, s := range []entity.NotificationSettings{u1, u2, u3} {
+		got, err := r.Notification.Set(ctx, &s)
+		require.NoError(t, err)
+		assert.Equal(t, s, *got)
+	}
+
+	settings, err = r.Notification.Get(ctx, []string{"u1", "u2", "u3"})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []entity.NotificationSettings{u1, u2, u3}, settings)
+
+	// Digests go to inactive users too, they may still have open reviews.
+	subscribers, err = r.Notification.Subscribers(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []entity.NotificationSettings{u1, u3}, subscribers)
+
+	// Moving the user to another team keeps the settings.
+	_, err = r.Team.Apply(ctx, []entity.TeamChange{
+		{Action: entity.ActionAddTeam, TeamName: "frontend"},
+		{Action: entity.ActionMoveMember, TeamName: "frontend", UserId: "u1", Username: "name-u1", IsActive: true, FromTeam: "backend"},
+	}, false)
+	require.NoError(t, err)
+
+	settings, err = r.Notification.Get(ctx, []string{"u1"})
+	require.NoError(t, err)
+	assert.Equal(t, []entity.NotificationSettings{u1}, settings)
+
+	u1.Email = nil
+
+	got, err := r.Notification.Set(ctx, &u1)
+	require.NoError(t, err)
+	assert.Nil(t, got.Email)
+
+	subscribers, err = r.Notification.Subscribers(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []entity.NotificationSettings{u3}, subscribers)
+}
+
+func testSLA(t *testing.T, r repo.Repos) {
+	ctx := context.Background()
+
+	_, err := r.SLA.Get(ctx, "backend")
+	requireErrType(t, err, cerr.NOT_FOUND)
+
+	sla := entity.TeamSLA{TeamName: "backend", RemindAfter: 4 * time.Hour, EscalateAfter: 24 * time.Hour}
+
+	_, err = r.SLA.Set(ctx, &sla)
+	requireErrType(t, err, cerr.NOT_FOUND)
+
+	addTeam(t, r, "backend", member("author", true), member("u1", true), member("u2", true), member("lead", false))
+	addTeam(t, r, "frontend", member("f1", true), member("f2", true))
+
+	sla.LeadId = ptr("missing")
+
+	_, err = r.SLA.Set(ctx, &sla)
+	requireErrType(t, err, cerr.NOT_FOUND)
+
+	sla.LeadId = ptr("lead")
+	sla.AutoReassign = true
+
+	got, err := r.SLA.Set(ctx, &sla)
+	require.NoError(t, err)
+	assert.Equal(t, sla, *got)
+
+	got, err = r.SLA.Get(ctx, "backend")
+	require.NoError(t, err)
+	assert.Equal(t, sla, *got)
+
+	slas, err := r.SLA.List(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []entity.TeamSLA{sla}, slas)
+
+	before := time.Now().Add(-time.Second)
+
+	pr := createPR(t, r, "pr-1", "author")
+	require.ElementsMatch(t, []string{"u1", "u2"}, pr.AssignedReviewers)
+
+	// Only teams with an SLA are watched.
+	createPR(t, r, "pr-2", "f1")
+
+	pending, err := r.SLA.Pending(ctx)
+	require.NoError(t, err)
+	require.Len(t, pending, 2)
+
+	for _, review := range pending {
+		assert.Equal(t, "pr-1", review.PullRequestId)
+		assert.Equal(t, "name-pr-1", review.PullRequestName)
+		assert.Equal(t, "author", review.AuthorId)
+		assert.Equal(t, "backend", review.TeamName)
+		assert.WithinRange(t, review.AssignedAt, before, time.Now().Add(time.Second))
+		assert.Nil(t, review.RemindedAt)
+		assert.Nil(t, review.EscalatedAt)
+	}
+
+	now := time.Now()
+
+	ok, err := r.SLA.MarkReminded(ctx, "pr-1", "u1", now)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	// The second instance to get there loses.
+	ok, err = r.SLA.MarkReminded(ctx, "pr-1", "u1", now)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	ok, err = r.SLA.MarkEscalated(ctx, "pr-1", "u1", now)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = r.SLA.MarkReminded(ctx, "pr-1", "missing", now)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	pending, err = r.SLA.Pending(ctx)
+	require.NoError(t, err)
+
+	for _, review := range pending {
+		if review.ReviewerId == "u1" {
+			require.NotNil(t, review.RemindedAt)
+			require.NotNil(t, review.EscalatedAt)
+			assert.WithinDuration(t, now, *review.RemindedAt, time.Millisecond)
+		} else {
+			assert.Nil(t, review.RemindedAt)
+		}
+	}
+
+	// A reassigned review starts over for the new reviewer.
+	_, err = r.User.SetIsActive(ctx, "lead", true)
+	require.NoError(t, err)
+
+	_, newReviewer, err := r.PullRequest.Reassign(ctx, "pr-1", "u1")
+	require.NoError(t, err)
+	require.Equal(t, "lead", newReviewer)
+
+	pending, err = r.SLA.Pending(ctx)
+	require.NoError(t, err)
+	require.Len(t, pending, 2)
+
+	for _, review := range pending {
+		assert.NotEqual(t, "u1", review.ReviewerId)
+		assert.Nil(t, review.RemindedAt)
+		assert.Nil(t, review.EscalatedAt)
+	}
+
+	_, err = r.PullRequest.Merge(ctx, "pr-1")
+	require.NoError(t, err)
+
+	pending, err = r.SLA.Pending(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+
+	require.NoError(t, r.SLA.Delete(ctx, "backend"))
+	requireErrType(t, r.SLA.Delete(ctx, "backend"), cerr.NOT_FOUND)
+
+	slas, err = r.SLA.List(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, slas)
+}
+
+func testOrganizations(t *testing.T, r repo.Repos) {
+	ctx := context.Background()
+	acme := org.With(ctx, "acme")
+	globex := org.With(ctx, "globex")
+
+	require.NoError(t, r.Organization.Ensure(ctx, []string{"acme", "globex"}))
+	require.NoError(t, r.Organization.Ensure(ctx, []string{"acme", org.Default}))
+
+	orgs, err := r.Organization.List(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"acme", org.Default, "globex"}, orgs)
+
+	// The same team, users and pull request in two organizations do not clash.
+	addTeam(t, r, "backend", member("author", true), member("u1", true), member("u2", true))
+	require.NoError(t, r.Team.Create(acme, &entity.Team{
+		TeamName: "backend",
+		Members:  []entity.TeamMember{member("author", true), member("u1", true), member("u3", true)},
+	}))
+
+	first := createPR(t, r, "pr-1", "author")
+	assert.ElementsMatch(t, []string{"u1", "u2"}, first.AssignedReviewers)
+
+	second, err := r.PullRequest.Create(acme, &entity.PullRequestCreate{PullRequestId: "pr-1", PullRequestName: "acme", AuthorId: "author"})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"u1", "u3"}, second.AssignedReviewers)
+
+	_, err = r.PullRequest.Merge(acme, "pr-1")
+	require.NoError(t, err)
+
+	prs, err := r.Lookup.PullRequests(ctx, []string{"pr-1"})
+	require.NoError(t, err)
+	require.Len(t, prs, 1)
+	assert.Equal(t, entity.PRStatusOPEN, prs[0].Status)
+
+	// Users of another organization are not found.
+	_, err = r.User.SetIsActive(acme, "u2", false)
+	requireErrType(t, err, cerr.NOT_FOUND)
+
+	_, _, err = r.PullRequest.Reassign(acme, "pr-1", "u2")
+	requireErrType(t, err, cerr.NOT_FOUND)
+
+	teams, err := r.Team.List(acme)
+	require.NoError(t, err)
+	require.Len(t, teams, 1)
+	assert.ElementsMatch(t, []entity.TeamMember{member("author", true), member("u1", true), member("u3", true)}, teams[0].Members)
+
+	stat, err := r.Stat.User(acme, "u1")
+	require.NoError(t, err)
+	assert.Equal(t, 1, stat.CountPr)
+	assert.NotNil(t, stat.AvgDuration)
+
+	stat, err = r.Stat.User(ctx, "u1")
+	require.NoError(t, err)
+	assert.Nil(t, stat.AvgDuration)
+
+	// An organization without data sees none of the others.
+	free, err := r.Team.CheckTeamName(globex, "backend")
+	require.NoError(t, err)
+	assert.True(t, free)
+
+	_, err = r.User.GetReview(globex, "u1")
+	requireErrType(t, err, cerr.NOT_FOUND)
+
+	history, err := r.History.Load(globex)
+	require.NoError(t, err)
+	assert.Empty(t, history.Teams)
+	assert.Empty(t, history.PullRequests)
+
+	users, err := r.Lookup.Users(globex, []string{"author", "u1"})
+	require.NoError(t, err)
+	assert.Empty(t, users)
+}
+
+func testArchive(t *testing.T, r repo.Repos) {
+	ctx := context.Background()
+
+	addTeam(t, r, "backend", member("author", true), member("u1", true), member("u2", true), member("u3", true))
+	addTeam(t, r, "solo", member("lonely", true))
+
+	first := createPR(t, r, "pr-1", "author")
+	second := createPR(t, r, "pr-2", "author")
+	createPR(t, r, "pr-3", "lonely")
+
+	for _, id := range []string{"pr-1", "pr-2"} {
+		_, err := r.PullRequest.Merge(ctx, id)
+		require.NoError(t, err)
+	}
+
+	statsOf := func() map[string]entity.UserStat {
+		t.Helper()
+
+		stats := make(map[string]entity.UserStat)
+
+		for _, id := range []string{"u1", "u2", "u3"} {
+			stat, err := r.Stat.User(ctx, id)
+			require.NoError(t, err)
+
+			stats[id] = *stat
+		}
+
+		looked, err := r.Lookup.UserStats(ctx, []string{"u1", "u2", "u3"})
+		require.NoError(t, err)
+		require.Len(t, looked, 3)
+
+		for _, stat := range looked {
+			assertStat(t, stats[stat.UserId], stat)
+		}
+
+		return stats
+	}
+
+	before := statsOf()
+
+	// Merged just now, nothing is old enough yet.
+	moved, err := r.Archive.Archive(ctx, time.Now().Add(-time.Hour), 10)
+	require.NoError(t, err)
+	assert.Zero(t, moved)
+
+	moved, err = r.Archive.Archive(ctx, time.Now().Add(time.Hour), 1)
+	require.NoError(t, err)
+	assert.Equal(t, 1, moved)
+
+	moved, err = r.Archive.Archive(ctx, time.Now().Add(time.Hour), 10)
+	require.NoError(t, err)
+	assert.Equal(t, 1, moved, "the open pull request stays")
+
+	after := statsOf()
+	for id, stat := range before {
+		assertStat(t, stat, after[id])
+	}
+
+	prs, err := r.Lookup.PullRequests(ctx, []string{"pr-1", "pr-2", "pr-3"})
+	require.NoError(t, err)
+	require.Len(t, prs, 1)
+	assert.Equal(t, "pr-3", prs[0].PullRequestId)
+
+	_, err = r.PullRequest.Merge(ctx, "pr-1")
+	requireErrType(t, err, cerr.NOT_FOUND)
+
+	_, err = r.PullRequest.Create(ctx, &entity.PullRequestCreate{PullRequestId: "pr-1", PullRequestName: "again", AuthorId: "author"})
+	requireErrType(t, err, cerr.PR_EXISTS)
+
+	// The archived reviews still count when reviewers are chosen, one of u1..u3 has
+	// reviewed both archived pull requests and is left out.
+	load := make(map[string]int)
+	for _, id := range []string{"u1", "u2", "u3"} {
+		load[id] = before[id].CountPr
+	}
+
+	next := createPR(t, r, "pr-4", "author")
+	require.Len(t, next.AssignedReviewers, 2)
+
+	for _, id := range next.AssignedReviewers {
+		for _, other := range []string{"u1", "u2", "u3"} {
+			if !slices.Contains(next.AssignedReviewers, other) {
+				assert.LessOrEqual(t, load[id], load[other], "%v was chosen over %v", id, other)
+			}
+		}
+	}
+
+	// Only pr-4 was added since before, and it is open. Restoring does not change the
+	// stats, the reviews just move out of the archive.
+	assertStats := func() {
+		t.Helper()
+
+		stats := statsOf()
+		for id, stat := range before {
+			if slices.Contains(next.AssignedReviewers, id) {
+				stat.CountPr++
+			}
+
+			assertStat(t, stat, stats[id])
+		}
+	}
+
+	time.Sleep(2 * time.Millisecond)
+
+	restoredAt := time.Now()
+
+	restored, err := r.Archive.Restore(ctx, "pr-1")
+	require.NoError(t, err)
+	assert.Equal(t, entity.PRStatusMERGED, restored.Status)
+	assert.Equal(t, "author", restored.AuthorId)
+	assert.ElementsMatch(t, first.AssignedReviewers, restored.AssignedReviewers)
+	assert.WithinDuration(t, *first.CreatedAt, *restored.CreatedAt, time.Millisecond)
+	require.NotNil(t, restored.MergedAt)
+
+	_, err = r.Archive.Restore(ctx, "pr-1")
+	requireErrType(t, err, cerr.NOT_FOUND)
+
+	_, err = r.Archive.Restore(ctx, "pr-3")
+	requireErrType(t, err, cerr.NOT_FOUND)
+
+	prs, err = r.Lookup.PullRequests(ctx, []string{"pr-1"})
+	require.NoError(t, err)
+	require.Len(t, prs, 1)
+	assert.ElementsMatch(t, first.AssignedReviewers, prs[0].AssignedReviewers)
+
+	// pr-2 is still archived, and one of u1..u3 reviewed both pull requests.
+	assertStats()
+
+	// A restored pull request is not archived again until it is as old as the cutoff.
+	moved, err = r.Archive.Archive(ctx, restoredAt, 10)
+	require.NoError(t, err)
+	assert.Zero(t, moved)
+
+	restored, err = r.Archive.Restore(ctx, "pr-2")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, second.AssignedReviewers, restored.AssignedReviewers)
+
+	assertStats()
+}
+
+func testErasure(t *testing.T, r repo.Repos) {
+	ctx := context.Background()
+
+	addTeam(t, r, "backend", member("author", true), member("u1", true), member("u2", true), member("u3", true))
+
+	archived := createPR(t, r, "pr-1", "author")
+	createPR(t, r, "pr-2", "author")
+
+	for _, id := range []string{"pr-1", "pr-2"} {
+		_, err := r.PullRequest.Merge(ctx, id)
+		require.NoError(t, err)
+	}
+
+	moved, err := r.Archive.Archive(ctx, time.Now().Add(time.Hour), 1)
+	require.NoError(t, err)
+	require.Equal(t, 1, moved)
+
+	open := createPR(t, r, "pr-3", "author")
+	require.Len(t, open.AssignedReviewers, 2)
+
+	erased := open.AssignedReviewers[0]
+
+	_, err = r.Notification.Set(ctx, &entity.NotificationSettings{UserId: erased, Email: ptr("gone@example.com"), EmailAssignments: true, EmailDigest: true})
+	require.NoError(t, err)
+
+	_, err = r.SLA.Set(ctx, &entity.TeamSLA{TeamName: "backend", RemindAfter: time.Hour, EscalateAfter: 2 * time.Hour, LeadId: &erased})
+	require.NoError(t, err)
+
+	before, err := r.Stat.User(ctx, erased)
+	require.NoError(t, err)
+
+	_, _, err = r.Erasure.Erase(ctx, "missing", "erased-0")
+	requireErrType(t, err, cerr.NOT_FOUND)
+
+	erasure, reassignments, err := r.Erasure.Erase(ctx, erased, "erased-1")
+	require.NoError(t, err)
+	assert.Equal(t, "erased-1", erasure.Pseudonym)
+	assert.Equal(t, "backend", erasure.TeamName)
+	assert.Equal(t, 1, erasure.ReassignedReviews)
+	assert.WithinDuration(t, time.Now(), erasure.ErasedAt, time.Minute)
+
+	// The open review goes to the one teammate that does not review pr-3 yet.
+	require.Len(t, reassignments, 1)
+	assert.Equal(t, "pr-3", reassignments[0].PullRequestId)
+	assert.Equal(t, "erased-1", reassignments[0].OldReviewerId)
+	require.NotNil(t, reassignments[0].NewReviewerId)
+	assert.NotContains(t, append(open.AssignedReviewers, "author"), *reassignments[0].NewReviewerId)
+
+	_, _, err = r.Erasure.Erase(ctx, erased, "erased-2")
+	requireErrType(t, err, cerr.NOT_FOUND)
+
+	_, err = r.Stat.User(ctx, erased)
+	requireErrType(t, err, cerr.NOT_FOUND)
+
+	// The merged reviews stay with the pseudonym, the open one is gone.
+	after, err := r.Stat.User(ctx, "erased-1")
+	require.NoError(t, err)
+
+	before.UserId = "erased-1"
+	before.CountPr--
+	before.IsActive = false
+	assertStat(t, *before, *after)
+
+	team, err := r.Team.Get(ctx, "backend")
+	require.NoError(t, err)
+	assert.Contains(t, team.Members, entity.TeamMember{UserId: "erased-1", Username: "erased-1", IsActive: false})
+
+	for _, m := range team.Members {
+		assert.NotEqual(t, erased, m.UserId)
+	}
+
+	settings, err := r.Notification.Get(ctx, []string{"erased-1"})
+	require.NoError(t, err)
+	require.Len(t, settings, 1)
+	assert.Nil(t, settings[0].Email)
+
+	sla, err := r.SLA.Get(ctx, "backend")
+	require.NoError(t, err)
+	assert.Nil(t, sla.LeadId)
+
+	restored, err := r.Archive.Restore(ctx, "pr-1")
+	require.NoError(t, err)
+	assert.Len(t, restored.AssignedReviewers, len(archived.AssignedReviewers))
+	assert.NotContains(t, restored.AssignedReviewers, erased)
+
+	if slices.Contains(archived.AssignedReviewers, erased) {
+		assert.Contains(t, restored.AssignedReviewers, "erased-1")
+	}
+
+	erasures, err := r.Erasure.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, erasures, 1)
+	assert.Equal(t, erasure.Pseudonym, erasures[0].Pseudonym)
+	assert.Equal(t, erasure.TeamName, erasures[0].TeamName)
+	assert.Equal(t, erasure.ReassignedReviews, erasures[0].ReassignedReviews)
+	assert.WithinDuration(t, erasure.ErasedAt, erasures[0].ErasedAt, time.Millisecond)
+
+	erasures, err = r.Erasure.List(org.With(ctx, "acme"))
+	require.NoError(t, err)
+	assert.Empty(t, erasures)
+}
+
+func assertStat(t *testing.T, expected entity.UserStat, actual entity.UserStat) {
+	t.Helper()
+
+	assert.Equal(t, expected.UserId, actual.UserId)
+	assert.Equal(t, expected.CountPr, actual.CountPr, expected.UserId)
+
+	if expected.AvgDuration == nil {
+		assert.Nil(t, actual.AvgDuration, expected.UserId)
+
+		return
+	}
+
+	require.NotNil(t, actual.AvgDuration, expected.UserId)
+	assert.InDelta(t, *expected.AvgDuration, *actual.AvgDuration, 1e-9, expected.UserId)
+}
@@ -0,0 +1,180 @@
+package sla
+
+import (
+	"context"
+	"time"
+
+	"avito/internal/cerr"
+	"avito/internal/entity"
+	"avito/internal/org"
+	"avito/internal/postgres"
+	"avito/internal/repo"
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+)
+
+var tracer = otel.Tracer("avito/internal/repo/sla")
+
+type Repo struct {
+	db *postgres.Pg
+}
+
+func InitSLARepo(db *postgres.Pg) repo.SLA {
+	return Repo{db: db}
+}
+
+const columns = `team_name, remind_after_seconds, escalate_after_seconds, lead_id, auto_reassign`
+
+func (r Repo) Get(ctx context.Context, teamName string) (*entity.TeamSLA, error) {
+	ctx, span := tracer.Start(ctx, "SLARepo.Get")
+	defer span.End()
+
+	rows, err := r.db.Pool.Query(ctx, `SELECT `+columns+` FROM team_slas WHERE org_id = $1 AND team_name = $2`,
+		org.FromContext(ctx), teamName)
+	if err != nil {
+		return nil, cerr.HandlePgErr(err)
+	}
+
+	sla, err := pgx.CollectExactlyOneRow(rows, scanSLA)
+	if err != nil {
+		return nil, cerr.HandlePgErr(err)
+	}
+
+	return &sla, nil
+}
+
+func (r Repo) Set(ctx context.Context, sla *entity.TeamSLA) (*entity.TeamSLA, error) {
+	ctx, span := tracer.Start(ctx, "SLARepo.Set")
+	defer span.End()
+
+	query := `INSERT INTO team_slas (org_id, ` + columns + `) VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (org_id, team_name) DO UPDATE SET remind_after_seconds = excluded.remind_after_seconds,
+    escalate_after_seconds = excluded.escalate_after_seconds, lead_id = excluded.lead_id, auto_reassign = excluded.auto_reassign
+RETURNING ` + columns
+
+	rows, err := r.db.Pool.Query(ctx, query, org.FromContext(ctx), sla.TeamName, seconds(sla.RemindAfter), seconds(sla.EscalateAfter), sla.LeadId, sla.AutoReassign)
+	if err != nil {
+		return nil, cerr.HandlePgErr(err)
+	}
+
+	result, err := pgx.CollectExactlyOneRow(rows, scanSLA)
+	if err != nil {
+		return nil, cerr.HandlePgErr(err)
+	}
+
+	return &result, nil
+}
+
+func (r Repo) Delete(ctx context.Context, teamName string) error {
+	ctx, span := tracer.Start(ctx, "SLARepo.Delete")
+	defer span.End()
+
+	tag, err := r.db.Pool.Exec(ctx, `DELETE FROM team_slas WHERE org_id = $1 AND team_name = $2`,
+		org.FromContext(ctx), teamName)
+	if err != nil {
+		return cerr.HandlePgErr(err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return cerr.HandlePgErr(pgx.ErrNoRows)
+	}
+
+	return nil
+}
+
+func (r Repo) List(ctx context.Context) ([]entity.TeamSLA, error) {
+	ctx, span := tracer.Start(ctx, "SLARepo.List")
+	defer span.End()
+
+	rows, err := r.db.Pool.Query(ctx, `SELECT `+columns+` FROM team_slas WHERE org_id = $1 ORDER BY team_name`,
+		org.FromContext(ctx))
+	if err != nil {
+		return nil, cerr.HandlePgErr(err)
+	}
+
+	slas, err := pgx.CollectRows(rows, scanSLA)
+	if err != nil {
+		return nil, cerr.HandlePgErr(err)
+	}
+
+	return slas, nil
+}
+
+func (r Repo) Pending(ctx context.Context) ([]entity.PendingReview, error) {
+	ctx, span := tracer.Start(ctx, "SLARepo.Pending")
+	defer span.End()
+
+	query := `SELECT pr.id, pr.name, pr.author_id, r.reviewer_id, u.team_name, r.assigned_at, r.reminded_at, r.escalated_at
+FROM reviewers AS r
+    INNER JOIN pull_requests AS pr ON pr.org_id = r.org_id AND pr.id = r.pull_request_id
+    INNER JOIN users AS u ON u.org_id = pr.org_id AND u.id = pr.author_id
+    INNER JOIN team_slas AS s ON s.org_id = u.org_id AND s.team_name = u.team_name
+WHERE r.org_id = $1 AND pr.merged_at IS NULL
+ORDER BY r.assigned_at, pr.id, r.reviewer_id`
+
+	rows, err := r.db.Pool.Query(ctx, query, org.FromContext(ctx))
+	if err != nil {
+		return nil, cerr.HandlePgErr(err)
+	}
+
+	reviews, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (entity.PendingReview, error) {
+		var review entity.PendingReview
+
+		err := row.Scan(&review.PullRequestId, &review.PullRequestName, &review.AuthorId, &review.ReviewerId,
+			&review.TeamName, &review.AssignedAt, &review.RemindedAt, &review.EscalatedAt)
+
+		return review, err
+	})
+	if err != nil {
+		return nil, cerr.HandlePgErr(err)
+	}
+
+	return reviews, nil
+}
+
+func (r Repo) MarkReminded(ctx context.Context, pullRequestID string, reviewerID string, at time.Time) (bool, error) {
+	ctx, span := tracer.Start(ctx, "SLARepo.MarkReminded")
+	defer span.End()
+
+	query := `UPDATE reviewers SET reminded_at = $1
+    WHERE org_id = $2 AND pull_request_id = $3 AND reviewer_id = $4 AND reminded_at IS NULL`
+
+	return r.mark(ctx, query, pullRequestID, reviewerID, at)
+}
+
+func (r Repo) MarkEscalated(ctx context.Context, pullRequestID string, reviewerID string, at time.Time) (bool, error) {
+	ctx, span := tracer.Start(ctx, "SLARepo.MarkEscalated")
+	defer span.End()
+
+	query := `UPDATE reviewers SET escalated_at = $1
+    WHERE org_id = $2 AND pull_request_id = $3 AND reviewer_id = $4 AND escalated_at IS NULL`
+
+	return r.mark(ctx, query, pullRequestID, reviewerID, at)
+}
+
+func (r Repo) mark(ctx context.Context, query string, pullRequestID string, reviewerID string, at time.Time) (bool, error) {
+	tag, err := r.db.Pool.Exec(ctx, query, at, org.FromContext(ctx), pullRequestID, reviewerID)
+	if err != nil {
+		return false, cerr.HandlePgErr(err)
+	}
+
+	return tag.RowsAffected() == 1, nil
+}
+
+func scanSLA(row pgx.CollectableRow) (entity.TeamSLA, error) {
+	var (
+		sla                        entity.TeamSLA
+		remindAfter, escalateAfter int64
+	)
+
+	err := row.Scan(&sla.TeamName, &remindAfter, &escalateAfter, &sla.LeadId, &sla.AutoReassign)
+
+	sla.RemindAfter = time.Duration(remindAfter) * time.Second
+	sla.EscalateAfter = time.Duration(escalateAfter) * time.Second
+
+	return sla, err
+}
+
+func seconds(d time.Duration) int64 {
+	return int64(d / time.Second)
+}
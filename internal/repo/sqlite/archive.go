@@ -0,0 +1,276 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"avito/internal/cerr"
+	"avito/internal/entity"
+	"avito/internal/org"
+	"avito/internal/repo"
+	sqlitedb "avito/internal/sqlite"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type ArchiveRepo struct {
+	db *sqlitedb.Sqlite
+}
+
+func InitArchiveRepo(db *sqlitedb.Sqlite) repo.Archive {
+	return ArchiveRepo{db: db}
+}
+
+// archivedStat is what the totals of one reviewer grow by.
+type archivedStat struct {
+	count int
+	hours float64
+}
+
+func (r ArchiveRepo) Archive(ctx context.Context, mergedBefore time.Time, limit int) (int, error) {
+	ctx, span := tracer.Start(ctx, "SqliteArchiveRepo.Archive")
+	defer span.End()
+
+	orgID := org.FromContext(ctx)
+
+	tx, err := r.db.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, cerr.HandleSqliteErr(err)
+	}
+	defer rollback(tx)
+
+	selectQuery := `SELECT id, created_at, merged_at FROM pull_requests
+    WHERE org_id = ?1 AND merged_at < ?2 AND (restored_at IS NULL OR restored_at < ?2)
+    ORDER BY merged_at
+    LIMIT ?3`
+
+	rows, err := tx.QueryContext(ctx, selectQuery, orgID, mergedBefore.UTC(), limit)
+	if err != nil {
+		return 0, cerr.HandleSqliteErr(err)
+	}
+
+	var ids []string
+
+	// The durations are summed in Go like in StatRepo, SQLite has no type for them.
+	hours := make(map[string]float64)
+
+	for rows.Next() {
+		var id string
+
+		var createdAt, mergedAt time.Time
+
+		if err = rows.Scan(&id, &createdAt, &mergedAt); err != nil {
+			rows.Close()
+
+			return 0, cerr.HandleSqliteErr(err)
+		}
+
+		ids = append(ids, id)
+		hours[id] = mergedAt.Sub(createdAt).Hours()
+	}
+
+	if err = rows.Err(); err != nil {
+		return 0, cerr.HandleSqliteErr(err)
+	}
+
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	keysJSON, err := keys(ids)
+	if err != nil {
+		return 0, err
+	}
+
+	stats, order, err := reviewStats(ctx, tx, orgID, keysJSON, hours)
+	if err != nil {
+		return 0, err
+	}
+
+	prQuery := `INSERT INTO archived_pull_requests (org_id, id, name, author_id, created_at, merged_at)
+SELECT org_id, id, name, author_id, created_at, merged_at FROM pull_requests
+WHERE org_id = ?1 AND id IN (SELECT value FROM json_each(?2)) ORDER BY merged_at`
+
+	reviewersQuery := `INSERT INTO archived_reviewers (org_id, pull_request_id, reviewer_id, assigned_at)
+SELECT org_id, pull_request_id, reviewer_id, assigned_at FROM reviewers
+WHERE org_id = ?1 AND pull_request_id IN (SELECT value FROM json_each(?2)) ORDER BY rowid`
+
+	for _, query := range []string{prQuery, reviewersQuery} {
+		if _, err = tx.ExecContext(ctx, query, orgID, keysJSON); err != nil {
+			return 0, cerr.HandleSqliteErr(err)
+		}
+	}
+
+	statsQuery := `INSERT INTO archived_review_stats (org_id, reviewer_id, review_count, merged_hours) VALUES (?, ?, ?, ?)
+ON CONFLICT (org_id, reviewer_id) DO UPDATE SET review_count = review_count + excluded.review_count,
+    merged_hours = merged_hours + excluded.merged_hours`
+
+	for _, reviewerID := range order {
+		stat := stats[reviewerID]
+
+		if _, err = tx.ExecContext(ctx, statsQuery, orgID, reviewerID, stat.count, stat.hours); err != nil {
+			return 0, cerr.HandleSqliteErr(err)
+		}
+	}
+
+	deleteQuery := `DELETE FROM pull_requests WHERE org_id = ?1 AND id IN (SELECT value FROM json_each(?2))`
+
+	if _, err = tx.ExecContext(ctx, deleteQuery, orgID, keysJSON); err != nil {
+		return 0, cerr.HandleSqliteErr(err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, cerr.HandleSqliteErr(err)
+	}
+
+	span.SetAttributes(attribute.Int("archive.moved", len(ids)))
+
+	return len(ids), nil
+}
+
+// reviewStats sums the reviews of the pull requests per reviewer, the reviewers in the
+// order they were first seen.
+func reviewStats(ctx context.Context, tx *sql.Tx, orgID string, keysJSON string, hours map[string]float64) (map[string]archivedStat, []string, error) {
+	query := `SELECT pull_request_id, reviewer_id FROM reviewers
+    WHERE org_id = ?1 AND pull_request_id IN (SELECT value FROM json_each(?2))
+    ORDER BY rowid`
+
+	rows, err := tx.QueryContext(ctx, query, orgID, keysJSON)
+	if err != nil {
+		return nil, nil, cerr.HandleSqliteErr(err)
+	}
+	defer rows.Close()
+
+	stats := make(map[string]archivedStat)
+
+	var order []string
+
+	for rows.Next() {
+		var pullRequestID, reviewerID string
+
+		if err = rows.Scan(&pullRequestID, &reviewerID); err != nil {
+			return nil, nil, cerr.HandleSqliteErr(err)
+		}
+
+		stat, ok := stats[reviewerID]
+		if !ok {
+			order = append(order, reviewerID)
+		}
+
+		stat.count++
+		stat.hours += hours[pullRequestID]
+		stats[reviewerID] = stat
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, nil, cerr.HandleSqliteErr(err)
+	}
+
+	return stats, order, nil
+}
+
+func (r ArchiveRepo) Restore(ctx context.Context, pullRequestID string) (*entity.PullRequest, error) {
+	ctx, span := tracer.Start(ctx, "SqliteArchiveRepo.Restore")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("pr.id", pullRequestID))
+
+	orgID := org.FromContext(ctx)
+
+	tx, err := r.db.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, cerr.HandleSqliteErr(err)
+	}
+	defer rollback(tx)
+
+	pullRequest := entity.PullRequest{
+		PullRequestId:     pullRequestID,
+		Status:            entity.PRStatusMERGED,
+		AssignedReviewers: []string{},
+	}
+
+	var createdAt, mergedAt time.Time
+
+	selectQuery := `SELECT name, author_id, created_at, merged_at FROM archived_pull_requests WHERE org_id = ? AND id = ?`
+
+	err = tx.QueryRowContext(ctx, selectQuery, orgID, pullRequestID).
+		Scan(&pullRequest.PullRequestName, &pullRequest.AuthorId, &createdAt, &mergedAt)
+	if err != nil {
+		return nil, cerr.HandleSqliteErr(err)
+	}
+
+	pullRequest.CreatedAt, pullRequest.MergedAt = &createdAt, &mergedAt
+
+	prQuery := `INSERT INTO pull_requests (org_id, id, name, author_id, status, created_at, merged_at, restored_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
+
+	_, err = tx.ExecContext(ctx, prQuery, orgID, pullRequestID, pullRequest.PullRequestName, pullRequest.AuthorId,
+		entity.PRStatusMERGED, createdAt, mergedAt, time.Now().UTC().Truncate(time.Microsecond))
+	if err != nil {
+		return nil, cerr.HandleSqliteErr(err)
+	}
+
+	rows, err := tx.QueryContext(ctx, `SELECT reviewer_id FROM archived_reviewers WHERE org_id = ? AND pull_request_id = ? ORDER BY rowid`,
+		orgID, pullRequestID)
+	if err != nil {
+		return nil, cerr.HandleSqliteErr(err)
+	}
+
+	for rows.Next() {
+		var reviewerID string
+
+		if err = rows.Scan(&reviewerID); err != nil {
+			rows.Close()
+
+			return nil, cerr.HandleSqliteErr(err)
+		}
+
+		pullRequest.AssignedReviewers = append(pullRequest.AssignedReviewers, reviewerID)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, cerr.HandleSqliteErr(err)
+	}
+
+	reviewers, err := keys(pullRequest.AssignedReviewers)
+	if err != nil {
+		return nil, err
+	}
+
+	reviewersQuery := `INSERT INTO reviewers (org_id, pull_request_id, reviewer_id, assigned_at)
+SELECT org_id, pull_request_id, reviewer_id, assigned_at FROM archived_reviewers
+WHERE org_id = ?1 AND pull_request_id = ?2 ORDER BY rowid`
+
+	// A reviewer whose reviews are all back loses the row. The delete goes first, otherwise
+	// a count the decrement has just brought down to one would be deleted too.
+	emptyQuery := `DELETE FROM archived_review_stats
+WHERE org_id = ?1 AND reviewer_id IN (SELECT value FROM json_each(?2)) AND review_count = 1`
+
+	statsQuery := `UPDATE archived_review_stats SET review_count = review_count - 1, merged_hours = merged_hours - ?3
+WHERE org_id = ?1 AND reviewer_id IN (SELECT value FROM json_each(?2))`
+
+	// The archived reviewers go with the pull request, so it is deleted last.
+	deleteQuery := `DELETE FROM archived_pull_requests WHERE org_id = ?1 AND id = ?2`
+
+	if _, err = tx.ExecContext(ctx, reviewersQuery, orgID, pullRequestID); err != nil {
+		return nil, cerr.HandleSqliteErr(err)
+	}
+
+	if _, err = tx.ExecContext(ctx, emptyQuery, orgID, reviewers); err != nil {
+		return nil, cerr.HandleSqliteErr(err)
+	}
+
+	if _, err = tx.ExecContext(ctx, statsQuery, orgID, reviewers, mergedAt.Sub(createdAt).Hours()); err != nil {
+		return nil, cerr.HandleSqliteErr(err)
+	}
+
+	if _, err = tx.ExecContext(ctx, deleteQuery, orgID, pullRequestID); err != nil {
+		return nil, cerr.HandleSqliteErr(err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, cerr.HandleSqliteErr(err)
+	}
+
+	return &pullRequest, nil
+}
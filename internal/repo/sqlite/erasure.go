@@ -0,0 +1,109 @@
+package sqlite
+
+import (
+	"context"
+	"time"
+
+	"avito/internal/cerr"
+	"avito/internal/entity"
+	"avito/internal/org"
+	"avito/internal/repo"
+	sqlitedb "avito/internal/sqlite"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type ErasureRepo struct {
+	db *sqlitedb.Sqlite
+}
+
+func InitErasureRepo(db *sqlitedb.Sqlite) repo.Erasure {
+	return ErasureRepo{db: db}
+}
+
+// Erase renames the user first, the foreign keys carry the new id to the other tables and
+// the reviews are handed off under the pseudonym, like in Postgres.
+func (r ErasureRepo) Erase(ctx context.Context, userID string, pseudonym string) (*entity.UserErasure, []entity.Reassignment, error) {
+	ctx, span := tracer.Start(ctx, "SqliteErasureRepo.Erase")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user.pseudonym", pseudonym))
+
+	orgID := org.FromContext(ctx)
+
+	tx, err := r.db.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, nil, cerr.HandleSqliteErr(err)
+	}
+	defer rollback(tx)
+
+	erasure := entity.UserErasure{Pseudonym: pseudonym, ErasedAt: time.Now().UTC().Truncate(time.Microsecond)}
+
+	userQuery := `UPDATE users SET id = ?3, username = ?3, email = NULL, is_active = 0
+WHERE org_id = ?1 AND id = ?2
+RETURNING team_name`
+
+	err = tx.QueryRowContext(ctx, userQuery, orgID, userID, pseudonym).Scan(&erasure.TeamName)
+	if err != nil {
+		return nil, nil, cerr.HandleSqliteErr(err)
+	}
+
+	leadQuery := `UPDATE team_slas SET lead_id = NULL WHERE org_id = ? AND lead_id = ?`
+
+	if _, err = tx.ExecContext(ctx, leadQuery, orgID, pseudonym); err != nil {
+		return nil, nil, cerr.HandleSqliteErr(err)
+	}
+
+	reassignments, err := reassignReviews(ctx, tx, orgID, pseudonym)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	erasure.ReassignedReviews = len(reassignments)
+
+	auditQuery := `INSERT INTO user_erasures (org_id, pseudonym, team_name, erased_at, reassigned_reviews) VALUES (?, ?, ?, ?, ?)`
+
+	_, err = tx.ExecContext(ctx, auditQuery, orgID, pseudonym, erasure.TeamName, erasure.ErasedAt, erasure.ReassignedReviews)
+	if err != nil {
+		return nil, nil, cerr.HandleSqliteErr(err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, nil, cerr.HandleSqliteErr(err)
+	}
+
+	return &erasure, reassignments, nil
+}
+
+func (r ErasureRepo) List(ctx context.Context) ([]entity.UserErasure, error) {
+	ctx, span := tracer.Start(ctx, "SqliteErasureRepo.List")
+	defer span.End()
+
+	query := `SELECT pseudonym, team_name, erased_at, reassigned_reviews FROM user_erasures
+    WHERE org_id = ?
+    ORDER BY erased_at, rowid`
+
+	rows, err := r.db.DB.QueryContext(ctx, query, org.FromContext(ctx))
+	if err != nil {
+		return nil, cerr.HandleSqliteErr(err)
+	}
+	defer rows.Close()
+
+	var erasures []entity.UserErasure
+
+	for rows.Next() {
+		var erasure entity.UserErasure
+
+		err = rows.Scan(&erasure.Pseudonym, &erasure.TeamName, &erasure.ErasedAt, &erasure.ReassignedReviews)
+		if err != nil {
+			return nil, cerr.HandleSqliteErr(err)
+		}
+
+		erasures = append(erasures, erasure)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, cerr.HandleSqliteErr(err)
+	}
+
+	return erasures, nil
+}
@@ -0,0 +1,121 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+
+	"avito/internal/cerr"
+	"avito/internal/entity"
+	"avito/internal/org"
+	"avito/internal/repo"
+	sqlitedb "avito/internal/sqlite"
+)
+
+type HistoryRepo struct {
+	db *sqlitedb.Sqlite
+}
+
+func InitHistoryRepo(db *sqlitedb.Sqlite) repo.History {
+	return HistoryRepo{db: db}
+}
+
+func (r HistoryRepo) Load(ctx context.Context) (*entity.History, error) {
+	ctx, span := tracer.Start(ctx, "SqliteHistoryRepo.Load")
+	defer span.End()
+
+	var history entity.History
+
+	teamIndex := make(map[string]int)
+
+	orgID := org.FromContext(ctx)
+
+	usersQuery := `SELECT t.name, u.id, u.username, u.is_active FROM teams AS t
+    INNER JOIN users AS u ON u.org_id = t.org_id AND u.team_name = t.name
+    WHERE t.org_id = ?
+    ORDER BY t.created_at, t.name, u.rowid`
+
+	rows, err := r.db.DB.QueryContext(ctx, usersQuery, orgID)
+	if err != nil {
+		return nil, cerr.HandleSqliteErr(err)
+	}
+
+	for rows.Next() {
+		var teamName string
+
+		var member entity.TeamMember
+
+		if err = rows.Scan(&teamName, &member.UserId, &member.Username, &member.IsActive); err != nil {
+			rows.Close()
+
+			return nil, cerr.HandleSqliteErr(err)
+		}
+
+		i, ok := teamIndex[teamName]
+		if !ok {
+			i = len(history.Teams)
+			teamIndex[teamName] = i
+			history.Teams = append(history.Teams, entity.Team{TeamName: teamName})
+		}
+
+		history.Teams[i].Members = append(history.Teams[i].Members, member)
+	}
+
+	rows.Close()
+
+	if err = rows.Err(); err != nil {
+		return nil, cerr.HandleSqliteErr(err)
+	}
+
+	// The archived pull requests are part of the history too, a replay without them would
+	// start from lighter loads than the reviewers really had.
+	prQuery := `SELECT pr.id, pr.author_id, pr.created_at, pr.merged_at, r.reviewer_id
+FROM pull_requests AS pr
+    LEFT JOIN reviewers AS r ON r.org_id = pr.org_id AND r.pull_request_id = pr.id
+WHERE pr.org_id = ?1
+UNION ALL
+SELECT pr.id, pr.author_id, pr.created_at, pr.merged_at, r.reviewer_id
+FROM archived_pull_requests AS pr
+    LEFT JOIN archived_reviewers AS r ON r.org_id = pr.org_id AND r.pull_request_id = pr.id
+WHERE pr.org_id = ?1
+ORDER BY created_at, id, reviewer_id`
+
+	rows, err = r.db.DB.QueryContext(ctx, prQuery, orgID)
+	if err != nil {
+		return nil, cerr.HandleSqliteErr(err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var pr entity.PullRequestHistory
+
+		var mergedAt sql.NullTime
+
+		var reviewerID sql.NullString
+
+		if err = rows.Scan(&pr.PullRequestId, &pr.AuthorId, &pr.CreatedAt, &mergedAt, &reviewerID); err != nil {
+			return nil, cerr.HandleSqliteErr(err)
+		}
+
+		// Rows of one pull request are adjacent, one per reviewer.
+		last := len(history.PullRequests) - 1
+		if last < 0 || history.PullRequests[last].PullRequestId != pr.PullRequestId {
+			if mergedAt.Valid {
+				pr.MergedAt = &mergedAt.Time
+			}
+
+			pr.AssignedReviewers = []string{}
+			history.PullRequests = append(history.PullRequests, pr)
+			last++
+		}
+
+		if reviewerID.Valid {
+			history.PullRequests[last].AssignedReviewers = append(history.PullRequests[last].AssignedReviewers, reviewerID.String)
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, cerr.HandleSqliteErr(err)
+	}
+
+	return &history, nil
+}
@@ -0,0 +1,310 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"avito/internal/cerr"
+	"avito/internal/entity"
+	"avito/internal/org"
+	"avito/internal/repo"
+	sqlitedb "avito/internal/sqlite"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type LookupRepo struct {
+	db *sqlitedb.Sqlite
+}
+
+func InitLookupRepo(db *sqlitedb.Sqlite) repo.Lookup {
+	return LookupRepo{db: db}
+}
+
+// keys passes a list to SQLite as one JSON array, the queries expand it with json_each.
+func keys(values []string) (string, error) {
+	data, err := json.Marshal(values)
+
+	return string(data), err
+}
+
+func (r LookupRepo) Users(ctx context.Context, userIDs []string) ([]entity.User, error) {
+	ctx, span := tracer.Start(ctx, "SqliteLookupRepo.Users")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int("lookup.keys", len(userIDs)))
+
+	ids, err := keys(userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `SELECT id, username, team_name, is_active FROM users
+    WHERE org_id = ? AND id IN (SELECT value FROM json_each(?))`
+
+	rows, err := r.db.DB.QueryContext(ctx, query, org.FromContext(ctx), ids)
+	if err != nil {
+		return nil, cerr.HandleSqliteErr(err)
+	}
+	defer rows.Close()
+
+	var users []entity.User
+
+	for rows.Next() {
+		var user entity.User
+
+		if err = rows.Scan(&user.UserId, &user.Username, &user.TeamName, &user.IsActive); err != nil {
+			return nil, cerr.HandleSqliteErr(err)
+		}
+
+		users = append(users, user)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, cerr.HandleSqliteErr(err)
+	}
+
+	return users, nil
+}
+
+func (r LookupRepo) Teams(ctx context.Context, teamNames []string) ([]entity.Team, error) {
+	ctx, span := tracer.Start(ctx, "SqliteLookupRepo.Teams")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int("lookup.keys", len(teamNames)))
+
+	names, err := keys(teamNames)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `SELECT t.name, u.id, u.username, u.is_active FROM teams AS t
+    INNER JOIN users AS u ON u.org_id = t.org_id AND u.team_name = t.name
+    WHERE t.org_id = ? AND t.name IN (SELECT value FROM json_each(?))
+    ORDER BY t.name, u.rowid`
+
+	rows, err := r.db.DB.QueryContext(ctx, query, org.FromContext(ctx), names)
+	if err != nil {
+		return nil, cerr.HandleSqliteErr(err)
+	}
+	defer rows.Close()
+
+	var teams []entity.Team
+
+	for rows.Next() {
+		var teamName string
+
+		var member entity.TeamMember
+
+		if err = rows.Scan(&teamName, &member.UserId, &member.Username, &member.IsActive); err != nil {
+			return nil, cerr.HandleSqliteErr(err)
+		}
+
+		if len(teams) == 0 || teams[len(teams)-1].TeamName != teamName {
+			teams = append(teams, entity.Team{TeamName: teamName})
+		}
+
+		teams[len(teams)-1].Members = append(teams[len(teams)-1].Members, member)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, cerr.HandleSqliteErr(err)
+	}
+
+	return teams, nil
+}
+
+func (r LookupRepo) PullRequests(ctx context.Context, pullRequestIDs []string) ([]entity.PullRequest, error) {
+	ctx, span := tracer.Start(ctx, "SqliteLookupRepo.PullRequests")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int("lookup.keys", len(pullRequestIDs)))
+
+	query := `SELECT id, name, author_id, status, created_at, merged_at FROM pull_requests
+    WHERE org_id = ?1 AND id IN (SELECT value FROM json_each(?2))`
+
+	return r.pullRequests(ctx, query, pullRequestIDs)
+}
+
+func (r LookupRepo) Reviews(ctx context.Context, userIDs []string) ([]entity.PullRequest, error) {
+	ctx, span := tracer.Start(ctx, "SqliteLookupRepo.Reviews")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int("lookup.keys", len(userIDs)))
+
+	query := `SELECT id, name, author_id, status, created_at, merged_at FROM pull_requests
+    WHERE org_id = ?1
+      AND id IN (SELECT pull_request_id FROM reviewers WHERE org_id = ?1 AND reviewer_id IN (SELECT value FROM json_each(?2)))
+    ORDER BY created_at, id`
+
+	return r.pullRequests(ctx, query, userIDs)
+}
+
+// pullRequests reads the pull requests matched by query and then their reviewers, the
+// first rows are closed before the second query since there may be only one connection.
+func (r LookupRepo) pullRequests(ctx context.Context, query string, values []string) ([]entity.PullRequest, error) {
+	param, err := keys(values)
+	if err != nil {
+		return nil, err
+	}
+
+	orgID := org.FromContext(ctx)
+
+	rows, err := r.db.DB.QueryContext(ctx, query, orgID, param)
+	if err != nil {
+		return nil, cerr.HandleSqliteErr(err)
+	}
+
+	var prs []entity.PullRequest
+
+	index := make(map[string]int)
+
+	for rows.Next() {
+		var pr entity.PullRequest
+
+		var createdAt time.Time
+
+		var mergedAt sql.NullTime
+
+		if err = rows.Scan(&pr.PullRequestId, &pr.PullRequestName, &pr.AuthorId, &pr.Status, &createdAt, &mergedAt); err != nil {
+			rows.Close()
+
+			return nil, cerr.HandleSqliteErr(err)
+		}
+
+		pr.CreatedAt = &createdAt
+
+		if mergedAt.Valid {
+			pr.MergedAt = &mergedAt.Time
+		}
+
+		index[pr.PullRequestId] = len(prs)
+		prs = append(prs, pr)
+	}
+
+	rows.Close()
+
+	if err = rows.Err(); err != nil {
+		return nil, cerr.HandleSqliteErr(err)
+	}
+
+	if len(prs) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]string, 0, len(prs))
+	for _, pr := range prs {
+		ids = append(ids, pr.PullRequestId)
+	}
+
+	if param, err = keys(ids); err != nil {
+		return nil, err
+	}
+
+	reviewersQuery := `SELECT pull_request_id, reviewer_id FROM reviewers
+    WHERE org_id = ? AND pull_request_id IN (SELECT value FROM json_each(?))
+    ORDER BY reviewer_id`
+
+	rows, err = r.db.DB.QueryContext(ctx, reviewersQuery, orgID, param)
+	if err != nil {
+		return nil, cerr.HandleSqliteErr(err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var pullRequestID, reviewerID string
+
+		if err = rows.Scan(&pullRequestID, &reviewerID); err != nil {
+			return nil, cerr.HandleSqliteErr(err)
+		}
+
+		i := index[pullRequestID]
+		prs[i].AssignedReviewers = append(prs[i].AssignedReviewers, reviewerID)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, cerr.HandleSqliteErr(err)
+	}
+
+	return prs, nil
+}
+
+func (r LookupRepo) UserStats(ctx context.Context, userIDs []string) ([]entity.UserStat, error) {
+	ctx, span := tracer.Start(ctx, "SqliteLookupRepo.UserStats")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int("lookup.keys", len(userIDs)))
+
+	ids, err := keys(userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `SELECT u.id, u.is_active, a.review_count, a.merged_hours, pr.id, pr.created_at, pr.merged_at FROM users AS u
+    LEFT JOIN archived_review_stats AS a ON a.org_id = u.org_id AND a.reviewer_id = u.id
+    LEFT JOIN reviewers AS r ON r.org_id = u.org_id AND r.reviewer_id = u.id
+    LEFT JOIN pull_requests AS pr ON pr.org_id = r.org_id AND pr.id = r.pull_request_id
+    WHERE u.org_id = ? AND u.id IN (SELECT value FROM json_each(?))
+    ORDER BY u.id`
+
+	rows, err := r.db.DB.QueryContext(ctx, query, org.FromContext(ctx), ids)
+	if err != nil {
+		return nil, cerr.HandleSqliteErr(err)
+	}
+	defer rows.Close()
+
+	var stats []entity.UserStat
+
+	// The durations are summed per user and turned into averages once all rows are read.
+	// The archived reviews come with every row of the user and are counted on the first.
+	var cntMerged, duration []float64
+
+	for rows.Next() {
+		var stat entity.UserStat
+
+		var archivedCount sql.NullInt64
+
+		var archivedHours sql.NullFloat64
+
+		var pullRequestID sql.NullString
+
+		var createdAt, mergedAt sql.NullTime
+
+		err = rows.Scan(&stat.UserId, &stat.IsActive, &archivedCount, &archivedHours, &pullRequestID, &createdAt, &mergedAt)
+		if err != nil {
+			return nil, cerr.HandleSqliteErr(err)
+		}
+
+		if len(stats) == 0 || stats[len(stats)-1].UserId != stat.UserId {
+			stat.CountPr = int(archivedCount.Int64)
+			stats = append(stats, stat)
+			cntMerged = append(cntMerged, float64(archivedCount.Int64))
+			duration = append(duration, archivedHours.Float64)
+		}
+
+		i := len(stats) - 1
+
+		if pullRequestID.Valid {
+			stats[i].CountPr++
+		}
+
+		if mergedAt.Valid {
+			duration[i] += mergedAt.Time.Sub(createdAt.Time).Hours()
+			cntMerged[i]++
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, cerr.HandleSqliteErr(err)
+	}
+
+	for i := range stats {
+		if cntMerged[i] != 0 {
+			avg := duration[i] / cntMerged[i]
+			stats[i].AvgDuration = &avg
+		}
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,91 @@
+package sqlite
+
+import (
+	"context"
+
+	"avito/internal/cerr"
+	"avito/internal/entity"
+	"avito/internal/org"
+	"avito/internal/repo"
+	sqlitedb "avito/internal/sqlite"
+)
+
+type NotificationRepo struct {
+	db *sqlitedb.Sqlite
+}
+
+func InitNotificationRepo(db *sqlitedb.Sqlite) repo.Notification {
+	return NotificationRepo{db: db}
+}
+
+func (r NotificationRepo) Get(ctx context.Context, userIDs []string) ([]entity.NotificationSettings, error) {
+	ctx, span := tracer.Start(ctx, "SqliteNotificationRepo.Get")
+	defer span.End()
+
+	ids, err := keys(userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `SELECT id, email, email_assignments, email_digest FROM users
+    WHERE org_id = ? AND id IN (SELECT value FROM json_each(?))`
+
+	return r.query(ctx, query, org.FromContext(ctx), ids)
+}
+
+func (r NotificationRepo) Set(ctx context.Context, settings *entity.NotificationSettings) (*entity.NotificationSettings, error) {
+	ctx, span := tracer.Start(ctx, "SqliteNotificationRepo.Set")
+	defer span.End()
+
+	var result entity.NotificationSettings
+
+	query := `UPDATE users SET email = ?, email_assignments = ?, email_digest = ?
+    WHERE org_id = ? AND id = ?
+    RETURNING id, email, email_assignments, email_digest`
+
+	err := r.db.DB.QueryRowContext(ctx, query, settings.Email, settings.EmailAssignments, settings.EmailDigest,
+		org.FromContext(ctx), settings.UserId).
+		Scan(&result.UserId, &result.Email, &result.EmailAssignments, &result.EmailDigest)
+	if err != nil {
+		return nil, cerr.HandleSqliteErr(err)
+	}
+
+	return &result, nil
+}
+
+func (r NotificationRepo) Subscribers(ctx context.Context) ([]entity.NotificationSettings, error) {
+	ctx, span := tracer.Start(ctx, "SqliteNotificationRepo.Subscribers")
+	defer span.End()
+
+	query := `SELECT id, email, email_assignments, email_digest FROM users
+    WHERE org_id = ? AND email IS NOT NULL AND email_digest
+    ORDER BY id`
+
+	return r.query(ctx, query, org.FromContext(ctx))
+}
+
+func (r NotificationRepo) query(ctx context.Context, query string, args ...any) ([]entity.NotificationSettings, error) {
+	rows, err := r.db.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, cerr.HandleSqliteErr(err)
+	}
+	defer rows.Close()
+
+	var settings []entity.NotificationSettings
+
+	for rows.Next() {
+		var s entity.NotificationSettings
+
+		if err = rows.Scan(&s.UserId, &s.Email, &s.EmailAssignments, &s.EmailDigest); err != nil {
+			return nil, cerr.HandleSqliteErr(err)
+		}
+
+		settings = append(settings, s)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, cerr.HandleSqliteErr(err)
+	}
+
+	return settings, nil
+}
@@ -0,0 +1,68 @@
+package sqlite
+
+import (
+	"context"
+
+	"avito/internal/cerr"
+	"avito/internal/repo"
+	sqlitedb "avito/internal/sqlite"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type OrganizationRepo struct {
+	db *sqlitedb.Sqlite
+}
+
+func InitOrganizationRepo(db *sqlitedb.Sqlite) repo.Organization {
+	return OrganizationRepo{db: db}
+}
+
+func (r OrganizationRepo) Ensure(ctx context.Context, orgIDs []string) error {
+	ctx, span := tracer.Start(ctx, "SqliteOrganizationRepo.Ensure")
+	defer span.End()
+
+	span.SetAttributes(attribute.StringSlice("org.ids", orgIDs))
+
+	ids, err := keys(orgIDs)
+	if err != nil {
+		return err
+	}
+
+	// WHERE true keeps SQLite from reading ON CONFLICT as part of the SELECT.
+	query := `INSERT INTO organizations (id) SELECT value FROM json_each(?) WHERE true ON CONFLICT DO NOTHING`
+
+	if _, err = r.db.DB.ExecContext(ctx, query, ids); err != nil {
+		return cerr.HandleSqliteErr(err)
+	}
+
+	return nil
+}
+
+func (r OrganizationRepo) List(ctx context.Context) ([]string, error) {
+	ctx, span := tracer.Start(ctx, "SqliteOrganizationRepo.List")
+	defer span.End()
+
+	rows, err := r.db.DB.QueryContext(ctx, `SELECT id FROM organizations ORDER BY id`)
+	if err != nil {
+		return nil, cerr.HandleSqliteErr(err)
+	}
+	defer rows.Close()
+
+	var orgIDs []string
+
+	for rows.Next() {
+		var orgID string
+
+		if err = rows.Scan(&orgID); err != nil {
+			return nil, cerr.HandleSqliteErr(err)
+		}
+
+		orgIDs = append(orgIDs, orgID)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, cerr.HandleSqliteErr(err)
+	}
+
+	return orgIDs, nil
+}
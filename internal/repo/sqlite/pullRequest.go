@@ -0,0 +1,317 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"avito/internal/cerr"
+	"avito/internal/entity"
+	"avito/internal/log"
+	"avito/internal/org"
+	"avito/internal/repo"
+	sqlitedb "avito/internal/sqlite"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type PullRequestRepo struct {
+	db *sqlitedb.Sqlite
+}
+
+func InitPullRequestRepo(db *sqlitedb.Sqlite) repo.PullRequest {
+	return PullRequestRepo{db: db}
+}
+
+// choiceQuery picks the active teammates of the author with the fewest review
+// assignments, archived ones included, skipping the author and up to two current
+// reviewers.
+const choiceQuery = `SELECT u.id
+FROM users AS u
+    LEFT JOIN reviewers AS r ON r.org_id = u.org_id AND u.id = r.reviewer_id
+    LEFT JOIN archived_review_stats AS a ON a.org_id = u.org_id AND a.reviewer_id = u.id
+WHERE u.org_id = ?5 AND u.team_name = (SELECT team_name FROM users WHERE org_id = ?5 AND id = ?1)
+  AND u.id != ?1 AND u.id != ?2 AND u.id != ?3 AND u.is_active = 1
+GROUP BY u.id
+ORDER BY COUNT(r.reviewer_id) + COALESCE(MAX(a.review_count), 0), u.rowid
+LIMIT ?4;`
+
+func (r PullRequestRepo) Create(ctx context.Context, pullRequestCreate *entity.PullRequestCreate) (*entity.PullRequest, error) {
+	ctx, span := tracer.Start(ctx, "SqlitePullRequestRepo.Create")
+	defer span.End()
+
+	createdAt := time.Now().UTC().Truncate(time.Microsecond)
+
+	pullRequest := entity.PullRequest{
+		AuthorId:        pullRequestCreate.AuthorId,
+		PullRequestName: pullRequestCreate.PullRequestName,
+		PullRequestId:   pullRequestCreate.PullRequestId,
+		Status:          entity.PRStatusOPEN,
+		CreatedAt:       &createdAt,
+	}
+
+	orgID := org.FromContext(ctx)
+
+	tx, err := r.db.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, cerr.HandleSqliteErr(err)
+	}
+	defer rollback(tx)
+
+	// SQLite does not name the violated foreign key, so the author is checked up front,
+	// after the id like the primary key in Postgres. An archived pull request keeps its id.
+	var prCount, authorCount int
+
+	checkQuery := `SELECT (SELECT COUNT(*) FROM pull_requests WHERE org_id = ?1 AND id = ?2)
+        + (SELECT COUNT(*) FROM archived_pull_requests WHERE org_id = ?1 AND id = ?2),
+    (SELECT COUNT(*) FROM users WHERE org_id = ?1 AND id = ?3)`
+
+	err = tx.QueryRowContext(ctx, checkQuery, orgID, pullRequestCreate.PullRequestId, pullRequestCreate.AuthorId).Scan(&prCount, &authorCount)
+	if err != nil {
+		return nil, cerr.HandleSqliteErr(err)
+	}
+
+	if prCount != 0 {
+		return nil, cerr.CustomError{
+			Err:     fmt.Errorf("sqlite: pull request %q exists", pullRequestCreate.PullRequestId),
+			ErrType: cerr.PR_EXISTS,
+		}
+	}
+
+	if authorCount == 0 {
+		return nil, cerr.CustomError{Err: errNoUser, ErrType: cerr.NOT_FOUND}
+	}
+
+	createQuery := `INSERT INTO pull_requests (org_id, id, name, author_id, status, created_at) VALUES (?, ?, ?, ?, ?, ?)`
+
+	_, err = tx.ExecContext(ctx, createQuery, orgID, pullRequestCreate.PullRequestId, pullRequestCreate.PullRequestName, pullRequestCreate.AuthorId, entity.PRStatusOPEN, createdAt)
+	if err != nil {
+		return nil, cerr.HandleSqliteErr(err)
+	}
+
+	reviewers, err := r.selectReviewers(ctx, tx, pullRequestCreate.AuthorId, "", "", 2)
+	if err != nil {
+		return nil, err
+	}
+
+	assignQuery := `INSERT INTO reviewers (org_id, pull_request_id, reviewer_id, assigned_at) VALUES (?, ?, ?, ?)`
+
+	for _, userID := range reviewers {
+		if _, err = tx.ExecContext(ctx, assignQuery, orgID, pullRequestCreate.PullRequestId, userID, createdAt); err != nil {
+			return nil, cerr.HandleSqliteErr(err)
+		}
+	}
+
+	pullRequest.AssignedReviewers = reviewers
+
+	if err = tx.Commit(); err != nil {
+		return nil, cerr.HandleSqliteErr(err)
+	}
+
+	log.Ctx(ctx).Debug(fmt.Sprintf("Reviewers assigned: %v", pullRequest.AssignedReviewers))
+
+	return &pullRequest, nil
+}
+
+func (r PullRequestRepo) Merge(ctx context.Context, pullRequestID string) (*entity.PullRequest, error) {
+	ctx, span := tracer.Start(ctx, "SqlitePullRequestRepo.Merge")
+	defer span.End()
+
+	mergedAt := time.Now().UTC().Truncate(time.Microsecond)
+	pullRequest := entity.PullRequest{
+		PullRequestId: pullRequestID,
+		MergedAt:      &mergedAt,
+		Status:        entity.PRStatusMERGED,
+	}
+
+	tx, err := r.db.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, cerr.HandleSqliteErr(err)
+	}
+	defer rollback(tx)
+
+	var createdAt time.Time
+
+	updateQuery := `UPDATE pull_requests SET status = ?, merged_at = ? WHERE org_id = ? AND id = ?
+    RETURNING name, author_id, created_at`
+
+	err = tx.QueryRowContext(ctx, updateQuery, entity.PRStatusMERGED, mergedAt, org.FromContext(ctx), pullRequestID).Scan(&pullRequest.PullRequestName, &pullRequest.AuthorId, &createdAt)
+	if err != nil {
+		return nil, cerr.HandleSqliteErr(err)
+	}
+
+	pullRequest.CreatedAt = &createdAt
+
+	pullRequest.AssignedReviewers, err = reviewersOf(ctx, tx, pullRequestID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, cerr.HandleSqliteErr(err)
+	}
+
+	return &pullRequest, nil
+}
+
+func (r PullRequestRepo) Reassign(ctx context.Context, pullRequestID string, oldUserID string) (*entity.PullRequest, string, error) {
+	ctx, span := tracer.Start(ctx, "SqlitePullRequestRepo.Reassign")
+	defer span.End()
+
+	pullRequest := entity.PullRequest{
+		PullRequestId: pullRequestID,
+		Status:        entity.PRStatusOPEN,
+	}
+
+	orgID := org.FromContext(ctx)
+
+	tx, err := r.db.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, "", cerr.HandleSqliteErr(err)
+	}
+	defer rollback(tx)
+
+	var count int
+
+	checkUserQuery := `SELECT COUNT(*) FROM users WHERE org_id = ? AND id = ?`
+
+	if err = tx.QueryRowContext(ctx, checkUserQuery, orgID, oldUserID).Scan(&count); err != nil {
+		return nil, "", cerr.HandleSqliteErr(err)
+	}
+
+	if count == 0 {
+		return nil, "", cerr.CustomError{Err: errNoUser, ErrType: cerr.NOT_FOUND}
+	}
+
+	var mergedAt sql.NullTime
+
+	PRQuery := `SELECT merged_at, name, author_id FROM pull_requests WHERE org_id = ? AND id = ?`
+
+	err = tx.QueryRowContext(ctx, PRQuery, orgID, pullRequestID).Scan(&mergedAt, &pullRequest.PullRequestName, &pullRequest.AuthorId)
+	if err != nil {
+		return nil, "", cerr.HandleSqliteErr(err)
+	}
+
+	if mergedAt.Valid {
+		return nil, "", cerr.CustomError{
+			Err:     errors.New("sqlite: pull request is merged"),
+			ErrType: cerr.PR_MERGED,
+		}
+	}
+
+	current, err := reviewersOf(ctx, tx, pullRequestID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var secondUser string
+
+	assigned := false
+
+	for _, userID := range current {
+		if userID == oldUserID {
+			assigned = true
+		} else {
+			secondUser = userID
+		}
+	}
+
+	if !assigned {
+		return nil, "", cerr.CustomError{
+			Err:     errors.New("sqlite: reviewer is not assigned"),
+			ErrType: cerr.NOT_ASSIGNED,
+		}
+	}
+
+	candidates, err := r.selectReviewers(ctx, tx, pullRequest.AuthorId, oldUserID, secondUser, 1)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if len(candidates) == 0 {
+		return nil, "", cerr.CustomError{
+			Err:     sql.ErrNoRows,
+			ErrType: cerr.NO_CANDIDATE,
+		}
+	}
+
+	newUser := candidates[0]
+
+	assignQuery := `UPDATE reviewers SET reviewer_id = ?, assigned_at = ?, reminded_at = NULL, escalated_at = NULL
+    WHERE org_id = ? AND pull_request_id = ? AND reviewer_id = ?`
+
+	_, err = tx.ExecContext(ctx, assignQuery, newUser, time.Now().UTC().Truncate(time.Microsecond), orgID, pullRequestID, oldUserID)
+	if err != nil {
+		return nil, "", cerr.HandleSqliteErr(err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, "", cerr.HandleSqliteErr(err)
+	}
+
+	pullRequest.AssignedReviewers = []string{newUser, secondUser}
+
+	log.Ctx(ctx).Debug(fmt.Sprintf("Reviewer %v replaced by %v", oldUserID, newUser))
+
+	return &pullRequest, newUser, nil
+}
+
+func (r PullRequestRepo) selectReviewers(ctx context.Context, tx *sql.Tx, authorID string, skip1 string, skip2 string, limit int) ([]string, error) {
+	ctx, span := tracer.Start(ctx, "SqlitePullRequestRepo.selectReviewers")
+	defer span.End()
+
+	rows, err := tx.QueryContext(ctx, choiceQuery, authorID, skip1, skip2, limit, org.FromContext(ctx))
+	if err != nil {
+		return nil, cerr.HandleSqliteErr(err)
+	}
+	defer rows.Close()
+
+	var ids []string
+
+	for rows.Next() {
+		var userID string
+
+		if err = rows.Scan(&userID); err != nil {
+			return nil, cerr.HandleSqliteErr(err)
+		}
+
+		ids = append(ids, userID)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, cerr.HandleSqliteErr(err)
+	}
+
+	span.SetAttributes(attribute.StringSlice("reviewers.selected", ids))
+
+	return ids, nil
+}
+
+func reviewersOf(ctx context.Context, tx *sql.Tx, pullRequestID string) ([]string, error) {
+	query := `SELECT reviewer_id FROM reviewers WHERE org_id = ? AND pull_request_id = ? ORDER BY rowid`
+
+	rows, err := tx.QueryContext(ctx, query, org.FromContext(ctx), pullRequestID)
+	if err != nil {
+		return nil, cerr.HandleSqliteErr(err)
+	}
+	defer rows.Close()
+
+	var ids []string
+
+	for rows.Next() {
+		var userID string
+
+		if err = rows.Scan(&userID); err != nil {
+			return nil, cerr.HandleSqliteErr(err)
+		}
+
+		ids = append(ids, userID)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, cerr.HandleSqliteErr(err)
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,224 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"avito/internal/cerr"
+	"avito/internal/entity"
+	"avito/internal/org"
+	"avito/internal/repo"
+	sqlitedb "avito/internal/sqlite"
+)
+
+type SLARepo struct {
+	db *sqlitedb.Sqlite
+}
+
+func InitSLARepo(db *sqlitedb.Sqlite) repo.SLA {
+	return SLARepo{db: db}
+}
+
+const slaColumns = `team_name, remind_after_seconds, escalate_after_seconds, lead_id, auto_reassign`
+
+func (r SLARepo) Get(ctx context.Context, teamName string) (*entity.TeamSLA, error) {
+	ctx, span := tracer.Start(ctx, "SqliteSLARepo.Get")
+	defer span.End()
+
+	query := `SELECT ` + slaColumns + ` FROM team_slas WHERE org_id = ? AND team_name = ?`
+
+	sla, err := scanSLA(r.db.DB.QueryRowContext(ctx, query, org.FromContext(ctx), teamName))
+	if err != nil {
+		return nil, cerr.HandleSqliteErr(err)
+	}
+
+	return &sla, nil
+}
+
+func (r SLARepo) Set(ctx context.Context, sla *entity.TeamSLA) (*entity.TeamSLA, error) {
+	ctx, span := tracer.Start(ctx, "SqliteSLARepo.Set")
+	defer span.End()
+
+	orgID := org.FromContext(ctx)
+
+	tx, err := r.db.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, cerr.HandleSqliteErr(err)
+	}
+	defer rollback(tx)
+
+	// SQLite does not name the violated foreign key, so the team and the lead are checked up front.
+	var teamCount, leadCount int
+
+	checkQuery := `SELECT (SELECT COUNT(*) FROM teams WHERE org_id = ?1 AND name = ?2),
+    (SELECT COUNT(*) FROM users WHERE org_id = ?1 AND id = ?3)`
+
+	if err = tx.QueryRowContext(ctx, checkQuery, orgID, sla.TeamName, sla.LeadId).Scan(&teamCount, &leadCount); err != nil {
+		return nil, cerr.HandleSqliteErr(err)
+	}
+
+	if teamCount == 0 {
+		return nil, cerr.CustomError{Err: errors.New("sqlite: team not found"), ErrType: cerr.NOT_FOUND}
+	}
+
+	if sla.LeadId != nil && leadCount == 0 {
+		return nil, cerr.CustomError{Err: errNoUser, ErrType: cerr.NOT_FOUND}
+	}
+
+	query := `INSERT INTO team_slas (org_id, ` + slaColumns + `) VALUES (?, ?, ?, ?, ?, ?)
+ON CONFLICT (org_id, team_name) DO UPDATE SET remind_after_seconds = excluded.remind_after_seconds,
+    escalate_after_seconds = excluded.escalate_after_seconds, lead_id = excluded.lead_id, auto_reassign = excluded.auto_reassign
+RETURNING ` + slaColumns
+
+	result, err := scanSLA(tx.QueryRowContext(ctx, query, orgID, sla.TeamName, int64(sla.RemindAfter/time.Second),
+		int64(sla.EscalateAfter/time.Second), sla.LeadId, sla.AutoReassign))
+	if err != nil {
+		return nil, cerr.HandleSqliteErr(err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, cerr.HandleSqliteErr(err)
+	}
+
+	return &result, nil
+}
+
+func (r SLARepo) Delete(ctx context.Context, teamName string) error {
+	ctx, span := tracer.Start(ctx, "SqliteSLARepo.Delete")
+	defer span.End()
+
+	res, err := r.db.DB.ExecContext(ctx, `DELETE FROM team_slas WHERE org_id = ? AND team_name = ?`,
+		org.FromContext(ctx), teamName)
+	if err != nil {
+		return cerr.HandleSqliteErr(err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return cerr.HandleSqliteErr(err)
+	}
+
+	if n == 0 {
+		return cerr.HandleSqliteErr(sql.ErrNoRows)
+	}
+
+	return nil
+}
+
+func (r SLARepo) List(ctx context.Context) ([]entity.TeamSLA, error) {
+	ctx, span := tracer.Start(ctx, "SqliteSLARepo.List")
+	defer span.End()
+
+	rows, err := r.db.DB.QueryContext(ctx, `SELECT `+slaColumns+` FROM team_slas WHERE org_id = ? ORDER BY team_name`,
+		org.FromContext(ctx))
+	if err != nil {
+		return nil, cerr.HandleSqliteErr(err)
+	}
+	defer rows.Close()
+
+	var slas []entity.TeamSLA
+
+	for rows.Next() {
+		sla, err := scanSLA(rows)
+		if err != nil {
+			return nil, cerr.HandleSqliteErr(err)
+		}
+
+		slas = append(slas, sla)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, cerr.HandleSqliteErr(err)
+	}
+
+	return slas, nil
+}
+
+func (r SLARepo) Pending(ctx context.Context) ([]entity.PendingReview, error) {
+	ctx, span := tracer.Start(ctx, "SqliteSLARepo.Pending")
+	defer span.End()
+
+	query := `SELECT pr.id, pr.name, pr.author_id, r.reviewer_id, u.team_name, r.assigned_at, r.reminded_at, r.escalated_at
+FROM reviewers AS r
+    INNER JOIN pull_requests AS pr ON pr.org_id = r.org_id AND pr.id = r.pull_request_id
+    INNER JOIN users AS u ON u.org_id = pr.org_id AND u.id = pr.author_id
+    INNER JOIN team_slas AS s ON s.org_id = u.org_id AND s.team_name = u.team_name
+WHERE r.org_id = ? AND pr.status = 'OPEN'
+ORDER BY r.assigned_at, pr.id, r.reviewer_id`
+
+	rows, err := r.db.DB.QueryContext(ctx, query, org.FromContext(ctx))
+	if err != nil {
+		return nil, cerr.HandleSqliteErr(err)
+	}
+	defer rows.Close()
+
+	var reviews []entity.PendingReview
+
+	for rows.Next() {
+		var review entity.PendingReview
+
+		err = rows.Scan(&review.PullRequestId, &review.PullRequestName, &review.AuthorId, &review.ReviewerId,
+			&review.TeamName, &review.AssignedAt, &review.RemindedAt, &review.EscalatedAt)
+		if err != nil {
+			return nil, cerr.HandleSqliteErr(err)
+		}
+
+		reviews = append(reviews, review)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, cerr.HandleSqliteErr(err)
+	}
+
+	return reviews, nil
+}
+
+func (r SLARepo) MarkReminded(ctx context.Context, pullRequestID string, reviewerID string, at time.Time) (bool, error) {
+	ctx, span := tracer.Start(ctx, "SqliteSLARepo.MarkReminded")
+	defer span.End()
+
+	query := `UPDATE reviewers SET reminded_at = ?
+    WHERE org_id = ? AND pull_request_id = ? AND reviewer_id = ? AND reminded_at IS NULL`
+
+	return r.mark(ctx, query, pullRequestID, reviewerID, at)
+}
+
+func (r SLARepo) MarkEscalated(ctx context.Context, pullRequestID string, reviewerID string, at time.Time) (bool, error) {
+	ctx, span := tracer.Start(ctx, "SqliteSLARepo.MarkEscalated")
+	defer span.End()
+
+	query := `UPDATE reviewers SET escalated_at = ?
+    WHERE org_id = ? AND pull_request_id = ? AND reviewer_id = ? AND escalated_at IS NULL`
+
+	return r.mark(ctx, query, pullRequestID, reviewerID, at)
+}
+
+func (r SLARepo) mark(ctx context.Context, query string, pullRequestID string, reviewerID string, at time.Time) (bool, error) {
+	res, err := r.db.DB.ExecContext(ctx, query, at.UTC().Truncate(time.Microsecond), org.FromContext(ctx), pullRequestID, reviewerID)
+	if err != nil {
+		return false, cerr.HandleSqliteErr(err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, cerr.HandleSqliteErr(err)
+	}
+
+	return n == 1, nil
+}
+
+func scanSLA(row interface{ Scan(dest ...any) error }) (entity.TeamSLA, error) {
+	var (
+		sla                        entity.TeamSLA
+		remindAfter, escalateAfter int64
+	)
+
+	err := row.Scan(&sla.TeamName, &remindAfter, &escalateAfter, &sla.LeadId, &sla.AutoReassign)
+
+	sla.RemindAfter = time.Duration(remindAfter) * time.Second
+	sla.EscalateAfter = time.Duration(escalateAfter) * time.Second
+
+	return sla, err
+}
@@ -0,0 +1,13 @@
+package sqlite
+
+import (
+	"database/sql"
+	"errors"
+)
+
+var errNoUser = errors.New("sqlite: user not found")
+
+// rollback is deferred right after BeginTx; once the transaction is committed it is a no-op.
+func rollback(tx *sql.Tx) {
+	_ = tx.Rollback()
+}
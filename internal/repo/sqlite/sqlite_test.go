@@ -0,0 +1,43 @@
+package sqlite_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"avito/internal/config"
+	"avito/internal/repo"
+	"avito/internal/repo/repotest"
+	sqliteRepo "avito/internal/repo/sqlite"
+	"avito/internal/sqlite"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConformance(t *testing.T) {
+	repotest.Run(t, func(t *testing.T) repo.Repos {
+		db := sqlite.MustInitSqlite(&config.Config{SqlitePath: filepath.Join(t.TempDir(), "avito_test.db")})
+		t.Cleanup(db.Close)
+
+		migrator, err := db.Migrator()
+		require.NoError(t, err)
+
+		defer migrator.Close()
+
+		_, err = migrator.Up(context.Background())
+		require.NoError(t, err)
+
+		return repo.Repos{
+			Team:         sqliteRepo.InitTeamRepo(db),
+			User:         sqliteRepo.InitUserRepo(db),
+			PullRequest:  sqliteRepo.InitPullRequestRepo(db),
+			Stat:         sqliteRepo.InitStatRepo(db),
+			History:      sqliteRepo.InitHistoryRepo(db),
+			Lookup:       sqliteRepo.InitLookupRepo(db),
+			Notification: sqliteRepo.InitNotificationRepo(db),
+			SLA:          sqliteRepo.InitSLARepo(db),
+			Organization: sqliteRepo.InitOrganizationRepo(db),
+			Archive:      sqliteRepo.InitArchiveRepo(db),
+			Erasure:      sqliteRepo.InitErasureRepo(db),
+		}
+	})
+}
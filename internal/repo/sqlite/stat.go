@@ -0,0 +1,156 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+
+	"avito/internal/cerr"
+	"avito/internal/entity"
+	"avito/internal/org"
+	"avito/internal/repo"
+	sqlitedb "avito/internal/sqlite"
+)
+
+type StatRepo struct {
+	db *sqlitedb.Sqlite
+}
+
+func InitStatRepo(db *sqlitedb.Sqlite) repo.Stat {
+	return StatRepo{db: db}
+}
+
+func (r StatRepo) User(ctx context.Context, userID string) (*entity.UserStat, error) {
+	ctx, span := tracer.Start(ctx, "SqliteStatRepo.User")
+	defer span.End()
+
+	var user entity.UserStat
+
+	orgID := org.FromContext(ctx)
+
+	userQuery := `SELECT id, is_active FROM users WHERE org_id = ? AND id = ?`
+
+	err := r.db.DB.QueryRowContext(ctx, userQuery, orgID, userID).Scan(&user.UserId, &user.IsActive)
+	if err != nil {
+		return nil, cerr.HandleSqliteErr(err)
+	}
+
+	query := `SELECT pr.created_at, pr.merged_at FROM pull_requests AS pr
+    INNER JOIN reviewers AS r ON r.org_id = pr.org_id AND pr.id = r.pull_request_id
+    WHERE pr.org_id = ? AND r.reviewer_id = ?`
+
+	rows, err := r.db.DB.QueryContext(ctx, query, orgID, userID)
+	if err != nil {
+		return nil, cerr.HandleSqliteErr(err)
+	}
+	defer rows.Close()
+
+	var cntMerged, duration float64
+
+	for rows.Next() {
+		var createdAt sql.NullTime
+
+		var mergedAt sql.NullTime
+
+		if err = rows.Scan(&createdAt, &mergedAt); err != nil {
+			return nil, cerr.HandleSqliteErr(err)
+		}
+
+		user.CountPr++
+
+		if mergedAt.Valid {
+			duration += mergedAt.Time.Sub(createdAt.Time).Hours()
+			cntMerged++
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, cerr.HandleSqliteErr(err)
+	}
+
+	// The archived reviews are all merged.
+	var archivedCount int
+
+	var archivedHours float64
+
+	archivedQuery := `SELECT COALESCE(SUM(review_count), 0), COALESCE(SUM(merged_hours), 0) FROM archived_review_stats
+    WHERE org_id = ? AND reviewer_id = ?`
+
+	err = r.db.DB.QueryRowContext(ctx, archivedQuery, orgID, userID).Scan(&archivedCount, &archivedHours)
+	if err != nil {
+		return nil, cerr.HandleSqliteErr(err)
+	}
+
+	user.CountPr += archivedCount
+	duration += archivedHours
+	cntMerged += float64(archivedCount)
+
+	if cntMerged != 0 {
+		avg := duration / cntMerged
+		user.AvgDuration = &avg
+	}
+
+	return &user, nil
+}
+
+func (r StatRepo) Team(ctx context.Context, teamName string) (*entity.TeamStat, error) {
+	ctx, span := tracer.Start(ctx, "SqliteStatRepo.Team")
+	defer span.End()
+
+	query := `SELECT id FROM users WHERE org_id = ? AND team_name = ? ORDER BY rowid`
+
+	rows, err := r.db.DB.QueryContext(ctx, query, org.FromContext(ctx), teamName)
+	if err != nil {
+		return nil, cerr.HandleSqliteErr(err)
+	}
+
+	// The ids are read up front: with a single connection the per-user queries below
+	// could not run while these rows are still open.
+	var ids []string
+
+	for rows.Next() {
+		var userID string
+
+		if err = rows.Scan(&userID); err != nil {
+			rows.Close()
+
+			return nil, cerr.HandleSqliteErr(err)
+		}
+
+		ids = append(ids, userID)
+	}
+
+	rows.Close()
+
+	if err = rows.Err(); err != nil {
+		return nil, cerr.HandleSqliteErr(err)
+	}
+
+	users := []entity.UserStat{}
+
+	var cntMerged, duration float64
+
+	for _, userID := range ids {
+		user, err := r.User(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+
+		if user.AvgDuration != nil {
+			duration += *user.AvgDuration
+			cntMerged++
+		}
+
+		users = append(users, *user)
+	}
+
+	avgDuration := -1.0
+	if cntMerged != 0 {
+		avgDuration = duration / cntMerged
+	}
+
+	return &entity.TeamStat{
+		TeamName:    teamName,
+		UsersStat:   users,
+		AvgDuration: avgDuration,
+	}, nil
+}
@@ -0,0 +1,296 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"avito/internal/cerr"
+	"avito/internal/entity"
+	"avito/internal/org"
+	"avito/internal/repo"
+	sqlitedb "avito/internal/sqlite"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var tracer = otel.Tracer("avito/internal/repo/sqlite")
+
+type TeamRepo struct {
+	db *sqlitedb.Sqlite
+}
+
+func InitTeamRepo(db *sqlitedb.Sqlite) repo.Team {
+	return TeamRepo{db: db}
+}
+
+func (r TeamRepo) CheckTeamName(ctx context.Context, teamName string) (bool, error) {
+	ctx, span := tracer.Start(ctx, "SqliteTeamRepo.CheckTeamName")
+	defer span.End()
+
+	var count int
+
+	query := `SELECT COUNT(*) FROM teams WHERE org_id = ? AND name = ?`
+
+	err := r.db.DB.QueryRowContext(ctx, query, org.FromContext(ctx), teamName).Scan(&count)
+	if err != nil {
+		return false, cerr.HandleSqliteErr(err)
+	}
+
+	return count == 0, nil
+}
+
+func (r TeamRepo) Create(ctx context.Context, team *entity.Team) error {
+	ctx, span := tracer.Start(ctx, "SqliteTeamRepo.Create")
+	defer span.End()
+
+	orgID := org.FromContext(ctx)
+
+	tx, err := r.db.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return cerr.HandleSqliteErr(err)
+	}
+	defer rollback(tx)
+
+	teamQuery := `INSERT INTO teams (org_id, name) VALUES (?, ?)`
+
+	if _, err = tx.ExecContext(ctx, teamQuery, orgID, team.TeamName); err != nil {
+		return cerr.HandleSqliteErr(err)
+	}
+
+	userQuery := `INSERT INTO users (org_id, id, username, team_name, is_active) VALUES (?, ?, ?, ?, ?)`
+
+	for _, user := range team.Members {
+		if _, err = tx.ExecContext(ctx, userQuery, orgID, user.UserId, user.Username, team.TeamName, user.IsActive); err != nil {
+			return cerr.HandleSqliteErr(err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return cerr.HandleSqliteErr(err)
+	}
+
+	return nil
+}
+
+func (r TeamRepo) Get(ctx context.Context, teamName string) (*entity.Team, error) {
+	ctx, span := tracer.Start(ctx, "SqliteTeamRepo.Get")
+	defer span.End()
+
+	team := entity.Team{TeamName: teamName}
+
+	query := `SELECT id, username, is_active FROM users WHERE org_id = ? AND team_name = ? ORDER BY rowid`
+
+	rows, err := r.db.DB.QueryContext(ctx, query, org.FromContext(ctx), teamName)
+	if err != nil {
+		return nil, cerr.HandleSqliteErr(err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var member entity.TeamMember
+
+		if err = rows.Scan(&member.UserId, &member.Username, &member.IsActive); err != nil {
+			return nil, cerr.HandleSqliteErr(err)
+		}
+
+		team.Members = append(team.Members, member)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, cerr.HandleSqliteErr(err)
+	}
+
+	return &team, nil
+}
+
+func (r TeamRepo) List(ctx context.Context) ([]entity.Team, error) {
+	ctx, span := tracer.Start(ctx, "SqliteTeamRepo.List")
+	defer span.End()
+
+	query := `SELECT t.name, u.id, u.username, u.is_active FROM teams AS t
+    LEFT JOIN users AS u ON u.org_id = t.org_id AND u.team_name = t.name
+    WHERE t.org_id = ?
+    ORDER BY t.created_at, t.name, u.rowid`
+
+	rows, err := r.db.DB.QueryContext(ctx, query, org.FromContext(ctx))
+	if err != nil {
+		return nil, cerr.HandleSqliteErr(err)
+	}
+	defer rows.Close()
+
+	var teams []entity.Team
+
+	for rows.Next() {
+		var teamName string
+
+		var userID, username sql.NullString
+
+		var isActive sql.NullBool
+
+		if err = rows.Scan(&teamName, &userID, &username, &isActive); err != nil {
+			return nil, cerr.HandleSqliteErr(err)
+		}
+
+		if len(teams) == 0 || teams[len(teams)-1].TeamName != teamName {
+			teams = append(teams, entity.Team{TeamName: teamName})
+		}
+
+		if userID.Valid {
+			teams[len(teams)-1].Members = append(teams[len(teams)-1].Members, entity.TeamMember{
+				IsActive: isActive.Bool,
+				UserId:   userID.String,
+				Username: username.String,
+			})
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, cerr.HandleSqliteErr(err)
+	}
+
+	return teams, nil
+}
+
+func (r TeamRepo) Apply(ctx context.Context, changes []entity.TeamChange, dryRun bool) ([]entity.Reassignment, error) {
+	ctx, span := tracer.Start(ctx, "SqliteTeamRepo.Apply")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int("team.changes", len(changes)), attribute.Bool("team.dry_run", dryRun))
+
+	orgID := org.FromContext(ctx)
+
+	tx, err := r.db.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, cerr.HandleSqliteErr(err)
+	}
+	defer rollback(tx)
+
+	teamQuery := `INSERT INTO teams (org_id, name) VALUES (?, ?) ON CONFLICT DO NOTHING`
+
+	userQuery := `INSERT INTO users (org_id, id, username, team_name, is_active) VALUES (?, ?, ?, ?, ?)
+ON CONFLICT (org_id, id) DO UPDATE SET username = excluded.username, team_name = excluded.team_name, is_active = excluded.is_active`
+
+	removeQuery := `UPDATE users SET is_active = 0 WHERE org_id = ? AND id = ?`
+
+	removeTeamQuery := `DELETE FROM teams WHERE org_id = ? AND name = ?`
+
+	var removed []string
+
+	for _, change := range changes {
+		switch change.Action {
+		case entity.ActionAddTeam:
+			_, err = tx.ExecContext(ctx, teamQuery, orgID, change.TeamName)
+		case entity.ActionRemoveTeam:
+			_, err = tx.ExecContext(ctx, removeTeamQuery, orgID, change.TeamName)
+		case entity.ActionRemoveMember:
+			_, err = tx.ExecContext(ctx, removeQuery, orgID, change.UserId)
+			removed = append(removed, change.UserId)
+		default:
+			_, err = tx.ExecContext(ctx, userQuery, orgID, change.UserId, change.Username, change.TeamName, change.IsActive)
+		}
+
+		if err != nil {
+			return nil, cerr.HandleSqliteErr(err)
+		}
+	}
+
+	var reassignments []entity.Reassignment
+
+	for _, userID := range removed {
+		userReassignments, err := reassignReviews(ctx, tx, orgID, userID)
+		if err != nil {
+			return nil, err
+		}
+
+		reassignments = append(reassignments, userReassignments...)
+	}
+
+	if dryRun {
+		return reassignments, nil
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, cerr.HandleSqliteErr(err)
+	}
+
+	return reassignments, nil
+}
+
+// replacementQuery picks the active teammate of the author with the fewest review
+// assignments, archived ones included, who does not review the pull request yet.
+const replacementQuery = `SELECT u.id
+FROM users AS u
+    LEFT JOIN reviewers AS r ON r.org_id = u.org_id AND u.id = r.reviewer_id
+    LEFT JOIN archived_review_stats AS a ON a.org_id = u.org_id AND a.reviewer_id = u.id
+WHERE u.org_id = ?3 AND u.team_name = (SELECT team_name FROM users WHERE org_id = ?3 AND id = ?1)
+  AND u.id != ?1 AND u.is_active = 1
+  AND u.id NOT IN (SELECT reviewer_id FROM reviewers WHERE org_id = ?3 AND pull_request_id = ?2)
+GROUP BY u.id
+ORDER BY COUNT(r.reviewer_id) + COALESCE(MAX(a.review_count), 0), u.rowid
+LIMIT 1`
+
+func reassignReviews(ctx context.Context, tx *sql.Tx, orgID string, userID string) ([]entity.Reassignment, error) {
+	reviewsQuery := `SELECT pr.id, pr.author_id FROM pull_requests AS pr
+    INNER JOIN reviewers AS r ON r.org_id = pr.org_id AND r.pull_request_id = pr.id
+    WHERE pr.org_id = ? AND r.reviewer_id = ? AND pr.merged_at IS NULL
+    ORDER BY pr.created_at, pr.id`
+
+	rows, err := tx.QueryContext(ctx, reviewsQuery, orgID, userID)
+	if err != nil {
+		return nil, cerr.HandleSqliteErr(err)
+	}
+
+	// The reviews are read up front: with a single connection the updates below could
+	// not run while these rows are still open.
+	var prIDs, authorIDs []string
+
+	for rows.Next() {
+		var prID, authorID string
+
+		if err = rows.Scan(&prID, &authorID); err != nil {
+			rows.Close()
+
+			return nil, cerr.HandleSqliteErr(err)
+		}
+
+		prIDs = append(prIDs, prID)
+		authorIDs = append(authorIDs, authorID)
+	}
+
+	rows.Close()
+
+	if err = rows.Err(); err != nil {
+		return nil, cerr.HandleSqliteErr(err)
+	}
+
+	reassignments := make([]entity.Reassignment, 0, len(prIDs))
+
+	for i, prID := range prIDs {
+		reassignment := entity.Reassignment{PullRequestId: prID, OldReviewerId: userID}
+
+		var newUserID string
+
+		err = tx.QueryRowContext(ctx, replacementQuery, authorIDs[i], prID, orgID).Scan(&newUserID)
+
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			_, err = tx.ExecContext(ctx, `DELETE FROM reviewers WHERE org_id = ? AND pull_request_id = ? AND reviewer_id = ?`,
+				orgID, prID, userID)
+		case err == nil:
+			reassignment.NewReviewerId = &newUserID
+			_, err = tx.ExecContext(ctx, `UPDATE reviewers SET reviewer_id = ?, assigned_at = ?, reminded_at = NULL, escalated_at = NULL
+    WHERE org_id = ? AND pull_request_id = ? AND reviewer_id = ?`,
+				newUserID, time.Now().UTC().Truncate(time.Microsecond), orgID, prID, userID)
+		}
+
+		if err != nil {
+			return nil, cerr.HandleSqliteErr(err)
+		}
+
+		reassignments = append(reassignments, reassignment)
+	}
+
+	return reassignments, nil
+}
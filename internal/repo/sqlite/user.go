@@ -0,0 +1,83 @@
+package sqlite
+
+import (
+	"context"
+
+	"avito/internal/cerr"
+	"avito/internal/entity"
+	"avito/internal/org"
+	"avito/internal/repo"
+	sqlitedb "avito/internal/sqlite"
+)
+
+type UserRepo struct {
+	db *sqlitedb.Sqlite
+}
+
+func InitUserRepo(db *sqlitedb.Sqlite) repo.User {
+	return UserRepo{db: db}
+}
+
+func (r UserRepo) SetIsActive(ctx context.Context, userID string, isActive bool) (*entity.User, error) {
+	ctx, span := tracer.Start(ctx, "SqliteUserRepo.SetIsActive")
+	defer span.End()
+
+	var user entity.User
+
+	query := `UPDATE users SET is_active = ? WHERE org_id = ? AND id = ? RETURNING id, username, team_name, is_active`
+
+	err := r.db.DB.QueryRowContext(ctx, query, isActive, org.FromContext(ctx), userID).Scan(&user.UserId, &user.Username, &user.TeamName, &user.IsActive)
+	if err != nil {
+		return nil, cerr.HandleSqliteErr(err)
+	}
+
+	return &user, nil
+}
+
+func (r UserRepo) GetReview(ctx context.Context, userID string) ([]entity.PullRequestShort, error) {
+	ctx, span := tracer.Start(ctx, "SqliteUserRepo.GetReview")
+	defer span.End()
+
+	var count int
+
+	orgID := org.FromContext(ctx)
+
+	checkQuery := `SELECT COUNT(*) FROM users WHERE org_id = ? AND id = ?`
+
+	if err := r.db.DB.QueryRowContext(ctx, checkQuery, orgID, userID).Scan(&count); err != nil {
+		return nil, cerr.HandleSqliteErr(err)
+	}
+
+	if count == 0 {
+		return nil, cerr.CustomError{Err: errNoUser, ErrType: cerr.NOT_FOUND}
+	}
+
+	query := `SELECT pr.author_id, pr.id, pr.name, pr.status FROM pull_requests AS pr
+    INNER JOIN reviewers AS r ON r.org_id = pr.org_id AND pr.id = r.pull_request_id
+    WHERE pr.org_id = ? AND r.reviewer_id = ?
+    ORDER BY r.rowid`
+
+	rows, err := r.db.DB.QueryContext(ctx, query, orgID, userID)
+	if err != nil {
+		return nil, cerr.HandleSqliteErr(err)
+	}
+	defer rows.Close()
+
+	var prs []entity.PullRequestShort
+
+	for rows.Next() {
+		var pr entity.PullRequestShort
+
+		if err = rows.Scan(&pr.AuthorId, &pr.PullRequestId, &pr.PullRequestName, &pr.Status); err != nil {
+			return nil, cerr.HandleSqliteErr(err)
+		}
+
+		prs = append(prs, pr)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, cerr.HandleSqliteErr(err)
+	}
+
+	return prs, nil
+}
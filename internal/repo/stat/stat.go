@@ -6,10 +6,14 @@ import (
 
 	"avito/internal/cerr"
 	"avito/internal/entity"
+	"avito/internal/org"
 	"avito/internal/postgres"
 	"avito/internal/repo"
+	"go.opentelemetry.io/otel"
 )
 
+var tracer = otel.Tracer("avito/internal/repo/stat")
+
 type Repo struct {
 	db *postgres.Pg
 }
@@ -19,18 +23,23 @@ func InitStatRepo(db *postgres.Pg) repo.Stat {
 }
 
 func (r Repo) User(ctx context.Context, userID string) (*entity.UserStat, error) {
+	ctx, span := tracer.Start(ctx, "StatRepo.User")
+	defer span.End()
+
 	var user entity.UserStat
 
 	var cntMerged, duration float64
 
 	var creatAt, mergedAt *time.Time
 
+	orgID := org.FromContext(ctx)
+
 	query := `SELECT pr.create_at, pr.merged_at, u.is_active, u.id FROM pull_requests as pr
-    INNER JOIN reviewers AS r on pr.id = r.pull_request_id
-    INNER JOIN users AS u on u.id = r.reviewer_id
-    WHERE r.reviewer_id = $1`
+    INNER JOIN reviewers AS r on r.org_id = pr.org_id AND pr.id = r.pull_request_id
+    INNER JOIN users AS u on u.org_id = r.org_id AND u.id = r.reviewer_id
+    WHERE pr.org_id = $1 AND r.reviewer_id = $2`
 
-	rows, err := r.db.Pool.Query(ctx, query, userID)
+	rows, err := r.db.Pool.Query(ctx, query, orgID, userID)
 	if err != nil {
 		return nil, cerr.HandlePgErr(err)
 	}
@@ -51,14 +60,31 @@ func (r Repo) User(ctx context.Context, userID string) (*entity.UserStat, error)
 	}
 
 	if user.UserId == "" {
-		query = `SELECT is_active, id from users where id = $1`
+		query = `SELECT is_active, id from users where org_id = $1 AND id = $2`
 
-		err = r.db.Pool.QueryRow(ctx, query, userID).Scan(&user.IsActive, &user.UserId)
+		err = r.db.Pool.QueryRow(ctx, query, orgID, userID).Scan(&user.IsActive, &user.UserId)
 		if err != nil {
 			return nil, cerr.HandlePgErr(err)
 		}
 	}
 
+	// The archived reviews are all merged.
+	var archivedCount int
+
+	var archivedHours float64
+
+	query = `SELECT COALESCE(SUM(review_count), 0), COALESCE(SUM(merged_hours), 0) FROM archived_review_stats
+    WHERE org_id = $1 AND reviewer_id = $2`
+
+	err = r.db.Pool.QueryRow(ctx, query, orgID, userID).Scan(&archivedCount, &archivedHours)
+	if err != nil {
+		return nil, cerr.HandlePgErr(err)
+	}
+
+	user.CountPr += archivedCount
+	duration += archivedHours
+	cntMerged += float64(archivedCount)
+
 	if cntMerged != 0 {
 		avg := duration / cntMerged
 		user.AvgDuration = &avg
@@ -68,15 +94,18 @@ func (r Repo) User(ctx context.Context, userID string) (*entity.UserStat, error)
 }
 
 func (r Repo) Team(ctx context.Context, teamName string) (*entity.TeamStat, error) {
+	ctx, span := tracer.Start(ctx, "StatRepo.Team")
+	defer span.End()
+
 	users := []entity.UserStat{}
 
 	var userID string
 
 	var cntMerged, duration, avgDuration float64
 
-	query := `SELECT id FROM users WHERE team_name = $1`
+	query := `SELECT id FROM users WHERE org_id = $1 AND team_name = $2`
 
-	rows, err := r.db.Pool.Query(ctx, query, teamName)
+	rows, err := r.db.Pool.Query(ctx, query, org.FromContext(ctx), teamName)
 	if err != nil {
 		return nil, cerr.HandlePgErr(err)
 	}
@@ -0,0 +1,121 @@
+package team
+
+import (
+	"context"
+
+	"avito/internal/cerr"
+	"avito/internal/entity"
+	"avito/internal/log"
+	"avito/internal/org"
+	"avito/internal/postgres"
+	"avito/internal/repo"
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// ErasureRepo lives with the teams, an erased user leaves like a removed member.
+type ErasureRepo struct {
+	db *postgres.Pg
+}
+
+func InitErasureRepo(db *postgres.Pg) repo.Erasure {
+	return ErasureRepo{db: db}
+}
+
+func (r ErasureRepo) Erase(ctx context.Context, userID string, pseudonym string) (*entity.UserErasure, []entity.Reassignment, error) {
+	ctx, span := tracer.Start(ctx, "ErasureRepo.Erase")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user.pseudonym", pseudonym))
+
+	tx, err := r.db.Pool.Begin(ctx)
+	if err != nil {
+		return nil, nil, cerr.HandlePgErr(err)
+	}
+
+	erasure, reassignments, err := erase(ctx, tx, userID, pseudonym)
+	if err != nil {
+		if txErr := tx.Rollback(ctx); txErr != nil {
+			return nil, nil, cerr.HandlePgErr(txErr)
+		}
+
+		return nil, nil, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, nil, cerr.HandlePgErr(err)
+	}
+
+	log.Ctx(ctx).Debug("User erased: " + pseudonym)
+
+	return erasure, reassignments, nil
+}
+
+// erase renames the user first, the foreign keys carry the new id to the pull requests,
+// the reviews, the archive and the SLAs. The reviews are then handed off under the
+// pseudonym, so nothing returned names the user.
+func erase(ctx context.Context, tx pgx.Tx, userID string, pseudonym string) (*entity.UserErasure, []entity.Reassignment, error) {
+	orgID := org.FromContext(ctx)
+
+	erasure := entity.UserErasure{Pseudonym: pseudonym}
+
+	userQuery := `UPDATE users SET id = $3, username = $3, email = NULL, is_active = false
+WHERE org_id = $1 AND id = $2
+RETURNING team_name`
+
+	err := tx.QueryRow(ctx, userQuery, orgID, userID, pseudonym).Scan(&erasure.TeamName)
+	if err != nil {
+		return nil, nil, cerr.HandlePgErr(err)
+	}
+
+	leadQuery := `UPDATE team_slas SET lead_id = NULL WHERE org_id = $1 AND lead_id = $2`
+
+	if _, err = tx.Exec(ctx, leadQuery, orgID, pseudonym); err != nil {
+		return nil, nil, cerr.HandlePgErr(err)
+	}
+
+	reassignments, err := reassignReviews(ctx, tx, orgID, pseudonym)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	erasure.ReassignedReviews = len(reassignments)
+
+	auditQuery := `INSERT INTO user_erasures (org_id, pseudonym, team_name, reassigned_reviews) VALUES ($1, $2, $3, $4)
+RETURNING erased_at`
+
+	err = tx.QueryRow(ctx, auditQuery, orgID, pseudonym, erasure.TeamName, erasure.ReassignedReviews).Scan(&erasure.ErasedAt)
+	if err != nil {
+		return nil, nil, cerr.HandlePgErr(err)
+	}
+
+	return &erasure, reassignments, nil
+}
+
+func (r ErasureRepo) List(ctx context.Context) ([]entity.UserErasure, error) {
+	ctx, span := tracer.Start(ctx, "ErasureRepo.List")
+	defer span.End()
+
+	query := `SELECT pseudonym, team_name, erased_at, reassigned_reviews FROM user_erasures
+WHERE org_id = $1
+ORDER BY erased_at, pseudonym`
+
+	rows, err := r.db.Pool.Query(ctx, query, org.FromContext(ctx))
+	if err != nil {
+		return nil, cerr.HandlePgErr(err)
+	}
+
+	erasures, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (entity.UserErasure, error) {
+		var erasure entity.UserErasure
+
+		err := row.Scan(&erasure.Pseudonym, &erasure.TeamName, &erasure.ErasedAt, &erasure.ReassignedReviews)
+
+		return erasure, err
+	})
+	if err != nil {
+		return nil, cerr.HandlePgErr(err)
+	}
+
+	return erasures, nil
+}
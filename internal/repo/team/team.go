@@ -2,13 +2,22 @@ package team
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
 	"avito/internal/cerr"
 	"avito/internal/entity"
+	"avito/internal/log"
+	"avito/internal/org"
 	"avito/internal/postgres"
 	"avito/internal/repo"
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
 )
 
+var tracer = otel.Tracer("avito/internal/repo/team")
+
 type Repo struct {
 	db *postgres.Pg
 }
@@ -18,11 +27,14 @@ func InitTeamRepo(db *postgres.Pg) repo.Team {
 }
 
 func (r Repo) CheckTeamName(ctx context.Context, teamName string) (bool, error) {
+	ctx, span := tracer.Start(ctx, "TeamRepo.CheckTeamName")
+	defer span.End()
+
 	var count int
 
-	query := `SELECT COUNT(*) FROM users WHERE team_name = $1`
+	query := `SELECT COUNT(*) FROM teams WHERE org_id = $1 AND name = $2`
 
-	err := r.db.Pool.QueryRow(ctx, query, teamName).Scan(&count)
+	err := r.db.Pool.QueryRow(ctx, query, org.FromContext(ctx), teamName).Scan(&count)
 	if err != nil {
 		return false, cerr.HandlePgErr(err)
 	}
@@ -31,14 +43,30 @@ func (r Repo) CheckTeamName(ctx context.Context, teamName string) (bool, error)
 }
 
 func (r Repo) Create(ctx context.Context, team *entity.Team) error {
+	ctx, span := tracer.Start(ctx, "TeamRepo.Create")
+	defer span.End()
+
+	orgID := org.FromContext(ctx)
+
 	tx, err := r.db.Pool.Begin(ctx)
 	if err != nil {
 		return cerr.HandlePgErr(err)
 	}
 
-	userQuery := `INSERT INTO users (id, username, team_name, is_active) VALUES ($1, $2, $3, $4)`
+	teamQuery := `INSERT INTO teams (org_id, name) VALUES ($1, $2)`
+
+	_, err = tx.Exec(ctx, teamQuery, orgID, team.TeamName)
+	if err != nil {
+		if txErr := tx.Rollback(ctx); txErr != nil {
+			return cerr.HandlePgErr(txErr)
+		}
+
+		return cerr.HandlePgErr(err)
+	}
+
+	userQuery := `INSERT INTO users (org_id, id, username, team_name, is_active) VALUES ($1, $2, $3, $4, $5)`
 	for _, user := range team.Members {
-		_, err = tx.Exec(ctx, userQuery, user.UserId, user.Username, team.TeamName, user.IsActive)
+		_, err = tx.Exec(ctx, userQuery, orgID, user.UserId, user.Username, team.TeamName, user.IsActive)
 		if err != nil {
 			if txErr := tx.Rollback(ctx); txErr != nil {
 				return cerr.HandlePgErr(txErr)
@@ -53,18 +81,23 @@ func (r Repo) Create(ctx context.Context, team *entity.Team) error {
 		return cerr.HandlePgErr(err)
 	}
 
+	log.Ctx(ctx).Debug(fmt.Sprintf("Team %v created with %d members", team.TeamName, len(team.Members)))
+
 	return nil
 }
 
 func (r Repo) Get(ctx context.Context, teamName string) (*entity.Team, error) {
+	ctx, span := tracer.Start(ctx, "TeamRepo.Get")
+	defer span.End()
+
 	var team entity.Team
 	team.TeamName = teamName
 
 	var member entity.TeamMember
 
-	query := `SELECT id, username, is_active FROM users WHERE team_name = $1`
+	query := `SELECT id, username, is_active FROM users WHERE org_id = $1 AND team_name = $2`
 
-	rows, err := r.db.Pool.Query(ctx, query, teamName)
+	rows, err := r.db.Pool.Query(ctx, query, org.FromContext(ctx), teamName)
 	if err != nil {
 		return nil, cerr.HandlePgErr(err)
 	}
@@ -86,3 +119,201 @@ func (r Repo) Get(ctx context.Context, teamName string) (*entity.Team, error) {
 
 	return &team, nil
 }
+
+func (r Repo) List(ctx context.Context) ([]entity.Team, error) {
+	ctx, span := tracer.Start(ctx, "TeamRepo.List")
+	defer span.End()
+
+	query := `SELECT t.name, u.id, u.username, u.is_active FROM teams AS t
+    LEFT JOIN users AS u ON u.org_id = t.org_id AND u.team_name = t.name
+    WHERE t.org_id = $1
+    ORDER BY t.created_at, t.name, u.id`
+
+	rows, err := r.db.Pool.Query(ctx, query, org.FromContext(ctx))
+	if err != nil {
+		return nil, cerr.HandlePgErr(err)
+	}
+
+	defer rows.Close()
+
+	var teams []entity.Team
+
+	for rows.Next() {
+		var teamName string
+
+		var userID, username *string
+
+		var isActive *bool
+
+		if err = rows.Scan(&teamName, &userID, &username, &isActive); err != nil {
+			return nil, cerr.HandlePgErr(err)
+		}
+
+		if len(teams) == 0 || teams[len(teams)-1].TeamName != teamName {
+			teams = append(teams, entity.Team{TeamName: teamName})
+		}
+
+		if userID != nil {
+			teams[len(teams)-1].Members = append(teams[len(teams)-1].Members, entity.TeamMember{
+				IsActive: *isActive,
+				UserId:   *userID,
+				Username: *username,
+			})
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, cerr.HandlePgErr(err)
+	}
+
+	return teams, nil
+}
+
+func (r Repo) Apply(ctx context.Context, changes []entity.TeamChange, dryRun bool) ([]entity.Reassignment, error) {
+	ctx, span := tracer.Start(ctx, "TeamRepo.Apply")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int("team.changes", len(changes)), attribute.Bool("team.dry_run", dryRun))
+
+	tx, err := r.db.Pool.Begin(ctx)
+	if err != nil {
+		return nil, cerr.HandlePgErr(err)
+	}
+
+	reassignments, err := apply(ctx, tx, changes)
+	if err != nil || dryRun {
+		if txErr := tx.Rollback(ctx); txErr != nil {
+			return nil, cerr.HandlePgErr(txErr)
+		}
+
+		return reassignments, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, cerr.HandlePgErr(err)
+	}
+
+	log.Ctx(ctx).Debug(fmt.Sprintf("Teams applied: %d changes, %d reassignments", len(changes), len(reassignments)))
+
+	return reassignments, nil
+}
+
+// apply runs the changes in order. The service puts removals last, so members added by
+// the same plan can already take over the reviews.
+func apply(ctx context.Context, tx pgx.Tx, changes []entity.TeamChange) ([]entity.Reassignment, error) {
+	orgID := org.FromContext(ctx)
+
+	teamQuery := `INSERT INTO teams (org_id, name) VALUES ($1, $2) ON CONFLICT DO NOTHING`
+
+	userQuery := `INSERT INTO users (org_id, id, username, team_name, is_active) VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (org_id, id) DO UPDATE SET username = excluded.username, team_name = excluded.team_name, is_active = excluded.is_active`
+
+	removeQuery := `UPDATE users SET is_active = false WHERE org_id = $1 AND id = $2`
+
+	removeTeamQuery := `DELETE FROM teams WHERE org_id = $1 AND name = $2`
+
+	var removed []string
+
+	for _, change := range changes {
+		var err error
+
+		switch change.Action {
+		case entity.ActionAddTeam:
+			_, err = tx.Exec(ctx, teamQuery, orgID, change.TeamName)
+		case entity.ActionRemoveTeam:
+			_, err = tx.Exec(ctx, removeTeamQuery, orgID, change.TeamName)
+		case entity.ActionRemoveMember:
+			_, err = tx.Exec(ctx, removeQuery, orgID, change.UserId)
+			removed = append(removed, change.UserId)
+		default:
+			_, err = tx.Exec(ctx, userQuery, orgID, change.UserId, change.Username, change.TeamName, change.IsActive)
+		}
+
+		if err != nil {
+			return nil, cerr.HandlePgErr(err)
+		}
+	}
+
+	var reassignments []entity.Reassignment
+
+	for _, userID := range removed {
+		userReassignments, err := reassignReviews(ctx, tx, orgID, userID)
+		if err != nil {
+			return nil, err
+		}
+
+		reassignments = append(reassignments, userReassignments...)
+	}
+
+	return reassignments, nil
+}
+
+// replacementQuery picks the active teammate of the author with the fewest review
+// assignments, archived ones included, who does not review the pull request yet.
+const replacementQuery = `SELECT u.id
+FROM users AS u
+    LEFT JOIN reviewers AS r ON r.org_id = u.org_id AND u.id = r.reviewer_id
+    LEFT JOIN archived_review_stats AS a ON a.org_id = u.org_id AND a.reviewer_id = u.id
+WHERE u.org_id = $1 AND u.team_name = (SELECT team_name FROM users WHERE org_id = $1 AND id = $2)
+  AND u.id != $2 AND u.is_active = true
+  AND u.id NOT IN (SELECT reviewer_id FROM reviewers WHERE org_id = $1 AND pull_request_id = $3)
+GROUP BY u.id
+ORDER BY COUNT(r.reviewer_id) + COALESCE(MAX(a.review_count), 0), u.id
+LIMIT 1`
+
+func reassignReviews(ctx context.Context, tx pgx.Tx, orgID string, userID string) ([]entity.Reassignment, error) {
+	reviewsQuery := `SELECT pr.id, pr.author_id FROM pull_requests AS pr
+    INNER JOIN reviewers AS r ON r.org_id = pr.org_id AND r.pull_request_id = pr.id
+    WHERE pr.org_id = $1 AND r.reviewer_id = $2 AND pr.merged_at IS NULL
+    ORDER BY pr.create_at, pr.id`
+
+	rows, err := tx.Query(ctx, reviewsQuery, orgID, userID)
+	if err != nil {
+		return nil, cerr.HandlePgErr(err)
+	}
+
+	type review struct {
+		pullRequestID string
+		authorID      string
+	}
+
+	reviews, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (review, error) {
+		var rev review
+
+		err := row.Scan(&rev.pullRequestID, &rev.authorID)
+
+		return rev, err
+	})
+	if err != nil {
+		return nil, cerr.HandlePgErr(err)
+	}
+
+	reassignments := make([]entity.Reassignment, 0, len(reviews))
+
+	for _, rev := range reviews {
+		reassignment := entity.Reassignment{PullRequestId: rev.pullRequestID, OldReviewerId: userID}
+
+		var newUserID string
+
+		err = tx.QueryRow(ctx, replacementQuery, orgID, rev.authorID, rev.pullRequestID).Scan(&newUserID)
+
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+			_, err = tx.Exec(ctx, `DELETE FROM reviewers WHERE org_id = $1 AND pull_request_id = $2 AND reviewer_id = $3`,
+				orgID, rev.pullRequestID, userID)
+		case err == nil:
+			reassignment.NewReviewerId = &newUserID
+			_, err = tx.Exec(ctx, `UPDATE reviewers SET reviewer_id = $1, assigned_at = now(), reminded_at = NULL, escalated_at = NULL
+    WHERE org_id = $2 AND pull_request_id = $3 AND reviewer_id = $4`, newUserID, orgID, rev.pullRequestID, userID)
+		}
+
+		if err != nil {
+			return nil, cerr.HandlePgErr(err)
+		}
+
+		reassignments = append(reassignments, reassignment)
+	}
+
+	return reassignments, nil
+}
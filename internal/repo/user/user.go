@@ -5,10 +5,14 @@ import (
 
 	"avito/internal/cerr"
 	"avito/internal/entity"
+	"avito/internal/org"
 	"avito/internal/postgres"
 	"avito/internal/repo"
+	"go.opentelemetry.io/otel"
 )
 
+var tracer = otel.Tracer("avito/internal/repo/user")
+
 type Repo struct {
 	db *postgres.Pg
 }
@@ -18,16 +22,21 @@ func InitUserRepo(db *postgres.Pg) repo.User {
 }
 
 func (r Repo) SetIsActive(ctx context.Context, userID string, isActive bool) (*entity.User, error) {
+	ctx, span := tracer.Start(ctx, "UserRepo.SetIsActive")
+	defer span.End()
+
 	var user entity.User
 
+	orgID := org.FromContext(ctx)
+
 	tx, err := r.db.Pool.Begin(ctx)
 	if err != nil {
 		return nil, cerr.HandlePgErr(err)
 	}
 
-	updateQuery := `UPDATE users SET is_active = $1 WHERE id = $2`
+	updateQuery := `UPDATE users SET is_active = $1 WHERE org_id = $2 AND id = $3`
 
-	_, err = tx.Exec(ctx, updateQuery, isActive, userID)
+	_, err = tx.Exec(ctx, updateQuery, isActive, orgID, userID)
 	if err != nil {
 		if txErr := tx.Rollback(ctx); txErr != nil {
 			return nil, cerr.HandlePgErr(txErr)
@@ -36,9 +45,9 @@ func (r Repo) SetIsActive(ctx context.Context, userID string, isActive bool) (*e
 		return nil, cerr.HandlePgErr(err)
 	}
 
-	selectQuery := `SELECT id, username, team_name, is_active FROM users WHERE id = $1`
+	selectQuery := `SELECT id, username, team_name, is_active FROM users WHERE org_id = $1 AND id = $2`
 
-	err = tx.QueryRow(ctx, selectQuery, userID).Scan(&user.UserId, &user.Username, &user.TeamName, &user.IsActive)
+	err = tx.QueryRow(ctx, selectQuery, orgID, userID).Scan(&user.UserId, &user.Username, &user.TeamName, &user.IsActive)
 	if err != nil {
 		if txErr := tx.Rollback(ctx); txErr != nil {
 			return nil, cerr.HandlePgErr(txErr)
@@ -56,15 +65,20 @@ func (r Repo) SetIsActive(ctx context.Context, userID string, isActive bool) (*e
 }
 
 func (r Repo) GetReview(ctx context.Context, userID string) ([]entity.PullRequestShort, error) {
+	ctx, span := tracer.Start(ctx, "UserRepo.GetReview")
+	defer span.End()
+
 	var prs []entity.PullRequestShort
 
 	var pr entity.PullRequestShort
 
 	var count int
 
-	checkQuery := `SELECT COUNT(*) FROM users WHERE id = $1`
+	orgID := org.FromContext(ctx)
+
+	checkQuery := `SELECT COUNT(*) FROM users WHERE org_id = $1 AND id = $2`
 
-	err := r.db.Pool.QueryRow(ctx, checkQuery, userID).Scan(&count)
+	err := r.db.Pool.QueryRow(ctx, checkQuery, orgID, userID).Scan(&count)
 	if err != nil {
 		return nil, cerr.HandlePgErr(err)
 	}
@@ -77,11 +91,11 @@ func (r Repo) GetReview(ctx context.Context, userID string) ([]entity.PullReques
 	}
 
 	query := `SELECT pr.author_id, pr.id, pr.name, s.name FROM pull_requests as pr
-    INNER JOIN reviewers AS r on pr.id = r.pull_request_id
+    INNER JOIN reviewers AS r on r.org_id = pr.org_id AND pr.id = r.pull_request_id
     INNER JOIN statuses s on s.id = pr.status_id
-    WHERE r.reviewer_id = $1`
+    WHERE pr.org_id = $1 AND r.reviewer_id = $2`
 
-	rows, err := r.db.Pool.Query(ctx, query, userID)
+	rows, err := r.db.Pool.Query(ctx, query, orgID, userID)
 	if err != nil {
 		return nil, cerr.HandlePgErr(err)
 	}
@@ -0,0 +1,107 @@
+// Package retention keeps the tables the API works on small. Pull requests merged longer
+// than the retention period ago are moved to the archive, the statistics keep counting
+// their reviews and an archived pull request can be restored by id.
+package retention
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"avito/internal/health"
+	"avito/internal/log"
+	"avito/internal/org"
+	"avito/internal/repo"
+	"avito/internal/tracing"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var tracer = otel.Tracer("avito/internal/retention")
+
+// batchSize bounds the pull requests moved in one transaction, so the job does not hold
+// long locks after it has been off for a while.
+const batchSize = 500
+
+type Archiver struct {
+	repo  repo.Archive
+	after time.Duration
+}
+
+// InitArchiver returns an archiver for the pull requests merged more than after ago.
+func InitArchiver(repo repo.Archive, after time.Duration) *Archiver {
+	return &Archiver{repo: repo, after: after}
+}
+
+// Archive moves the pull requests of the organization of ctx that are due at now and
+// returns how many it moved.
+func (a *Archiver) Archive(ctx context.Context, now time.Time) (int, error) {
+	ctx, span := tracer.Start(ctx, "Archiver.Archive")
+	defer span.End()
+
+	mergedBefore := now.Add(-a.after)
+
+	total := 0
+
+	for {
+		moved, err := a.repo.Archive(ctx, mergedBefore, batchSize)
+		total += moved
+
+		if err != nil {
+			tracing.RecordError(span, err)
+
+			return total, fmt.Errorf("archive pull requests merged before %v: %w", mergedBefore.Format(time.RFC3339), err)
+		}
+
+		if moved < batchSize {
+			break
+		}
+	}
+
+	span.SetAttributes(attribute.Int("archive.moved", total))
+
+	return total, nil
+}
+
+// Run returns the worker that archives the pull requests of every organization every
+// interval.
+func (a *Archiver) Run(orgs repo.Organization, interval time.Duration) func(ctx context.Context) {
+	return func(ctx context.Context) {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		beat := time.NewTicker(health.BeatInterval)
+		defer beat.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-beat.C:
+				health.Beat(ctx)
+			case now := <-ticker.C:
+				health.Beat(ctx)
+
+				orgIDs, err := orgs.List(ctx)
+				if err != nil {
+					log.Log.Error(fmt.Errorf("archive organizations: %w", err))
+
+					continue
+				}
+
+				for _, orgID := range orgIDs {
+					health.Beat(ctx)
+
+					moved, err := a.Archive(org.With(ctx, orgID), now)
+					if err != nil {
+						log.Log.Error(fmt.Errorf("archive of %v: %w", orgID, err))
+					}
+
+					if moved > 0 {
+						log.Log.Info(fmt.Sprintf("%d pull requests of %v archived", moved, orgID))
+					}
+				}
+			}
+		}
+	}
+}
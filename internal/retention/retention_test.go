@@ -0,0 +1,67 @@
+package retention_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"avito/internal/entity"
+	"avito/internal/org"
+	"avito/internal/repo/memory"
+	"avito/internal/retention"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArchive(t *testing.T) {
+	store := memory.InitStore()
+	teams := memory.InitTeamRepo(store)
+	prs := memory.InitPullRequestRepo(store)
+	stats := memory.InitStatRepo(store)
+	archive := memory.InitArchiveRepo(store)
+
+	acme := org.With(context.Background(), "acme")
+
+	for _, ctx := range []context.Context{context.Background(), acme} {
+		require.NoError(t, teams.Create(ctx, &entity.Team{TeamName: "backend", Members: []entity.TeamMember{
+			{UserId: "author", Username: "Author", IsActive: true},
+			{UserId: "u1", Username: "U1", IsActive: true},
+		}}))
+
+		for _, id := range []string{"pr-1", "pr-2", "pr-3"} {
+			_, err := prs.Create(ctx, &entity.PullRequestCreate{PullRequestId: id, PullRequestName: id, AuthorId: "author"})
+			require.NoError(t, err)
+		}
+
+		for _, id := range []string{"pr-1", "pr-2"} {
+			_, err := prs.Merge(ctx, id)
+			require.NoError(t, err)
+		}
+	}
+
+	ctx := context.Background()
+
+	before, err := stats.User(ctx, "u1")
+	require.NoError(t, err)
+
+	archiver := retention.InitArchiver(archive, 30*24*time.Hour)
+
+	moved, err := archiver.Archive(ctx, time.Now())
+	require.NoError(t, err)
+	assert.Zero(t, moved, "merged just now")
+
+	moved, err = archiver.Archive(ctx, time.Now().Add(31*24*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 2, moved)
+
+	after, err := stats.User(ctx, "u1")
+	require.NoError(t, err)
+	assert.Equal(t, before.CountPr, after.CountPr)
+	require.NotNil(t, after.AvgDuration)
+	assert.InDelta(t, *before.AvgDuration, *after.AvgDuration, 1e-9)
+
+	// The other organization is archived on its own.
+	moved, err = archiver.Archive(acme, time.Now().Add(31*24*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 2, moved)
+}
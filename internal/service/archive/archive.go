@@ -0,0 +1,42 @@
+package archive
+
+import (
+	"context"
+
+	"avito/internal/entity"
+	"avito/internal/log"
+	"avito/internal/repo"
+	"avito/internal/service"
+	"avito/internal/tracing"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var tracer = otel.Tracer("avito/internal/service/archive")
+
+type Serv struct {
+	Repo repo.Archive
+}
+
+func InitArchiveServ(repo repo.Archive) service.Archive {
+	return Serv{Repo: repo}
+}
+
+func (s Serv) Restore(ctx context.Context, pullRequestID string) (*entity.PullRequest, error) {
+	ctx, span := tracer.Start(ctx, "ArchiveServ.Restore")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("pull_request.id", pullRequestID))
+
+	log.AddField(ctx, "pull_request_id", pullRequestID)
+
+	pullRequest, err := s.Repo.Restore(ctx, pullRequestID)
+	if err != nil {
+		tracing.RecordError(span, err)
+		log.Ctx(ctx).Error(err)
+
+		return nil, err
+	}
+
+	return pullRequest, nil
+}
@@ -0,0 +1,495 @@
+package directory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"avito/internal/cerr"
+	"avito/internal/entity"
+	"avito/internal/gen"
+	"avito/internal/log"
+	"avito/internal/repo"
+	"avito/internal/service"
+	"avito/internal/tracing"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("avito/internal/service/directory")
+
+// Serv reads the whole directory through Team.List and writes through Team.Apply, so
+// every change is one transaction and removals reassign reviews like the team sync.
+// Activity goes through the user service, the same path as /users/setIsActive.
+type Serv struct {
+	Repo        repo.Team
+	UserServ    service.User
+	DefaultTeam string
+}
+
+func InitDirectoryServ(repo repo.Team, user service.User, defaultTeam string) service.Directory {
+	return Serv{Repo: repo, UserServ: user, DefaultTeam: defaultTeam}
+}
+
+func (s Serv) Users(ctx context.Context) ([]entity.User, error) {
+	ctx, span := tracer.Start(ctx, "DirectoryServ.Users")
+	defer span.End()
+
+	_, users, err := s.load(ctx)
+	if err != nil {
+		tracing.RecordError(span, err)
+		log.Ctx(ctx).Error(err)
+
+		return nil, err
+	}
+
+	return users, nil
+}
+
+func (s Serv) User(ctx context.Context, userID string) (*entity.User, error) {
+	ctx, span := tracer.Start(ctx, "DirectoryServ.User")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user.id", userID))
+
+	log.AddField(ctx, "user_id", userID)
+
+	user, err := s.user(ctx, userID)
+	if err != nil {
+		tracing.RecordError(span, err)
+		log.Ctx(ctx).Error(err)
+
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// CreateUser puts the user in its team, or the default team when it has none.
+func (s Serv) CreateUser(ctx context.Context, user *entity.User) (*entity.User, error) {
+	ctx, span := tracer.Start(ctx, "DirectoryServ.CreateUser")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user.id", user.UserId))
+
+	log.AddField(ctx, "user_id", user.UserId)
+
+	_, users, err := s.load(ctx)
+	if err != nil {
+		tracing.RecordError(span, err)
+		log.Ctx(ctx).Error(err)
+
+		return nil, err
+	}
+
+	if slices.ContainsFunc(users, func(u entity.User) bool { return u.UserId == user.UserId }) {
+		err = cerr.CustomError{Err: err, ErrType: cerr.USER_EXISTS}
+		tracing.RecordError(span, err)
+		log.Ctx(ctx).Error(err)
+
+		return nil, err
+	}
+
+	created := *user
+	if created.TeamName == "" {
+		created.TeamName = s.DefaultTeam
+	}
+
+	_, err = s.Repo.Apply(ctx, []entity.TeamChange{
+		{Action: entity.ActionAddTeam, TeamName: created.TeamName},
+		{
+			Action:   entity.ActionAddMember,
+			TeamName: created.TeamName,
+			UserId:   created.UserId,
+			Username: created.Username,
+			IsActive: created.IsActive,
+		},
+	}, false)
+	if err != nil {
+		tracing.RecordError(span, err)
+		log.Ctx(ctx).Error(err)
+
+		return nil, err
+	}
+
+	return &created, nil
+}
+
+func (s Serv) UpdateUser(ctx context.Context, user *entity.User) (*entity.User, error) {
+	ctx, span := tracer.Start(ctx, "DirectoryServ.UpdateUser")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user.id", user.UserId))
+
+	log.AddField(ctx, "user_id", user.UserId)
+
+	current, err := s.user(ctx, user.UserId)
+	if err != nil {
+		tracing.RecordError(span, err)
+		log.Ctx(ctx).Error(err)
+
+		return nil, err
+	}
+
+	if current.Username != user.Username {
+		_, err = s.Repo.Apply(ctx, []entity.TeamChange{{
+			Action:       entity.ActionRename,
+			TeamName:     current.TeamName,
+			UserId:       current.UserId,
+			Username:     user.Username,
+			IsActive:     current.IsActive,
+			FromUsername: current.Username,
+		}}, false)
+		if err != nil {
+			tracing.RecordError(span, err)
+			log.Ctx(ctx).Error(err)
+
+			return nil, err
+		}
+
+		current.Username = user.Username
+	}
+
+	if current.IsActive != user.IsActive {
+		if current, err = s.UserServ.SetIsActive(ctx, user.UserId, user.IsActive); err != nil {
+			tracing.RecordError(span, err)
+
+			return nil, err
+		}
+	}
+
+	return current, nil
+}
+
+func (s Serv) DeleteUser(ctx context.Context, userID string) error {
+	ctx, span := tracer.Start(ctx, "DirectoryServ.DeleteUser")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user.id", userID))
+
+	log.AddField(ctx, "user_id", userID)
+
+	current, err := s.user(ctx, userID)
+	if err != nil {
+		tracing.RecordError(span, err)
+		log.Ctx(ctx).Error(err)
+
+		return err
+	}
+
+	reassignments, err := s.Repo.Apply(ctx, []entity.TeamChange{{
+		Action:   entity.ActionRemoveMember,
+		TeamName: current.TeamName,
+		UserId:   current.UserId,
+		Username: current.Username,
+	}}, false)
+	if err != nil {
+		tracing.RecordError(span, err)
+		log.Ctx(ctx).Error(err)
+
+		return err
+	}
+
+	log.Ctx(ctx).Info(fmt.Sprintf("User removed: %d reviews reassigned", len(reassignments)))
+
+	return nil
+}
+
+func (s Serv) Teams(ctx context.Context) ([]entity.Team, error) {
+	ctx, span := tracer.Start(ctx, "DirectoryServ.Teams")
+	defer span.End()
+
+	teams, _, err := s.load(ctx)
+	if err != nil {
+		tracing.RecordError(span, err)
+		log.Ctx(ctx).Error(err)
+
+		return nil, err
+	}
+
+	return teams, nil
+}
+
+func (s Serv) Team(ctx context.Context, teamName string) (*entity.Team, error) {
+	ctx, span := tracer.Start(ctx, "DirectoryServ.Team")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("team.name", teamName))
+
+	log.AddField(ctx, "team_name", teamName)
+
+	team, err := s.team(ctx, teamName)
+	if err != nil {
+		tracing.RecordError(span, err)
+		log.Ctx(ctx).Error(err)
+
+		return nil, err
+	}
+
+	return team, nil
+}
+
+// CreateTeam creates a team, unlike /team/add it may be empty and takes members that
+// already exist, moving them out of their teams.
+func (s Serv) CreateTeam(ctx context.Context, teamName string, userIDs []string) (*entity.Team, error) {
+	ctx, span := tracer.Start(ctx, "DirectoryServ.CreateTeam")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("team.name", teamName),
+		attribute.Int("team.members", len(userIDs)),
+	)
+
+	log.AddField(ctx, "team_name", teamName)
+
+	teams, users, err := s.load(ctx)
+	if err != nil {
+		tracing.RecordError(span, err)
+		log.Ctx(ctx).Error(err)
+
+		return nil, err
+	}
+
+	if slices.ContainsFunc(teams, func(t entity.Team) bool { return t.TeamName == teamName }) {
+		err = cerr.CustomError{Err: err, ErrType: cerr.TEAM_EXISTS}
+		tracing.RecordError(span, err)
+		log.Ctx(ctx).Error(err)
+
+		return nil, err
+	}
+
+	changes := []entity.TeamChange{{Action: entity.ActionAddTeam, TeamName: teamName}}
+
+	moves, err := s.moves(users, teamName, userIDs)
+	if err != nil {
+		tracing.RecordError(span, err)
+		log.Ctx(ctx).Error(err)
+
+		return nil, err
+	}
+
+	return s.apply(ctx, span, teamName, append(changes, moves...))
+}
+
+func (s Serv) SetMembers(ctx context.Context, teamName string, userIDs []string) (*entity.Team, error) {
+	ctx, span := tracer.Start(ctx, "DirectoryServ.SetMembers")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("team.name", teamName),
+		attribute.Int("team.members", len(userIDs)),
+	)
+
+	log.AddField(ctx, "team_name", teamName)
+
+	teams, users, err := s.load(ctx)
+	if err != nil {
+		tracing.RecordError(span, err)
+		log.Ctx(ctx).Error(err)
+
+		return nil, err
+	}
+
+	team, err := find(teams, teamName)
+	if err != nil {
+		tracing.RecordError(span, err)
+		log.Ctx(ctx).Error(err)
+
+		return nil, err
+	}
+
+	changes, err := s.moves(users, teamName, userIDs)
+	if err != nil {
+		tracing.RecordError(span, err)
+		log.Ctx(ctx).Error(err)
+
+		return nil, err
+	}
+
+	var leaving []string
+
+	for _, member := range team.Members {
+		if !slices.Contains(userIDs, member.UserId) {
+			leaving = append(leaving, member.UserId)
+		}
+	}
+
+	// Members of the default team have nowhere else to go.
+	if len(leaving) > 0 && teamName != s.DefaultTeam {
+		moves, _ := s.moves(users, s.DefaultTeam, leaving)
+		changes = append(changes, entity.TeamChange{Action: entity.ActionAddTeam, TeamName: s.DefaultTeam})
+		changes = append(changes, moves...)
+	}
+
+	if len(changes) == 0 {
+		return team, nil
+	}
+
+	return s.apply(ctx, span, teamName, changes)
+}
+
+// DeleteTeam moves the members to the default team and drops the team. The default team
+// itself can only go once it is empty, it is created again when needed.
+func (s Serv) DeleteTeam(ctx context.Context, teamName string) error {
+	ctx, span := tracer.Start(ctx, "DirectoryServ.DeleteTeam")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("team.name", teamName))
+
+	log.AddField(ctx, "team_name", teamName)
+
+	team, err := s.team(ctx, teamName)
+	if err != nil {
+		tracing.RecordError(span, err)
+		log.Ctx(ctx).Error(err)
+
+		return err
+	}
+
+	var changes []entity.TeamChange
+
+	if len(team.Members) > 0 {
+		if teamName == s.DefaultTeam {
+			err = cerr.CustomError{
+				Err: cerr.FieldsError{Fields: []gen.FieldError{{
+					Field:  "id",
+					Reason: "the default team keeps the users without a group, move them out first",
+				}}},
+				ErrType: cerr.VALIDATION,
+			}
+			tracing.RecordError(span, err)
+			log.Ctx(ctx).Error(err)
+
+			return err
+		}
+
+		changes = append(changes, entity.TeamChange{Action: entity.ActionAddTeam, TeamName: s.DefaultTeam})
+
+		for _, member := range team.Members {
+			changes = append(changes, entity.TeamChange{
+				Action:   entity.ActionMoveMember,
+				TeamName: s.DefaultTeam,
+				UserId:   member.UserId,
+				Username: member.Username,
+				IsActive: member.IsActive,
+				FromTeam: teamName,
+			})
+		}
+	}
+
+	changes = append(changes, entity.TeamChange{Action: entity.ActionRemoveTeam, TeamName: teamName})
+
+	if _, err = s.Repo.Apply(ctx, changes, false); err != nil {
+		tracing.RecordError(span, err)
+		log.Ctx(ctx).Error(err)
+
+		return err
+	}
+
+	return nil
+}
+
+func (s Serv) apply(ctx context.Context, span trace.Span, teamName string, changes []entity.TeamChange) (*entity.Team, error) {
+	if _, err := s.Repo.Apply(ctx, changes, false); err != nil {
+		tracing.RecordError(span, err)
+		log.Ctx(ctx).Error(err)
+
+		return nil, err
+	}
+
+	team, err := s.team(ctx, teamName)
+	if err != nil {
+		tracing.RecordError(span, err)
+		log.Ctx(ctx).Error(err)
+
+		return nil, err
+	}
+
+	return team, nil
+}
+
+// moves lists the changes that bring the users into the team, every one of them must exist.
+func (s Serv) moves(users []entity.User, teamName string, userIDs []string) ([]entity.TeamChange, error) {
+	var changes []entity.TeamChange
+
+	for i, id := range userIDs {
+		j := slices.IndexFunc(users, func(u entity.User) bool { return u.UserId == id })
+		if j < 0 {
+			return nil, cerr.CustomError{
+				Err: cerr.FieldsError{Fields: []gen.FieldError{{
+					Field:  fmt.Sprintf("members[%d].value", i),
+					Reason: fmt.Sprintf("unknown user %q", id),
+				}}},
+				ErrType: cerr.VALIDATION,
+			}
+		}
+
+		if user := users[j]; user.TeamName != teamName {
+			changes = append(changes, entity.TeamChange{
+				Action:   entity.ActionMoveMember,
+				TeamName: teamName,
+				UserId:   user.UserId,
+				Username: user.Username,
+				IsActive: user.IsActive,
+				FromTeam: user.TeamName,
+			})
+		}
+	}
+
+	return changes, nil
+}
+
+func (s Serv) load(ctx context.Context) ([]entity.Team, []entity.User, error) {
+	teams, err := s.Repo.List(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var users []entity.User
+
+	for _, team := range teams {
+		for _, member := range team.Members {
+			users = append(users, entity.User{
+				IsActive: member.IsActive,
+				TeamName: team.TeamName,
+				UserId:   member.UserId,
+				Username: member.Username,
+			})
+		}
+	}
+
+	return teams, users, nil
+}
+
+func (s Serv) user(ctx context.Context, userID string) (*entity.User, error) {
+	_, users, err := s.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	i := slices.IndexFunc(users, func(u entity.User) bool { return u.UserId == userID })
+	if i < 0 {
+		return nil, cerr.CustomError{Err: err, ErrType: cerr.NOT_FOUND}
+	}
+
+	return &users[i], nil
+}
+
+func (s Serv) team(ctx context.Context, teamName string) (*entity.Team, error) {
+	teams, _, err := s.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return find(teams, teamName)
+}
+
+func find(teams []entity.Team, teamName string) (*entity.Team, error) {
+	i := slices.IndexFunc(teams, func(t entity.Team) bool { return t.TeamName == teamName })
+	if i < 0 {
+		return nil, cerr.CustomError{ErrType: cerr.NOT_FOUND}
+	}
+
+	return &teams[i], nil
+}
@@ -0,0 +1,71 @@
+package erasure
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+
+	"avito/internal/entity"
+	"avito/internal/log"
+	"avito/internal/repo"
+	"avito/internal/service"
+	"avito/internal/tracing"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var tracer = otel.Tracer("avito/internal/service/erasure")
+
+type Serv struct {
+	Repo repo.Erasure
+}
+
+func InitErasureServ(repo repo.Erasure) service.Erasure {
+	return Serv{Repo: repo}
+}
+
+// Erase gives the user a random pseudonym. It cannot be derived from the old id, so the
+// rows that keep it say nothing about who the user was.
+func (s Serv) Erase(ctx context.Context, userID string) (*entity.UserErasure, []entity.Reassignment, error) {
+	ctx, span := tracer.Start(ctx, "ErasureServ.Erase")
+	defer span.End()
+
+	pseudonym := newPseudonym()
+
+	// The old id is left out of the traces and the logs too.
+	span.SetAttributes(attribute.String("user.pseudonym", pseudonym))
+
+	log.AddField(ctx, "pseudonym", pseudonym)
+
+	erasure, reassignments, err := s.Repo.Erase(ctx, userID, pseudonym)
+	if err != nil {
+		tracing.RecordError(span, err)
+		log.Ctx(ctx).Error(err)
+
+		return nil, nil, err
+	}
+
+	return erasure, reassignments, nil
+}
+
+func (s Serv) List(ctx context.Context) ([]entity.UserErasure, error) {
+	ctx, span := tracer.Start(ctx, "ErasureServ.List")
+	defer span.End()
+
+	erasures, err := s.Repo.List(ctx)
+	if err != nil {
+		tracing.RecordError(span, err)
+		log.Ctx(ctx).Error(err)
+
+		return nil, err
+	}
+
+	return erasures, nil
+}
+
+func newPseudonym() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+
+	return "erased-" + hex.EncodeToString(b)
+}
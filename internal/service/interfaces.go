@@ -9,6 +9,27 @@ import (
 type Team interface {
 	Create(ctx context.Context, team *entity.Team) error
 	Get(ctx context.Context, teamName string) (*entity.Team, error)
+	// Apply brings the teams in line with the desired set, see TeamPlan.
+	Apply(ctx context.Context, teams []entity.Team, dryRun bool) (*entity.TeamPlan, error)
+}
+
+// Directory is the view of users and teams an identity provider keeps in sync, SCIM maps
+// onto it. A user always belongs to one team, users the provider has not put in a group
+// yet wait in the default team.
+type Directory interface {
+	Users(ctx context.Context) ([]entity.User, error)
+	User(ctx context.Context, userID string) (*entity.User, error)
+	CreateUser(ctx context.Context, user *entity.User) (*entity.User, error)
+	// UpdateUser changes the username and the activity, the team follows the groups.
+	UpdateUser(ctx context.Context, user *entity.User) (*entity.User, error)
+	// DeleteUser removes the user like the team sync does: deactivated, reviews reassigned.
+	DeleteUser(ctx context.Context, userID string) error
+	Teams(ctx context.Context) ([]entity.Team, error)
+	Team(ctx context.Context, teamName string) (*entity.Team, error)
+	CreateTeam(ctx context.Context, teamName string, userIDs []string) (*entity.Team, error)
+	// SetMembers moves the users into the team and the other members to the default team.
+	SetMembers(ctx context.Context, teamName string, userIDs []string) (*entity.Team, error)
+	DeleteTeam(ctx context.Context, teamName string) error
 }
 
 type User interface {
@@ -16,12 +37,37 @@ type User interface {
 	GetReview(ctx context.Context, userID string) ([]entity.PullRequestShort, error)
 }
 
+// Notification reads and replaces the email settings of a user.
+type Notification interface {
+	Get(ctx context.Context, userID string) (*entity.NotificationSettings, error)
+	Set(ctx context.Context, settings *entity.NotificationSettings) (*entity.NotificationSettings, error)
+}
+
+// SLA manages the review SLA of teams, the scheduler in internal/sla enforces it.
+type SLA interface {
+	Get(ctx context.Context, teamName string) (*entity.TeamSLA, error)
+	Set(ctx context.Context, sla *entity.TeamSLA) (*entity.TeamSLA, error)
+	Delete(ctx context.Context, teamName string) error
+}
+
 type PullRequest interface {
 	Create(ctx context.Context, PullRequestCreate *entity.PullRequestCreate) (*entity.PullRequest, error)
 	Merge(ctx context.Context, PullRequestID string) (*entity.PullRequest, error)
 	Reassign(ctx context.Context, PullRequestID string, oldUserID string) (*entity.PullRequest, string, error)
 }
 
+// Archive brings back the pull requests the retention job in internal/retention moved
+// out of the way.
+type Archive interface {
+	Restore(ctx context.Context, pullRequestID string) (*entity.PullRequest, error)
+}
+
+// Erasure anonymizes users on request and keeps the record of it.
+type Erasure interface {
+	Erase(ctx context.Context, userID string) (*entity.UserErasure, []entity.Reassignment, error)
+	List(ctx context.Context) ([]entity.UserErasure, error)
+}
+
 type Stat interface {
 	User(ctx context.Context, userID string) (*entity.UserStat, error)
 	Team(ctx context.Context, teamName string) (*entity.TeamStat, error)
@@ -0,0 +1,74 @@
+package notification
+
+import (
+	"context"
+	"errors"
+
+	"avito/internal/cerr"
+	"avito/internal/entity"
+	"avito/internal/log"
+	"avito/internal/repo"
+	"avito/internal/service"
+	"avito/internal/tracing"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var tracer = otel.Tracer("avito/internal/service/notification")
+
+var errNoUser = errors.New("notification: user not found")
+
+type Serv struct {
+	Repo repo.Notification
+}
+
+func InitNotificationServ(repo repo.Notification) service.Notification {
+	return Serv{Repo: repo}
+}
+
+func (s Serv) Get(ctx context.Context, userID string) (*entity.NotificationSettings, error) {
+	ctx, span := tracer.Start(ctx, "NotificationServ.Get")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user.id", userID))
+
+	log.AddField(ctx, "user_id", userID)
+
+	settings, err := s.Repo.Get(ctx, []string{userID})
+	if err == nil && len(settings) == 0 {
+		err = cerr.CustomError{Err: errNoUser, ErrType: cerr.NOT_FOUND}
+	}
+
+	if err != nil {
+		tracing.RecordError(span, err)
+		log.Ctx(ctx).Error(err)
+
+		return nil, err
+	}
+
+	return &settings[0], nil
+}
+
+func (s Serv) Set(ctx context.Context, settings *entity.NotificationSettings) (*entity.NotificationSettings, error) {
+	ctx, span := tracer.Start(ctx, "NotificationServ.Set")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user.id", settings.UserId),
+		attribute.Bool("notification.has_email", settings.Email != nil),
+		attribute.Bool("notification.email_assignments", settings.EmailAssignments),
+		attribute.Bool("notification.email_digest", settings.EmailDigest),
+	)
+
+	log.AddField(ctx, "user_id", settings.UserId)
+
+	result, err := s.Repo.Set(ctx, settings)
+	if err != nil {
+		tracing.RecordError(span, err)
+		log.Ctx(ctx).Error(err)
+
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -7,8 +7,13 @@ import (
 	"avito/internal/log"
 	"avito/internal/repo"
 	"avito/internal/service"
+	"avito/internal/tracing"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
 )
 
+var tracer = otel.Tracer("avito/internal/service/pullRequest")
+
 type Serv struct {
 	Repo repo.PullRequest
 }
@@ -18,9 +23,21 @@ func InitPullRequestServ(repo repo.PullRequest) service.PullRequest {
 }
 
 func (s Serv) Create(ctx context.Context, pullRequestCreate *entity.PullRequestCreate) (*entity.PullRequest, error) {
+	ctx, span := tracer.Start(ctx, "PullRequestServ.Create")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("pull_request.id", pullRequestCreate.PullRequestId),
+		attribute.String("pull_request.author_id", pullRequestCreate.AuthorId),
+	)
+
+	log.AddField(ctx, "pull_request_id", pullRequestCreate.PullRequestId)
+	log.AddField(ctx, "user_id", pullRequestCreate.AuthorId)
+
 	pullRequest, err := s.Repo.Create(ctx, pullRequestCreate)
 	if err != nil {
-		log.Log.Error(err)
+		tracing.RecordError(span, err)
+		log.Ctx(ctx).Error(err)
 
 		return nil, err
 	}
@@ -29,9 +46,17 @@ func (s Serv) Create(ctx context.Context, pullRequestCreate *entity.PullRequestC
 }
 
 func (s Serv) Merge(ctx context.Context, pullRequestID string) (*entity.PullRequest, error) {
+	ctx, span := tracer.Start(ctx, "PullRequestServ.Merge")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("pull_request.id", pullRequestID))
+
+	log.AddField(ctx, "pull_request_id", pullRequestID)
+
 	pullRequest, err := s.Repo.Merge(ctx, pullRequestID)
 	if err != nil {
-		log.Log.Error(err)
+		tracing.RecordError(span, err)
+		log.Ctx(ctx).Error(err)
 
 		return nil, err
 	}
@@ -40,9 +65,21 @@ func (s Serv) Merge(ctx context.Context, pullRequestID string) (*entity.PullRequ
 }
 
 func (s Serv) Reassign(ctx context.Context, pullRequestID string, oldUserID string) (*entity.PullRequest, string, error) {
+	ctx, span := tracer.Start(ctx, "PullRequestServ.Reassign")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("pull_request.id", pullRequestID),
+		attribute.String("reviewer.old_id", oldUserID),
+	)
+
+	log.AddField(ctx, "pull_request_id", pullRequestID)
+	log.AddField(ctx, "user_id", oldUserID)
+
 	pullRequest, newReviewer, err := s.Repo.Reassign(ctx, pullRequestID, oldUserID)
 	if err != nil {
-		log.Log.Error(err)
+		tracing.RecordError(span, err)
+		log.Ctx(ctx).Error(err)
 
 		return nil, "", err
 	}
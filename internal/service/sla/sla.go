@@ -0,0 +1,102 @@
+package sla
+
+import (
+	"context"
+	"time"
+
+	"avito/internal/cerr"
+	"avito/internal/entity"
+	"avito/internal/gen"
+	"avito/internal/log"
+	"avito/internal/repo"
+	"avito/internal/service"
+	"avito/internal/tracing"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var tracer = otel.Tracer("avito/internal/service/sla")
+
+type Serv struct {
+	Repo repo.SLA
+}
+
+func InitSLAServ(repo repo.SLA) service.SLA {
+	return Serv{Repo: repo}
+}
+
+func (s Serv) Get(ctx context.Context, teamName string) (*entity.TeamSLA, error) {
+	ctx, span := tracer.Start(ctx, "SLAServ.Get")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("team.name", teamName))
+
+	log.AddField(ctx, "team_name", teamName)
+
+	sla, err := s.Repo.Get(ctx, teamName)
+	if err != nil {
+		tracing.RecordError(span, err)
+		log.Ctx(ctx).Error(err)
+
+		return nil, err
+	}
+
+	return sla, nil
+}
+
+func (s Serv) Set(ctx context.Context, sla *entity.TeamSLA) (*entity.TeamSLA, error) {
+	ctx, span := tracer.Start(ctx, "SLAServ.Set")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("team.name", sla.TeamName),
+		attribute.String("sla.remind_after", sla.RemindAfter.String()),
+		attribute.String("sla.escalate_after", sla.EscalateAfter.String()),
+		attribute.Bool("sla.auto_reassign", sla.AutoReassign),
+	)
+
+	log.AddField(ctx, "team_name", sla.TeamName)
+
+	// The thresholds are stored in whole seconds.
+	if sla.RemindAfter < time.Second || sla.EscalateAfter.Truncate(time.Second) <= sla.RemindAfter.Truncate(time.Second) {
+		err := cerr.CustomError{
+			Err: cerr.FieldsError{Fields: []gen.FieldError{{
+				Field:  "escalate_after_hours",
+				Reason: "must be greater than remind_after_hours, both at least a second",
+			}}},
+			ErrType: cerr.VALIDATION,
+		}
+		tracing.RecordError(span, err)
+		log.Ctx(ctx).Error(err)
+
+		return nil, err
+	}
+
+	result, err := s.Repo.Set(ctx, sla)
+	if err != nil {
+		tracing.RecordError(span, err)
+		log.Ctx(ctx).Error(err)
+
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (s Serv) Delete(ctx context.Context, teamName string) error {
+	ctx, span := tracer.Start(ctx, "SLAServ.Delete")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("team.name", teamName))
+
+	log.AddField(ctx, "team_name", teamName)
+
+	if err := s.Repo.Delete(ctx, teamName); err != nil {
+		tracing.RecordError(span, err)
+		log.Ctx(ctx).Error(err)
+
+		return err
+	}
+
+	return nil
+}
@@ -7,8 +7,13 @@ import (
 	"avito/internal/log"
 	"avito/internal/repo"
 	"avito/internal/service"
+	"avito/internal/tracing"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
 )
 
+var tracer = otel.Tracer("avito/internal/service/stat")
+
 type Serv struct {
 	Repo repo.Stat
 }
@@ -18,9 +23,17 @@ func InitStatServ(repo repo.Stat) service.Stat {
 }
 
 func (s Serv) User(ctx context.Context, userID string) (*entity.UserStat, error) {
+	ctx, span := tracer.Start(ctx, "StatServ.User")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user.id", userID))
+
+	log.AddField(ctx, "user_id", userID)
+
 	user, err := s.Repo.User(ctx, userID)
 	if err != nil {
-		log.Log.Error(err)
+		tracing.RecordError(span, err)
+		log.Ctx(ctx).Error(err)
 
 		return nil, err
 	}
@@ -29,9 +42,17 @@ func (s Serv) User(ctx context.Context, userID string) (*entity.UserStat, error)
 }
 
 func (s Serv) Team(ctx context.Context, teamName string) (*entity.TeamStat, error) {
+	ctx, span := tracer.Start(ctx, "StatServ.Team")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("team.name", teamName))
+
+	log.AddField(ctx, "team_name", teamName)
+
 	team, err := s.Repo.Team(ctx, teamName)
 	if err != nil {
-		log.Log.Error(err)
+		tracing.RecordError(span, err)
+		log.Ctx(ctx).Error(err)
 
 		return nil, err
 	}
@@ -2,14 +2,20 @@ package team
 
 import (
 	"context"
+	"fmt"
 
 	"avito/internal/cerr"
 	"avito/internal/entity"
 	"avito/internal/log"
 	"avito/internal/repo"
 	"avito/internal/service"
+	"avito/internal/tracing"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
 )
 
+var tracer = otel.Tracer("avito/internal/service/team")
+
 type Serv struct {
 	Repo repo.Team
 }
@@ -19,23 +25,36 @@ func InitTeamServ(repo repo.Team) service.Team {
 }
 
 func (s Serv) Create(ctx context.Context, team *entity.Team) error {
+	ctx, span := tracer.Start(ctx, "TeamServ.Create")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("team.name", team.TeamName),
+		attribute.Int("team.members", len(team.Members)),
+	)
+
+	log.AddField(ctx, "team_name", team.TeamName)
+
 	isFreeName, err := s.Repo.CheckTeamName(ctx, team.TeamName)
 	if err != nil {
-		log.Log.Error(err)
+		tracing.RecordError(span, err)
+		log.Ctx(ctx).Error(err)
 
 		return err
 	}
 
 	if !isFreeName {
 		err = cerr.CustomError{Err: err, ErrType: cerr.TEAM_EXISTS}
-		log.Log.Error(err)
+		tracing.RecordError(span, err)
+		log.Ctx(ctx).Error(err)
 
 		return err
 	}
 
 	err = s.Repo.Create(ctx, team)
 	if err != nil {
-		log.Log.Error(err)
+		tracing.RecordError(span, err)
+		log.Ctx(ctx).Error(err)
 
 		return err
 	}
@@ -44,9 +63,17 @@ func (s Serv) Create(ctx context.Context, team *entity.Team) error {
 }
 
 func (s Serv) Get(ctx context.Context, teamName string) (*entity.Team, error) {
+	ctx, span := tracer.Start(ctx, "TeamServ.Get")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("team.name", teamName))
+
+	log.AddField(ctx, "team_name", teamName)
+
 	team, err := s.Repo.Get(ctx, teamName)
 	if err != nil {
-		log.Log.Error(err)
+		tracing.RecordError(span, err)
+		log.Ctx(ctx).Error(err)
 
 		return nil, err
 	}
@@ -54,10 +81,154 @@ func (s Serv) Get(ctx context.Context, teamName string) (*entity.Team, error) {
 	if len(team.Members) == 0 {
 		err = cerr.CustomError{Err: err, ErrType: cerr.NOT_FOUND}
 
-		log.Log.Error(err)
+		tracing.RecordError(span, err)
+		log.Ctx(ctx).Error(err)
 
 		return nil, err
 	}
 
 	return team, nil
 }
+
+// Apply brings the teams in line with the desired set, which lists every team. Members
+// missing from it are removed, which deactivates them: their pull requests and reviews
+// stay in the history. Teams are never dropped, a team left out only loses its members.
+func (s Serv) Apply(ctx context.Context, teams []entity.Team, dryRun bool) (*entity.TeamPlan, error) {
+	ctx, span := tracer.Start(ctx, "TeamServ.Apply")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.Int("team.count", len(teams)),
+		attribute.Bool("team.dry_run", dryRun),
+	)
+
+	current, err := s.Repo.List(ctx)
+	if err != nil {
+		tracing.RecordError(span, err)
+		log.Ctx(ctx).Error(err)
+
+		return nil, err
+	}
+
+	plan := &entity.TeamPlan{
+		DryRun:        dryRun,
+		Changes:       diff(current, teams),
+		Reassignments: []entity.Reassignment{},
+	}
+
+	span.SetAttributes(attribute.Int("team.changes", len(plan.Changes)))
+
+	if len(plan.Changes) == 0 {
+		return plan, nil
+	}
+
+	reassignments, err := s.Repo.Apply(ctx, plan.Changes, dryRun)
+	if err != nil {
+		tracing.RecordError(span, err)
+		log.Ctx(ctx).Error(err)
+
+		return nil, err
+	}
+
+	plan.Reassignments = append(plan.Reassignments, reassignments...)
+
+	if !dryRun {
+		log.Ctx(ctx).Info(fmt.Sprintf("Teams applied: %d changes, %d reviews reassigned", len(plan.Changes), len(plan.Reassignments)))
+	}
+
+	return plan, nil
+}
+
+// diff lists the changes from current to desired: new teams first, then the members in
+// the desired order and the removals last, so added members can take over reviews.
+func diff(current []entity.Team, desired []entity.Team) []entity.TeamChange {
+	changes := []entity.TeamChange{}
+
+	teams := make(map[string]struct{}, len(current))
+	users := make(map[string]entity.User)
+
+	for _, team := range current {
+		teams[team.TeamName] = struct{}{}
+
+		for _, member := range team.Members {
+			users[member.UserId] = entity.User{
+				IsActive: member.IsActive,
+				TeamName: team.TeamName,
+				UserId:   member.UserId,
+				Username: member.Username,
+			}
+		}
+	}
+
+	for _, team := range desired {
+		if _, ok := teams[team.TeamName]; !ok {
+			changes = append(changes, entity.TeamChange{Action: entity.ActionAddTeam, TeamName: team.TeamName})
+		}
+	}
+
+	wanted := make(map[string]struct{})
+
+	for _, team := range desired {
+		for _, member := range team.Members {
+			wanted[member.UserId] = struct{}{}
+
+			change := entity.TeamChange{
+				TeamName: team.TeamName,
+				UserId:   member.UserId,
+				Username: member.Username,
+				IsActive: member.IsActive,
+			}
+
+			user, ok := users[member.UserId]
+			if !ok {
+				change.Action = entity.ActionAddMember
+				changes = append(changes, change)
+
+				continue
+			}
+
+			if user.TeamName != team.TeamName {
+				move := change
+				move.Action = entity.ActionMoveMember
+				move.FromTeam = user.TeamName
+				changes = append(changes, move)
+			}
+
+			if user.Username != member.Username {
+				rename := change
+				rename.Action = entity.ActionRename
+				rename.FromUsername = user.Username
+				changes = append(changes, rename)
+			}
+
+			if user.IsActive != member.IsActive {
+				toggle := change
+				toggle.Action = entity.ActionDeactivate
+
+				if member.IsActive {
+					toggle.Action = entity.ActionActivate
+				}
+
+				changes = append(changes, toggle)
+			}
+		}
+	}
+
+	// Inactive members are already as good as removed.
+	for _, team := range current {
+		for _, member := range team.Members {
+			if _, ok := wanted[member.UserId]; ok || !member.IsActive {
+				continue
+			}
+
+			changes = append(changes, entity.TeamChange{
+				Action:   entity.ActionRemoveMember,
+				TeamName: team.TeamName,
+				UserId:   member.UserId,
+				Username: member.Username,
+			})
+		}
+	}
+
+	return changes
+}
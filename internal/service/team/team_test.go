@@ -0,0 +1,95 @@
+package team_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"avito/internal/cerr"
+	"avito/internal/entity"
+	"avito/internal/repo/memory"
+	"avito/internal/service/team"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTeamServ(t *testing.T) {
+	ctx := context.Background()
+	serv := team.InitTeamServ(memory.InitTeamRepo(memory.InitStore()))
+
+	backend := &entity.Team{
+		TeamName: "backend",
+		Members:  []entity.TeamMember{{UserId: "u1", Username: "Alice", IsActive: true}},
+	}
+
+	require.NoError(t, serv.Create(ctx, backend))
+
+	got, err := serv.Get(ctx, "backend")
+	require.NoError(t, err)
+	assert.Equal(t, backend, got)
+
+	var customErr cerr.CustomError
+
+	err = serv.Create(ctx, backend)
+	require.True(t, errors.As(err, &customErr))
+	assert.Equal(t, cerr.TEAM_EXISTS, customErr.ErrType)
+
+	_, err = serv.Get(ctx, "unknown")
+	require.True(t, errors.As(err, &customErr))
+	assert.Equal(t, cerr.NOT_FOUND, customErr.ErrType)
+}
+
+func TestApply(t *testing.T) {
+	ctx := context.Background()
+	serv := team.InitTeamServ(memory.InitTeamRepo(memory.InitStore()))
+
+	require.NoError(t, serv.Create(ctx, &entity.Team{
+		TeamName: "backend",
+		Members: []entity.TeamMember{
+			{UserId: "u1", Username: "Alice", IsActive: true},
+			{UserId: "u2", Username: "Bob", IsActive: true},
+			{UserId: "u3", Username: "Carol", IsActive: false},
+			{UserId: "u4", Username: "Dave", IsActive: true},
+		},
+	}))
+
+	desired := []entity.Team{
+		{TeamName: "backend", Members: []entity.TeamMember{
+			{UserId: "u1", Username: "Alice", IsActive: true},
+			{UserId: "u3", Username: "Carol", IsActive: true},
+		}},
+		{TeamName: "frontend", Members: []entity.TeamMember{
+			{UserId: "u2", Username: "Robert", IsActive: true},
+			{UserId: "u5", Username: "Eve", IsActive: true},
+		}},
+	}
+
+	want := []entity.TeamChange{
+		{Action: entity.ActionAddTeam, TeamName: "frontend"},
+		{Action: entity.ActionActivate, TeamName: "backend", UserId: "u3", Username: "Carol", IsActive: true},
+		{Action: entity.ActionMoveMember, TeamName: "frontend", UserId: "u2", Username: "Robert", IsActive: true, FromTeam: "backend"},
+		{Action: entity.ActionRename, TeamName: "frontend", UserId: "u2", Username: "Robert", IsActive: true, FromUsername: "Bob"},
+		{Action: entity.ActionAddMember, TeamName: "frontend", UserId: "u5", Username: "Eve", IsActive: true},
+		{Action: entity.ActionRemoveMember, TeamName: "backend", UserId: "u4", Username: "Dave"},
+	}
+
+	plan, err := serv.Apply(ctx, desired, true)
+	require.NoError(t, err)
+	assert.True(t, plan.DryRun)
+	assert.Equal(t, want, plan.Changes)
+	assert.Empty(t, plan.Reassignments)
+
+	plan, err = serv.Apply(ctx, desired, false)
+	require.NoError(t, err)
+	assert.False(t, plan.DryRun)
+	assert.Equal(t, want, plan.Changes)
+
+	got, err := serv.Get(ctx, "frontend")
+	require.NoError(t, err)
+	assert.Equal(t, desired[1].Members, got.Members)
+
+	// The removed member stays deactivated, so the same set is a no-op.
+	plan, err = serv.Apply(ctx, desired, false)
+	require.NoError(t, err)
+	assert.Empty(t, plan.Changes)
+}
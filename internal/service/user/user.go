@@ -7,8 +7,13 @@ import (
 	"avito/internal/log"
 	"avito/internal/repo"
 	"avito/internal/service"
+	"avito/internal/tracing"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
 )
 
+var tracer = otel.Tracer("avito/internal/service/user")
+
 type Serv struct {
 	Repo repo.User
 }
@@ -18,9 +23,20 @@ func InitUserServ(repo repo.User) service.User {
 }
 
 func (s Serv) SetIsActive(ctx context.Context, userID string, isActive bool) (*entity.User, error) {
+	ctx, span := tracer.Start(ctx, "UserServ.SetIsActive")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user.id", userID),
+		attribute.Bool("user.is_active", isActive),
+	)
+
+	log.AddField(ctx, "user_id", userID)
+
 	user, err := s.Repo.SetIsActive(ctx, userID, isActive)
 	if err != nil {
-		log.Log.Error(err)
+		tracing.RecordError(span, err)
+		log.Ctx(ctx).Error(err)
 
 		return nil, err
 	}
@@ -29,9 +45,17 @@ func (s Serv) SetIsActive(ctx context.Context, userID string, isActive bool) (*e
 }
 
 func (s Serv) GetReview(ctx context.Context, userID string) ([]entity.PullRequestShort, error) {
+	ctx, span := tracer.Start(ctx, "UserServ.GetReview")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user.id", userID))
+
+	log.AddField(ctx, "user_id", userID)
+
 	reviews, err := s.Repo.GetReview(ctx, userID)
 	if err != nil {
-		log.Log.Error(err)
+		tracing.RecordError(span, err)
+		log.Ctx(ctx).Error(err)
 
 		return nil, err
 	}
@@ -0,0 +1,84 @@
+package simulate
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+func WriteReport(w io.Writer, results []Result, format string) error {
+	switch format {
+	case FormatText:
+		return writeText(w, results)
+	case FormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+
+		return enc.Encode(results)
+	default:
+		return fmt.Errorf("unknown format %q", format)
+	}
+}
+
+func writeText(w io.Writer, results []Result) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	row := func(name string, value func(r Result) string) {
+		cells := []string{name}
+		for _, r := range results {
+			cells = append(cells, value(r))
+		}
+
+		fmt.Fprintln(tw, strings.Join(cells, "\t")+"\t")
+	}
+
+	row("", func(r Result) string { return r.Strategy })
+	row("pull requests", func(r Result) string { return fmt.Sprint(r.PullRequests) })
+	row("skipped", func(r Result) string { return fmt.Sprint(r.Skipped) })
+	row("assignments", func(r Result) string { return fmt.Sprint(r.Assignments) })
+	row("no candidate", func(r Result) string { return fmt.Sprint(r.NoCandidate) })
+	row("understaffed", func(r Result) string { return fmt.Sprint(r.Understaffed) })
+	row("max open", func(r Result) string { return fmt.Sprint(r.MaxOpen) })
+	row("jain index", func(r Result) string { return fmt.Sprintf("%.3f", r.Jain) })
+	row("gini", func(r Result) string { return fmt.Sprintf("%.3f", r.Gini) })
+
+	if len(results) > 0 {
+		fmt.Fprintln(tw, "\t")
+		row("reviewer (total/max open)", func(r Result) string { return r.Strategy })
+
+		// Every run lists the same users in the same order, except reviewers that only
+		// the actual history knows about, so rows are matched by id.
+		for _, reviewer := range longest(results) {
+			row(reviewer.UserId, func(r Result) string {
+				for _, load := range r.Reviewers {
+					if load.UserId == reviewer.UserId {
+						return fmt.Sprintf("%d/%d", load.Total, load.MaxOpen)
+					}
+				}
+
+				return "-"
+			})
+		}
+	}
+
+	return tw.Flush()
+}
+
+func longest(results []Result) []ReviewerLoad {
+	reviewers := results[0].Reviewers
+
+	for _, r := range results[1:] {
+		if len(r.Reviewers) > len(reviewers) {
+			reviewers = r.Reviewers
+		}
+	}
+
+	return reviewers
+}
@@ -0,0 +1,262 @@
+// Package simulate replays pull request history under different reviewer selection
+// strategies, so their load distribution can be compared before changing the real one.
+package simulate
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	"avito/internal/entity"
+)
+
+// ReviewersPerPR is how many reviewers the service assigns to a new pull request.
+const ReviewersPerPR = 2
+
+type user struct {
+	team     string
+	isActive bool
+}
+
+// State is what a strategy can see at the moment a pull request is created.
+type State struct {
+	users   map[string]user
+	rosters map[string][]string
+	total   map[string]int
+	open    map[string]int
+}
+
+// Total returns how many reviews the user was assigned so far.
+func (s *State) Total(userID string) int {
+	return s.total[userID]
+}
+
+// Open returns how many of the user's reviews are not merged yet.
+func (s *State) Open(userID string) int {
+	return s.open[userID]
+}
+
+func (s *State) TeamOf(userID string) string {
+	return s.users[userID].team
+}
+
+// Roster returns the team members in team order, inactive ones included.
+func (s *State) Roster(team string) []string {
+	return s.rosters[team]
+}
+
+func (s *State) candidates(authorID string) []string {
+	team := s.TeamOf(authorID)
+
+	var ids []string
+
+	for _, id := range s.rosters[team] {
+		if id != authorID && s.users[id].isActive {
+			ids = append(ids, id)
+		}
+	}
+
+	return ids
+}
+
+type ReviewerLoad struct {
+	UserId   string `json:"user_id"`
+	TeamName string `json:"team_name"`
+	Total    int    `json:"total"`
+	MaxOpen  int    `json:"max_open"`
+}
+
+type Result struct {
+	Strategy     string `json:"strategy"`
+	PullRequests int    `json:"pull_requests"`
+	// Skipped counts pull requests whose author is not a member of any team.
+	Skipped     int `json:"skipped"`
+	Assignments int `json:"assignments"`
+	// NoCandidate counts pull requests left without reviewers, Understaffed the ones
+	// that got fewer than ReviewersPerPR.
+	NoCandidate  int `json:"no_candidate"`
+	Understaffed int `json:"understaffed"`
+	MaxOpen      int `json:"max_open"`
+	// Jain and Gini are computed over every user that was a candidate at least once.
+	Jain      float64        `json:"jain_index"`
+	Gini      float64        `json:"gini"`
+	Reviewers []ReviewerLoad `json:"reviewers"`
+}
+
+type eventKind int
+
+// Merges sort before creations at the same instant, freeing reviewers first.
+const (
+	eventMerge eventKind = iota
+	eventCreate
+)
+
+type event struct {
+	at   time.Time
+	kind eventKind
+	pr   int
+}
+
+// Compare runs every named strategy over the same history.
+func Compare(history *entity.History, names []string, seed uint64) ([]Result, error) {
+	results := make([]Result, 0, len(names))
+
+	for _, name := range names {
+		strategy, err := NewStrategy(name, seed)
+		if err != nil {
+			return nil, err
+		}
+
+		results = append(results, Run(history, strategy))
+	}
+
+	return results, nil
+}
+
+// Run replays the history in time order. Users keep their current is_active flag for the
+// whole replay, the history does not record when it changed.
+func Run(history *entity.History, strategy Strategy) Result {
+	st := &State{
+		users:   make(map[string]user),
+		rosters: make(map[string][]string),
+		total:   make(map[string]int),
+		open:    make(map[string]int),
+	}
+
+	var order []string
+
+	for _, team := range history.Teams {
+		for _, member := range team.Members {
+			st.users[member.UserId] = user{team: team.TeamName, isActive: member.IsActive}
+			st.rosters[team.TeamName] = append(st.rosters[team.TeamName], member.UserId)
+			order = append(order, member.UserId)
+		}
+	}
+
+	result := Result{Strategy: strategy.Name()}
+
+	var events []event
+
+	for i, pr := range history.PullRequests {
+		if _, ok := st.users[pr.AuthorId]; !ok {
+			result.Skipped++
+
+			continue
+		}
+
+		events = append(events, event{at: pr.CreatedAt, kind: eventCreate, pr: i})
+
+		if pr.MergedAt != nil {
+			events = append(events, event{at: *pr.MergedAt, kind: eventMerge, pr: i})
+		}
+	}
+
+	slices.SortStableFunc(events, func(a, b event) int {
+		return cmp.Or(a.at.Compare(b.at), cmp.Compare(a.kind, b.kind))
+	})
+
+	assigned := make(map[int][]string)
+	maxOpen := make(map[string]int)
+	eligible := make(map[string]struct{})
+
+	for _, e := range events {
+		pr := history.PullRequests[e.pr]
+
+		if e.kind == eventMerge {
+			for _, id := range assigned[e.pr] {
+				st.open[id]--
+			}
+
+			continue
+		}
+
+		candidates := st.candidates(pr.AuthorId)
+		for _, id := range candidates {
+			eligible[id] = struct{}{}
+		}
+
+		reviewers := strategy.Pick(st, pr, candidates)
+		assigned[e.pr] = reviewers
+
+		result.PullRequests++
+		result.Assignments += len(reviewers)
+
+		switch {
+		case len(reviewers) == 0:
+			result.NoCandidate++
+		case len(reviewers) < ReviewersPerPR:
+			result.Understaffed++
+		}
+
+		for _, id := range reviewers {
+			if _, ok := st.users[id]; !ok && st.total[id] == 0 {
+				// A recorded reviewer that is no longer in any team.
+				order = append(order, id)
+			}
+
+			st.total[id]++
+			st.open[id]++
+			maxOpen[id] = max(maxOpen[id], st.open[id])
+			result.MaxOpen = max(result.MaxOpen, st.open[id])
+		}
+	}
+
+	var loads []float64
+
+	for _, id := range order {
+		result.Reviewers = append(result.Reviewers, ReviewerLoad{
+			UserId:   id,
+			TeamName: st.users[id].team,
+			Total:    st.total[id],
+			MaxOpen:  maxOpen[id],
+		})
+
+		if _, ok := eligible[id]; ok {
+			loads = append(loads, float64(st.total[id]))
+		}
+	}
+
+	result.Jain = jain(loads)
+	result.Gini = gini(loads)
+
+	return result
+}
+
+// jain is Jain's fairness index: 1 when everyone has the same load, 1/n when one has it all.
+func jain(loads []float64) float64 {
+	var sum, squares float64
+
+	for _, x := range loads {
+		sum += x
+		squares += x * x
+	}
+
+	if squares == 0 {
+		return 1
+	}
+
+	return sum * sum / (float64(len(loads)) * squares)
+}
+
+// gini is the Gini coefficient: 0 for equal loads, close to 1 when one has it all.
+func gini(loads []float64) float64 {
+	var sum, diff float64
+
+	for _, x := range loads {
+		sum += x
+
+		for _, y := range loads {
+			if x > y {
+				diff += x - y
+			} else {
+				diff += y - x
+			}
+		}
+	}
+
+	if sum == 0 {
+		return 0
+	}
+
+	return diff / (2 * float64(len(loads)) * sum)
+}
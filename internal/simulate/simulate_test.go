@@ -0,0 +1,218 @@
+package simulate_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"avito/internal/entity"
+	"avito/internal/simulate"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var start = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func at(hours int) time.Time {
+	return start.Add(time.Duration(hours) * time.Hour)
+}
+
+func merged(hours int) *time.Time {
+	t := at(hours)
+
+	return &t
+}
+
+func team(name string, ids ...string) entity.Team {
+	t := entity.Team{TeamName: name}
+	for _, id := range ids {
+		t.Members = append(t.Members, entity.TeamMember{UserId: id, Username: "name-" + id, IsActive: true})
+	}
+
+	return t
+}
+
+func pr(id string, author string, created int, mergedAt *time.Time, reviewers ...string) entity.PullRequestHistory {
+	return entity.PullRequestHistory{
+		AssignedReviewers: reviewers,
+		AuthorId:          author,
+		CreatedAt:         at(created),
+		MergedAt:          mergedAt,
+		PullRequestId:     id,
+	}
+}
+
+func run(t *testing.T, history *entity.History, name string) simulate.Result {
+	t.Helper()
+
+	results, err := simulate.Compare(history, []string{name}, 1)
+	require.NoError(t, err)
+
+	return results[0]
+}
+
+func loads(result simulate.Result) map[string]int {
+	totals := make(map[string]int)
+	for _, r := range result.Reviewers {
+		totals[r.UserId] = r.Total
+	}
+
+	return totals
+}
+
+func TestStrategies(t *testing.T) {
+	history := &entity.History{
+		Teams: []entity.Team{team("backend", "a", "b", "c", "d")},
+		PullRequests: []entity.PullRequestHistory{
+			pr("pr-1", "a", 1, nil, "b", "c"),
+			pr("pr-2", "a", 2, nil, "b", "c"),
+			pr("pr-3", "a", 3, nil, "b", "c"),
+		},
+	}
+
+	tests := []struct {
+		strategy string
+		loads    map[string]int
+		jain     float64
+	}{
+		{simulate.StrategyActual, map[string]int{"a": 0, "b": 3, "c": 3, "d": 0}, 2.0 / 3},
+		{simulate.StrategyCurrent, map[string]int{"a": 0, "b": 2, "c": 2, "d": 2}, 1},
+		{simulate.StrategyLeastOpen, map[string]int{"a": 0, "b": 2, "c": 2, "d": 2}, 1},
+		{simulate.StrategyRoundRobin, map[string]int{"a": 0, "b": 2, "c": 2, "d": 2}, 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.strategy, func(t *testing.T) {
+			result := run(t, history, test.strategy)
+
+			assert.Equal(t, test.strategy, result.Strategy)
+			assert.Equal(t, 3, result.PullRequests)
+			assert.Equal(t, 6, result.Assignments)
+			assert.Equal(t, test.loads, loads(result))
+			assert.InDelta(t, test.jain, result.Jain, 1e-9)
+		})
+	}
+}
+
+func TestMergesFreeReviewersFirst(t *testing.T) {
+	history := &entity.History{
+		Teams: []entity.Team{team("backend", "a", "b", "c")},
+		PullRequests: []entity.PullRequestHistory{
+			pr("pr-1", "a", 1, merged(2)),
+			pr("pr-2", "a", 2, nil),
+		},
+	}
+
+	result := run(t, history, simulate.StrategyCurrent)
+
+	// pr-1 is merged at the instant pr-2 opens, so its reviewers are free again.
+	assert.Equal(t, 1, result.MaxOpen)
+	assert.Equal(t, map[string]int{"a": 0, "b": 2, "c": 2}, loads(result))
+}
+
+func TestNoCandidate(t *testing.T) {
+	inactive := team("pair", "p1", "p2")
+	inactive.Members[1].IsActive = false
+
+	history := &entity.History{
+		Teams: []entity.Team{team("solo", "s"), team("duo", "d1", "d2"), inactive},
+		PullRequests: []entity.PullRequestHistory{
+			pr("pr-1", "s", 1, nil),
+			pr("pr-2", "d1", 2, nil),
+			pr("pr-3", "p1", 3, nil),
+			pr("pr-4", "ghost", 4, nil),
+		},
+	}
+
+	for _, strategy := range []string{simulate.StrategyCurrent, simulate.StrategyLeastOpen, simulate.StrategyRoundRobin, simulate.StrategyRandom} {
+		result := run(t, history, strategy)
+
+		assert.Equal(t, 3, result.PullRequests, strategy)
+		assert.Equal(t, 1, result.Skipped, strategy)
+		assert.Equal(t, 2, result.NoCandidate, strategy)
+		assert.Equal(t, 1, result.Understaffed, strategy)
+		assert.Equal(t, 1, loads(result)["d2"], strategy)
+	}
+}
+
+func TestActualKeepsUnknownReviewers(t *testing.T) {
+	history := &entity.History{
+		Teams:        []entity.Team{team("backend", "a", "b")},
+		PullRequests: []entity.PullRequestHistory{pr("pr-1", "a", 1, nil, "b", "left")},
+	}
+
+	result := run(t, history, simulate.StrategyActual)
+
+	require.Len(t, result.Reviewers, 3)
+	assert.Equal(t, simulate.ReviewerLoad{UserId: "left", Total: 1, MaxOpen: 1}, result.Reviewers[2])
+}
+
+func TestRandomIsSeeded(t *testing.T) {
+	history := &entity.History{Teams: []entity.Team{team("backend", "a", "b", "c", "d", "e")}}
+	for i := range 20 {
+		history.PullRequests = append(history.PullRequests, pr(string(rune('A'+i)), "a", i, nil))
+	}
+
+	first, err := simulate.Compare(history, []string{simulate.StrategyRandom}, 42)
+	require.NoError(t, err)
+
+	second, err := simulate.Compare(history, []string{simulate.StrategyRandom}, 42)
+	require.NoError(t, err)
+
+	assert.Equal(t, first, second)
+	assert.Equal(t, 40, first[0].Assignments)
+}
+
+func TestUnknownStrategy(t *testing.T) {
+	_, err := simulate.Compare(&entity.History{}, []string{"best"}, 1)
+	assert.Error(t, err)
+}
+
+func TestFairnessIndices(t *testing.T) {
+	history := &entity.History{
+		Teams: []entity.Team{team("backend", "a", "b", "c")},
+		PullRequests: []entity.PullRequestHistory{
+			pr("pr-1", "a", 1, nil, "b"),
+			pr("pr-2", "a", 2, nil, "b"),
+			pr("pr-3", "a", 3, nil, "b"),
+		},
+	}
+
+	// Loads over the eligible b and c are 3 and 0, a never was a candidate.
+	result := run(t, history, simulate.StrategyActual)
+	assert.InDelta(t, 0.5, result.Jain, 1e-9)
+	assert.InDelta(t, 0.5, result.Gini, 1e-9)
+
+	empty := run(t, &entity.History{}, simulate.StrategyCurrent)
+	assert.Equal(t, 1.0, empty.Jain)
+	assert.Equal(t, 0.0, empty.Gini)
+}
+
+func TestWriteReport(t *testing.T) {
+	history := &entity.History{
+		Teams:        []entity.Team{team("backend", "a", "b", "c")},
+		PullRequests: []entity.PullRequestHistory{pr("pr-1", "a", 1, nil, "b", "c")},
+	}
+
+	results, err := simulate.Compare(history, []string{simulate.StrategyActual, simulate.StrategyCurrent}, 1)
+	require.NoError(t, err)
+
+	var text bytes.Buffer
+
+	require.NoError(t, simulate.WriteReport(&text, results, simulate.FormatText))
+	assert.Regexp(t, `actual\s+current`, text.String())
+	assert.Regexp(t, `no candidate\s+0\s+0`, text.String())
+	assert.Regexp(t, `b\s+1/1\s+1/1`, text.String())
+
+	var raw bytes.Buffer
+
+	require.NoError(t, simulate.WriteReport(&raw, results, simulate.FormatJSON))
+
+	var decoded []simulate.Result
+
+	require.NoError(t, json.Unmarshal(raw.Bytes(), &decoded))
+	assert.Equal(t, results, decoded)
+
+	assert.Error(t, simulate.WriteReport(&raw, results, "xml"))
+}
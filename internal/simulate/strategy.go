@@ -0,0 +1,135 @@
+package simulate
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+
+	"avito/internal/entity"
+)
+
+const (
+	StrategyActual     = "actual"
+	StrategyCurrent    = "current"
+	StrategyLeastOpen  = "least-open"
+	StrategyRoundRobin = "round-robin"
+	StrategyRandom     = "random"
+)
+
+// Strategies lists every strategy NewStrategy knows, in report order.
+var Strategies = []string{StrategyActual, StrategyCurrent, StrategyLeastOpen, StrategyRoundRobin, StrategyRandom}
+
+// Strategy picks reviewers for a pull request. Candidates are the active teammates of the
+// author in team order; a strategy returns at most ReviewersPerPR of them.
+type Strategy interface {
+	Name() string
+	Pick(st *State, pr entity.PullRequestHistory, candidates []string) []string
+}
+
+// NewStrategy returns a fresh strategy, stateful ones must not be shared between runs.
+func NewStrategy(name string, seed uint64) (Strategy, error) {
+	switch name {
+	case StrategyActual:
+		return actual{}, nil
+	case StrategyCurrent:
+		return current{}, nil
+	case StrategyLeastOpen:
+		return leastOpen{}, nil
+	case StrategyRoundRobin:
+		return &roundRobin{next: make(map[string]int)}, nil
+	case StrategyRandom:
+		return &random{rnd: rand.New(rand.NewPCG(seed, seed))}, nil
+	default:
+		return nil, fmt.Errorf("unknown strategy %q", name)
+	}
+}
+
+// actual replays the reviewers recorded in the history.
+type actual struct{}
+
+func (actual) Name() string { return StrategyActual }
+
+func (actual) Pick(_ *State, pr entity.PullRequestHistory, _ []string) []string {
+	return pr.AssignedReviewers
+}
+
+// current is the rule the service uses: fewest reviews ever assigned, ties in team order.
+type current struct{}
+
+func (current) Name() string { return StrategyCurrent }
+
+func (current) Pick(st *State, _ entity.PullRequestHistory, candidates []string) []string {
+	ids := slices.Clone(candidates)
+	slices.SortStableFunc(ids, func(a, b string) int {
+		return cmp.Compare(st.Total(a), st.Total(b))
+	})
+
+	return head(ids)
+}
+
+// leastOpen prefers reviewers with the fewest unmerged reviews, then the fewest in total.
+type leastOpen struct{}
+
+func (leastOpen) Name() string { return StrategyLeastOpen }
+
+func (leastOpen) Pick(st *State, _ entity.PullRequestHistory, candidates []string) []string {
+	ids := slices.Clone(candidates)
+	slices.SortStableFunc(ids, func(a, b string) int {
+		return cmp.Or(cmp.Compare(st.Open(a), st.Open(b)), cmp.Compare(st.Total(a), st.Total(b)))
+	})
+
+	return head(ids)
+}
+
+// roundRobin walks each team roster in a circle, skipping members that are not candidates.
+type roundRobin struct {
+	next map[string]int
+}
+
+func (*roundRobin) Name() string { return StrategyRoundRobin }
+
+func (r *roundRobin) Pick(st *State, pr entity.PullRequestHistory, candidates []string) []string {
+	team := st.TeamOf(pr.AuthorId)
+	roster := st.Roster(team)
+
+	var ids []string
+
+	first := r.next[team]
+
+	for i := 0; i < len(roster) && len(ids) < ReviewersPerPR; i++ {
+		pos := (first + i) % len(roster)
+		if !slices.Contains(candidates, roster[pos]) {
+			continue
+		}
+
+		ids = append(ids, roster[pos])
+		r.next[team] = pos + 1
+	}
+
+	return ids
+}
+
+// random picks uniformly, the seed makes runs reproducible.
+type random struct {
+	rnd *rand.Rand
+}
+
+func (*random) Name() string { return StrategyRandom }
+
+func (r *random) Pick(_ *State, _ entity.PullRequestHistory, candidates []string) []string {
+	ids := slices.Clone(candidates)
+	r.rnd.Shuffle(len(ids), func(i, j int) {
+		ids[i], ids[j] = ids[j], ids[i]
+	})
+
+	return head(ids)
+}
+
+func head(ids []string) []string {
+	if len(ids) > ReviewersPerPR {
+		return ids[:ReviewersPerPR]
+	}
+
+	return ids
+}
@@ -0,0 +1,219 @@
+// Package sla enforces the review SLA of teams. A reviewer who has not acted within the
+// remind threshold of the author's team gets a reminder, after the escalate threshold the
+// team lead is told and, if the team asks for it, the review is reassigned. Reviews leave
+// the watch when their pull request is merged, a reassigned review starts over.
+package sla
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"avito/internal/cerr"
+	"avito/internal/entity"
+	"avito/internal/health"
+	"avito/internal/log"
+	"avito/internal/org"
+	"avito/internal/repo"
+	"avito/internal/service"
+	"avito/internal/tracing"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var tracer = otel.Tracer("avito/internal/sla")
+
+// Events receives the reminders and escalations. Emit must not block, the check waits
+// for it.
+type Events interface {
+	Emit(ctx context.Context, event entity.SLAEvent)
+}
+
+type Scheduler struct {
+	repo   repo.SLA
+	prs    service.PullRequest
+	events []Events
+}
+
+// InitScheduler returns a scheduler that reassigns through prs, so reassignments go the
+// same way as the ones users make.
+func InitScheduler(repo repo.SLA, prs service.PullRequest, events ...Events) *Scheduler {
+	return &Scheduler{repo: repo, prs: prs, events: events}
+}
+
+// Check emits the reminders and escalations that are due at now. Every event is marked
+// before it is emitted and only the instance that marked it emits it, so replicas can
+// run the check side by side.
+func (s *Scheduler) Check(ctx context.Context, now time.Time) error {
+	ctx, span := tracer.Start(ctx, "SLAScheduler.Check")
+	defer span.End()
+
+	slas, err := s.repo.List(ctx)
+	if err != nil {
+		tracing.RecordError(span, err)
+
+		return fmt.Errorf("team slas: %w", err)
+	}
+
+	if len(slas) == 0 {
+		return nil
+	}
+
+	byTeam := make(map[string]entity.TeamSLA, len(slas))
+	for _, sla := range slas {
+		byTeam[sla.TeamName] = sla
+	}
+
+	pending, err := s.repo.Pending(ctx)
+	if err != nil {
+		tracing.RecordError(span, err)
+
+		return fmt.Errorf("pending reviews: %w", err)
+	}
+
+	var errs []error
+
+	reminders, escalations := 0, 0
+
+	for _, review := range pending {
+		sla, ok := byTeam[review.TeamName]
+		if !ok {
+			continue
+		}
+
+		event := entity.SLAEvent{Review: review, SLA: sla, Waited: now.Sub(review.AssignedAt)}
+
+		var marked bool
+
+		switch {
+		case event.Waited >= sla.EscalateAfter && review.EscalatedAt == nil:
+			event.Kind = entity.SLAEscalation
+			marked, err = s.repo.MarkEscalated(ctx, review.PullRequestId, review.ReviewerId, now)
+		case event.Waited >= sla.RemindAfter && review.RemindedAt == nil && review.EscalatedAt == nil:
+			event.Kind = entity.SLAReminder
+			marked, err = s.repo.MarkReminded(ctx, review.PullRequestId, review.ReviewerId, now)
+		default:
+			continue
+		}
+
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%v of %v on %v: %w", event.Kind, review.ReviewerId, review.PullRequestId, err))
+
+			continue
+		}
+
+		if !marked {
+			continue
+		}
+
+		if event.Kind == entity.SLAEscalation {
+			escalations++
+
+			if sla.AutoReassign {
+				event.ReassignedTo = s.reassign(ctx, review)
+			}
+		} else {
+			reminders++
+		}
+
+		for _, events := range s.events {
+			events.Emit(ctx, event)
+		}
+	}
+
+	span.SetAttributes(
+		attribute.Int("sla.pending", len(pending)),
+		attribute.Int("sla.reminders", reminders),
+		attribute.Int("sla.escalations", escalations),
+	)
+
+	err = errors.Join(errs...)
+	if err != nil {
+		tracing.RecordError(span, err)
+	}
+
+	return err
+}
+
+// reassign hands the overdue review to another teammate. Without a candidate the review
+// stays where it is, the escalation has been sent either way.
+func (s *Scheduler) reassign(ctx context.Context, review entity.PendingReview) *string {
+	_, newUserID, err := s.prs.Reassign(ctx, review.PullRequestId, review.ReviewerId)
+	if err != nil {
+		var customErr cerr.CustomError
+		if errors.As(err, &customErr) && customErr.ErrType == cerr.NO_CANDIDATE {
+			log.Ctx(ctx).Warn(fmt.Sprintf("overdue review of %v on %v kept, no teammate to take it", review.ReviewerId, review.PullRequestId))
+		} else {
+			log.Ctx(ctx).Error(fmt.Errorf("reassign overdue review of %v on %v: %w", review.ReviewerId, review.PullRequestId, err))
+		}
+
+		return nil
+	}
+
+	return &newUserID
+}
+
+// Run returns the worker that runs the check of every organization every interval.
+func (s *Scheduler) Run(orgs repo.Organization, interval time.Duration) func(ctx context.Context) {
+	return func(ctx context.Context) {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		beat := time.NewTicker(health.BeatInterval)
+		defer beat.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-beat.C:
+				health.Beat(ctx)
+			case now := <-ticker.C:
+				health.Beat(ctx)
+
+				orgIDs, err := orgs.List(ctx)
+				if err != nil {
+					log.Ctx(ctx).Error(fmt.Errorf("sla organizations: %w", err))
+
+					continue
+				}
+
+				for _, orgID := range orgIDs {
+					health.Beat(ctx)
+
+					// The organization goes on the logger too, everything logged during
+					// the check, the events included, carries it.
+					orgCtx := log.WithLogger(org.With(ctx, orgID), log.Log.With("org_id", orgID))
+
+					if err = s.Check(orgCtx, now); err != nil {
+						log.Ctx(orgCtx).Error(fmt.Errorf("sla of %v: %w", orgID, err))
+					}
+				}
+			}
+		}
+	}
+}
+
+// LogEvents writes the events to the log, for teams that watch it instead of the mail.
+// The organization comes with the logger of ctx.
+type LogEvents struct{}
+
+func (LogEvents) Emit(ctx context.Context, event entity.SLAEvent) {
+	l := log.Ctx(ctx).
+		With("sla_event", string(event.Kind)).
+		With("pull_request_id", event.Review.PullRequestId).
+		With("reviewer_id", event.Review.ReviewerId).
+		With("team_name", event.Review.TeamName).
+		With("waited", event.Waited.Round(time.Second).String())
+
+	if event.SLA.LeadId != nil {
+		l = l.With("lead_id", *event.SLA.LeadId)
+	}
+
+	if event.ReassignedTo != nil {
+		l = l.With("reassigned_to", *event.ReassignedTo)
+	}
+
+	l.Warn(fmt.Sprintf("review of %v by %v is overdue", event.Review.PullRequestId, event.Review.ReviewerId))
+}
@@ -0,0 +1,163 @@
+package sla_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"avito/internal/entity"
+	"avito/internal/repo"
+	"avito/internal/repo/memory"
+	"avito/internal/service/pullRequest"
+	"avito/internal/sla"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recorder struct {
+	mu     sync.Mutex
+	events []entity.SLAEvent
+}
+
+func (r *recorder) Emit(_ context.Context, event entity.SLAEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.events = append(r.events, event)
+}
+
+func (r *recorder) take() []entity.SLAEvent {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	events := r.events
+	r.events = nil
+
+	return events
+}
+
+func newRepos(t *testing.T) repo.Repos {
+	t.Helper()
+
+	ctx := context.Background()
+	store := memory.InitStore()
+	repos := repo.Repos{
+		Team:        memory.InitTeamRepo(store),
+		User:        memory.InitUserRepo(store),
+		PullRequest: memory.InitPullRequestRepo(store),
+		SLA:         memory.InitSLARepo(store),
+	}
+
+	require.NoError(t, repos.Team.Create(ctx, &entity.Team{TeamName: "backend", Members: []entity.TeamMember{
+		{UserId: "author", Username: "Author", IsActive: true},
+		{UserId: "u1", Username: "U1", IsActive: true},
+		{UserId: "u2", Username: "U2", IsActive: false},
+	}}))
+	require.NoError(t, repos.Team.Create(ctx, &entity.Team{TeamName: "leads", Members: []entity.TeamMember{
+		{UserId: "lead", Username: "Lead", IsActive: true},
+	}}))
+
+	return repos
+}
+
+func TestCheck(t *testing.T) {
+	ctx := context.Background()
+	repos := newRepos(t)
+	events := &recorder{}
+	scheduler := sla.InitScheduler(repos.SLA, pullRequest.InitPullRequestServ(repos.PullRequest), events)
+
+	// Without SLAs nothing is watched.
+	require.NoError(t, scheduler.Check(ctx, time.Now()))
+
+	_, err := repos.SLA.Set(ctx, &entity.TeamSLA{
+		TeamName: "backend", RemindAfter: 4 * time.Hour, EscalateAfter: 24 * time.Hour, LeadId: ptr("lead"), AutoReassign: true,
+	})
+	require.NoError(t, err)
+
+	start := time.Now()
+
+	pr, err := repos.PullRequest.Create(ctx, &entity.PullRequestCreate{PullRequestId: "pr-1", PullRequestName: "Add search", AuthorId: "author"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"u1"}, pr.AssignedReviewers)
+
+	require.NoError(t, scheduler.Check(ctx, start.Add(time.Hour)))
+	assert.Empty(t, events.take())
+
+	require.NoError(t, scheduler.Check(ctx, start.Add(5*time.Hour)))
+
+	reminders := events.take()
+	require.Len(t, reminders, 1)
+	assert.Equal(t, entity.SLAReminder, reminders[0].Kind)
+	assert.Equal(t, "u1", reminders[0].Review.ReviewerId)
+	assert.Equal(t, "backend", reminders[0].Review.TeamName)
+	assert.InDelta(t, 5*time.Hour, reminders[0].Waited, float64(time.Second))
+
+	// A reminder goes out once.
+	require.NoError(t, scheduler.Check(ctx, start.Add(6*time.Hour)))
+	assert.Empty(t, events.take())
+
+	_, err = repos.User.SetIsActive(ctx, "u2", true)
+	require.NoError(t, err)
+
+	require.NoError(t, scheduler.Check(ctx, start.Add(25*time.Hour)))
+
+	escalations := events.take()
+	require.Len(t, escalations, 1)
+	assert.Equal(t, entity.SLAEscalation, escalations[0].Kind)
+	assert.Equal(t, "u1", escalations[0].Review.ReviewerId)
+	assert.Equal(t, ptr("lead"), escalations[0].SLA.LeadId)
+	assert.Equal(t, ptr("u2"), escalations[0].ReassignedTo)
+
+	// The new reviewer starts over.
+	pending, err := repos.SLA.Pending(ctx)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, "u2", pending[0].ReviewerId)
+	assert.Nil(t, pending[0].RemindedAt)
+	assert.WithinDuration(t, time.Now(), pending[0].AssignedAt, time.Minute)
+
+	_, err = repos.PullRequest.Merge(ctx, "pr-1")
+	require.NoError(t, err)
+
+	require.NoError(t, scheduler.Check(ctx, start.Add(100*time.Hour)))
+	assert.Empty(t, events.take())
+}
+
+func TestCheckEscalatesOnceWithoutCandidate(t *testing.T) {
+	ctx := context.Background()
+	repos := newRepos(t)
+	prs := pullRequest.InitPullRequestServ(repos.PullRequest)
+
+	_, err := repos.SLA.Set(ctx, &entity.TeamSLA{TeamName: "backend", RemindAfter: time.Hour, EscalateAfter: 2 * time.Hour, AutoReassign: true})
+	require.NoError(t, err)
+
+	_, err = repos.PullRequest.Create(ctx, &entity.PullRequestCreate{PullRequestId: "pr-1", PullRequestName: "Add search", AuthorId: "author"})
+	require.NoError(t, err)
+
+	// Two replicas check at the same time, only one of them sends.
+	first, second := &recorder{}, &recorder{}
+	later := time.Now().Add(3 * time.Hour)
+
+	require.NoError(t, sla.InitScheduler(repos.SLA, prs, first).Check(ctx, later))
+	require.NoError(t, sla.InitScheduler(repos.SLA, prs, second).Check(ctx, later))
+
+	events := append(first.take(), second.take()...)
+	require.Len(t, events, 1)
+
+	// Past the escalation the reminder is not sent on its own, and there is no one to
+	// take the review.
+	assert.Equal(t, entity.SLAEscalation, events[0].Kind)
+	assert.Nil(t, events[0].ReassignedTo)
+	assert.Nil(t, events[0].SLA.LeadId)
+
+	pending, err := repos.SLA.Pending(ctx)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, "u1", pending[0].ReviewerId)
+	assert.NotNil(t, pending[0].EscalatedAt)
+}
+
+func ptr(s string) *string {
+	return &s
+}
@@ -0,0 +1,78 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"avito/internal/config"
+	"avito/internal/migrator"
+	sqliteMigrations "avito/migrations/sqlite"
+	"github.com/pressly/goose/v3"
+	_ "modernc.org/sqlite"
+)
+
+type Sqlite struct {
+	dsn    string
+	dbName string
+	DB     *sql.DB
+}
+
+func MustInitSqlite(cfg *config.Config) *Sqlite {
+	s := &Sqlite{
+		dsn: fmt.Sprintf("file:%v?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)",
+			cfg.SqlitePath),
+		dbName: strings.TrimSuffix(filepath.Base(cfg.SqlitePath), filepath.Ext(cfg.SqlitePath)),
+	}
+
+	db, err := sql.Open("sqlite", s.dsn)
+	if err != nil {
+		panic(fmt.Sprintf("error opening sqlite: %v", err.Error()))
+	}
+
+	// SQLite has a single writer; one connection keeps transactions from failing with
+	// SQLITE_BUSY and makes them queue instead.
+	db.SetMaxOpenConns(1)
+
+	if err = db.Ping(); err != nil {
+		panic(fmt.Sprintf("error while connecting to sqlite: %v", err.Error()))
+	}
+
+	s.DB = db
+
+	return s
+}
+
+func (s *Sqlite) Close() {
+	if s.DB != nil {
+		_ = s.DB.Close()
+	}
+}
+
+func (s *Sqlite) Ping(ctx context.Context) error {
+	return s.DB.PingContext(ctx)
+}
+
+func (s *Sqlite) MigrationVersion(ctx context.Context) (int64, error) {
+	migrator, err := s.Migrator()
+	if err != nil {
+		return 0, err
+	}
+	defer migrator.Close()
+
+	current, _, err := migrator.Versions(ctx)
+
+	return current, err
+}
+
+// Migrator works on its own handle: goose closes the database it was given.
+func (s *Sqlite) Migrator() (*migrator.Migrator, error) {
+	db, err := sql.Open("sqlite", s.dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	return migrator.New(goose.DialectSQLite3, db, sqliteMigrations.FS, s.dbName)
+}
@@ -0,0 +1,127 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS teams
+(
+    name       varchar PRIMARY KEY,
+    created_at timestamptz NOT NULL DEFAULT now()
+);
+
+INSERT INTO teams (name)
+SELECT DISTINCT team_name
+FROM users
+WHERE team_name IS NOT NULL
+ON CONFLICT DO NOTHING;
+
+-- Users without a team could never be picked as reviewers. They cannot be deleted, their
+-- pull requests and reviews refer to them, so they go to a placeholder team, inactive.
+INSERT INTO teams (name)
+SELECT 'unassigned'
+WHERE EXISTS (SELECT 1 FROM users WHERE team_name IS NULL)
+ON CONFLICT DO NOTHING;
+
+UPDATE users SET team_name = 'unassigned', is_active = false WHERE team_name IS NULL;
+
+UPDATE users SET is_active = true WHERE is_active IS NULL;
+UPDATE users SET username = id WHERE username IS NULL;
+
+ALTER TABLE users
+    ALTER COLUMN username SET NOT NULL,
+    ALTER COLUMN team_name SET NOT NULL,
+    ALTER COLUMN is_active SET NOT NULL,
+    ALTER COLUMN is_active SET DEFAULT true,
+    ADD CONSTRAINT users_team_name_fkey FOREIGN KEY (team_name)
+        REFERENCES teams (name) ON UPDATE CASCADE ON DELETE RESTRICT;
+
+ALTER TABLE pull_requests
+    ALTER COLUMN create_at TYPE timestamptz USING create_at AT TIME ZONE 'UTC',
+    ALTER COLUMN merged_at TYPE timestamptz USING merged_at AT TIME ZONE 'UTC',
+    ALTER COLUMN create_at SET NOT NULL,
+    ALTER COLUMN create_at SET DEFAULT now(),
+    ALTER COLUMN name SET NOT NULL,
+    ALTER COLUMN author_id SET NOT NULL,
+    ALTER COLUMN status_id SET NOT NULL;
+
+DELETE FROM reviewers AS r
+WHERE NOT EXISTS (SELECT 1 FROM pull_requests AS pr WHERE pr.id = r.pull_request_id)
+   OR r.reviewer_id IS NULL;
+
+DELETE FROM reviewers AS r
+    USING reviewers AS d
+WHERE r.pull_request_id = d.pull_request_id
+  AND r.reviewer_id = d.reviewer_id
+  AND r.ctid > d.ctid;
+
+ALTER TABLE reviewers
+    ADD CONSTRAINT reviewers_pkey PRIMARY KEY (pull_request_id, reviewer_id),
+    ADD CONSTRAINT reviewers_pull_request_id_fkey FOREIGN KEY (pull_request_id)
+        REFERENCES pull_requests (id) ON DELETE CASCADE,
+    DROP CONSTRAINT reviewers_reviewer_id_fkey,
+    ADD CONSTRAINT reviewers_reviewer_id_fkey FOREIGN KEY (reviewer_id)
+        REFERENCES users (id) ON UPDATE CASCADE ON DELETE RESTRICT;
+
+ALTER TABLE pull_requests
+    DROP CONSTRAINT pull_requests_author_id_fkey,
+    ADD CONSTRAINT pull_requests_author_id_fkey FOREIGN KEY (author_id)
+        REFERENCES users (id) ON UPDATE CASCADE ON DELETE RESTRICT;
+
+ALTER TABLE statuses
+    ALTER COLUMN name SET NOT NULL,
+    ADD CONSTRAINT statuses_name_key UNIQUE (name);
+
+CREATE INDEX IF NOT EXISTS reviewers_reviewer_id_idx
+    ON reviewers (reviewer_id);
+
+CREATE INDEX IF NOT EXISTS pull_requests_open_idx
+    ON pull_requests (id) WHERE merged_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS pull_requests_author_id_idx
+    ON pull_requests (author_id);
+
+DROP INDEX IF EXISTS users_team_name_idx;
+
+CREATE INDEX IF NOT EXISTS users_team_name_active_idx
+    ON users (team_name, is_active);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS users_team_name_active_idx;
+DROP INDEX IF EXISTS pull_requests_author_id_idx;
+DROP INDEX IF EXISTS pull_requests_open_idx;
+DROP INDEX IF EXISTS reviewers_reviewer_id_idx;
+
+CREATE INDEX IF NOT EXISTS users_team_name_idx
+    ON users (team_name);
+
+ALTER TABLE statuses
+    DROP CONSTRAINT IF EXISTS statuses_name_key,
+    ALTER COLUMN name DROP NOT NULL;
+
+ALTER TABLE pull_requests
+    DROP CONSTRAINT pull_requests_author_id_fkey,
+    ADD CONSTRAINT pull_requests_author_id_fkey FOREIGN KEY (author_id) REFERENCES users (id);
+
+ALTER TABLE reviewers
+    DROP CONSTRAINT reviewers_reviewer_id_fkey,
+    ADD CONSTRAINT reviewers_reviewer_id_fkey FOREIGN KEY (reviewer_id) REFERENCES users (id),
+    DROP CONSTRAINT IF EXISTS reviewers_pull_request_id_fkey,
+    DROP CONSTRAINT IF EXISTS reviewers_pkey;
+
+ALTER TABLE pull_requests
+    ALTER COLUMN status_id DROP NOT NULL,
+    ALTER COLUMN author_id DROP NOT NULL,
+    ALTER COLUMN name DROP NOT NULL,
+    ALTER COLUMN create_at DROP DEFAULT,
+    ALTER COLUMN create_at DROP NOT NULL,
+    ALTER COLUMN merged_at TYPE timestamp USING merged_at AT TIME ZONE 'UTC',
+    ALTER COLUMN create_at TYPE timestamp USING create_at AT TIME ZONE 'UTC';
+
+ALTER TABLE users
+    DROP CONSTRAINT IF EXISTS users_team_name_fkey,
+    ALTER COLUMN is_active DROP DEFAULT,
+    ALTER COLUMN is_active DROP NOT NULL,
+    ALTER COLUMN team_name DROP NOT NULL,
+    ALTER COLUMN username DROP NOT NULL;
+
+DROP TABLE IF EXISTS teams;
+-- +goose StatementEnd
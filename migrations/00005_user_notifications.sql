@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS email varchar,
+    ADD COLUMN IF NOT EXISTS email_assignments boolean NOT NULL DEFAULT true,
+    ADD COLUMN IF NOT EXISTS email_digest boolean NOT NULL DEFAULT true;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE users
+    DROP COLUMN IF EXISTS email_digest,
+    DROP COLUMN IF EXISTS email_assignments,
+    DROP COLUMN IF EXISTS email;
+-- +goose StatementEnd
@@ -0,0 +1,36 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE reviewers
+    ADD COLUMN IF NOT EXISTS assigned_at timestamptz,
+    ADD COLUMN IF NOT EXISTS reminded_at timestamptz,
+    ADD COLUMN IF NOT EXISTS escalated_at timestamptz;
+
+UPDATE reviewers AS r
+SET assigned_at = pr.create_at
+FROM pull_requests AS pr
+WHERE pr.id = r.pull_request_id
+  AND r.assigned_at IS NULL;
+
+ALTER TABLE reviewers
+    ALTER COLUMN assigned_at SET DEFAULT now(),
+    ALTER COLUMN assigned_at SET NOT NULL;
+
+CREATE TABLE IF NOT EXISTS team_slas
+(
+    team_name              varchar PRIMARY KEY REFERENCES teams (name) ON UPDATE CASCADE ON DELETE CASCADE,
+    remind_after_seconds   bigint  NOT NULL CHECK (remind_after_seconds > 0),
+    escalate_after_seconds bigint  NOT NULL CHECK (escalate_after_seconds > remind_after_seconds),
+    lead_id                varchar REFERENCES users (id) ON UPDATE CASCADE ON DELETE SET NULL,
+    auto_reassign          boolean NOT NULL DEFAULT false
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS team_slas;
+
+ALTER TABLE reviewers
+    DROP COLUMN IF EXISTS escalated_at,
+    DROP COLUMN IF EXISTS reminded_at,
+    DROP COLUMN IF EXISTS assigned_at;
+-- +goose StatementEnd
@@ -0,0 +1,153 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS organizations
+(
+    id         varchar PRIMARY KEY,
+    created_at timestamptz NOT NULL DEFAULT now()
+);
+
+INSERT INTO organizations (id) VALUES ('default') ON CONFLICT DO NOTHING;
+
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS org_id varchar NOT NULL DEFAULT 'default';
+ALTER TABLE users ADD COLUMN IF NOT EXISTS org_id varchar NOT NULL DEFAULT 'default';
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS org_id varchar NOT NULL DEFAULT 'default';
+ALTER TABLE reviewers ADD COLUMN IF NOT EXISTS org_id varchar NOT NULL DEFAULT 'default';
+ALTER TABLE team_slas ADD COLUMN IF NOT EXISTS org_id varchar NOT NULL DEFAULT 'default';
+
+-- Children first, the keys they reference are replaced below.
+ALTER TABLE team_slas
+    DROP CONSTRAINT team_slas_lead_id_fkey,
+    DROP CONSTRAINT team_slas_team_name_fkey,
+    DROP CONSTRAINT team_slas_pkey;
+
+ALTER TABLE reviewers
+    DROP CONSTRAINT reviewers_reviewer_id_fkey,
+    DROP CONSTRAINT reviewers_pull_request_id_fkey,
+    DROP CONSTRAINT reviewers_pkey;
+
+ALTER TABLE pull_requests
+    DROP CONSTRAINT pull_requests_author_id_fkey,
+    DROP CONSTRAINT pull_requests_pkey;
+
+ALTER TABLE users
+    DROP CONSTRAINT users_team_name_fkey,
+    DROP CONSTRAINT users_pkey;
+
+ALTER TABLE teams
+    DROP CONSTRAINT teams_pkey;
+
+ALTER TABLE teams
+    ADD CONSTRAINT teams_pkey PRIMARY KEY (org_id, name),
+    ADD CONSTRAINT teams_org_id_fkey FOREIGN KEY (org_id) REFERENCES organizations (id);
+
+ALTER TABLE users
+    ADD CONSTRAINT users_pkey PRIMARY KEY (org_id, id),
+    ADD CONSTRAINT users_team_name_fkey FOREIGN KEY (org_id, team_name)
+        REFERENCES teams (org_id, name) ON UPDATE CASCADE ON DELETE RESTRICT;
+
+ALTER TABLE pull_requests
+    ADD CONSTRAINT pull_requests_pkey PRIMARY KEY (org_id, id),
+    ADD CONSTRAINT pull_requests_author_id_fkey FOREIGN KEY (org_id, author_id)
+        REFERENCES users (org_id, id) ON UPDATE CASCADE ON DELETE RESTRICT;
+
+ALTER TABLE reviewers
+    ADD CONSTRAINT reviewers_pkey PRIMARY KEY (org_id, pull_request_id, reviewer_id),
+    ADD CONSTRAINT reviewers_pull_request_id_fkey FOREIGN KEY (org_id, pull_request_id)
+        REFERENCES pull_requests (org_id, id) ON DELETE CASCADE,
+    ADD CONSTRAINT reviewers_reviewer_id_fkey FOREIGN KEY (org_id, reviewer_id)
+        REFERENCES users (org_id, id) ON UPDATE CASCADE ON DELETE RESTRICT;
+
+ALTER TABLE team_slas
+    ADD CONSTRAINT team_slas_pkey PRIMARY KEY (org_id, team_name),
+    ADD CONSTRAINT team_slas_team_name_fkey FOREIGN KEY (org_id, team_name)
+        REFERENCES teams (org_id, name) ON UPDATE CASCADE ON DELETE CASCADE,
+    ADD CONSTRAINT team_slas_lead_id_fkey FOREIGN KEY (org_id, lead_id)
+        REFERENCES users (org_id, id) ON UPDATE CASCADE ON DELETE SET NULL (lead_id);
+
+-- Rows always name their organization from now on.
+ALTER TABLE teams ALTER COLUMN org_id DROP DEFAULT;
+ALTER TABLE users ALTER COLUMN org_id DROP DEFAULT;
+ALTER TABLE pull_requests ALTER COLUMN org_id DROP DEFAULT;
+ALTER TABLE reviewers ALTER COLUMN org_id DROP DEFAULT;
+ALTER TABLE team_slas ALTER COLUMN org_id DROP DEFAULT;
+
+DROP INDEX IF EXISTS users_team_name_active_idx;
+DROP INDEX IF EXISTS reviewers_reviewer_id_idx;
+DROP INDEX IF EXISTS pull_requests_author_id_idx;
+DROP INDEX IF EXISTS pull_requests_open_idx;
+
+CREATE INDEX IF NOT EXISTS users_team_name_active_idx ON users (org_id, team_name, is_active);
+CREATE INDEX IF NOT EXISTS reviewers_reviewer_id_idx ON reviewers (org_id, reviewer_id);
+CREATE INDEX IF NOT EXISTS pull_requests_author_id_idx ON pull_requests (org_id, author_id);
+CREATE INDEX IF NOT EXISTS pull_requests_open_idx ON pull_requests (org_id, id) WHERE merged_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+-- Going back to global ids fails on the primary keys once two organizations share an id.
+DROP INDEX IF EXISTS pull_requests_open_idx;
+DROP INDEX IF EXISTS pull_requests_author_id_idx;
+DROP INDEX IF EXISTS reviewers_reviewer_id_idx;
+DROP INDEX IF EXISTS users_team_name_active_idx;
+
+ALTER TABLE team_slas
+    DROP CONSTRAINT team_slas_lead_id_fkey,
+    DROP CONSTRAINT team_slas_team_name_fkey,
+    DROP CONSTRAINT team_slas_pkey;
+
+ALTER TABLE reviewers
+    DROP CONSTRAINT reviewers_reviewer_id_fkey,
+    DROP CONSTRAINT reviewers_pull_request_id_fkey,
+    DROP CONSTRAINT reviewers_pkey;
+
+ALTER TABLE pull_requests
+    DROP CONSTRAINT pull_requests_author_id_fkey,
+    DROP CONSTRAINT pull_requests_pkey;
+
+ALTER TABLE users
+    DROP CONSTRAINT users_team_name_fkey,
+    DROP CONSTRAINT users_pkey;
+
+ALTER TABLE teams
+    DROP CONSTRAINT teams_org_id_fkey,
+    DROP CONSTRAINT teams_pkey;
+
+ALTER TABLE teams ADD CONSTRAINT teams_pkey PRIMARY KEY (name);
+
+ALTER TABLE users
+    ADD CONSTRAINT users_pkey PRIMARY KEY (id),
+    ADD CONSTRAINT users_team_name_fkey FOREIGN KEY (team_name)
+        REFERENCES teams (name) ON UPDATE CASCADE ON DELETE RESTRICT;
+
+ALTER TABLE pull_requests
+    ADD CONSTRAINT pull_requests_pkey PRIMARY KEY (id),
+    ADD CONSTRAINT pull_requests_author_id_fkey FOREIGN KEY (author_id)
+        REFERENCES users (id) ON UPDATE CASCADE ON DELETE RESTRICT;
+
+ALTER TABLE reviewers
+    ADD CONSTRAINT reviewers_pkey PRIMARY KEY (pull_request_id, reviewer_id),
+    ADD CONSTRAINT reviewers_pull_request_id_fkey FOREIGN KEY (pull_request_id)
+        REFERENCES pull_requests (id) ON DELETE CASCADE,
+    ADD CONSTRAINT reviewers_reviewer_id_fkey FOREIGN KEY (reviewer_id)
+        REFERENCES users (id) ON UPDATE CASCADE ON DELETE RESTRICT;
+
+ALTER TABLE team_slas
+    ADD CONSTRAINT team_slas_pkey PRIMARY KEY (team_name),
+    ADD CONSTRAINT team_slas_team_name_fkey FOREIGN KEY (team_name)
+        REFERENCES teams (name) ON UPDATE CASCADE ON DELETE CASCADE,
+    ADD CONSTRAINT team_slas_lead_id_fkey FOREIGN KEY (lead_id)
+        REFERENCES users (id) ON UPDATE CASCADE ON DELETE SET NULL;
+
+ALTER TABLE team_slas DROP COLUMN IF EXISTS org_id;
+ALTER TABLE reviewers DROP COLUMN IF EXISTS org_id;
+ALTER TABLE pull_requests DROP COLUMN IF EXISTS org_id;
+ALTER TABLE users DROP COLUMN IF EXISTS org_id;
+ALTER TABLE teams DROP COLUMN IF EXISTS org_id;
+
+CREATE INDEX IF NOT EXISTS users_team_name_active_idx ON users (team_name, is_active);
+CREATE INDEX IF NOT EXISTS reviewers_reviewer_id_idx ON reviewers (reviewer_id);
+CREATE INDEX IF NOT EXISTS pull_requests_author_id_idx ON pull_requests (author_id);
+CREATE INDEX IF NOT EXISTS pull_requests_open_idx ON pull_requests (id) WHERE merged_at IS NULL;
+
+DROP TABLE IF EXISTS organizations;
+-- +goose StatementEnd
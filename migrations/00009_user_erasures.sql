@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+-- The audit trail of erased users. The pseudonym is what the user row is called now, the
+-- old id is deliberately not kept.
+CREATE TABLE IF NOT EXISTS user_erasures
+(
+    org_id             varchar     NOT NULL REFERENCES organizations (id),
+    pseudonym          varchar     NOT NULL,
+    team_name          varchar     NOT NULL,
+    erased_at          timestamptz NOT NULL DEFAULT now(),
+    reassigned_reviews integer     NOT NULL,
+    PRIMARY KEY (org_id, pseudonym)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS user_erasures;
+-- +goose StatementEnd
@@ -0,0 +1,47 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS teams
+(
+    name       TEXT PRIMARY KEY,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS users
+(
+    id        TEXT PRIMARY KEY,
+    username  TEXT    NOT NULL,
+    team_name TEXT    NOT NULL REFERENCES teams (name) ON UPDATE CASCADE ON DELETE RESTRICT,
+    is_active INTEGER NOT NULL DEFAULT 1
+);
+
+CREATE INDEX IF NOT EXISTS users_team_name_active_idx ON users (team_name, is_active);
+
+CREATE TABLE IF NOT EXISTS pull_requests
+(
+    id         TEXT PRIMARY KEY,
+    name       TEXT     NOT NULL,
+    author_id  TEXT     NOT NULL REFERENCES users (id) ON UPDATE CASCADE ON DELETE RESTRICT,
+    status     TEXT     NOT NULL CHECK (status IN ('OPEN', 'MERGED')),
+    created_at DATETIME NOT NULL,
+    merged_at  DATETIME
+);
+
+CREATE INDEX IF NOT EXISTS pull_requests_author_id_idx ON pull_requests (author_id);
+
+CREATE TABLE IF NOT EXISTS reviewers
+(
+    pull_request_id TEXT NOT NULL REFERENCES pull_requests (id) ON DELETE CASCADE,
+    reviewer_id     TEXT NOT NULL REFERENCES users (id) ON UPDATE CASCADE ON DELETE RESTRICT,
+    PRIMARY KEY (pull_request_id, reviewer_id)
+);
+
+CREATE INDEX IF NOT EXISTS reviewers_reviewer_id_idx ON reviewers (reviewer_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS reviewers;
+DROP TABLE IF EXISTS pull_requests;
+DROP TABLE IF EXISTS users;
+DROP TABLE IF EXISTS teams;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE users ADD COLUMN email TEXT;
+ALTER TABLE users ADD COLUMN email_assignments INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE users ADD COLUMN email_digest INTEGER NOT NULL DEFAULT 1;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE users DROP COLUMN email_digest;
+ALTER TABLE users DROP COLUMN email_assignments;
+ALTER TABLE users DROP COLUMN email;
+-- +goose StatementEnd
@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE reviewers ADD COLUMN assigned_at DATETIME;
+ALTER TABLE reviewers ADD COLUMN reminded_at DATETIME;
+ALTER TABLE reviewers ADD COLUMN escalated_at DATETIME;
+
+UPDATE reviewers
+SET assigned_at = (SELECT created_at FROM pull_requests WHERE id = reviewers.pull_request_id)
+WHERE assigned_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS team_slas
+(
+    team_name              TEXT PRIMARY KEY REFERENCES teams (name) ON UPDATE CASCADE ON DELETE CASCADE,
+    remind_after_seconds   INTEGER NOT NULL CHECK (remind_after_seconds > 0),
+    escalate_after_seconds INTEGER NOT NULL CHECK (escalate_after_seconds > remind_after_seconds),
+    lead_id                TEXT REFERENCES users (id) ON UPDATE CASCADE ON DELETE SET NULL,
+    auto_reassign          INTEGER NOT NULL DEFAULT 0
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS team_slas;
+
+ALTER TABLE reviewers DROP COLUMN escalated_at;
+ALTER TABLE reviewers DROP COLUMN reminded_at;
+ALTER TABLE reviewers DROP COLUMN assigned_at;
+-- +goose StatementEnd
@@ -0,0 +1,187 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS organizations
+(
+    id         TEXT PRIMARY KEY,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT OR IGNORE INTO organizations (id) VALUES ('default');
+
+-- SQLite cannot change a primary key, so the tables are rebuilt. Renaming a table
+-- repoints the foreign keys of the others at the old copy until it is dropped.
+DROP INDEX IF EXISTS users_team_name_active_idx;
+DROP INDEX IF EXISTS pull_requests_author_id_idx;
+DROP INDEX IF EXISTS reviewers_reviewer_id_idx;
+
+ALTER TABLE teams RENAME TO teams_old;
+ALTER TABLE users RENAME TO users_old;
+ALTER TABLE pull_requests RENAME TO pull_requests_old;
+ALTER TABLE reviewers RENAME TO reviewers_old;
+ALTER TABLE team_slas RENAME TO team_slas_old;
+
+CREATE TABLE teams
+(
+    org_id     TEXT     NOT NULL REFERENCES organizations (id),
+    name       TEXT     NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (org_id, name)
+);
+
+CREATE TABLE users
+(
+    org_id            TEXT    NOT NULL,
+    id                TEXT    NOT NULL,
+    username          TEXT    NOT NULL,
+    team_name         TEXT    NOT NULL,
+    is_active         INTEGER NOT NULL DEFAULT 1,
+    email             TEXT,
+    email_assignments INTEGER NOT NULL DEFAULT 1,
+    email_digest      INTEGER NOT NULL DEFAULT 1,
+    PRIMARY KEY (org_id, id),
+    FOREIGN KEY (org_id, team_name) REFERENCES teams (org_id, name) ON UPDATE CASCADE ON DELETE RESTRICT
+);
+
+CREATE TABLE pull_requests
+(
+    org_id     TEXT     NOT NULL,
+    id         TEXT     NOT NULL,
+    name       TEXT     NOT NULL,
+    author_id  TEXT     NOT NULL,
+    status     TEXT     NOT NULL CHECK (status IN ('OPEN', 'MERGED')),
+    created_at DATETIME NOT NULL,
+    merged_at  DATETIME,
+    PRIMARY KEY (org_id, id),
+    FOREIGN KEY (org_id, author_id) REFERENCES users (org_id, id) ON UPDATE CASCADE ON DELETE RESTRICT
+);
+
+CREATE TABLE reviewers
+(
+    org_id          TEXT NOT NULL,
+    pull_request_id TEXT NOT NULL,
+    reviewer_id     TEXT NOT NULL,
+    assigned_at     DATETIME,
+    reminded_at     DATETIME,
+    escalated_at    DATETIME,
+    PRIMARY KEY (org_id, pull_request_id, reviewer_id),
+    FOREIGN KEY (org_id, pull_request_id) REFERENCES pull_requests (org_id, id) ON DELETE CASCADE,
+    FOREIGN KEY (org_id, reviewer_id) REFERENCES users (org_id, id) ON UPDATE CASCADE ON DELETE RESTRICT
+);
+
+CREATE TABLE team_slas
+(
+    org_id                 TEXT    NOT NULL,
+    team_name              TEXT    NOT NULL,
+    remind_after_seconds   INTEGER NOT NULL CHECK (remind_after_seconds > 0),
+    escalate_after_seconds INTEGER NOT NULL CHECK (escalate_after_seconds > remind_after_seconds),
+    lead_id                TEXT,
+    auto_reassign          INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (org_id, team_name),
+    FOREIGN KEY (org_id, team_name) REFERENCES teams (org_id, name) ON UPDATE CASCADE ON DELETE CASCADE,
+    FOREIGN KEY (org_id, lead_id) REFERENCES users (org_id, id) ON UPDATE CASCADE ON DELETE RESTRICT
+);
+
+CREATE INDEX users_team_name_active_idx ON users (org_id, team_name, is_active);
+CREATE INDEX pull_requests_author_id_idx ON pull_requests (org_id, author_id);
+CREATE INDEX reviewers_reviewer_id_idx ON reviewers (org_id, reviewer_id);
+
+INSERT INTO teams (org_id, name, created_at) SELECT 'default', name, created_at FROM teams_old ORDER BY rowid;
+INSERT INTO users (org_id, id, username, team_name, is_active, email, email_assignments, email_digest) SELECT 'default', id, username, team_name, is_active, email, email_assignments, email_digest FROM users_old ORDER BY rowid;
+INSERT INTO pull_requests (org_id, id, name, author_id, status, created_at, merged_at) SELECT 'default', id, name, author_id, status, created_at, merged_at FROM pull_requests_old ORDER BY rowid;
+INSERT INTO reviewers (org_id, pull_request_id, reviewer_id, assigned_at, reminded_at, escalated_at) SELECT 'default', pull_request_id, reviewer_id, assigned_at, reminded_at, escalated_at FROM reviewers_old ORDER BY rowid;
+INSERT INTO team_slas (org_id, team_name, remind_after_seconds, escalate_after_seconds, lead_id, auto_reassign) SELECT 'default', team_name, remind_after_seconds, escalate_after_seconds, lead_id, auto_reassign FROM team_slas_old ORDER BY rowid;
+
+DROP TABLE team_slas_old;
+DROP TABLE reviewers_old;
+DROP TABLE pull_requests_old;
+DROP TABLE users_old;
+DROP TABLE teams_old;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+-- Going back to global ids fails on the primary keys once two organizations share an id.
+DROP INDEX IF EXISTS users_team_name_active_idx;
+DROP INDEX IF EXISTS pull_requests_author_id_idx;
+DROP INDEX IF EXISTS reviewers_reviewer_id_idx;
+
+ALTER TABLE teams RENAME TO teams_old;
+ALTER TABLE users RENAME TO users_old;
+ALTER TABLE pull_requests RENAME TO pull_requests_old;
+ALTER TABLE reviewers RENAME TO reviewers_old;
+ALTER TABLE team_slas RENAME TO team_slas_old;
+
+CREATE TABLE teams
+(
+    name       TEXT     NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (name)
+);
+
+CREATE TABLE users
+(
+    id                TEXT    NOT NULL,
+    username          TEXT    NOT NULL,
+    team_name         TEXT    NOT NULL,
+    is_active         INTEGER NOT NULL DEFAULT 1,
+    email             TEXT,
+    email_assignments INTEGER NOT NULL DEFAULT 1,
+    email_digest      INTEGER NOT NULL DEFAULT 1,
+    PRIMARY KEY (id),
+    FOREIGN KEY (team_name) REFERENCES teams (name) ON UPDATE CASCADE ON DELETE RESTRICT
+);
+
+CREATE TABLE pull_requests
+(
+    id         TEXT     NOT NULL,
+    name       TEXT     NOT NULL,
+    author_id  TEXT     NOT NULL,
+    status     TEXT     NOT NULL CHECK (status IN ('OPEN', 'MERGED')),
+    created_at DATETIME NOT NULL,
+    merged_at  DATETIME,
+    PRIMARY KEY (id),
+    FOREIGN KEY (author_id) REFERENCES users (id) ON UPDATE CASCADE ON DELETE RESTRICT
+);
+
+CREATE TABLE reviewers
+(
+    pull_request_id TEXT NOT NULL,
+    reviewer_id     TEXT NOT NULL,
+    assigned_at     DATETIME,
+    reminded_at     DATETIME,
+    escalated_at    DATETIME,
+    PRIMARY KEY (pull_request_id, reviewer_id),
+    FOREIGN KEY (pull_request_id) REFERENCES pull_requests (id) ON DELETE CASCADE,
+    FOREIGN KEY (reviewer_id) REFERENCES users (id) ON UPDATE CASCADE ON DELETE RESTRICT
+);
+
+CREATE TABLE team_slas
+(
+    team_name              TEXT    NOT NULL,
+    remind_after_seconds   INTEGER NOT NULL CHECK (remind_after_seconds > 0),
+    escalate_after_seconds INTEGER NOT NULL CHECK (escalate_after_seconds > remind_after_seconds),
+    lead_id                TEXT,
+    auto_reassign          INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (team_name),
+    FOREIGN KEY (team_name) REFERENCES teams (name) ON UPDATE CASCADE ON DELETE CASCADE,
+    FOREIGN KEY (lead_id) REFERENCES users (id) ON UPDATE CASCADE ON DELETE SET NULL
+);
+
+CREATE INDEX users_team_name_active_idx ON users (team_name, is_active);
+CREATE INDEX pull_requests_author_id_idx ON pull_requests (author_id);
+CREATE INDEX reviewers_reviewer_id_idx ON reviewers (reviewer_id);
+
+INSERT INTO teams (name, created_at) SELECT name, created_at FROM teams_old ORDER BY rowid;
+INSERT INTO users (id, username, team_name, is_active, email, email_assignments, email_digest) SELECT id, username, team_name, is_active, email, email_assignments, email_digest FROM users_old ORDER BY rowid;
+INSERT INTO pull_requests (id, name, author_id, status, created_at, merged_at) SELECT id, name, author_id, status, created_at, merged_at FROM pull_requests_old ORDER BY rowid;
+INSERT INTO reviewers (pull_request_id, reviewer_id, assigned_at, reminded_at, escalated_at) SELECT pull_request_id, reviewer_id, assigned_at, reminded_at, escalated_at FROM reviewers_old ORDER BY rowid;
+INSERT INTO team_slas (team_name, remind_after_seconds, escalate_after_seconds, lead_id, auto_reassign) SELECT team_name, remind_after_seconds, escalate_after_seconds, lead_id, auto_reassign FROM team_slas_old ORDER BY rowid;
+
+DROP TABLE team_slas_old;
+DROP TABLE reviewers_old;
+DROP TABLE pull_requests_old;
+DROP TABLE users_old;
+DROP TABLE teams_old;
+
+DROP TABLE IF EXISTS organizations;
+-- +goose StatementEnd
@@ -0,0 +1,64 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS archived_pull_requests
+(
+    org_id      TEXT     NOT NULL,
+    id          TEXT     NOT NULL,
+    name        TEXT     NOT NULL,
+    author_id   TEXT     NOT NULL,
+    created_at  DATETIME NOT NULL,
+    merged_at   DATETIME NOT NULL,
+    archived_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (org_id, id),
+    FOREIGN KEY (org_id, author_id) REFERENCES users (org_id, id) ON UPDATE CASCADE ON DELETE RESTRICT
+);
+
+CREATE TABLE IF NOT EXISTS archived_reviewers
+(
+    org_id          TEXT NOT NULL,
+    pull_request_id TEXT NOT NULL,
+    reviewer_id     TEXT NOT NULL,
+    assigned_at     DATETIME,
+    PRIMARY KEY (org_id, pull_request_id, reviewer_id),
+    FOREIGN KEY (org_id, pull_request_id) REFERENCES archived_pull_requests (org_id, id) ON DELETE CASCADE,
+    FOREIGN KEY (org_id, reviewer_id) REFERENCES users (org_id, id) ON UPDATE CASCADE ON DELETE RESTRICT
+);
+
+-- What the statistics and the reviewer choice read of the archived reviews, every archived
+-- pull request is merged.
+CREATE TABLE IF NOT EXISTS archived_review_stats
+(
+    org_id       TEXT    NOT NULL,
+    reviewer_id  TEXT    NOT NULL,
+    review_count INTEGER NOT NULL CHECK (review_count > 0),
+    merged_hours REAL    NOT NULL,
+    PRIMARY KEY (org_id, reviewer_id),
+    FOREIGN KEY (org_id, reviewer_id) REFERENCES users (org_id, id) ON UPDATE CASCADE ON DELETE RESTRICT
+);
+
+-- A restored pull request stays out of the archive for another retention period.
+ALTER TABLE pull_requests ADD COLUMN restored_at DATETIME;
+
+CREATE INDEX IF NOT EXISTS pull_requests_merged_at_idx ON pull_requests (org_id, merged_at) WHERE merged_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS archived_reviewers_reviewer_id_idx ON archived_reviewers (org_id, reviewer_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+INSERT INTO pull_requests (org_id, id, name, author_id, status, created_at, merged_at)
+SELECT org_id, id, name, author_id, 'MERGED', created_at, merged_at
+FROM archived_pull_requests;
+
+INSERT INTO reviewers (org_id, pull_request_id, reviewer_id, assigned_at)
+SELECT org_id, pull_request_id, reviewer_id, assigned_at
+FROM archived_reviewers;
+
+DROP INDEX IF EXISTS archived_reviewers_reviewer_id_idx;
+DROP INDEX IF EXISTS pull_requests_merged_at_idx;
+
+DROP TABLE IF EXISTS archived_review_stats;
+DROP TABLE IF EXISTS archived_reviewers;
+DROP TABLE IF EXISTS archived_pull_requests;
+
+ALTER TABLE pull_requests DROP COLUMN restored_at;
+-- +goose StatementEnd
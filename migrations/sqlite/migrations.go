@@ -0,0 +1,6 @@
+package sqlite
+
+import "embed"
+
+//go:embed *.sql
+var FS embed.FS